@@ -23,12 +23,15 @@ const (
 var _ mainflux.AuthServiceClient = (*grpcClient)(nil)
 
 type grpcClient struct {
-	issue     endpoint.Endpoint
-	identify  endpoint.Endpoint
-	authorize endpoint.Endpoint
-	assign    endpoint.Endpoint
-	members   endpoint.Endpoint
-	timeout   time.Duration
+	issue        endpoint.Endpoint
+	identify     endpoint.Endpoint
+	authorize    endpoint.Endpoint
+	assign       endpoint.Endpoint
+	members      endpoint.Endpoint
+	addPolicy    endpoint.Endpoint
+	deletePolicy endpoint.Endpoint
+	listObjects  endpoint.Endpoint
+	timeout      time.Duration
 }
 
 // NewClient returns new gRPC client instance.
@@ -74,6 +77,30 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeMembersResponse,
 			mainflux.MembersRes{},
 		).Endpoint()),
+		addPolicy: kitot.TraceClient(tracer, "add_policy")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"AddPolicy",
+			encodePolicyRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		deletePolicy: kitot.TraceClient(tracer, "delete_policy")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"DeletePolicy",
+			encodePolicyRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		listObjects: kitot.TraceClient(tracer, "list_objects")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"ListObjects",
+			encodeListObjectsRequest,
+			decodeListObjectsResponse,
+			mainflux.ListObjectsRes{},
+		).Endpoint()),
 
 		timeout: timeout,
 	}
@@ -224,3 +251,57 @@ func decodeAssignResponse(_ context.Context, grpcReq interface{}) (interface{},
 		Act: req.Act,
 	}, nil
 }
+
+func (client grpcClient) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	if _, err := client.addPolicy(ctx, policyReq{token: req.GetToken(), sub: req.GetSub(), obj: req.GetObj(), act: req.GetAct()}); err != nil {
+		return &empty.Empty{}, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (client grpcClient) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	if _, err := client.deletePolicy(ctx, policyReq{token: req.GetToken(), sub: req.GetSub(), obj: req.GetObj(), act: req.GetAct()}); err != nil {
+		return &empty.Empty{}, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func encodePolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(policyReq)
+	return &mainflux.PolicyReq{Token: req.token, Sub: req.sub, Obj: req.obj, Act: req.act}, nil
+}
+
+func decodeEmptyResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return emptyRes{}, nil
+}
+
+func (client grpcClient) ListObjects(ctx context.Context, req *mainflux.ListObjectsReq, _ ...grpc.CallOption) (*mainflux.ListObjectsRes, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.listObjects(ctx, listObjectsReq{sub: req.GetSub(), act: req.GetAct()})
+	if err != nil {
+		return &mainflux.ListObjectsRes{}, err
+	}
+
+	lr := res.(listObjectsRes)
+	return &mainflux.ListObjectsRes{Objects: lr.objects}, nil
+}
+
+func encodeListObjectsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(listObjectsReq)
+	return &mainflux.ListObjectsReq{Sub: req.sub, Act: req.act}, nil
+}
+
+func decodeListObjectsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.ListObjectsRes)
+	return listObjectsRes{objects: res.GetObjects()}, nil
+}
@@ -62,12 +62,7 @@ func authorizeEndpoint(svc auth.Service) endpoint.Endpoint {
 			return authorizeRes{}, err
 		}
 
-		_, err := svc.Identify(ctx, req.token)
-		if err != nil {
-			return authorizeRes{}, err
-		}
-
-		authorized, err := svc.Authorize(ctx, req.token, req.Sub, req.Obj, req.Obj)
+		authorized, err := svc.Authorize(ctx, req.Sub, req.Obj, req.Act)
 		if err != nil {
 			return authorizeRes{}, err
 		}
@@ -125,3 +120,59 @@ func membersEndpoint(svc auth.Service) endpoint.Endpoint {
 		}, nil
 	}
 }
+
+func addPolicyEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policyReq)
+		if err := req.validate(); err != nil {
+			return emptyRes{}, err
+		}
+
+		p := auth.Policy{
+			Subject: req.sub,
+			Object:  req.obj,
+			Action:  req.act,
+		}
+		if err := svc.AddPolicy(ctx, req.token, p); err != nil {
+			return emptyRes{}, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func deletePolicyEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policyReq)
+		if err := req.validate(); err != nil {
+			return emptyRes{}, err
+		}
+
+		p := auth.Policy{
+			Subject: req.sub,
+			Object:  req.obj,
+			Action:  req.act,
+		}
+		if err := svc.DeletePolicy(ctx, req.token, p); err != nil {
+			return emptyRes{}, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func listObjectsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listObjectsReq)
+		if err := req.validate(); err != nil {
+			return listObjectsRes{}, err
+		}
+
+		objects, err := svc.ListObjects(ctx, req.sub, req.act)
+		if err != nil {
+			return listObjectsRes{}, err
+		}
+
+		return listObjectsRes{objects: objects}, nil
+	}
+}
@@ -36,15 +36,19 @@ const (
 	numOfUsers  = 5
 )
 
-var svc auth.Service
+var (
+	svc        auth.Service
+	policyRepo auth.PolicyRepository
+)
 
 func newService() auth.Service {
 	repo := mocks.NewKeyRepository()
 	groupRepo := mocks.NewGroupRepository()
+	policyRepo = mocks.NewPolicyRepository(groupRepo)
 	idProvider := uuid.NewMock()
 	t := jwt.New(secret)
 
-	return auth.New(repo, groupRepo, idProvider, t)
+	return auth.New(repo, groupRepo, policyRepo, idProvider, t)
 }
 
 func startGRPCServer(svc auth.Service, port int) {
@@ -185,6 +189,169 @@ func TestIdentify(t *testing.T) {
 	}
 }
 
+func TestAuthorize(t *testing.T) {
+	err := policyRepo.Save(context.Background(), auth.Policy{Subject: id, Object: "object", Action: "read"})
+	assert.Nil(t, err, fmt.Sprintf("Saving policy expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	cases := []struct {
+		desc       string
+		sub        string
+		obj        string
+		act        string
+		authorized bool
+		code       codes.Code
+	}{
+		{
+			desc:       "authorize subject with policy",
+			sub:        id,
+			obj:        "object",
+			act:        "read",
+			authorized: true,
+			code:       codes.OK,
+		},
+		{
+			desc:       "authorize subject without policy",
+			sub:        id,
+			obj:        "object",
+			act:        "write",
+			authorized: false,
+			code:       codes.OK,
+		},
+		{
+			desc:       "authorize with empty subject",
+			sub:        "",
+			obj:        "object",
+			act:        "read",
+			authorized: false,
+			code:       codes.InvalidArgument,
+		},
+		{
+			desc:       "authorize with empty action",
+			sub:        id,
+			obj:        "object",
+			act:        "",
+			authorized: false,
+			code:       codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := client.Authorize(context.Background(), &mainflux.AuthorizeReq{Sub: tc.sub, Obj: tc.obj, Act: tc.act})
+		assert.Equal(t, tc.authorized, res.GetAuthorized(), fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.authorized, res.GetAuthorized()))
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+	}
+}
+
+func TestAddPolicy(t *testing.T) {
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	subID := "subjectID"
+	cases := []struct {
+		desc  string
+		token string
+		sub   string
+		obj   string
+		act   string
+		code  codes.Code
+	}{
+		{
+			desc:  "claim unowned object",
+			token: token,
+			sub:   id,
+			obj:   "shared",
+			act:   auth.OwnerAction,
+			code:  codes.OK,
+		},
+		{
+			desc:  "add policy as the owner",
+			token: token,
+			sub:   subID,
+			obj:   "shared",
+			act:   "read",
+			code:  codes.OK,
+		},
+		{
+			desc:  "add policy as a non-owner",
+			token: token,
+			sub:   subID,
+			obj:   "unowned",
+			act:   "read",
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "add policy without token",
+			token: "",
+			sub:   subID,
+			obj:   "shared",
+			act:   "write",
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "add policy with invalid token",
+			token: "invalid",
+			sub:   subID,
+			obj:   "shared",
+			act:   "write",
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "add policy granting the owner action to another user",
+			token: token,
+			sub:   subID,
+			obj:   "shared",
+			act:   auth.OwnerAction,
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "add policy with empty object",
+			token: token,
+			sub:   subID,
+			obj:   "",
+			act:   "read",
+			code:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.AddPolicy(context.Background(), &mainflux.PolicyReq{Token: tc.token, Sub: tc.sub, Obj: tc.obj, Act: tc.act})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+	}
+
+	res, err := client.ListObjects(context.Background(), &mainflux.ListObjectsReq{Sub: subID, Act: "read"})
+	assert.Nil(t, err, fmt.Sprintf("listing objects expected to succeed: %s", err))
+	assert.Contains(t, res.GetObjects(), "shared", "expected added policy object to be listed")
+
+	_, err = client.DeletePolicy(context.Background(), &mainflux.PolicyReq{Token: token, Sub: subID, Obj: "unowned", Act: "read"})
+	e, ok := status.FromError(err)
+	assert.True(t, ok, "gRPC status can't be extracted from the error")
+	assert.Equal(t, codes.Unauthenticated, e.Code(), fmt.Sprintf("deleting policy as a non-owner: expected %s got %s", codes.Unauthenticated, e.Code()))
+
+	_, err = client.DeletePolicy(context.Background(), &mainflux.PolicyReq{Token: token, Sub: subID, Obj: "shared", Act: "read"})
+	assert.Nil(t, err, fmt.Sprintf("deleting policy expected to succeed: %s", err))
+
+	res, err = client.ListObjects(context.Background(), &mainflux.ListObjectsReq{Sub: subID, Act: "read"})
+	assert.Nil(t, err, fmt.Sprintf("listing objects expected to succeed: %s", err))
+	assert.NotContains(t, res.GetObjects(), "shared", "expected deleted policy object not to be listed")
+
+	_, err = client.ListObjects(context.Background(), &mainflux.ListObjectsReq{Sub: id})
+	e, ok = status.FromError(err)
+	assert.True(t, ok, "gRPC status can't be extracted from the error")
+	assert.Equal(t, codes.InvalidArgument, e.Code(), fmt.Sprintf("listing objects with empty action: expected %s got %s", codes.InvalidArgument, e.Code()))
+}
+
 func TestMembers(t *testing.T) {
 	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
 	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
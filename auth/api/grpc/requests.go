@@ -87,17 +87,12 @@ func (req membersReq) validate() error {
 // 2. object - an entity over which action will be executed
 // 3. action - type of action that will be executed (read/write)
 type authReq struct {
-	token string
-	Sub   string
-	Obj   string
-	Act   string
+	Sub string
+	Obj string
+	Act string
 }
 
 func (req authReq) validate() error {
-	if req.token == "" {
-		return auth.ErrMalformedEntity
-	}
-
 	if req.Sub == "" {
 		return auth.ErrMalformedEntity
 	}
@@ -112,3 +107,35 @@ func (req authReq) validate() error {
 
 	return nil
 }
+
+type policyReq struct {
+	token string
+	sub   string
+	obj   string
+	act   string
+}
+
+func (req policyReq) validate() error {
+	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.sub == "" || req.obj == "" || req.act == "" {
+		return auth.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type listObjectsReq struct {
+	sub string
+	act string
+}
+
+func (req listObjectsReq) validate() error {
+	if req.sub == "" || req.act == "" {
+		return auth.ErrMalformedEntity
+	}
+
+	return nil
+}
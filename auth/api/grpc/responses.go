@@ -25,3 +25,7 @@ type membersRes struct {
 type emptyRes struct {
 	err error
 }
+
+type listObjectsRes struct {
+	objects []string
+}
@@ -20,11 +20,14 @@ import (
 var _ mainflux.AuthServiceServer = (*grpcServer)(nil)
 
 type grpcServer struct {
-	issue     kitgrpc.Handler
-	identify  kitgrpc.Handler
-	authorize kitgrpc.Handler
-	assign    kitgrpc.Handler
-	members   kitgrpc.Handler
+	issue        kitgrpc.Handler
+	identify     kitgrpc.Handler
+	authorize    kitgrpc.Handler
+	assign       kitgrpc.Handler
+	members      kitgrpc.Handler
+	addPolicy    kitgrpc.Handler
+	deletePolicy kitgrpc.Handler
+	listObjects  kitgrpc.Handler
 }
 
 // NewServer returns new AuthServiceServer instance.
@@ -55,6 +58,21 @@ func NewServer(tracer opentracing.Tracer, svc auth.Service) mainflux.AuthService
 			decodeMembersRequest,
 			encodeMembersResponse,
 		),
+		addPolicy: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "add_policy")(addPolicyEndpoint(svc)),
+			decodePolicyRequest,
+			encodeEmptyResponse,
+		),
+		deletePolicy: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "delete_policy")(deletePolicyEndpoint(svc)),
+			decodePolicyRequest,
+			encodeEmptyResponse,
+		),
+		listObjects: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "list_objects")(listObjectsEndpoint(svc)),
+			decodeListObjectsRequest,
+			encodeListObjectsResponse,
+		),
 	}
 }
 
@@ -98,6 +116,30 @@ func (s *grpcServer) Members(ctx context.Context, req *mainflux.MembersReq) (*ma
 	return res.(*mainflux.MembersRes), nil
 }
 
+func (s *grpcServer) AddPolicy(ctx context.Context, req *mainflux.PolicyReq) (*empty.Empty, error) {
+	_, res, err := s.addPolicy.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
+func (s *grpcServer) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq) (*empty.Empty, error) {
+	_, res, err := s.deletePolicy.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
+func (s *grpcServer) ListObjects(ctx context.Context, req *mainflux.ListObjectsReq) (*mainflux.ListObjectsRes, error) {
+	_, res, err := s.listObjects.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.ListObjectsRes), nil
+}
+
 func decodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.IssueReq)
 	return issueReq{id: req.GetId(), email: req.GetEmail(), keyType: req.GetType()}, nil
@@ -124,8 +166,8 @@ func decodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}
 }
 
 func encodeAuthorizeResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
-	res := grpcRes.(*mainflux.AuthorizeRes)
-	return res, nil
+	res := grpcRes.(authorizeRes)
+	return &mainflux.AuthorizeRes{Authorized: res.authorized}, nil
 }
 
 func decodeAssignRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -155,6 +197,21 @@ func encodeMembersResponse(_ context.Context, grpcRes interface{}) (interface{},
 	}, nil
 }
 
+func decodePolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.PolicyReq)
+	return policyReq{token: req.GetToken(), sub: req.GetSub(), obj: req.GetObj(), act: req.GetAct()}, nil
+}
+
+func decodeListObjectsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ListObjectsReq)
+	return listObjectsReq{sub: req.GetSub(), act: req.GetAct()}, nil
+}
+
+func encodeListObjectsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(listObjectsRes)
+	return &mainflux.ListObjectsRes{Objects: res.objects}, nil
+}
+
 func encodeEmptyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(emptyRes)
 	return &empty.Empty{}, encodeError(res.err)
@@ -0,0 +1,347 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package policies_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/auth"
+	httpapi "github.com/mainflux/mainflux/auth/api/http"
+	"github.com/mainflux/mainflux/auth/jwt"
+	"github.com/mainflux/mainflux/auth/mocks"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	secret      = "secret"
+	contentType = "application/json"
+	id          = "123e4567-e89b-12d3-a456-000000000001"
+	subID       = "123e4567-e89b-12d3-a456-000000000002"
+	email       = "user@example.com"
+	object      = "object"
+)
+
+type policiesReq struct {
+	Object   string   `json:"object,omitempty"`
+	Subjects []string `json:"subjects,omitempty"`
+	Actions  []string `json:"actions,omitempty"`
+}
+
+type policyRes struct {
+	Subject string `json:"subject"`
+	Object  string `json:"object"`
+	Action  string `json:"action"`
+}
+
+type policiesPageRes struct {
+	Total    uint64      `json:"total"`
+	Offset   uint64      `json:"offset"`
+	Limit    uint64      `json:"limit"`
+	Policies []policyRes `json:"policies"`
+}
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	req.Header.Set("Referer", "http://localhost")
+	return tr.client.Do(req)
+}
+
+func newService() (auth.Service, auth.PolicyRepository) {
+	repo := mocks.NewKeyRepository()
+	groupRepo := mocks.NewGroupRepository()
+	policyRepo := mocks.NewPolicyRepository(groupRepo)
+	idProvider := uuid.NewMock()
+	t := jwt.New(secret)
+	return auth.New(repo, groupRepo, policyRepo, idProvider, t), policyRepo
+}
+
+func newServer(svc auth.Service) *httptest.Server {
+	mux := httpapi.MakeHandler(svc, mocktracer.New())
+	return httptest.NewServer(mux)
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+func TestAddPolicies(t *testing.T) {
+	svc, policies := newService()
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	err = policies.Save(context.Background(), auth.Policy{Subject: id, Object: object, Action: "read"})
+	require.Nil(t, err, fmt.Sprintf("Saving policy expected to succeed: %s", err))
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		req    string
+		ct     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "add delegated policies",
+			req:    toJSON(policiesReq{Object: object, Subjects: []string{subID}, Actions: []string{"read"}}),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "add policies with action not held by the user",
+			req:    toJSON(policiesReq{Object: object, Subjects: []string{subID}, Actions: []string{"write"}}),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "add policies without subjects",
+			req:    toJSON(policiesReq{Object: object, Actions: []string{"read"}}),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "add policies with empty action",
+			req:    toJSON(policiesReq{Object: object, Subjects: []string{subID}, Actions: []string{""}}),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "add policies with invalid request format",
+			req:    "{",
+			ct:     contentType,
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "add policies with wrong content type",
+			req:    toJSON(policiesReq{Object: object, Subjects: []string{subID}, Actions: []string{"read"}}),
+			ct:     "",
+			token:  token,
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:   "add policies with invalid token",
+			req:    toJSON(policiesReq{Object: object, Subjects: []string{subID}, Actions: []string{"read"}}),
+			ct:     contentType,
+			token:  "wrong",
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "add policies with empty token",
+			req:    toJSON(policiesReq{Object: object, Subjects: []string{subID}, Actions: []string{"read"}}),
+			ct:     contentType,
+			token:  "",
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/policies", ts.URL),
+			contentType: tc.ct,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRemovePolicies(t *testing.T) {
+	svc, policies := newService()
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	err = policies.Save(context.Background(),
+		auth.Policy{Subject: id, Object: object, Action: auth.OwnerAction},
+		auth.Policy{Subject: subID, Object: object, Action: "read"},
+	)
+	require.Nil(t, err, fmt.Sprintf("Saving policy expected to succeed: %s", err))
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		req    string
+		ct     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "remove policies as the owner",
+			req:    toJSON(policiesReq{Object: object, Subjects: []string{subID}, Actions: []string{"read"}}),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove policies of the object not owned by the user",
+			req:    toJSON(policiesReq{Object: "other", Subjects: []string{subID}, Actions: []string{"read"}}),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove the owner action",
+			req:    toJSON(policiesReq{Object: object, Subjects: []string{id}, Actions: []string{auth.OwnerAction}}),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove policies without object",
+			req:    toJSON(policiesReq{Subjects: []string{subID}, Actions: []string{"read"}}),
+			ct:     contentType,
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "remove policies with invalid token",
+			req:    toJSON(policiesReq{Object: object, Subjects: []string{subID}, Actions: []string{"read"}}),
+			ct:     contentType,
+			token:  "wrong",
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodDelete,
+			url:         fmt.Sprintf("%s/policies", ts.URL),
+			contentType: tc.ct,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListPolicies(t *testing.T) {
+	svc, policies := newService()
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	err = policies.Save(context.Background(),
+		auth.Policy{Subject: id, Object: object, Action: "read"},
+		auth.Policy{Subject: subID, Object: object, Action: "read"},
+		auth.Policy{Subject: subID, Object: object, Action: "write"},
+	)
+	require.Nil(t, err, fmt.Sprintf("Saving policy expected to succeed: %s", err))
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list policies",
+			url:    fmt.Sprintf("%s/policies", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list policies filtered by subject",
+			url:    fmt.Sprintf("%s/policies?subject=%s", ts.URL, subID),
+			token:  token,
+			status: http.StatusOK,
+			size:   1,
+		},
+		{
+			desc:   "list policies with limit",
+			url:    fmt.Sprintf("%s/policies?offset=1&limit=1", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   1,
+		},
+		{
+			desc:   "list policies with invalid limit",
+			url:    fmt.Sprintf("%s/policies?limit=1000", ts.URL),
+			token:  token,
+			status: http.StatusBadRequest,
+			size:   0,
+		},
+		{
+			desc:   "list policies with invalid offset",
+			url:    fmt.Sprintf("%s/policies?offset=-1", ts.URL),
+			token:  token,
+			status: http.StatusBadRequest,
+			size:   0,
+		},
+		{
+			desc:   "list policies with invalid token",
+			url:    fmt.Sprintf("%s/policies", ts.URL),
+			token:  "wrong",
+			status: http.StatusForbidden,
+			size:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var page policiesPageRes
+		json.NewDecoder(res.Body).Decode(&page)
+		assert.Equal(t, tc.size, len(page.Policies), fmt.Sprintf("%s: expected %d policies got %d", tc.desc, tc.size, len(page.Policies)))
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import "github.com/mainflux/mainflux/auth"
+
+const maxLimitSize = 100
+
+type policiesReq struct {
+	token    string
+	Object   string   `json:"object,omitempty"`
+	Subjects []string `json:"subjects,omitempty"`
+	Actions  []string `json:"actions,omitempty"`
+}
+
+func (req policiesReq) validate() error {
+	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.Object == "" || len(req.Subjects) == 0 || len(req.Actions) == 0 {
+		return auth.ErrMalformedEntity
+	}
+
+	for _, sub := range req.Subjects {
+		if sub == "" {
+			return auth.ErrMalformedEntity
+		}
+	}
+
+	for _, act := range req.Actions {
+		if act == "" {
+			return auth.ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
+type listPoliciesReq struct {
+	token   string
+	subject string
+	object  string
+	action  string
+	offset  uint64
+	limit   uint64
+}
+
+func (req listPoliciesReq) validate() error {
+	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return auth.ErrMalformedEntity
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"net/http"
+
+	"github.com/mainflux/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*addPoliciesRes)(nil)
+	_ mainflux.Response = (*removePoliciesRes)(nil)
+	_ mainflux.Response = (*policiesPageRes)(nil)
+)
+
+type addPoliciesRes struct{}
+
+func (res addPoliciesRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res addPoliciesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res addPoliciesRes) Empty() bool {
+	return true
+}
+
+type removePoliciesRes struct{}
+
+func (res removePoliciesRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removePoliciesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removePoliciesRes) Empty() bool {
+	return true
+}
+
+type policyRes struct {
+	Subject string `json:"subject"`
+	Object  string `json:"object"`
+	Action  string `json:"action"`
+}
+
+type policiesPageRes struct {
+	Total    uint64      `json:"total"`
+	Offset   uint64      `json:"offset"`
+	Limit    uint64      `json:"limit"`
+	Policies []policyRes `json:"policies"`
+}
+
+func (res policiesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res policiesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res policiesPageRes) Empty() bool {
+	return false
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
@@ -82,9 +82,9 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id auth.
 	return lm.svc.Identify(ctx, key)
 }
 
-func (lm *loggingMiddleware) Authorize(ctx context.Context, token, sub, obj, act string) (auth bool, err error) {
+func (lm *loggingMiddleware) Authorize(ctx context.Context, sub, obj, act string) (auth bool, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method authorize took %s to complete", time.Since(begin))
+		message := fmt.Sprintf("Method authorize for subject %s, object %s and action %s took %s to complete", sub, obj, act, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -92,7 +92,85 @@ func (lm *loggingMiddleware) Authorize(ctx context.Context, token, sub, obj, act
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Authorize(ctx, token, sub, obj, act)
+	return lm.svc.Authorize(ctx, sub, obj, act)
+}
+
+func (lm *loggingMiddleware) AddPolicy(ctx context.Context, token string, p auth.Policy) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_policy for subject %s, object %s and action %s took %s to complete", p.Subject, p.Object, p.Action, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AddPolicy(ctx, token, p)
+}
+
+func (lm *loggingMiddleware) DeletePolicy(ctx context.Context, token string, p auth.Policy) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method delete_policy for subject %s, object %s and action %s took %s to complete", p.Subject, p.Object, p.Action, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DeletePolicy(ctx, token, p)
+}
+
+func (lm *loggingMiddleware) ListObjects(ctx context.Context, sub, act string) (objs []string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_objects for subject %s and action %s took %s to complete", sub, act, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListObjects(ctx, sub, act)
+}
+
+func (lm *loggingMiddleware) AddPolicies(ctx context.Context, token, object string, subjectIDs, actions []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_policies for object %s took %s to complete", object, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AddPolicies(ctx, token, object, subjectIDs, actions)
+}
+
+func (lm *loggingMiddleware) RemovePolicies(ctx context.Context, token, object string, subjectIDs, actions []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_policies for object %s took %s to complete", object, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemovePolicies(ctx, token, object, subjectIDs, actions)
+}
+
+func (lm *loggingMiddleware) ListPolicies(ctx context.Context, token string, filter auth.Policy, pm auth.PageMetadata) (pp auth.PolicyPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_policies took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListPolicies(ctx, token, filter, pm)
 }
 
 func (lm *loggingMiddleware) CreateGroup(ctx context.Context, token string, group auth.Group) (g auth.Group, err error) {
@@ -64,13 +64,67 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, token string) (auth.I
 	return ms.svc.Identify(ctx, token)
 }
 
-func (ms *metricsMiddleware) Authorize(ctx context.Context, token, sub, obj, act string) (auth bool, err error) {
+func (ms *metricsMiddleware) Authorize(ctx context.Context, sub, obj, act string) (auth bool, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "authorize").Add(1)
 		ms.latency.With("method", "authorize").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Authorize(ctx, token, sub, obj, act)
+	return ms.svc.Authorize(ctx, sub, obj, act)
+}
+
+func (ms *metricsMiddleware) AddPolicy(ctx context.Context, token string, p auth.Policy) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_policy").Add(1)
+		ms.latency.With("method", "add_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AddPolicy(ctx, token, p)
+}
+
+func (ms *metricsMiddleware) DeletePolicy(ctx context.Context, token string, p auth.Policy) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_policy").Add(1)
+		ms.latency.With("method", "delete_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DeletePolicy(ctx, token, p)
+}
+
+func (ms *metricsMiddleware) ListObjects(ctx context.Context, sub, act string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_objects").Add(1)
+		ms.latency.With("method", "list_objects").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListObjects(ctx, sub, act)
+}
+
+func (ms *metricsMiddleware) AddPolicies(ctx context.Context, token, object string, subjectIDs, actions []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_policies").Add(1)
+		ms.latency.With("method", "add_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AddPolicies(ctx, token, object, subjectIDs, actions)
+}
+
+func (ms *metricsMiddleware) RemovePolicies(ctx context.Context, token, object string, subjectIDs, actions []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_policies").Add(1)
+		ms.latency.With("method", "remove_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemovePolicies(ctx, token, object, subjectIDs, actions)
+}
+
+func (ms *metricsMiddleware) ListPolicies(ctx context.Context, token string, filter auth.Policy, pm auth.PageMetadata) (auth.PolicyPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_policies").Add(1)
+		ms.latency.With("method", "list_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListPolicies(ctx, token, filter, pm)
 }
 
 func (ms *metricsMiddleware) CreateGroup(ctx context.Context, token string, group auth.Group) (gr auth.Group, err error) {
@@ -0,0 +1,186 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/auth"
+)
+
+const maxMemberships = 1000
+
+var _ auth.PolicyRepository = (*policyRepositoryMock)(nil)
+
+type policyRepositoryMock struct {
+	mu       sync.Mutex
+	groups   auth.GroupRepository
+	policies map[auth.Policy]bool
+}
+
+// NewPolicyRepository creates in-memory policy repository. Provided
+// group repository is used to resolve policies inherited through groups.
+func NewPolicyRepository(groups auth.GroupRepository) auth.PolicyRepository {
+	return &policyRepositoryMock{
+		groups:   groups,
+		policies: make(map[auth.Policy]bool),
+	}
+}
+
+func (prm *policyRepositoryMock) Save(ctx context.Context, policies ...auth.Policy) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	for _, p := range policies {
+		// The object can have a single owner.
+		if p.Action == auth.OwnerAction {
+			if _, err := prm.owner(p.Object); err == nil {
+				continue
+			}
+		}
+		prm.policies[p] = true
+	}
+	return nil
+}
+
+func (prm *policyRepositoryMock) Remove(ctx context.Context, policies ...auth.Policy) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	for _, p := range policies {
+		delete(prm.policies, p)
+	}
+	return nil
+}
+
+func (prm *policyRepositoryMock) RetrieveAll(ctx context.Context, subject string, filter auth.Policy, pm auth.PageMetadata) (auth.PolicyPage, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	var all []auth.Policy
+	for p := range prm.policies {
+		if (filter.Subject != "" && filter.Subject != p.Subject) ||
+			(filter.Object != "" && filter.Object != p.Object) ||
+			(filter.Action != "" && filter.Action != p.Action) {
+			continue
+		}
+		if prm.evaluate(ctx, auth.Policy{Subject: subject, Object: p.Object, Action: p.Action}) {
+			all = append(all, p)
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Subject != all[j].Subject {
+			return all[i].Subject < all[j].Subject
+		}
+		if all[i].Object != all[j].Object {
+			return all[i].Object < all[j].Object
+		}
+		return all[i].Action < all[j].Action
+	})
+
+	var items []auth.Policy
+	first := pm.Offset
+	last := first + pm.Limit
+	for i, p := range all {
+		if uint64(i) >= first && uint64(i) < last {
+			items = append(items, p)
+		}
+	}
+
+	return auth.PolicyPage{
+		Policies: items,
+		PageMetadata: auth.PageMetadata{
+			Total:  uint64(len(all)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+			Size:   uint64(len(items)),
+		},
+	}, nil
+}
+
+func (prm *policyRepositoryMock) Evaluate(ctx context.Context, p auth.Policy) (bool, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	return prm.evaluate(ctx, p), nil
+}
+
+func (prm *policyRepositoryMock) RetrieveObjects(ctx context.Context, subject, action string) ([]string, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	subjects := make(map[string]bool)
+	for _, sub := range prm.inherited(ctx, subject) {
+		subjects[sub] = true
+	}
+
+	objects := make(map[string]bool)
+	for p := range prm.policies {
+		if p.Action != action || !subjects[p.Subject] {
+			continue
+		}
+		objects[p.Object] = true
+	}
+
+	var ids []string
+	for obj := range objects {
+		ids = append(ids, obj)
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+func (prm *policyRepositoryMock) RetrieveOwner(ctx context.Context, object string) (string, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	return prm.owner(object)
+}
+
+func (prm *policyRepositoryMock) owner(object string) (string, error) {
+	for p := range prm.policies {
+		if p.Object == object && p.Action == auth.OwnerAction {
+			return p.Subject, nil
+		}
+	}
+	return "", auth.ErrNotFound
+}
+
+func (prm *policyRepositoryMock) evaluate(ctx context.Context, p auth.Policy) bool {
+	for _, sub := range prm.inherited(ctx, p.Subject) {
+		for _, obj := range prm.inherited(ctx, p.Object) {
+			if prm.policies[auth.Policy{Subject: sub, Object: obj, Action: p.Action}] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inherited returns the entity ID together with the IDs of all the
+// groups the entity inherits policies from.
+func (prm *policyRepositoryMock) inherited(ctx context.Context, id string) []string {
+	ids := []string{id}
+
+	groups := []auth.Group{{ID: id}}
+	if gp, err := prm.groups.Memberships(ctx, id, auth.PageMetadata{Limit: maxMemberships}); err == nil {
+		groups = append(groups, gp.Groups...)
+	}
+
+	for _, g := range groups {
+		gp, err := prm.groups.RetrieveAllParents(ctx, g.ID, auth.PageMetadata{Level: auth.MaxLevel})
+		if err != nil {
+			continue
+		}
+		for _, pg := range gp.Groups {
+			ids = append(ids, pg.ID)
+		}
+	}
+
+	return ids
+}
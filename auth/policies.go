@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import "context"
+
+const (
+	// OwnerAction is the action held by the owner of the object. The owner
+	// is allowed to grant any action on the object and is the only one
+	// allowed to revoke them. Unlike the other actions, it is never
+	// inherited through the groups hierarchy.
+	OwnerAction = "owner"
+
+	// AuthoritiesObject is the object the administrators are members of.
+	AuthoritiesObject = "authorities"
+
+	// MemberRelation is the action held by the members of the object.
+	MemberRelation = "member"
+)
+
+// Policy represents a relation stating that the subject is allowed to
+// perform the action on the object. Both subject and object can be
+// groups, in which case the policy applies to all of the group members
+// and all of the descendant groups.
+type Policy struct {
+	Subject string
+	Object  string
+	Action  string
+}
+
+// PolicyPage contains a page of policies.
+type PolicyPage struct {
+	PageMetadata
+	Policies []Policy
+}
+
+// PolicyService specifies an API for managing policies.
+type PolicyService interface {
+	// AddPolicies grants the actions on the object to the subjects. The
+	// user identified by the token must either own the object or be
+	// authorized to perform each of the actions on it. The owner action
+	// can't be granted.
+	AddPolicies(ctx context.Context, token, object string, subjectIDs, actions []string) error
+
+	// RemovePolicies revokes the actions on the object from the subjects.
+	// The user identified by the token must own the object. The owner
+	// action can't be revoked.
+	RemovePolicies(ctx context.Context, token, object string, subjectIDs, actions []string) error
+
+	// ListPolicies retrieves the policies that match the non-empty fields
+	// of the filter and that the user identified by the token is allowed
+	// to manage.
+	ListPolicies(ctx context.Context, token string, filter Policy, pm PageMetadata) (PolicyPage, error)
+}
+
+// PolicyRepository specifies a policy persistence API.
+type PolicyRepository interface {
+	// Save persists the policies. Saving an existing policy is a no-op.
+	Save(ctx context.Context, policies ...Policy) error
+
+	// Remove removes the policies.
+	Remove(ctx context.Context, policies ...Policy) error
+
+	// RetrieveAll retrieves the policies that match the non-empty fields
+	// of the filter and whose action the subject is allowed to perform
+	// on their object.
+	RetrieveAll(ctx context.Context, subject string, filter Policy, pm PageMetadata) (PolicyPage, error)
+
+	// Evaluate checks whether there is a policy, either direct or
+	// inherited through the groups hierarchy, that allows the policy
+	// subject to perform the policy action on the policy object.
+	Evaluate(ctx context.Context, p Policy) (bool, error)
+
+	// RetrieveObjects retrieves IDs of the objects the subject, or any of
+	// the groups it inherits policies from, has been granted the action on.
+	RetrieveObjects(ctx context.Context, subject, action string) ([]string, error)
+
+	// RetrieveOwner retrieves ID of the subject holding the owner action
+	// on the object. ErrNotFound is returned if the object has no owner.
+	RetrieveOwner(ctx context.Context, object string) (string, error)
+}
+
+// SeedAdmin makes the user a member of the authorities and the owner of
+// the authorities object, so that the user can manage the other
+// administrators. ErrConflict is returned if the authorities object is
+// already owned by another user.
+func SeedAdmin(ctx context.Context, policies PolicyRepository, userID string) error {
+	if userID == "" {
+		return ErrMalformedEntity
+	}
+
+	err := policies.Save(ctx,
+		Policy{Subject: userID, Object: AuthoritiesObject, Action: MemberRelation},
+		Policy{Subject: userID, Object: AuthoritiesObject, Action: OwnerAction},
+	)
+	if err != nil {
+		return err
+	}
+
+	owner, err := policies.RetrieveOwner(ctx, AuthoritiesObject)
+	if err != nil {
+		return err
+	}
+	if owner != userID {
+		return ErrConflict
+	}
+
+	return nil
+}
@@ -770,7 +770,9 @@ func TestUnassign(t *testing.T) {
 }
 
 func cleanUp(t *testing.T) {
-	_, err := db.Exec("delete from group_relations")
+	_, err := db.Exec("delete from policies")
+	require.Nil(t, err, fmt.Sprintf("clean policies unexpected error: %s", err))
+	_, err = db.Exec("delete from group_relations")
 	require.Nil(t, err, fmt.Sprintf("clean relations unexpected error: %s", err))
 	_, err = db.Exec("delete from groups")
 	require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
@@ -109,6 +109,31 @@ func migrateDB(db *sqlx.DB) error {
 					`DROP TRIGGER IF EXISTS inherit_group_tr ON groups`,
 				},
 			},
+			{
+				Id: "auth_2",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS policies (
+						subject     VARCHAR(254) NOT NULL,
+						object      VARCHAR(254) NOT NULL,
+						action      VARCHAR(254) NOT NULL,
+						created_at  TIMESTAMPTZ,
+						PRIMARY KEY (subject, object, action)
+					)`,
+					`CREATE INDEX IF NOT EXISTS policies_object_idx ON policies (object)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS policies`,
+				},
+			},
+			{
+				Id: "auth_3",
+				Up: []string{
+					`CREATE UNIQUE INDEX IF NOT EXISTS policies_owner_idx ON policies (object) WHERE action = 'owner'`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS policies_owner_idx`,
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,273 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	errSavePolicies     = errors.New("failed to save policies to database")
+	errRemovePolicies   = errors.New("failed to remove policies from database")
+	errRetrievePolicies = errors.New("failed to retrieve policies from database")
+	errEvaluatePolicies = errors.New("failed to evaluate policies")
+)
+
+var _ auth.PolicyRepository = (*policyRepository)(nil)
+
+type policyRepository struct {
+	db Database
+}
+
+// NewPolicyRepo instantiates a PostgreSQL implementation of policy
+// repository.
+func NewPolicyRepo(db Database) auth.PolicyRepository {
+	return &policyRepository{
+		db: db,
+	}
+}
+
+func (pr policyRepository) Save(ctx context.Context, policies ...auth.Policy) error {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errSavePolicies, err)
+	}
+
+	q := `INSERT INTO policies (subject, object, action, created_at)
+	      VALUES (:subject, :object, :action, :created_at)
+	      ON CONFLICT DO NOTHING`
+
+	created := time.Now()
+	for _, p := range policies {
+		dbp := toDBPolicy(p)
+		dbp.CreatedAt = created
+
+		if _, err := tx.NamedExecContext(ctx, q, dbp); err != nil {
+			tx.Rollback()
+			pqErr, ok := err.(*pq.Error)
+			if ok {
+				switch pqErr.Code.Name() {
+				case errInvalid, errTruncation:
+					return errors.Wrap(auth.ErrMalformedEntity, err)
+				}
+			}
+
+			return errors.Wrap(errSavePolicies, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errSavePolicies, err)
+	}
+
+	return nil
+}
+
+func (pr policyRepository) Remove(ctx context.Context, policies ...auth.Policy) error {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+
+	q := `DELETE FROM policies WHERE subject = :subject AND object = :object AND action = :action`
+
+	for _, p := range policies {
+		if _, err := tx.NamedExecContext(ctx, q, toDBPolicy(p)); err != nil {
+			tx.Rollback()
+			return errors.Wrap(errRemovePolicies, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errRemovePolicies, err)
+	}
+
+	return nil
+}
+
+func (pr policyRepository) RetrieveAll(ctx context.Context, subject string, filter auth.Policy, pm auth.PageMetadata) (auth.PolicyPage, error) {
+	fq := policyFilterQuery(filter)
+
+	// A policy is listed only if the subject is allowed to perform the
+	// policy action on the policy object.
+	sq := fmt.Sprintf(`EXISTS (SELECT 1 FROM policies sp
+	                   WHERE sp.action = p.action AND sp.subject IN (%s) AND sp.object IN (%s))`,
+		inherited(":subject"), inherited("p.object"))
+
+	q := fmt.Sprintf(`SELECT p.subject, p.object, p.action FROM policies p
+	                  WHERE %s %s ORDER BY p.subject, p.object, p.action LIMIT :limit OFFSET :offset`, sq, fq)
+
+	params := dbPolicyPage{
+		Subject:       subject,
+		FilterSubject: filter.Subject,
+		FilterObject:  filter.Object,
+		FilterAction:  filter.Action,
+		Offset:        pm.Offset,
+		Limit:         pm.Limit,
+	}
+
+	rows, err := pr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return auth.PolicyPage{}, errors.Wrap(errRetrievePolicies, err)
+	}
+	defer rows.Close()
+
+	var items []auth.Policy
+	for rows.Next() {
+		dbp := dbPolicy{}
+		if err := rows.StructScan(&dbp); err != nil {
+			return auth.PolicyPage{}, errors.Wrap(errRetrievePolicies, err)
+		}
+		items = append(items, toPolicy(dbp))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM policies p WHERE %s %s`, sq, fq)
+
+	total, err := total(ctx, pr.db, cq, params)
+	if err != nil {
+		return auth.PolicyPage{}, errors.Wrap(errRetrievePolicies, err)
+	}
+
+	page := auth.PolicyPage{
+		Policies: items,
+		PageMetadata: auth.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+			Size:   uint64(len(items)),
+		},
+	}
+
+	return page, nil
+}
+
+func (pr policyRepository) Evaluate(ctx context.Context, p auth.Policy) (bool, error) {
+	q := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM policies
+	                  WHERE action = :action AND subject IN (%s) AND object IN (%s))`,
+		inherited(":subject"), inherited(":object"))
+
+	rows, err := pr.db.NamedQueryContext(ctx, q, toDBPolicy(p))
+	if err != nil {
+		return false, errors.Wrap(errEvaluatePolicies, err)
+	}
+	defer rows.Close()
+
+	exists := false
+	if rows.Next() {
+		if err := rows.Scan(&exists); err != nil {
+			return false, errors.Wrap(errEvaluatePolicies, err)
+		}
+	}
+
+	return exists, nil
+}
+
+func (pr policyRepository) RetrieveObjects(ctx context.Context, subject, action string) ([]string, error) {
+	q := fmt.Sprintf(`SELECT DISTINCT object FROM policies
+	                  WHERE action = :action AND subject IN (%s) ORDER BY object`, inherited(":subject"))
+
+	rows, err := pr.db.NamedQueryContext(ctx, q, toDBPolicy(auth.Policy{Subject: subject, Action: action}))
+	if err != nil {
+		return nil, errors.Wrap(errRetrievePolicies, err)
+	}
+	defer rows.Close()
+
+	var objects []string
+	for rows.Next() {
+		var obj string
+		if err := rows.Scan(&obj); err != nil {
+			return nil, errors.Wrap(errRetrievePolicies, err)
+		}
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+func (pr policyRepository) RetrieveOwner(ctx context.Context, object string) (string, error) {
+	q := `SELECT subject FROM policies WHERE object = $1 AND action = $2`
+
+	var owner string
+	if err := pr.db.QueryRowxContext(ctx, q, object, auth.OwnerAction).Scan(&owner); err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.Wrap(auth.ErrNotFound, err)
+		}
+		return "", errors.Wrap(errRetrievePolicies, err)
+	}
+
+	return owner, nil
+}
+
+// inherited returns a query that selects the entity identified by the
+// provided expression together with all the groups it inherits policies
+// from: groups it belongs to, their ancestors and, if the entity is a
+// group itself, its ancestors.
+func inherited(id string) string {
+	return fmt.Sprintf(`SELECT CAST(%[1]s AS VARCHAR)
+	                    UNION
+	                    SELECT g.id FROM groups g, groups m, group_relations gr
+	                    WHERE gr.member_id = %[1]s AND gr.group_id = m.id AND g.path @> m.path
+	                    UNION
+	                    SELECT g.id FROM groups g, groups e
+	                    WHERE e.id = %[1]s AND g.path @> e.path`, id)
+}
+
+func policyFilterQuery(filter auth.Policy) string {
+	var query []string
+	if filter.Subject != "" {
+		query = append(query, "p.subject = :filter_subject")
+	}
+	if filter.Object != "" {
+		query = append(query, "p.object = :filter_object")
+	}
+	if filter.Action != "" {
+		query = append(query, "p.action = :filter_action")
+	}
+
+	if len(query) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("AND %s", strings.Join(query, " AND "))
+}
+
+type dbPolicy struct {
+	Subject   string    `db:"subject"`
+	Object    string    `db:"object"`
+	Action    string    `db:"action"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type dbPolicyPage struct {
+	Subject       string `db:"subject"`
+	FilterSubject string `db:"filter_subject"`
+	FilterObject  string `db:"filter_object"`
+	FilterAction  string `db:"filter_action"`
+	Limit         uint64 `db:"limit"`
+	Offset        uint64 `db:"offset"`
+}
+
+func toDBPolicy(p auth.Policy) dbPolicy {
+	return dbPolicy{
+		Subject: p.Subject,
+		Object:  p.Object,
+		Action:  p.Action,
+	}
+}
+
+func toPolicy(dbp dbPolicy) auth.Policy {
+	return auth.Policy{
+		Subject: dbp.Subject,
+		Object:  dbp.Object,
+		Action:  dbp.Action,
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/auth/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicySave(t *testing.T) {
+	t.Cleanup(func() { cleanUp(t) })
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewPolicyRepo(dbMiddleware)
+
+	subID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	p := auth.Policy{Subject: subID, Object: "object", Action: "read"}
+
+	err = repo.Save(context.Background(), p)
+	assert.Nil(t, err, fmt.Sprintf("save policy: unexpected error: %s", err))
+
+	err = repo.Save(context.Background(), p)
+	assert.Nil(t, err, fmt.Sprintf("save existing policy: unexpected error: %s", err))
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	t.Cleanup(func() { cleanUp(t) })
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewPolicyRepo(dbMiddleware)
+	groupRepo := postgres.NewGroupRepo(dbMiddleware)
+
+	userID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	memberID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thingID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	parent, err := groupRepo.Save(context.Background(), auth.Group{ID: generateGroupID(t), OwnerID: userID, Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+	child, err := groupRepo.Save(context.Background(), auth.Group{ID: generateGroupID(t), OwnerID: userID, ParentID: parent.ID, Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+	things, err := groupRepo.Save(context.Background(), auth.Group{ID: generateGroupID(t), OwnerID: userID, Name: groupName + "things"})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	err = groupRepo.Assign(context.Background(), child.ID, "users", memberID)
+	require.Nil(t, err, fmt.Sprintf("member assign got unexpected error: %s", err))
+	err = groupRepo.Assign(context.Background(), things.ID, "things", thingID)
+	require.Nil(t, err, fmt.Sprintf("member assign got unexpected error: %s", err))
+
+	err = repo.Save(context.Background(),
+		auth.Policy{Subject: userID, Object: "object", Action: "read"},
+		auth.Policy{Subject: parent.ID, Object: "object", Action: "write"},
+		auth.Policy{Subject: userID, Object: things.ID, Action: "delete"},
+	)
+	require.Nil(t, err, fmt.Sprintf("save policies got unexpected error: %s", err))
+
+	cases := []struct {
+		desc       string
+		policy     auth.Policy
+		authorized bool
+	}{
+		{
+			desc:       "evaluate direct policy",
+			policy:     auth.Policy{Subject: userID, Object: "object", Action: "read"},
+			authorized: true,
+		},
+		{
+			desc:       "evaluate direct policy with wrong action",
+			policy:     auth.Policy{Subject: userID, Object: "object", Action: "write"},
+			authorized: false,
+		},
+		{
+			desc:       "evaluate policy inherited by member of a child group",
+			policy:     auth.Policy{Subject: memberID, Object: "object", Action: "write"},
+			authorized: true,
+		},
+		{
+			desc:       "evaluate policy inherited by a child group",
+			policy:     auth.Policy{Subject: child.ID, Object: "object", Action: "write"},
+			authorized: true,
+		},
+		{
+			desc:       "evaluate policy over object group",
+			policy:     auth.Policy{Subject: userID, Object: thingID, Action: "delete"},
+			authorized: true,
+		},
+		{
+			desc:       "evaluate non-existing policy",
+			policy:     auth.Policy{Subject: memberID, Object: thingID, Action: "delete"},
+			authorized: false,
+		},
+	}
+
+	for _, tc := range cases {
+		authorized, err := repo.Evaluate(context.Background(), tc.policy)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.authorized, authorized, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.authorized, authorized))
+	}
+}
+
+func TestPolicyRetrieveObjects(t *testing.T) {
+	t.Cleanup(func() { cleanUp(t) })
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewPolicyRepo(dbMiddleware)
+	groupRepo := postgres.NewGroupRepo(dbMiddleware)
+
+	userID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	memberID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	group, err := groupRepo.Save(context.Background(), auth.Group{ID: generateGroupID(t), OwnerID: userID, Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+	err = groupRepo.Assign(context.Background(), group.ID, "users", memberID)
+	require.Nil(t, err, fmt.Sprintf("member assign got unexpected error: %s", err))
+
+	err = repo.Save(context.Background(),
+		auth.Policy{Subject: memberID, Object: "object1", Action: "read"},
+		auth.Policy{Subject: group.ID, Object: "object2", Action: "read"},
+		auth.Policy{Subject: group.ID, Object: "object3", Action: "write"},
+	)
+	require.Nil(t, err, fmt.Sprintf("save policies got unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		subject string
+		action  string
+		objects []string
+	}{
+		{
+			desc:    "retrieve objects with direct and group policies",
+			subject: memberID,
+			action:  "read",
+			objects: []string{"object1", "object2"},
+		},
+		{
+			desc:    "retrieve objects with group policies",
+			subject: memberID,
+			action:  "write",
+			objects: []string{"object3"},
+		},
+		{
+			desc:    "retrieve objects without policies",
+			subject: userID,
+			action:  "read",
+			objects: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		objects, err := repo.RetrieveObjects(context.Background(), tc.subject, tc.action)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.objects, objects, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.objects, objects))
+	}
+}
+
+func TestPolicyRetrieveAll(t *testing.T) {
+	t.Cleanup(func() { cleanUp(t) })
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewPolicyRepo(dbMiddleware)
+
+	userID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	subID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = repo.Save(context.Background(),
+		auth.Policy{Subject: userID, Object: "object", Action: "read"},
+		auth.Policy{Subject: subID, Object: "object", Action: "read"},
+		auth.Policy{Subject: subID, Object: "object", Action: "write"},
+	)
+	require.Nil(t, err, fmt.Sprintf("save policies got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		filter auth.Policy
+		pm     auth.PageMetadata
+		total  uint64
+		size   uint64
+	}{
+		{
+			desc:  "retrieve all manageable policies",
+			pm:    auth.PageMetadata{Limit: 10},
+			total: 2,
+			size:  2,
+		},
+		{
+			desc:   "retrieve policies filtered by subject",
+			filter: auth.Policy{Subject: subID},
+			pm:     auth.PageMetadata{Limit: 10},
+			total:  1,
+			size:   1,
+		},
+		{
+			desc:  "retrieve policies with limit",
+			pm:    auth.PageMetadata{Limit: 1},
+			total: 2,
+			size:  1,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), userID, tc.filter, tc.pm)
+		size := uint64(len(page.Policies))
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, size))
+	}
+}
+
+func TestPolicyRemove(t *testing.T) {
+	t.Cleanup(func() { cleanUp(t) })
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewPolicyRepo(dbMiddleware)
+
+	subID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	p := auth.Policy{Subject: subID, Object: "object", Action: "read"}
+	err = repo.Save(context.Background(), p)
+	require.Nil(t, err, fmt.Sprintf("save policy got unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), p)
+	assert.Nil(t, err, fmt.Sprintf("remove policy: unexpected error: %s", err))
+
+	authorized, err := repo.Evaluate(context.Background(), p)
+	assert.Nil(t, err, fmt.Sprintf("evaluate policy: unexpected error: %s", err))
+	assert.False(t, authorized, "removed policy expected not to authorize")
+}
+
+func TestPolicyRetrieveOwner(t *testing.T) {
+	t.Cleanup(func() { cleanUp(t) })
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewPolicyRepo(dbMiddleware)
+
+	ownerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	otherID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	_, err = repo.RetrieveOwner(context.Background(), "object")
+	assert.True(t, errors.Contains(err, auth.ErrNotFound), fmt.Sprintf("retrieve owner of unowned object: expected %s got %s\n", auth.ErrNotFound, err))
+
+	err = repo.Save(context.Background(), auth.Policy{Subject: ownerID, Object: "object", Action: auth.OwnerAction})
+	require.Nil(t, err, fmt.Sprintf("save policy got unexpected error: %s", err))
+
+	// The object can have a single owner, so saving another one is a no-op.
+	err = repo.Save(context.Background(), auth.Policy{Subject: otherID, Object: "object", Action: auth.OwnerAction})
+	assert.Nil(t, err, fmt.Sprintf("save another owner: unexpected error: %s", err))
+
+	owner, err := repo.RetrieveOwner(context.Background(), "object")
+	assert.Nil(t, err, fmt.Sprintf("retrieve owner: unexpected error: %s", err))
+	assert.Equal(t, ownerID, owner, fmt.Sprintf("retrieve owner: expected %s got %s\n", ownerID, owner))
+}
@@ -54,6 +54,7 @@ var (
 	errRevoke    = errors.New("failed to remove key")
 	errRetrieve  = errors.New("failed to retrieve key data")
 	errIdentify  = errors.New("failed to validate token")
+	errAuthorize = errors.New("failed to evaluate policies")
 )
 
 // Authn specifies an API that must be fullfiled by the domain service
@@ -81,8 +82,25 @@ type Authn interface {
 // Authz specifies an API for the authorization and will be implemented
 // by evaluation of policies.
 type Authz interface {
-	// Authorize checks access rights
-	Authorize(ctx context.Context, token, sub, obj, act string) (bool, error)
+	// Authorize checks whether the subject is allowed to perform the action
+	// on the object, either directly or through the groups hierarchy.
+	Authorize(ctx context.Context, sub, obj, act string) (bool, error)
+
+	// AddPolicy saves the policy on behalf of the user identified by the
+	// token. It is meant to be used by the other services. The same rules
+	// as in AddPolicies apply, except that the policy with the owner
+	// action lets the user claim the object that has no owner yet, which
+	// the services do for the entities they create.
+	AddPolicy(ctx context.Context, token string, p Policy) error
+
+	// DeletePolicy removes the policy on behalf of the user identified by
+	// the token. It is meant to be used by the other services and, as in
+	// RemovePolicies, only the owner of the object can remove the policy.
+	DeletePolicy(ctx context.Context, token string, p Policy) error
+
+	// ListObjects retrieves IDs of the objects the subject has been granted
+	// the action on, either directly or through the groups hierarchy.
+	ListObjects(ctx context.Context, sub, act string) ([]string, error)
 }
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -93,6 +111,9 @@ type Service interface {
 	Authn
 	Authz
 
+	// Implements policies API, adding, removing and listing policies
+	PolicyService
+
 	// Implements groups API, creating groups, assigning members
 	GroupService
 }
@@ -102,17 +123,19 @@ var _ Service = (*service)(nil)
 type service struct {
 	keys         KeyRepository
 	groups       GroupRepository
+	policies     PolicyRepository
 	idProvider   mainflux.IDProvider
 	ulidProvider mainflux.IDProvider
 	tokenizer    Tokenizer
 }
 
 // New instantiates the auth service implementation.
-func New(keys KeyRepository, groups GroupRepository, idp mainflux.IDProvider, tokenizer Tokenizer) Service {
+func New(keys KeyRepository, groups GroupRepository, policies PolicyRepository, idp mainflux.IDProvider, tokenizer Tokenizer) Service {
 	return &service{
 		tokenizer:    tokenizer,
 		keys:         keys,
 		groups:       groups,
+		policies:     policies,
 		idProvider:   idp,
 		ulidProvider: ulid.New(),
 	}
@@ -170,8 +193,202 @@ func (svc service) Identify(ctx context.Context, token string) (Identity, error)
 	}
 }
 
-func (svc service) Authorize(ctx context.Context, token, sub, obj, act string) (bool, error) {
-	return true, nil
+func (svc service) Authorize(ctx context.Context, sub, obj, act string) (bool, error) {
+	p := Policy{
+		Subject: sub,
+		Object:  obj,
+		Action:  act,
+	}
+	authorized, err := svc.policies.Evaluate(ctx, p)
+	if err != nil {
+		return false, errors.Wrap(errAuthorize, err)
+	}
+
+	return authorized, nil
+}
+
+func (svc service) AddPolicy(ctx context.Context, token string, p Policy) error {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if p.Subject == "" || p.Object == "" || p.Action == "" {
+		return ErrMalformedEntity
+	}
+
+	if p.Action == OwnerAction {
+		return svc.claim(ctx, user.ID, p)
+	}
+
+	if err := svc.canGrant(ctx, user.ID, p.Object, []string{p.Action}); err != nil {
+		return err
+	}
+
+	return svc.policies.Save(ctx, p)
+}
+
+func (svc service) DeletePolicy(ctx context.Context, token string, p Policy) error {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if p.Subject == "" || p.Object == "" || p.Action == "" {
+		return ErrMalformedEntity
+	}
+
+	if err := svc.canRevoke(ctx, user.ID, p.Object, []string{p.Action}); err != nil {
+		return err
+	}
+
+	return svc.policies.Remove(ctx, p)
+}
+
+func (svc service) ListObjects(ctx context.Context, sub, act string) ([]string, error) {
+	return svc.policies.RetrieveObjects(ctx, sub, act)
+}
+
+func (svc service) AddPolicies(ctx context.Context, token, object string, subjectIDs, actions []string) error {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if err := svc.canGrant(ctx, user.ID, object, actions); err != nil {
+		return err
+	}
+
+	return svc.policies.Save(ctx, toPolicies(object, subjectIDs, actions)...)
+}
+
+func (svc service) RemovePolicies(ctx context.Context, token, object string, subjectIDs, actions []string) error {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if err := svc.canRevoke(ctx, user.ID, object, actions); err != nil {
+		return err
+	}
+
+	return svc.policies.Remove(ctx, toPolicies(object, subjectIDs, actions)...)
+}
+
+func (svc service) ListPolicies(ctx context.Context, token string, filter Policy, pm PageMetadata) (PolicyPage, error) {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return PolicyPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return svc.policies.RetrieveAll(ctx, user.ID, filter, pm)
+}
+
+// canGrant checks whether the user is allowed to grant the actions on
+// the object. The owner can grant any action, while the others can only
+// delegate the actions they are allowed to perform. The owner action
+// can't be granted.
+func (svc service) canGrant(ctx context.Context, userID, object string, actions []string) error {
+	for _, act := range actions {
+		if act == OwnerAction {
+			return ErrUnauthorizedAccess
+		}
+	}
+
+	owner, err := svc.owns(ctx, userID, object)
+	if err != nil {
+		return err
+	}
+	if owner {
+		return nil
+	}
+
+	for _, act := range actions {
+		authorized, err := svc.Authorize(ctx, userID, object, act)
+		if err != nil {
+			return err
+		}
+		if !authorized {
+			return ErrUnauthorizedAccess
+		}
+	}
+
+	return nil
+}
+
+// canRevoke checks whether the user is allowed to revoke the actions on
+// the object. Only the owner can revoke the actions, and the owner action
+// can't be revoked.
+func (svc service) canRevoke(ctx context.Context, userID, object string, actions []string) error {
+	for _, act := range actions {
+		if act == OwnerAction {
+			return ErrUnauthorizedAccess
+		}
+	}
+
+	owner, err := svc.owns(ctx, userID, object)
+	if err != nil {
+		return err
+	}
+	if !owner {
+		return ErrUnauthorizedAccess
+	}
+
+	return nil
+}
+
+// claim makes the user the owner of the object. The user can only claim
+// the object for themselves, and only if the object is not a group and is
+// not owned yet. Claiming the object the user already owns is a no-op.
+func (svc service) claim(ctx context.Context, userID string, p Policy) error {
+	if p.Subject != userID || p.Object == AuthoritiesObject {
+		return ErrUnauthorizedAccess
+	}
+
+	if _, err := svc.groups.RetrieveByID(ctx, p.Object); err == nil {
+		return ErrUnauthorizedAccess
+	} else if !errors.Contains(err, ErrGroupNotFound) {
+		return err
+	}
+
+	// The owner is unique, so the concurrent claims are resolved by the
+	// repository and the claim succeeds only if it was saved.
+	if err := svc.policies.Save(ctx, p); err != nil {
+		return err
+	}
+
+	owner, err := svc.policies.RetrieveOwner(ctx, p.Object)
+	if err != nil {
+		return err
+	}
+	if owner != userID {
+		return ErrUnauthorizedAccess
+	}
+
+	return nil
+}
+
+// owns checks whether the user owns the object. The group is owned by the
+// user who created it, while the other objects are owned by the subject
+// holding the owner action on them.
+func (svc service) owns(ctx context.Context, userID, object string) (bool, error) {
+	group, err := svc.groups.RetrieveByID(ctx, object)
+	if err == nil {
+		return group.OwnerID == userID, nil
+	}
+	if !errors.Contains(err, ErrGroupNotFound) {
+		return false, err
+	}
+
+	owner, err := svc.policies.RetrieveOwner(ctx, object)
+	if errors.Contains(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return owner == userID, nil
 }
 
 func (svc service) tmpKey(duration time.Duration, key Key) (Key, string, error) {
@@ -328,6 +545,21 @@ func (svc service) ListMemberships(ctx context.Context, token string, memberID s
 	return svc.groups.Memberships(ctx, memberID, pm)
 }
 
+func toPolicies(object string, subjectIDs, actions []string) []Policy {
+	var policies []Policy
+	for _, sub := range subjectIDs {
+		for _, act := range actions {
+			policies = append(policies, Policy{
+				Subject: sub,
+				Object:  object,
+				Action:  act,
+			})
+		}
+	}
+
+	return policies
+}
+
 func getTimestmap() time.Time {
 	return time.Now().UTC().Round(time.Millisecond)
 }
@@ -29,11 +29,17 @@ const (
 )
 
 func newService() auth.Service {
+	svc, _ := newServiceWithPolicies()
+	return svc
+}
+
+func newServiceWithPolicies() (auth.Service, auth.PolicyRepository) {
 	repo := mocks.NewKeyRepository()
 	groupRepo := mocks.NewGroupRepository()
+	policyRepo := mocks.NewPolicyRepository(groupRepo)
 	idProvider := uuid.NewMock()
 	t := jwt.New(secret)
-	return auth.New(repo, groupRepo, idProvider, t)
+	return auth.New(repo, groupRepo, policyRepo, idProvider, t), policyRepo
 }
 
 func TestIssue(t *testing.T) {
@@ -981,3 +987,628 @@ func TestUnassign(t *testing.T) {
 	err = svc.Unassign(context.Background(), apiToken, group.ID, mid)
 	assert.True(t, errors.Contains(err, auth.ErrGroupNotFound), fmt.Sprintf("Unauthorized access: expected %v got %v", nil, err))
 }
+
+func TestAuthorize(t *testing.T) {
+	svc, policies := newServiceWithPolicies()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	parent, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+	child, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName + "child", ParentID: parent.ID})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	memberID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = svc.Assign(context.Background(), secret, child.ID, "users", memberID)
+	require.Nil(t, err, fmt.Sprintf("member assign unexpected error: %s", err))
+
+	thingID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thingsGroup, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName + "things"})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+	err = svc.Assign(context.Background(), secret, thingsGroup.ID, "things", thingID)
+	require.Nil(t, err, fmt.Sprintf("member assign unexpected error: %s", err))
+
+	err = policies.Save(context.Background(),
+		auth.Policy{Subject: id, Object: "object", Action: "read"},
+		auth.Policy{Subject: parent.ID, Object: "object", Action: "write"},
+		auth.Policy{Subject: id, Object: thingsGroup.ID, Action: "delete"},
+	)
+	require.Nil(t, err, fmt.Sprintf("saving policies expected to succeed: %s", err))
+
+	cases := []struct {
+		desc       string
+		sub        string
+		obj        string
+		act        string
+		authorized bool
+	}{
+		{
+			desc:       "authorize with direct policy",
+			sub:        id,
+			obj:        "object",
+			act:        "read",
+			authorized: true,
+		},
+		{
+			desc:       "authorize with direct policy and wrong action",
+			sub:        id,
+			obj:        "object",
+			act:        "write",
+			authorized: false,
+		},
+		{
+			desc:       "authorize member of a child group with parent group policy",
+			sub:        memberID,
+			obj:        "object",
+			act:        "write",
+			authorized: true,
+		},
+		{
+			desc:       "authorize child group with parent group policy",
+			sub:        child.ID,
+			obj:        "object",
+			act:        "write",
+			authorized: true,
+		},
+		{
+			desc:       "authorize member of a child group without policy",
+			sub:        memberID,
+			obj:        "object",
+			act:        "read",
+			authorized: false,
+		},
+		{
+			desc:       "authorize with policy over object group",
+			sub:        id,
+			obj:        thingID,
+			act:        "delete",
+			authorized: true,
+		},
+		{
+			desc:       "authorize with non-existing object",
+			sub:        id,
+			obj:        "unknown",
+			act:        "read",
+			authorized: false,
+		},
+	}
+
+	for _, tc := range cases {
+		authorized, err := svc.Authorize(context.Background(), tc.sub, tc.obj, tc.act)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.authorized, authorized, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.authorized, authorized))
+	}
+}
+
+func TestAddPolicy(t *testing.T) {
+	svc, _ := newServiceWithPolicies()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	otherID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: otherID, Subject: "other@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	subID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	group, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		policy auth.Policy
+		err    error
+	}{
+		{
+			desc:   "claim unowned object",
+			token:  secret,
+			policy: auth.Policy{Subject: id, Object: "object", Action: auth.OwnerAction},
+			err:    nil,
+		},
+		{
+			desc:   "claim object owned by the invoker",
+			token:  secret,
+			policy: auth.Policy{Subject: id, Object: "object", Action: auth.OwnerAction},
+			err:    nil,
+		},
+		{
+			desc:   "claim object owned by another user",
+			token:  otherSecret,
+			policy: auth.Policy{Subject: otherID, Object: "object", Action: auth.OwnerAction},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "claim object for another user",
+			token:  secret,
+			policy: auth.Policy{Subject: subID, Object: "other", Action: auth.OwnerAction},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "claim group",
+			token:  otherSecret,
+			policy: auth.Policy{Subject: otherID, Object: group.ID, Action: auth.OwnerAction},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "claim the authorities",
+			token:  otherSecret,
+			policy: auth.Policy{Subject: otherID, Object: auth.AuthoritiesObject, Action: auth.OwnerAction},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "add policy as the owner",
+			token:  secret,
+			policy: auth.Policy{Subject: subID, Object: "object", Action: "read"},
+			err:    nil,
+		},
+		{
+			desc:   "add existing policy",
+			token:  secret,
+			policy: auth.Policy{Subject: subID, Object: "object", Action: "read"},
+			err:    nil,
+		},
+		{
+			desc:   "add policy granting the action to a third party as a non-owner",
+			token:  otherSecret,
+			policy: auth.Policy{Subject: subID, Object: "object", Action: "write"},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "add policy granting the action to the invoker as a non-owner",
+			token:  otherSecret,
+			policy: auth.Policy{Subject: otherID, Object: "object", Action: "read"},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "add policy granting the membership in the authorities",
+			token:  otherSecret,
+			policy: auth.Policy{Subject: otherID, Object: auth.AuthoritiesObject, Action: auth.MemberRelation},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "add policy with invalid token",
+			token:  "invalid",
+			policy: auth.Policy{Subject: subID, Object: "object", Action: "write"},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "add policy with empty subject",
+			token:  secret,
+			policy: auth.Policy{Object: "object", Action: "read"},
+			err:    auth.ErrMalformedEntity,
+		},
+		{
+			desc:   "add policy with empty action",
+			token:  secret,
+			policy: auth.Policy{Subject: subID, Object: "object"},
+			err:    auth.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.AddPolicy(context.Background(), tc.token, tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	authorized, err := svc.Authorize(context.Background(), subID, "object", "read")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, authorized, "expected added policy to authorize")
+
+	for _, p := range []auth.Policy{
+		{Subject: subID, Object: "object", Action: "write"},
+		{Subject: otherID, Object: "object", Action: "read"},
+		{Subject: otherID, Object: auth.AuthoritiesObject, Action: auth.MemberRelation},
+	} {
+		authorized, err = svc.Authorize(context.Background(), p.Subject, p.Object, p.Action)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.False(t, authorized, fmt.Sprintf("expected rejected policy %v not to authorize", p))
+	}
+
+	deleteCases := []struct {
+		desc   string
+		token  string
+		policy auth.Policy
+		err    error
+	}{
+		{
+			desc:   "delete policy with invalid token",
+			token:  "invalid",
+			policy: auth.Policy{Subject: subID, Object: "object", Action: "read"},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "delete policy as a non-owner",
+			token:  otherSecret,
+			policy: auth.Policy{Subject: subID, Object: "object", Action: "read"},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "delete the owner policy",
+			token:  secret,
+			policy: auth.Policy{Subject: id, Object: "object", Action: auth.OwnerAction},
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "delete policy as the owner",
+			token:  secret,
+			policy: auth.Policy{Subject: subID, Object: "object", Action: "read"},
+			err:    nil,
+		},
+	}
+
+	for _, tc := range deleteCases {
+		err := svc.DeletePolicy(context.Background(), tc.token, tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	authorized, err = svc.Authorize(context.Background(), subID, "object", "read")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.False(t, authorized, "expected deleted policy not to authorize")
+}
+
+func TestListObjects(t *testing.T) {
+	svc, policies := newServiceWithPolicies()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	group, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+	memberID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = svc.Assign(context.Background(), secret, group.ID, "users", memberID)
+	require.Nil(t, err, fmt.Sprintf("member assign unexpected error: %s", err))
+
+	err = policies.Save(context.Background(),
+		auth.Policy{Subject: memberID, Object: "object1", Action: "read"},
+		auth.Policy{Subject: group.ID, Object: "object2", Action: "read"},
+		auth.Policy{Subject: group.ID, Object: "object3", Action: "write"},
+	)
+	require.Nil(t, err, fmt.Sprintf("saving policies expected to succeed: %s", err))
+
+	cases := []struct {
+		desc    string
+		sub     string
+		act     string
+		objects []string
+	}{
+		{
+			desc:    "list objects with direct and group policies",
+			sub:     memberID,
+			act:     "read",
+			objects: []string{"object1", "object2"},
+		},
+		{
+			desc:    "list objects with group policies",
+			sub:     memberID,
+			act:     "write",
+			objects: []string{"object3"},
+		},
+		{
+			desc:    "list objects without policies",
+			sub:     id,
+			act:     "read",
+			objects: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		objects, err := svc.ListObjects(context.Background(), tc.sub, tc.act)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.objects, objects, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.objects, objects))
+	}
+}
+
+func TestAddPolicies(t *testing.T) {
+	svc, policies := newServiceWithPolicies()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	ownerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, ownerSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: "owner@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = policies.Save(context.Background(),
+		auth.Policy{Subject: id, Object: "object", Action: "read"},
+		auth.Policy{Subject: ownerID, Object: "object", Action: auth.OwnerAction},
+	)
+	require.Nil(t, err, fmt.Sprintf("saving policies expected to succeed: %s", err))
+
+	subID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		object   string
+		subjects []string
+		actions  []string
+		err      error
+	}{
+		{
+			desc:     "add policies with delegated action",
+			token:    secret,
+			object:   "object",
+			subjects: []string{subID},
+			actions:  []string{"read"},
+			err:      nil,
+		},
+		{
+			desc:     "add policies with action not held by the user",
+			token:    secret,
+			object:   "object",
+			subjects: []string{subID},
+			actions:  []string{"read", "write"},
+			err:      auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "add policies as the owner",
+			token:    ownerSecret,
+			object:   "object",
+			subjects: []string{subID},
+			actions:  []string{"delete"},
+			err:      nil,
+		},
+		{
+			desc:     "add policies granting the owner action",
+			token:    ownerSecret,
+			object:   "object",
+			subjects: []string{subID},
+			actions:  []string{auth.OwnerAction},
+			err:      auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "add policies to the authorities as a non-member",
+			token:    secret,
+			object:   auth.AuthoritiesObject,
+			subjects: []string{subID},
+			actions:  []string{auth.MemberRelation},
+			err:      auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "add policies with wrong credentials",
+			token:    "wrongToken",
+			object:   "object",
+			subjects: []string{subID},
+			actions:  []string{"read"},
+			err:      auth.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.AddPolicies(context.Background(), tc.token, tc.object, tc.subjects, tc.actions)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	authorized, err := svc.Authorize(context.Background(), subID, "object", "read")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, authorized, "subject expected to be authorized after adding policy")
+
+	authorized, err = svc.Authorize(context.Background(), subID, "object", "delete")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, authorized, "subject expected to be authorized after owner added policy")
+
+	authorized, err = svc.Authorize(context.Background(), subID, "object", "write")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.False(t, authorized, "subject expected not to be authorized for not added policy")
+
+	owner, err := policies.RetrieveOwner(context.Background(), "object")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, ownerID, owner, fmt.Sprintf("expected owner %s got %s", ownerID, owner))
+}
+
+func TestRemovePolicies(t *testing.T) {
+	svc, policies := newServiceWithPolicies()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	granteeID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, granteeSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: granteeID, Subject: "grantee@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	subID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	group, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	err = policies.Save(context.Background(),
+		auth.Policy{Subject: id, Object: "object", Action: auth.OwnerAction},
+		auth.Policy{Subject: granteeID, Object: "object", Action: "read"},
+		auth.Policy{Subject: subID, Object: "object", Action: "read"},
+		auth.Policy{Subject: subID, Object: group.ID, Action: "read"},
+	)
+	require.Nil(t, err, fmt.Sprintf("saving policies expected to succeed: %s", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		object   string
+		subjects []string
+		actions  []string
+		err      error
+	}{
+		{
+			desc:     "remove policies with wrong credentials",
+			token:    "wrongToken",
+			object:   "object",
+			subjects: []string{subID},
+			actions:  []string{"read"},
+			err:      auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "remove policies as a grantee of the action",
+			token:    granteeSecret,
+			object:   "object",
+			subjects: []string{subID},
+			actions:  []string{"read"},
+			err:      auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "remove the owner action",
+			token:    secret,
+			object:   "object",
+			subjects: []string{id},
+			actions:  []string{auth.OwnerAction},
+			err:      auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "remove policies as the owner",
+			token:    secret,
+			object:   "object",
+			subjects: []string{subID},
+			actions:  []string{"read"},
+			err:      nil,
+		},
+		{
+			desc:     "remove policies as the group owner",
+			token:    secret,
+			object:   group.ID,
+			subjects: []string{subID},
+			actions:  []string{"read"},
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemovePolicies(context.Background(), tc.token, tc.object, tc.subjects, tc.actions)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	for _, obj := range []string{"object", group.ID} {
+		authorized, err := svc.Authorize(context.Background(), subID, obj, "read")
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.False(t, authorized, fmt.Sprintf("subject expected not to be authorized on %s after removing policy", obj))
+	}
+
+	owner, err := policies.RetrieveOwner(context.Background(), "object")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, id, owner, fmt.Sprintf("expected owner %s got %s", id, owner))
+}
+
+func TestSeedAdmin(t *testing.T) {
+	svc, policies := newServiceWithPolicies()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	otherID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		userID string
+		err    error
+	}{
+		{
+			desc:   "seed admin",
+			userID: id,
+			err:    nil,
+		},
+		{
+			desc:   "seed existing admin",
+			userID: id,
+			err:    nil,
+		},
+		{
+			desc:   "seed admin when the authorities are owned by another user",
+			userID: otherID,
+			err:    auth.ErrConflict,
+		},
+		{
+			desc:   "seed admin with empty ID",
+			userID: "",
+			err:    auth.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := auth.SeedAdmin(context.Background(), policies, tc.userID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	authorized, err := svc.Authorize(context.Background(), id, auth.AuthoritiesObject, auth.MemberRelation)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, authorized, "seeded admin expected to be a member of the authorities")
+
+	err = svc.AddPolicies(context.Background(), secret, auth.AuthoritiesObject, []string{otherID}, []string{auth.MemberRelation})
+	assert.Nil(t, err, fmt.Sprintf("adding admin expected to succeed: %s", err))
+
+	err = svc.RemovePolicies(context.Background(), secret, auth.AuthoritiesObject, []string{otherID}, []string{auth.MemberRelation})
+	assert.Nil(t, err, fmt.Sprintf("removing admin expected to succeed: %s", err))
+
+	authorized, err = svc.Authorize(context.Background(), otherID, auth.AuthoritiesObject, auth.MemberRelation)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.False(t, authorized, "removed admin expected not to be a member of the authorities")
+}
+
+func TestListPolicies(t *testing.T) {
+	svc, policies := newServiceWithPolicies()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	subID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = policies.Save(context.Background(),
+		auth.Policy{Subject: id, Object: "object", Action: "read"},
+		auth.Policy{Subject: id, Object: "object", Action: "write"},
+		auth.Policy{Subject: subID, Object: "object", Action: "read"},
+		auth.Policy{Subject: subID, Object: "other", Action: "read"},
+	)
+	require.Nil(t, err, fmt.Sprintf("saving policies expected to succeed: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		filter auth.Policy
+		size   uint64
+		err    error
+	}{
+		{
+			desc:  "list all manageable policies",
+			token: secret,
+			size:  3,
+			err:   nil,
+		},
+		{
+			desc:   "list policies filtered by subject",
+			token:  secret,
+			filter: auth.Policy{Subject: subID},
+			size:   1,
+			err:    nil,
+		},
+		{
+			desc:   "list policies filtered by action",
+			token:  secret,
+			filter: auth.Policy{Action: "write"},
+			size:   1,
+			err:    nil,
+		},
+		{
+			desc:   "list policies over object the user has no rights on",
+			token:  secret,
+			filter: auth.Policy{Object: "other"},
+			size:   0,
+			err:    nil,
+		},
+		{
+			desc:  "list policies with wrong credentials",
+			token: "wrongToken",
+			size:  0,
+			err:   auth.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListPolicies(context.Background(), tc.token, tc.filter, auth.PageMetadata{Limit: 10})
+		size := uint64(len(page.Policies))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, size))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
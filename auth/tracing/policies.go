@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/auth"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	savePolicies        = "save_policies"
+	removePolicies      = "remove_policies"
+	retrieveAllPolicies = "retrieve_all_policies"
+	evaluatePolicies    = "evaluate_policies"
+	retrieveObjects     = "retrieve_objects"
+	retrieveOwner       = "retrieve_owner"
+)
+
+var _ auth.PolicyRepository = (*policyRepositoryMiddleware)(nil)
+
+type policyRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   auth.PolicyRepository
+}
+
+// PolicyRepositoryMiddleware tracks request and their latency, and adds spans to context.
+func PolicyRepositoryMiddleware(tracer opentracing.Tracer, pr auth.PolicyRepository) auth.PolicyRepository {
+	return policyRepositoryMiddleware{
+		tracer: tracer,
+		repo:   pr,
+	}
+}
+
+func (prm policyRepositoryMiddleware) Save(ctx context.Context, policies ...auth.Policy) error {
+	span := createSpan(ctx, prm.tracer, savePolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Save(ctx, policies...)
+}
+
+func (prm policyRepositoryMiddleware) Remove(ctx context.Context, policies ...auth.Policy) error {
+	span := createSpan(ctx, prm.tracer, removePolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Remove(ctx, policies...)
+}
+
+func (prm policyRepositoryMiddleware) RetrieveAll(ctx context.Context, subject string, filter auth.Policy, pm auth.PageMetadata) (auth.PolicyPage, error) {
+	span := createSpan(ctx, prm.tracer, retrieveAllPolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveAll(ctx, subject, filter, pm)
+}
+
+func (prm policyRepositoryMiddleware) Evaluate(ctx context.Context, p auth.Policy) (bool, error) {
+	span := createSpan(ctx, prm.tracer, evaluatePolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Evaluate(ctx, p)
+}
+
+func (prm policyRepositoryMiddleware) RetrieveObjects(ctx context.Context, subject, action string) ([]string, error) {
+	span := createSpan(ctx, prm.tracer, retrieveObjects)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveObjects(ctx, subject, action)
+}
+
+func (prm policyRepositoryMiddleware) RetrieveOwner(ctx context.Context, object string) (string, error) {
+	span := createSpan(ctx, prm.tracer, retrieveOwner)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveOwner(ctx, object)
+}
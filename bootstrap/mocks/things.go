@@ -7,6 +7,7 @@ import (
 	"context"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/things"
@@ -70,7 +71,7 @@ func (svc *mainfluxThings) ViewThing(_ context.Context, owner, id string) (thing
 	return things.Thing{}, things.ErrNotFound
 }
 
-func (svc *mainfluxThings) Connect(_ context.Context, owner string, chIDs, thIDs []string) error {
+func (svc *mainfluxThings) Connect(_ context.Context, owner string, chIDs, thIDs []string, _ things.ConnectionACL) error {
 	svc.mu.Lock()
 	defer svc.mu.Unlock()
 
@@ -166,7 +167,27 @@ func (svc *mainfluxThings) UpdateThing(context.Context, string, things.Thing) er
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) UpdateKey(context.Context, string, string, string) error {
+func (svc *mainfluxThings) UpdateThingStatus(context.Context, string, string, string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UpdateChannelStatus(context.Context, string, string, string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UpdateKey(context.Context, string, string, string, time.Duration) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) AddKey(context.Context, string, string, things.Key) (things.Key, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListKeys(context.Context, string, string) ([]things.Key, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RevokeKey(context.Context, string, string, string) error {
 	panic("not implemented")
 }
 
@@ -212,11 +233,11 @@ func (svc *mainfluxThings) RemoveChannel(context.Context, string, string) error
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) CanAccessByKey(context.Context, string, string) (string, error) {
+func (svc *mainfluxThings) CanAccessByKey(context.Context, string, string, string, string) (string, error) {
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) CanAccessByID(context.Context, string, string) error {
+func (svc *mainfluxThings) CanAccessByID(context.Context, string, string, string, string) error {
 	panic("not implemented")
 }
 
@@ -241,3 +262,35 @@ func findIndex(list []string, val string) int {
 func (svc *mainfluxThings) ListMembers(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.Page, error) {
 	panic("not implemented")
 }
+
+func (svc *mainfluxThings) ShareThing(ctx context.Context, token, thingID string, actions, subjectIDs []string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UnshareThing(ctx context.Context, token, thingID string, actions, subjectIDs []string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ShareChannel(ctx context.Context, token, chanID string, actions, subjectIDs []string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UnshareChannel(ctx context.Context, token, chanID string, actions, subjectIDs []string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ViewPresence(context.Context, string, string) (things.Presence, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ThingConnectedHandler(ctx context.Context, thingID string, at time.Time) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ThingDisconnectedHandler(ctx context.Context, thingID string, at time.Time) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ThingPublishedHandler(ctx context.Context, thingID string, at time.Time) error {
+	panic("not implemented")
+}
@@ -51,3 +51,15 @@ func (svc serviceMock) Members(ctx context.Context, req *mainflux.MembersReq, _
 func (svc serviceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc serviceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) ListObjects(ctx context.Context, req *mainflux.ListObjectsReq, _ ...grpc.CallOption) (r *mainflux.ListObjectsRes, err error) {
+	panic("not implemented")
+}
@@ -47,7 +47,7 @@ var cmdChannels = []cobra.Command{
 			}
 
 			if args[0] == "all" {
-				l, err := sdk.Channels(args[1], uint64(Offset), uint64(Limit), Name)
+				l, err := sdk.Channels(args[1], uint64(Offset), uint64(Limit), Name, Status)
 				if err != nil {
 					logError(err)
 					return
@@ -66,6 +66,42 @@ var cmdChannels = []cobra.Command{
 			logJSON(c)
 		},
 	},
+	cobra.Command{
+		Use:   "enable",
+		Short: "enable <channel_id> <user_auth_token>",
+		Long:  `Enables disabled channel`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			if err := sdk.EnableChannel(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+	cobra.Command{
+		Use:   "disable",
+		Short: "disable <channel_id> <user_auth_token>",
+		Long:  `Disables channel without removing it`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			if err := sdk.DisableChannel(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
 	cobra.Command{
 		Use:   "update",
 		Short: "update <JSON_string> <user_auth_token>",
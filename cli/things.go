@@ -47,7 +47,7 @@ var cmdThings = []cobra.Command{
 			}
 
 			if args[0] == "all" {
-				l, err := sdk.Things(args[1], uint64(Offset), uint64(Limit), Name)
+				l, err := sdk.Things(args[1], uint64(Offset), uint64(Limit), Name, Status)
 				if err != nil {
 					logError(err)
 					return
@@ -83,6 +83,61 @@ var cmdThings = []cobra.Command{
 			logOK()
 		},
 	},
+	cobra.Command{
+		Use:   "enable",
+		Short: "enable <thing_id> <user_auth_token>",
+		Long:  `Enables disabled thing`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			if err := sdk.EnableThing(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+	cobra.Command{
+		Use:   "disable",
+		Short: "disable <thing_id> <user_auth_token>",
+		Long:  `Disables thing without removing it`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			if err := sdk.DisableThing(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+	cobra.Command{
+		Use:   "presence",
+		Short: "presence <thing_id> <user_auth_token>",
+		Long:  `Get thing presence`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			p, err := sdk.ThingPresence(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(p)
+		},
+	},
 	cobra.Command{
 		Use:   "update",
 		Short: "update <JSON_string> <user_auth_token>",
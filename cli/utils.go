@@ -18,6 +18,8 @@ var (
 	Offset uint = 0
 	// Name query parameter
 	Name string = ""
+	// Status query parameter
+	Status string = ""
 	// ConfigPath config path parameter
 	ConfigPath string = ""
 	// RawOutput raw output mode
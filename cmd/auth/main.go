@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -47,6 +48,7 @@ const (
 	defServerCert    = ""
 	defServerKey     = ""
 	defJaegerURL     = ""
+	defAdminID       = ""
 
 	envLogLevel      = "MF_AUTH_LOG_LEVEL"
 	envDBHost        = "MF_AUTH_DB_HOST"
@@ -64,6 +66,7 @@ const (
 	envServerCert    = "MF_AUTH_SERVER_CERT"
 	envServerKey     = "MF_AUTH_SERVER_KEY"
 	envJaegerURL     = "MF_JAEGER_URL"
+	envAdminID       = "MF_AUTH_ADMIN_ID"
 )
 
 type config struct {
@@ -76,6 +79,7 @@ type config struct {
 	serverKey  string
 	jaegerURL  string
 	resetURL   string
+	adminID    string
 }
 
 type tokenConfig struct {
@@ -100,7 +104,7 @@ func main() {
 	dbTracer, dbCloser := initJaeger("auth_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
-	svc := newService(db, dbTracer, cfg.secret, logger)
+	svc := newService(db, dbTracer, cfg.secret, cfg.adminID, logger)
 	errs := make(chan error, 2)
 
 	go startHTTPServer(tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, errs)
@@ -138,6 +142,7 @@ func loadConfig() config {
 		serverCert: mainflux.Env(envServerCert, defServerCert),
 		serverKey:  mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:  mainflux.Env(envJaegerURL, defJaegerURL),
+		adminID:    mainflux.Env(envAdminID, defAdminID),
 	}
 
 }
@@ -175,17 +180,27 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
-func newService(db *sqlx.DB, tracer opentracing.Tracer, secret string, logger logger.Logger) auth.Service {
+func newService(db *sqlx.DB, tracer opentracing.Tracer, secret, adminID string, logger logger.Logger) auth.Service {
 	database := postgres.NewDatabase(db)
 	keysRepo := tracing.New(postgres.New(database), tracer)
 
 	groupsRepo := postgres.NewGroupRepo(database)
 	groupsRepo = tracing.GroupRepositoryMiddleware(tracer, groupsRepo)
 
+	policiesRepo := postgres.NewPolicyRepo(database)
+	policiesRepo = tracing.PolicyRepositoryMiddleware(tracer, policiesRepo)
+
+	if adminID != "" {
+		if err := auth.SeedAdmin(context.Background(), policiesRepo, adminID); err != nil {
+			logger.Error(fmt.Sprintf("Failed to seed the administrator %s: %s", adminID, err))
+			os.Exit(1)
+		}
+	}
+
 	idProvider := uuid.New()
 	t := jwt.New(secret)
 
-	svc := auth.New(keysRepo, groupsRepo, idProvider, t)
+	svc := auth.New(keysRepo, groupsRepo, policiesRepo, idProvider, t)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -158,6 +158,13 @@ func main() {
 		"name query parameter",
 	)
 
+	rootCmd.PersistentFlags().StringVar(
+		&cli.Status,
+		"status",
+		"",
+		"status query parameter",
+	)
+
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
 	}
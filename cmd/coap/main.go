@@ -20,8 +20,9 @@ import (
 	"github.com/mainflux/mainflux/coap"
 	"github.com/mainflux/mainflux/coap/api"
 	logger "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging/kafka"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
-	broker "github.com/nats-io/nats.go"
 	opentracing "github.com/opentracing/opentracing-go"
 	gocoap "github.com/plgd-dev/go-coap/v2"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -31,8 +32,14 @@ import (
 )
 
 const (
+	natsBroker  = "nats"
+	kafkaBroker = "kafka"
+
 	defPort              = "5683"
 	defNatsURL           = "nats://localhost:4222"
+	defBrokerType        = "nats"
+	defKafkaURL          = "localhost:9092"
+	defKafkaTopic        = "mainflux"
 	defLogLevel          = "error"
 	defClientTLS         = "false"
 	defCACerts           = ""
@@ -42,6 +49,9 @@ const (
 
 	envPort              = "MF_COAP_ADAPTER_PORT"
 	envNatsURL           = "MF_NATS_URL"
+	envBrokerType        = "MF_BROKER_TYPE"
+	envKafkaURL          = "MF_KAFKA_URL"
+	envKafkaTopic        = "MF_KAFKA_TOPIC"
 	envLogLevel          = "MF_COAP_ADAPTER_LOG_LEVEL"
 	envClientTLS         = "MF_COAP_ADAPTER_CLIENT_TLS"
 	envCACerts           = "MF_COAP_ADAPTER_CA_CERTS"
@@ -53,6 +63,9 @@ const (
 type config struct {
 	port              string
 	natsURL           string
+	brokerType        string
+	kafkaURL          string
+	kafkaConfig       kafka.Config
 	logLevel          string
 	clientTLS         bool
 	caCerts           string
@@ -77,13 +90,14 @@ func main() {
 
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsAuthTimeout)
 
-	nc, err := broker.Connect(cfg.natsURL)
+	pubSub, err := newPubSub(cfg, "", logger)
 	if err != nil {
-		log.Fatalf(err.Error())
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
 	}
-	defer nc.Close()
+	defer pubSub.Close()
 
-	svc := coap.New(tc, nc)
+	svc := coap.New(tc, pubSub)
 
 	svc = api.LoggingMiddleware(svc, logger)
 
@@ -131,6 +145,9 @@ func loadConfig() config {
 
 	return config{
 		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
+		brokerType:        mainflux.Env(envBrokerType, defBrokerType),
+		kafkaURL:          mainflux.Env(envKafkaURL, defKafkaURL),
+		kafkaConfig:       kafka.Config{Topic: mainflux.Env(envKafkaTopic, defKafkaTopic)},
 		port:              mainflux.Env(envPort, defPort),
 		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
 		clientTLS:         tls,
@@ -200,3 +217,16 @@ func startCOAPServer(cfg config, svc coap.Service, auth mainflux.ThingsServiceCl
 	l.Info(fmt.Sprintf("CoAP adapter service started, exposed port %s", cfg.port))
 	errs <- gocoap.ListenAndServe("udp", p, api.MakeCoAPHandler(svc, l))
 }
+
+// newPubSub returns the PubSub of the configured message broker. Parameter
+// queue is used as the Kafka consumer group.
+func newPubSub(cfg config, queue string, logger logger.Logger) (nats.PubSub, error) {
+	switch cfg.brokerType {
+	case kafkaBroker:
+		return kafka.NewPubSub(cfg.kafkaURL, queue, cfg.kafkaConfig, logger)
+	case natsBroker:
+		return nats.NewPubSub(cfg.natsURL, queue, logger)
+	default:
+		return nil, fmt.Errorf("unsupported message broker %s", cfg.brokerType)
+	}
+}
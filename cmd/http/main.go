@@ -22,6 +22,7 @@ import (
 	adapter "github.com/mainflux/mainflux/http"
 	"github.com/mainflux/mainflux/http/api"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging/kafka"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
 	"github.com/opentracing/opentracing-go"
@@ -31,11 +32,17 @@ import (
 )
 
 const (
+	natsBroker  = "nats"
+	kafkaBroker = "kafka"
+
 	defLogLevel          = "error"
 	defClientTLS         = "false"
 	defCACerts           = ""
 	defPort              = "8180"
 	defNatsURL           = "nats://localhost:4222"
+	defBrokerType        = "nats"
+	defKafkaURL          = "localhost:9092"
+	defKafkaTopic        = "mainflux"
 	defJaegerURL         = ""
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
@@ -45,6 +52,9 @@ const (
 	envCACerts           = "MF_HTTP_ADAPTER_CA_CERTS"
 	envPort              = "MF_HTTP_ADAPTER_PORT"
 	envNatsURL           = "MF_NATS_URL"
+	envBrokerType        = "MF_BROKER_TYPE"
+	envKafkaURL          = "MF_KAFKA_URL"
+	envKafkaTopic        = "MF_KAFKA_TOPIC"
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
@@ -52,6 +62,9 @@ const (
 
 type config struct {
 	natsURL           string
+	brokerType        string
+	kafkaURL          string
+	kafkaConfig       kafka.Config
 	logLevel          string
 	port              string
 	clientTLS         bool
@@ -78,9 +91,9 @@ func main() {
 	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
 	defer thingsCloser.Close()
 
-	pub, err := nats.NewPublisher(cfg.natsURL)
+	pub, err := newPublisher(cfg)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
 	}
 	defer pub.Close()
@@ -136,6 +149,9 @@ func loadConfig() config {
 
 	return config{
 		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
+		brokerType:        mainflux.Env(envBrokerType, defBrokerType),
+		kafkaURL:          mainflux.Env(envKafkaURL, defKafkaURL),
+		kafkaConfig:       kafka.Config{Topic: mainflux.Env(envKafkaTopic, defKafkaTopic)},
 		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
 		port:              mainflux.Env(envPort, defPort),
 		clientTLS:         tls,
@@ -193,3 +209,15 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	}
 	return conn
 }
+
+// newPublisher returns the Publisher of the configured message broker.
+func newPublisher(cfg config) (nats.Publisher, error) {
+	switch cfg.brokerType {
+	case kafkaBroker:
+		return kafka.NewPublisher(cfg.kafkaURL, cfg.kafkaConfig)
+	case natsBroker:
+		return nats.NewPublisher(cfg.natsURL)
+	default:
+		return nil, fmt.Errorf("unsupported message broker %s", cfg.brokerType)
+	}
+}
@@ -5,67 +5,141 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	influxdata "github.com/influxdata/influxdb/client/v2"
 	"github.com/mainflux/mainflux"
+	authapi "github.com/mainflux/mainflux/auth/api/grpc"
 	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	dlapi "github.com/mainflux/mainflux/consumers/deadletter/api"
+	dljetstream "github.com/mainflux/mainflux/consumers/deadletter/jetstream"
+	dlkafka "github.com/mainflux/mainflux/consumers/deadletter/kafka"
+	"github.com/mainflux/mainflux/consumers/deadletter/memory"
+	dlnats "github.com/mainflux/mainflux/consumers/deadletter/nats"
+	"github.com/mainflux/mainflux/consumers/retention"
+	retapi "github.com/mainflux/mainflux/consumers/retention/api"
+	"github.com/mainflux/mainflux/consumers/writers"
 	"github.com/mainflux/mainflux/consumers/writers/api"
 	"github.com/mainflux/mainflux/consumers/writers/influxdb"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/jetstream"
+	"github.com/mainflux/mainflux/pkg/messaging/kafka"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	"github.com/mainflux/mainflux/pkg/transformers"
 	"github.com/mainflux/mainflux/pkg/transformers/json"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
+	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
+	natsBroker  = "nats"
+	kafkaBroker = "kafka"
+
 	svcName = "influxdb-writer"
 
-	defNatsURL     = "nats://localhost:4222"
-	defLogLevel    = "error"
-	defPort        = "8180"
-	defDB          = "mainflux"
-	defDBHost      = "localhost"
-	defDBPort      = "8086"
-	defDBUser      = "mainflux"
-	defDBPass      = "mainflux"
-	defConfigPath  = "/config.toml"
-	defContentType = "application/senml+json"
-	defTransformer = "senml"
-
-	envNatsURL     = "MF_NATS_URL"
-	envLogLevel    = "MF_INFLUX_WRITER_LOG_LEVEL"
-	envPort        = "MF_INFLUX_WRITER_PORT"
-	envDB          = "MF_INFLUXDB_DB"
-	envDBHost      = "MF_INFLUX_WRITER_DB_HOST"
-	envDBPort      = "MF_INFLUXDB_PORT"
-	envDBUser      = "MF_INFLUXDB_ADMIN_USER"
-	envDBPass      = "MF_INFLUXDB_ADMIN_PASSWORD"
-	envConfigPath  = "MF_INFLUX_WRITER_CONFIG_PATH"
-	envContentType = "MF_INFLUX_WRITER_CONTENT_TYPE"
-	envTransformer = "MF_INFLUX_WRITER_TRANSFORMER"
+	defNatsURL           = "nats://localhost:4222"
+	defBrokerType        = "nats"
+	defKafkaURL          = "localhost:9092"
+	defKafkaTopic        = "mainflux"
+	defLogLevel          = "error"
+	defPort              = "8180"
+	defDB                = "mainflux"
+	defDBHost            = "localhost"
+	defDBPort            = "8086"
+	defDBUser            = "mainflux"
+	defDBPass            = "mainflux"
+	defConfigPath        = "/config.toml"
+	defContentType       = "application/senml+json"
+	defTransformer       = "senml"
+	defDLQSubject        = "deadletter.influxdb-writer"
+	defDLQLimit          = "1000"
+	defBatchSize         = "100"
+	defBatchInterval     = "1s"
+	defBatchRetryTimeout = "10s"
+	defPruneInterval     = "1h"
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defJaegerURL         = ""
+	defAuthURL           = "localhost:8181"
+	defAuthTimeout       = "1s"
+	defThingsAuthURL     = "localhost:8181"
+	defThingsAuthTimeout = "1s"
+
+	envNatsURL           = "MF_NATS_URL"
+	envBrokerType        = "MF_BROKER_TYPE"
+	envKafkaURL          = "MF_KAFKA_URL"
+	envKafkaTopic        = "MF_KAFKA_TOPIC"
+	envLogLevel          = "MF_INFLUX_WRITER_LOG_LEVEL"
+	envPort              = "MF_INFLUX_WRITER_PORT"
+	envDB                = "MF_INFLUXDB_DB"
+	envDBHost            = "MF_INFLUX_WRITER_DB_HOST"
+	envDBPort            = "MF_INFLUXDB_PORT"
+	envDBUser            = "MF_INFLUXDB_ADMIN_USER"
+	envDBPass            = "MF_INFLUXDB_ADMIN_PASSWORD"
+	envConfigPath        = "MF_INFLUX_WRITER_CONFIG_PATH"
+	envContentType       = "MF_INFLUX_WRITER_CONTENT_TYPE"
+	envTransformer       = "MF_INFLUX_WRITER_TRANSFORMER"
+	envDLQSubject        = "MF_INFLUX_WRITER_DLQ_SUBJECT"
+	envDLQLimit          = "MF_INFLUX_WRITER_DLQ_LIMIT"
+	envBatchSize         = "MF_INFLUX_WRITER_BATCH_SIZE"
+	envBatchInterval     = "MF_INFLUX_WRITER_BATCH_INTERVAL"
+	envBatchRetryTimeout = "MF_INFLUX_WRITER_BATCH_RETRY_TIMEOUT"
+	envPruneInterval     = "MF_INFLUX_WRITER_PRUNE_INTERVAL"
+	envClientTLS         = "MF_INFLUX_WRITER_CLIENT_TLS"
+	envCACerts           = "MF_INFLUX_WRITER_CA_CERTS"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envAuthURL           = "MF_AUTH_GRPC_URL"
+	envAuthTimeout       = "MF_AUTH_GRPC_TIMEOUT"
+	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 )
 
 type config struct {
-	natsURL     string
-	logLevel    string
-	port        string
-	dbName      string
-	dbHost      string
-	dbPort      string
-	dbUser      string
-	dbPass      string
-	configPath  string
-	contentType string
-	transformer string
+	natsURL           string
+	brokerType        string
+	kafkaURL          string
+	kafkaConfig       kafka.Config
+	jetStream         bool
+	jsConfig          jetstream.Config
+	logLevel          string
+	port              string
+	dbName            string
+	dbHost            string
+	dbPort            string
+	dbUser            string
+	dbPass            string
+	configPath        string
+	contentType       string
+	transformer       string
+	dlqSubject        string
+	dlqLimit          int
+	batchConfig       writers.BatchConfig
+	pruneInterval     time.Duration
+	clientTLS         bool
+	caCerts           string
+	jaegerURL         string
+	authURL           string
+	authTimeout       time.Duration
+	thingsAuthURL     string
+	thingsAuthTimeout time.Duration
 }
 
 func main() {
@@ -76,9 +150,9 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
-	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
+	pubSub, err := newPubSub(cfg, logger)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
 	}
 	defer pubSub.Close()
@@ -92,16 +166,61 @@ func main() {
 
 	repo := influxdb.New(client, cfg.dbName)
 
-	counter, latency := makeMetrics()
+	counter, latency, batchSize := makeMetrics()
 	repo = api.LoggingMiddleware(repo, logger)
-	repo = api.MetricsMiddleware(repo, counter, latency)
+	repo = api.MetricsMiddleware(repo, counter, latency, batchSize)
 	t := makeTransformer(cfg, logger)
 
-	if err := consumers.Start(pubSub, repo, t, cfg.configPath, logger); err != nil {
+	h := consumers.Handler(t, repo)
+
+	dlPub, err := newDeadLetterPublisher(cfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to dead-letter queue: %s", err))
+		os.Exit(1)
+	}
+	defer dlPub.Close()
+
+	dlRepo, closeDLRepo, err := newDeadLetterRepository(cfg, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create dead letters repository: %s", err))
+		os.Exit(1)
+	}
+	defer closeDLRepo()
+	dls := newDeadLetterService(dlRepo, dlPub, h, logger)
+
+	batch := writers.NewBatchConsumer(repo, cfg.batchConfig, logger)
+	defer batch.Close()
+
+	rs := newRetentionService(influxdb.NewRetentionRepository(client, cfg.dbName), influxdb.NewPruner(client, cfg.dbName), logger)
+	if cfg.pruneInterval > 0 {
+		stop := retention.StartPruning(rs, cfg.pruneInterval, logger)
+		defer stop()
+	}
+
+	// The messages which can never be stored are moved to the dead-letter
+	// queue instead of being redelivered.
+	dlh := deadletter.Handler(dls, consumers.Handler(t, batch), consumers.ErrTransform, influxdb.ErrInvalidMessage)
+	if err := consumers.Subscribe(pubSub, newHandler(cfg, dlh, logger), cfg.configPath, logger); err != nil {
 		logger.Error(fmt.Sprintf("Failed to start InfluxDB writer: %s", err))
 		os.Exit(1)
 	}
 
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(authTracer, authConn, cfg.authTimeout)
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	thingsConn := connectToThings(cfg, logger)
+	defer thingsConn.Close()
+
+	things := thingsapi.NewClient(thingsConn, thingsTracer, cfg.thingsAuthTimeout)
+
 	errs := make(chan error, 2)
 	go func() {
 		c := make(chan os.Signal)
@@ -109,25 +228,71 @@ func main() {
 		errs <- fmt.Errorf("%s", <-c)
 	}()
 
-	go startHTTPService(cfg.port, logger, errs)
+	go startHTTPService(cfg.port, auth, things, dls, rs, logger, errs)
 
 	err = <-errs
 	logger.Error(fmt.Sprintf("InfluxDB writer service terminated: %s", err))
 }
 
 func loadConfigs() (config, influxdata.HTTPConfig) {
+	dlqLimit, err := strconv.Atoi(mainflux.Env(envDLQLimit, defDLQLimit))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envDLQLimit, err.Error())
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authTimeout, err := time.ParseDuration(mainflux.Env(envAuthTimeout, defAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthTimeout, err.Error())
+	}
+
+	thingsAuthTimeout, err := time.ParseDuration(mainflux.Env(envThingsAuthTimeout, defThingsAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
+	}
+
+	batchConfig := loadBatchConfig()
+
+	// Messages are handled concurrently so that the batches can be filled,
+	// while each of them is acknowledged only once it's stored.
+	js, jsConfig, err := jetstream.LoadConfig()
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+	jsConfig.MaxInFlight = batchConfig.Size
+
 	cfg := config{
-		natsURL:     mainflux.Env(envNatsURL, defNatsURL),
-		logLevel:    mainflux.Env(envLogLevel, defLogLevel),
-		port:        mainflux.Env(envPort, defPort),
-		dbName:      mainflux.Env(envDB, defDB),
-		dbHost:      mainflux.Env(envDBHost, defDBHost),
-		dbPort:      mainflux.Env(envDBPort, defDBPort),
-		dbUser:      mainflux.Env(envDBUser, defDBUser),
-		dbPass:      mainflux.Env(envDBPass, defDBPass),
-		configPath:  mainflux.Env(envConfigPath, defConfigPath),
-		contentType: mainflux.Env(envContentType, defContentType),
-		transformer: mainflux.Env(envTransformer, defTransformer),
+		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
+		brokerType:        mainflux.Env(envBrokerType, defBrokerType),
+		kafkaURL:          mainflux.Env(envKafkaURL, defKafkaURL),
+		kafkaConfig:       kafka.Config{Topic: mainflux.Env(envKafkaTopic, defKafkaTopic), MaxInFlight: batchConfig.Size},
+		jetStream:         js,
+		jsConfig:          jsConfig,
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		port:              mainflux.Env(envPort, defPort),
+		dbName:            mainflux.Env(envDB, defDB),
+		dbHost:            mainflux.Env(envDBHost, defDBHost),
+		dbPort:            mainflux.Env(envDBPort, defDBPort),
+		dbUser:            mainflux.Env(envDBUser, defDBUser),
+		dbPass:            mainflux.Env(envDBPass, defDBPass),
+		configPath:        mainflux.Env(envConfigPath, defConfigPath),
+		contentType:       mainflux.Env(envContentType, defContentType),
+		transformer:       mainflux.Env(envTransformer, defTransformer),
+		dlqSubject:        mainflux.Env(envDLQSubject, defDLQSubject),
+		dlqLimit:          dlqLimit,
+		batchConfig:       batchConfig,
+		pruneInterval:     loadPruneInterval(),
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		authURL:           mainflux.Env(envAuthURL, defAuthURL),
+		authTimeout:       authTimeout,
+		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
+		thingsAuthTimeout: thingsAuthTimeout,
 	}
 
 	clientCfg := influxdata.HTTPConfig{
@@ -139,7 +304,7 @@ func loadConfigs() (config, influxdata.HTTPConfig) {
 	return cfg, clientCfg
 }
 
-func makeMetrics() (*kitprometheus.Counter, *kitprometheus.Summary) {
+func makeMetrics() (*kitprometheus.Counter, *kitprometheus.Summary, *kitprometheus.Summary) {
 	counter := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
 		Namespace: "influxdb",
 		Subsystem: "message_writer",
@@ -154,26 +319,247 @@ func makeMetrics() (*kitprometheus.Counter, *kitprometheus.Summary) {
 		Help:      "Total duration of inserts in microseconds.",
 	}, []string{"method"})
 
-	return counter, latency
+	batchSize := kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+		Namespace: "influxdb",
+		Subsystem: "message_writer",
+		Name:      "batch_size",
+		Help:      "Number of messages stored per insert.",
+	}, []string{"method"})
+
+	return counter, latency, batchSize
+}
+
+func newDeadLetterPublisher(cfg config) (dlnats.Publisher, error) {
+	switch cfg.brokerType {
+	case kafkaBroker:
+		return dlkafka.NewPublisher(cfg.kafkaURL, cfg.dlqSubject)
+	case natsBroker:
+		return dlnats.NewPublisher(cfg.natsURL, cfg.dlqSubject)
+	default:
+		return nil, fmt.Errorf("unsupported message broker %s", cfg.brokerType)
+	}
+}
+
+// newDeadLetterRepository returns the repository which persists the dead
+// letters in the JetStream stream if JetStream is enabled. Otherwise, the
+// latest dead letters are kept in memory only.
+func newDeadLetterRepository(cfg config, logger logger.Logger) (deadletter.Repository, func(), error) {
+	if cfg.brokerType == natsBroker && cfg.jetStream {
+		repo, err := dljetstream.NewRepository(cfg.natsURL, cfg.dlqSubject, int64(cfg.dlqLimit))
+		if err != nil {
+			return nil, nil, err
+		}
+		return repo, repo.Close, nil
+	}
+
+	logger.Warn("Dead letters are kept in memory, enable JetStream to persist them")
+	return memory.NewRepository(cfg.dlqLimit), func() {}, nil
+}
+
+func newDeadLetterService(repo deadletter.Repository, pub deadletter.Publisher, h messaging.MessageHandler, logger logger.Logger) deadletter.Service {
+	svc := deadletter.New(repo, pub, h, uuid.New())
+	svc = dlapi.LoggingMiddleware(svc, logger)
+	svc = dlapi.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "influxdb",
+			Subsystem: "dead_letters",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "influxdb",
+			Subsystem: "dead_letters",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func newRetentionService(repo retention.Repository, pruner retention.Pruner, logger logger.Logger) retention.Service {
+	svc := retention.New(repo, pruner)
+	svc = retapi.LoggingMiddleware(svc, logger)
+	svc = retapi.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "influxdb",
+			Subsystem: "retention",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "influxdb",
+			Subsystem: "retention",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
 }
 
 func makeTransformer(cfg config, logger logger.Logger) transformers.Transformer {
+	var def transformers.Transformer
 	switch strings.ToUpper(cfg.transformer) {
 	case "SENML":
 		logger.Info("Using SenML transformer")
-		return senml.New(cfg.contentType)
+		def = senml.New(cfg.contentType)
 	case "JSON":
 		logger.Info("Using JSON transformer")
-		return json.New()
+		def = json.New()
 	default:
 		logger.Error(fmt.Sprintf("Can't create transformer: unknown transformer type %s", cfg.transformer))
 		os.Exit(1)
-		return nil
 	}
+
+	// Messages that carry their content type are transformed by the matching
+	// transformer, regardless of the configured one.
+	return transformers.New(def, map[string]transformers.Transformer{
+		senml.JSON:       senml.New(senml.JSON),
+		senml.CBOR:       senml.New(senml.CBOR),
+		json.ContentType: json.New(),
+	})
 }
 
-func startHTTPService(port string, logger logger.Logger, errs chan error) {
+func startHTTPService(port string, ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient, dls deadletter.Service, rs retention.Service, logger logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", port)
 	logger.Info(fmt.Sprintf("InfluxDB writer service started, exposed port %s", p))
-	errs <- http.ListenAndServe(p, api.MakeHandler(svcName))
+	errs <- http.ListenAndServe(p, api.MakeHandler(svcName, ac, tc, dls, rs))
+}
+
+func loadBatchConfig() writers.BatchConfig {
+	size, err := strconv.Atoi(mainflux.Env(envBatchSize, defBatchSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchSize, err.Error())
+	}
+
+	interval, err := time.ParseDuration(mainflux.Env(envBatchInterval, defBatchInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchInterval, err.Error())
+	}
+
+	retryTimeout, err := time.ParseDuration(mainflux.Env(envBatchRetryTimeout, defBatchRetryTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchRetryTimeout, err.Error())
+	}
+
+	return writers.BatchConfig{
+		Size:         size,
+		Interval:     interval,
+		RetryTimeout: retryTimeout,
+	}
+}
+
+func loadPruneInterval() time.Duration {
+	interval, err := time.ParseDuration(mainflux.Env(envPruneInterval, defPruneInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envPruneInterval, err.Error())
+	}
+
+	return interval
+}
+
+// newHandler returns the handler of the subscribed messages. Unlike
+// JetStream and Kafka, core NATS delivers the messages one at a time and
+// doesn't redeliver the failed ones, so the messages are handled in the
+// background to let the batches fill.
+func newHandler(cfg config, h messaging.MessageHandler, logger logger.Logger) messaging.MessageHandler {
+	if cfg.brokerType != natsBroker || cfg.jetStream {
+		return h
+	}
+
+	return consumers.AsyncHandler(h, cfg.batchConfig.Size, logger)
+}
+
+// newPubSub returns the PubSub of the configured message broker. JetStream
+// is used instead of core NATS if it's enabled, so that the messages
+// published while the service is down are not lost.
+func newPubSub(cfg config, logger logger.Logger) (nats.PubSub, error) {
+	switch cfg.brokerType {
+	case kafkaBroker:
+		logger.Info("Using Kafka")
+		return kafka.NewPubSub(cfg.kafkaURL, svcName, cfg.kafkaConfig, logger)
+	case natsBroker:
+		if !cfg.jetStream {
+			return nats.NewPubSub(cfg.natsURL, "", logger)
+		}
+		logger.Info("Using NATS JetStream")
+		return jetstream.NewPubSub(cfg.natsURL, svcName, cfg.jsConfig, logger)
+	default:
+		return nil, fmt.Errorf("unsupported message broker %s", cfg.brokerType)
+	}
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsAuthURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	return conn
+}
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.authURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+	return conn
 }
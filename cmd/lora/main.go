@@ -21,6 +21,7 @@ import (
 	"github.com/mainflux/mainflux/lora"
 	"github.com/mainflux/mainflux/lora/api"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/kafka"
 	"github.com/mainflux/mainflux/pkg/messaging/mqtt"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 
@@ -30,11 +31,17 @@ import (
 )
 
 const (
+	natsBroker  = "nats"
+	kafkaBroker = "kafka"
+
 	defLogLevel       = "error"
 	defHTTPPort       = "8180"
 	defLoraMsgURL     = "tcp://localhost:1883"
 	defSubTimeout     = "30s" // 30 seconds
 	defNatsURL        = "nats://localhost:4222"
+	defBrokerType     = "nats"
+	defKafkaURL       = "localhost:9092"
+	defKafkaTopic     = "mainflux"
 	defESURL          = "localhost:6379"
 	defESPass         = ""
 	defESDB           = "0"
@@ -47,6 +54,9 @@ const (
 	envLoraMsgURL     = "MF_LORA_ADAPTER_MESSAGES_URL"
 	envSubTimeout     = "MF_LORA_ADAPTER_SUBSCRIBER_TIMEOUT"
 	envNatsURL        = "MF_NATS_URL"
+	envBrokerType     = "MF_BROKER_TYPE"
+	envKafkaURL       = "MF_KAFKA_URL"
+	envKafkaTopic     = "MF_KAFKA_TOPIC"
 	envLogLevel       = "MF_LORA_ADAPTER_LOG_LEVEL"
 	envESURL          = "MF_THINGS_ES_URL"
 	envESPass         = "MF_THINGS_ES_PASS"
@@ -67,6 +77,9 @@ type config struct {
 	httpPort       string
 	loraMsgURL     string
 	natsURL        string
+	brokerType     string
+	kafkaURL       string
+	kafkaConfig    kafka.Config
 	subTimeout     time.Duration
 	logLevel       string
 	esURL          string
@@ -92,9 +105,9 @@ func main() {
 	esConn := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
 	defer esConn.Close()
 
-	pub, err := nats.NewPublisher(cfg.natsURL)
+	pub, err := newPublisher(cfg)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
 	}
 	defer pub.Close()
@@ -155,6 +168,9 @@ func loadConfig() config {
 		loraMsgURL:     mainflux.Env(envLoraMsgURL, defLoraMsgURL),
 		subTimeout:     mqttTimeout,
 		natsURL:        mainflux.Env(envNatsURL, defNatsURL),
+		brokerType:     mainflux.Env(envBrokerType, defBrokerType),
+		kafkaURL:       mainflux.Env(envKafkaURL, defKafkaURL),
+		kafkaConfig:    kafka.Config{Topic: mainflux.Env(envKafkaTopic, defKafkaTopic)},
 		logLevel:       mainflux.Env(envLogLevel, defLogLevel),
 		esURL:          mainflux.Env(envESURL, defESURL),
 		esPass:         mainflux.Env(envESPass, defESPass),
@@ -217,3 +233,15 @@ func startHTTPServer(cfg config, logger logger.Logger, errs chan error) {
 	logger.Info(fmt.Sprintf("LoRa-adapter service started, exposed port %s", cfg.httpPort))
 	errs <- http.ListenAndServe(p, api.MakeHandler())
 }
+
+// newPublisher returns the Publisher of the configured message broker.
+func newPublisher(cfg config) (nats.Publisher, error) {
+	switch cfg.brokerType {
+	case kafkaBroker:
+		return kafka.NewPublisher(cfg.kafkaURL, cfg.kafkaConfig)
+	case natsBroker:
+		return nats.NewPublisher(cfg.natsURL)
+	default:
+		return nil, fmt.Errorf("unsupported message broker %s", cfg.brokerType)
+	}
+}
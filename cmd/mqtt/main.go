@@ -17,16 +17,16 @@ import (
 	"github.com/mainflux/mainflux"
 	mflog "github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/mqtt"
+	"github.com/mainflux/mainflux/mqtt/proxy"
 	mqttredis "github.com/mainflux/mainflux/mqtt/redis"
 	"github.com/mainflux/mainflux/pkg/auth"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/kafka"
 	mqttpub "github.com/mainflux/mainflux/pkg/messaging/mqtt"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
-	mp "github.com/mainflux/mproxy/pkg/mqtt"
 	"github.com/mainflux/mproxy/pkg/session"
-	ws "github.com/mainflux/mproxy/pkg/websocket"
 	opentracing "github.com/opentracing/opentracing-go"
 	jconfig "github.com/uber/jaeger-client-go/config"
 	"google.golang.org/grpc"
@@ -34,6 +34,9 @@ import (
 )
 
 const (
+	natsBroker  = "nats"
+	kafkaBroker = "kafka"
+
 	// Logging
 	defLogLevel = "error"
 	envLogLevel = "MF_MQTT_ADAPTER_LOG_LEVEL"
@@ -63,8 +66,14 @@ const (
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 	// Nats
-	defNatsURL = "nats://localhost:4222"
-	envNatsURL = "MF_NATS_URL"
+	defNatsURL    = "nats://localhost:4222"
+	defBrokerType = "nats"
+	defKafkaURL   = "localhost:9092"
+	defKafkaTopic = "mainflux"
+	envNatsURL    = "MF_NATS_URL"
+	envBrokerType = "MF_BROKER_TYPE"
+	envKafkaURL   = "MF_KAFKA_URL"
+	envKafkaTopic = "MF_KAFKA_TOPIC"
 	// Jaeger
 	defJaegerURL = ""
 	envJaegerURL = "MF_JAEGER_URL"
@@ -108,6 +117,9 @@ type config struct {
 	thingsAuthURL         string
 	thingsAuthTimeout     time.Duration
 	natsURL               string
+	brokerType            string
+	kafkaURL              string
+	kafkaConfig           kafka.Config
 	clientTLS             bool
 	caCerts               string
 	instance              string
@@ -145,9 +157,9 @@ func main() {
 	ec := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
 	defer ec.Close()
 
-	nps, err := nats.NewPubSub(cfg.natsURL, "mqtt", logger)
+	nps, err := newPubSub(cfg, "mqtt", logger)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
 	}
 	defer nps.Close()
@@ -164,9 +176,9 @@ func main() {
 		os.Exit(1)
 	}
 
-	np, err := nats.NewPublisher(cfg.natsURL)
+	np, err := newPublisher(cfg)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
 	}
 	defer np.Close()
@@ -234,6 +246,9 @@ func loadConfig() config {
 		thingsAuthTimeout:     authTimeout,
 		thingsURL:             mainflux.Env(envThingsAuthURL, defThingsAuthURL),
 		natsURL:               mainflux.Env(envNatsURL, defNatsURL),
+		brokerType:            mainflux.Env(envBrokerType, defBrokerType),
+		kafkaURL:              mainflux.Env(envKafkaURL, defKafkaURL),
+		kafkaConfig:           kafka.Config{Topic: mainflux.Env(envKafkaTopic, defKafkaTopic)},
 		logLevel:              mainflux.Env(envLogLevel, defLogLevel),
 		clientTLS:             tls,
 		caCerts:               mainflux.Env(envCACerts, defCACerts),
@@ -312,13 +327,13 @@ func connectToRedis(redisURL, redisPass, redisDB string, logger mflog.Logger) *r
 func proxyMQTT(cfg config, logger mflog.Logger, handler session.Handler, errs chan error) {
 	address := fmt.Sprintf(":%s", cfg.mqttPort)
 	target := fmt.Sprintf("%s:%s", cfg.mqttTargetHost, cfg.mqttTargetPort)
-	mp := mp.New(address, target, handler, logger)
+	mp := proxy.New(address, target, handler, logger)
 
 	errs <- mp.Listen()
 }
 func proxyWS(cfg config, logger mflog.Logger, handler session.Handler, errs chan error) {
 	target := fmt.Sprintf("%s:%s", cfg.httpTargetHost, cfg.httpTargetPort)
-	wp := ws.New(target, cfg.httpTargetPath, "ws", handler, logger)
+	wp := proxy.NewWebSocket(target, cfg.httpTargetPath, "ws", handler, logger)
 	http.Handle("/mqtt", wp.Handler())
 
 	errs <- wp.Listen(cfg.httpPort)
@@ -341,3 +356,28 @@ func healthcheck(cfg config) func() error {
 		return nil
 	}
 }
+
+// newPublisher returns the Publisher of the configured message broker.
+func newPublisher(cfg config) (nats.Publisher, error) {
+	switch cfg.brokerType {
+	case kafkaBroker:
+		return kafka.NewPublisher(cfg.kafkaURL, cfg.kafkaConfig)
+	case natsBroker:
+		return nats.NewPublisher(cfg.natsURL)
+	default:
+		return nil, fmt.Errorf("unsupported message broker %s", cfg.brokerType)
+	}
+}
+
+// newPubSub returns the PubSub of the configured message broker. Parameter
+// queue is used as the Kafka consumer group.
+func newPubSub(cfg config, queue string, logger mflog.Logger) (nats.PubSub, error) {
+	switch cfg.brokerType {
+	case kafkaBroker:
+		return kafka.NewPubSub(cfg.kafkaURL, queue, cfg.kafkaConfig, logger)
+	case natsBroker:
+		return nats.NewPubSub(cfg.natsURL, queue, logger)
+	default:
+		return nil, fmt.Errorf("unsupported message broker %s", cfg.brokerType)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/mainflux/mainflux/opcua/db"
 	"github.com/mainflux/mainflux/opcua/gopcua"
 	"github.com/mainflux/mainflux/opcua/redis"
+	"github.com/mainflux/mainflux/pkg/messaging/kafka"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
@@ -28,6 +29,9 @@ import (
 )
 
 const (
+	natsBroker  = "nats"
+	kafkaBroker = "kafka"
+
 	defLogLevel       = "error"
 	defHTTPPort       = "8180"
 	defOPCIntervalMs  = "1000"
@@ -36,6 +40,9 @@ const (
 	defOPCCertFile    = ""
 	defOPCKeyFile     = ""
 	defNatsURL        = "nats://localhost:4222"
+	defBrokerType     = "nats"
+	defKafkaURL       = "localhost:9092"
+	defKafkaTopic     = "mainflux"
 	defESURL          = "localhost:6379"
 	defESPass         = ""
 	defESDB           = "0"
@@ -52,6 +59,9 @@ const (
 	envOPCCertFile    = "MF_OPCUA_ADAPTER_CERT_FILE"
 	envOPCKeyFile     = "MF_OPCUA_ADAPTER_KEY_FILE"
 	envNatsURL        = "MF_NATS_URL"
+	envBrokerType     = "MF_BROKER_TYPE"
+	envKafkaURL       = "MF_KAFKA_URL"
+	envKafkaTopic     = "MF_KAFKA_TOPIC"
 	envESURL          = "MF_THINGS_ES_URL"
 	envESPass         = "MF_THINGS_ES_PASS"
 	envESDB           = "MF_THINGS_ES_DB"
@@ -69,6 +79,9 @@ type config struct {
 	httpPort       string
 	opcuaConfig    opcua.Config
 	natsURL        string
+	brokerType     string
+	kafkaURL       string
+	kafkaConfig    kafka.Config
 	logLevel       string
 	esURL          string
 	esPass         string
@@ -97,9 +110,9 @@ func main() {
 	esConn := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
 	defer esConn.Close()
 
-	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
+	pubSub, err := newPubSub(cfg, "", logger)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
 	}
 	defer pubSub.Close()
@@ -155,6 +168,9 @@ func loadConfig() config {
 		httpPort:       mainflux.Env(envHTTPPort, defHTTPPort),
 		opcuaConfig:    oc,
 		natsURL:        mainflux.Env(envNatsURL, defNatsURL),
+		brokerType:     mainflux.Env(envBrokerType, defBrokerType),
+		kafkaURL:       mainflux.Env(envKafkaURL, defKafkaURL),
+		kafkaConfig:    kafka.Config{Topic: mainflux.Env(envKafkaTopic, defKafkaTopic)},
 		logLevel:       mainflux.Env(envLogLevel, defLogLevel),
 		esURL:          mainflux.Env(envESURL, defESURL),
 		esPass:         mainflux.Env(envESPass, defESPass),
@@ -221,3 +237,16 @@ func startHTTPServer(svc opcua.Service, cfg config, logger logger.Logger, errs c
 	logger.Info(fmt.Sprintf("opcua-adapter service started, exposed port %s", cfg.httpPort))
 	errs <- http.ListenAndServe(p, api.MakeHandler(svc))
 }
+
+// newPubSub returns the PubSub of the configured message broker. Parameter
+// queue is used as the Kafka consumer group.
+func newPubSub(cfg config, queue string, logger logger.Logger) (nats.PubSub, error) {
+	switch cfg.brokerType {
+	case kafkaBroker:
+		return kafka.NewPubSub(cfg.kafkaURL, queue, cfg.kafkaConfig, logger)
+	case natsBroker:
+		return nats.NewPubSub(cfg.natsURL, queue, logger)
+	default:
+		return nil, fmt.Errorf("unsupported message broker %s", cfg.brokerType)
+	}
+}
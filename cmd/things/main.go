@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -35,6 +36,7 @@ import (
 	thhttpapi "github.com/mainflux/mainflux/things/api/things/http"
 	"github.com/mainflux/mainflux/things/postgres"
 	rediscache "github.com/mainflux/mainflux/things/redis"
+	rediscons "github.com/mainflux/mainflux/things/redis/consumer"
 	localusers "github.com/mainflux/mainflux/things/users"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
@@ -60,6 +62,7 @@ const (
 	defESURL           = "localhost:6379"
 	defESPass          = ""
 	defESDB            = "0"
+	defESConsumerName  = "things"
 	defHTTPPort        = "8182"
 	defAuthHTTPPort    = "8989"
 	defAuthGRPCPort    = "8181"
@@ -89,6 +92,7 @@ const (
 	envESURL           = "MF_THINGS_ES_URL"
 	envESPass          = "MF_THINGS_ES_PASS"
 	envESDB            = "MF_THINGS_ES_DB"
+	envESConsumerName  = "MF_THINGS_EVENT_CONSUMER"
 	envHTTPPort        = "MF_THINGS_HTTP_PORT"
 	envAuthHTTPPort    = "MF_THINGS_AUTH_HTTP_PORT"
 	envAuthGRPCPort    = "MF_THINGS_AUTH_GRPC_PORT"
@@ -112,6 +116,7 @@ type config struct {
 	esURL           string
 	esPass          string
 	esDB            string
+	esConsumerName  string
 	httpPort        string
 	authHTTPPort    string
 	authGRPCPort    string
@@ -162,6 +167,7 @@ func main() {
 	go startHTTPServer(thhttpapi.MakeHandler(thingsTracer, svc), cfg.httpPort, cfg, logger, errs)
 	go startHTTPServer(authhttpapi.MakeHandler(thingsTracer, svc), cfg.authHTTPPort, cfg, logger, errs)
 	go startGRPCServer(svc, thingsTracer, cfg, logger, errs)
+	go subscribeToMQTTES(svc, esClient, cfg.esConsumerName, logger)
 
 	go func() {
 		c := make(chan os.Signal)
@@ -207,6 +213,7 @@ func loadConfig() config {
 		esURL:           mainflux.Env(envESURL, defESURL),
 		esPass:          mainflux.Env(envESPass, defESPass),
 		esDB:            mainflux.Env(envESDB, defESDB),
+		esConsumerName:  mainflux.Env(envESConsumerName, defESConsumerName),
 		httpPort:        mainflux.Env(envHTTPPort, defHTTPPort),
 		authHTTPPort:    mainflux.Env(envAuthHTTPPort, defAuthHTTPPort),
 		authGRPCPort:    mainflux.Env(envAuthGRPCPort, defAuthGRPCPort),
@@ -315,9 +322,12 @@ func newService(auth mainflux.AuthServiceClient, dbTracer opentracing.Tracer, ca
 
 	thingCache := rediscache.NewThingCache(cacheClient)
 	thingCache = tracing.ThingCacheMiddleware(cacheTracer, thingCache)
+
+	presenceRepo := rediscache.NewPresenceRepository(cacheClient)
+	presenceRepo = tracing.PresenceRepositoryMiddleware(cacheTracer, presenceRepo)
 	idProvider := uuid.New()
 
-	svc := things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, idProvider)
+	svc := things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, presenceRepo, idProvider)
 	svc = rediscache.NewEventStoreMiddleware(svc, esClient)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
@@ -376,3 +386,11 @@ func startGRPCServer(svc things.Service, tracer opentracing.Tracer, cfg config,
 	mainflux.RegisterThingsServiceServer(server, authgrpcapi.NewServer(tracer, svc))
 	errs <- server.Serve(listener)
 }
+
+func subscribeToMQTTES(svc things.Service, client *redis.Client, consumer string, logger logger.Logger) {
+	eventStore := rediscons.NewEventStore(svc, client, consumer, logger)
+	logger.Info("Subscribed to Redis Event Store")
+	if err := eventStore.Subscribe(context.Background(), "mainflux.mqtt"); err != nil {
+		logger.Warn(fmt.Sprintf("Things service failed to subscribe to event sourcing: %s", err))
+	}
+}
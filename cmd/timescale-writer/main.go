@@ -0,0 +1,611 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/jmoiron/sqlx"
+	"github.com/mainflux/mainflux"
+	authapi "github.com/mainflux/mainflux/auth/api/grpc"
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	dlapi "github.com/mainflux/mainflux/consumers/deadletter/api"
+	dljetstream "github.com/mainflux/mainflux/consumers/deadletter/jetstream"
+	dlkafka "github.com/mainflux/mainflux/consumers/deadletter/kafka"
+	"github.com/mainflux/mainflux/consumers/deadletter/memory"
+	dlnats "github.com/mainflux/mainflux/consumers/deadletter/nats"
+	"github.com/mainflux/mainflux/consumers/retention"
+	retapi "github.com/mainflux/mainflux/consumers/retention/api"
+	"github.com/mainflux/mainflux/consumers/writers"
+	"github.com/mainflux/mainflux/consumers/writers/api"
+	"github.com/mainflux/mainflux/consumers/writers/timescale"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/jetstream"
+	"github.com/mainflux/mainflux/pkg/messaging/kafka"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/mainflux/mainflux/pkg/transformers"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	natsBroker  = "nats"
+	kafkaBroker = "kafka"
+
+	svcName = "timescale-writer"
+	sep     = ","
+
+	defLogLevel          = "error"
+	defNatsURL           = "nats://localhost:4222"
+	defBrokerType        = "nats"
+	defKafkaURL          = "localhost:9092"
+	defKafkaTopic        = "mainflux"
+	defPort              = "8180"
+	defDBHost            = "localhost"
+	defDBPort            = "5432"
+	defDBUser            = "mainflux"
+	defDBPass            = "mainflux"
+	defDB                = "mainflux"
+	defDBSSLMode         = "disable"
+	defDBSSLCert         = ""
+	defDBSSLKey          = ""
+	defDBSSLRootCert     = ""
+	defConfigPath        = "/config.toml"
+	defContentType       = "application/senml+json"
+	defTransformer       = "senml"
+	defDLQSubject        = "deadletter.timescale-writer"
+	defDLQLimit          = "1000"
+	defBatchSize         = "100"
+	defBatchInterval     = "1s"
+	defBatchRetryTimeout = "10s"
+	defPruneInterval     = "1h"
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defJaegerURL         = ""
+	defAuthURL           = "localhost:8181"
+	defAuthTimeout       = "1s"
+	defThingsAuthURL     = "localhost:8181"
+	defThingsAuthTimeout = "1s"
+	defChunkInterval     = "168h"
+	defRetention         = "0s"
+	defAggregates        = ""
+
+	envNatsURL           = "MF_NATS_URL"
+	envBrokerType        = "MF_BROKER_TYPE"
+	envKafkaURL          = "MF_KAFKA_URL"
+	envKafkaTopic        = "MF_KAFKA_TOPIC"
+	envLogLevel          = "MF_TIMESCALE_WRITER_LOG_LEVEL"
+	envPort              = "MF_TIMESCALE_WRITER_PORT"
+	envDBHost            = "MF_TIMESCALE_WRITER_DB_HOST"
+	envDBPort            = "MF_TIMESCALE_WRITER_DB_PORT"
+	envDBUser            = "MF_TIMESCALE_WRITER_DB_USER"
+	envDBPass            = "MF_TIMESCALE_WRITER_DB_PASS"
+	envDB                = "MF_TIMESCALE_WRITER_DB"
+	envDBSSLMode         = "MF_TIMESCALE_WRITER_DB_SSL_MODE"
+	envDBSSLCert         = "MF_TIMESCALE_WRITER_DB_SSL_CERT"
+	envDBSSLKey          = "MF_TIMESCALE_WRITER_DB_SSL_KEY"
+	envDBSSLRootCert     = "MF_TIMESCALE_WRITER_DB_SSL_ROOT_CERT"
+	envConfigPath        = "MF_TIMESCALE_WRITER_CONFIG_PATH"
+	envContentType       = "MF_TIMESCALE_WRITER_CONTENT_TYPE"
+	envTransformer       = "MF_TIMESCALE_WRITER_TRANSFORMER"
+	envDLQSubject        = "MF_TIMESCALE_WRITER_DLQ_SUBJECT"
+	envDLQLimit          = "MF_TIMESCALE_WRITER_DLQ_LIMIT"
+	envBatchSize         = "MF_TIMESCALE_WRITER_BATCH_SIZE"
+	envBatchInterval     = "MF_TIMESCALE_WRITER_BATCH_INTERVAL"
+	envBatchRetryTimeout = "MF_TIMESCALE_WRITER_BATCH_RETRY_TIMEOUT"
+	envPruneInterval     = "MF_TIMESCALE_WRITER_PRUNE_INTERVAL"
+	envClientTLS         = "MF_TIMESCALE_WRITER_CLIENT_TLS"
+	envCACerts           = "MF_TIMESCALE_WRITER_CA_CERTS"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envAuthURL           = "MF_AUTH_GRPC_URL"
+	envAuthTimeout       = "MF_AUTH_GRPC_TIMEOUT"
+	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envChunkInterval     = "MF_TIMESCALE_WRITER_CHUNK_INTERVAL"
+	envRetention         = "MF_TIMESCALE_WRITER_RETENTION"
+	envAggregates        = "MF_TIMESCALE_WRITER_AGGREGATES"
+)
+
+type config struct {
+	natsURL           string
+	brokerType        string
+	kafkaURL          string
+	kafkaConfig       kafka.Config
+	jetStream         bool
+	jsConfig          jetstream.Config
+	logLevel          string
+	port              string
+	configPath        string
+	contentType       string
+	transformer       string
+	dlqSubject        string
+	dlqLimit          int
+	batchConfig       writers.BatchConfig
+	pruneInterval     time.Duration
+	clientTLS         bool
+	caCerts           string
+	jaegerURL         string
+	authURL           string
+	authTimeout       time.Duration
+	thingsAuthURL     string
+	thingsAuthTimeout time.Duration
+	dbConfig          timescale.Config
+	policy            timescale.Policy
+}
+
+func main() {
+	cfg := loadConfig()
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	pubSub, err := newPubSub(cfg, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	db := connectToDB(cfg.dbConfig, cfg.policy, logger)
+	defer db.Close()
+
+	repo := newService(db, cfg.policy, logger)
+	t := makeTransformer(cfg, logger)
+
+	h := consumers.Handler(t, repo)
+
+	dlPub, err := newDeadLetterPublisher(cfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to dead-letter queue: %s", err))
+		os.Exit(1)
+	}
+	defer dlPub.Close()
+
+	dlRepo, closeDLRepo, err := newDeadLetterRepository(cfg, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create dead letters repository: %s", err))
+		os.Exit(1)
+	}
+	defer closeDLRepo()
+	dls := newDeadLetterService(dlRepo, dlPub, h, logger)
+
+	batch := writers.NewBatchConsumer(repo, cfg.batchConfig, logger)
+	defer batch.Close()
+
+	rs := newRetentionService(timescale.NewRetentionRepository(db), timescale.NewPruner(db), logger)
+	if cfg.pruneInterval > 0 {
+		stop := retention.StartPruning(rs, cfg.pruneInterval, logger)
+		defer stop()
+	}
+
+	// The messages which can never be stored are moved to the dead-letter
+	// queue instead of being redelivered.
+	dlh := deadletter.Handler(dls, consumers.Handler(t, batch), consumers.ErrTransform, timescale.ErrInvalidMessage, timescale.ErrNoTable)
+	if err = consumers.Subscribe(pubSub, newHandler(cfg, dlh, logger), cfg.configPath, logger); err != nil {
+		logger.Error(fmt.Sprintf("Failed to create Timescale writer: %s", err))
+	}
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(authTracer, authConn, cfg.authTimeout)
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	thingsConn := connectToThings(cfg, logger)
+	defer thingsConn.Close()
+
+	things := thingsapi.NewClient(thingsConn, thingsTracer, cfg.thingsAuthTimeout)
+
+	errs := make(chan error, 2)
+
+	go startHTTPServer(cfg.port, auth, things, dls, rs, errs, logger)
+
+	go func() {
+		c := make(chan os.Signal)
+		signal.Notify(c, syscall.SIGINT)
+		errs <- fmt.Errorf("%s", <-c)
+	}()
+
+	err = <-errs
+	logger.Error(fmt.Sprintf("Timescale writer service terminated: %s", err))
+}
+
+func loadConfig() config {
+	dlqLimit, err := strconv.Atoi(mainflux.Env(envDLQLimit, defDLQLimit))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envDLQLimit, err.Error())
+	}
+
+	batchConfig := loadBatchConfig()
+
+	// Messages are handled concurrently so that the batches can be filled,
+	// while each of them is acknowledged only once it's stored.
+	js, jsConfig, err := jetstream.LoadConfig()
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+	jsConfig.MaxInFlight = batchConfig.Size
+
+	dbConfig := timescale.Config{
+		Host:        mainflux.Env(envDBHost, defDBHost),
+		Port:        mainflux.Env(envDBPort, defDBPort),
+		User:        mainflux.Env(envDBUser, defDBUser),
+		Pass:        mainflux.Env(envDBPass, defDBPass),
+		Name:        mainflux.Env(envDB, defDB),
+		SSLMode:     mainflux.Env(envDBSSLMode, defDBSSLMode),
+		SSLCert:     mainflux.Env(envDBSSLCert, defDBSSLCert),
+		SSLKey:      mainflux.Env(envDBSSLKey, defDBSSLKey),
+		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authTimeout, err := time.ParseDuration(mainflux.Env(envAuthTimeout, defAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthTimeout, err.Error())
+	}
+
+	thingsAuthTimeout, err := time.ParseDuration(mainflux.Env(envThingsAuthTimeout, defThingsAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
+	}
+
+	return config{
+		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
+		brokerType:        mainflux.Env(envBrokerType, defBrokerType),
+		kafkaURL:          mainflux.Env(envKafkaURL, defKafkaURL),
+		kafkaConfig:       kafka.Config{Topic: mainflux.Env(envKafkaTopic, defKafkaTopic), MaxInFlight: batchConfig.Size},
+		jetStream:         js,
+		jsConfig:          jsConfig,
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		port:              mainflux.Env(envPort, defPort),
+		configPath:        mainflux.Env(envConfigPath, defConfigPath),
+		contentType:       mainflux.Env(envContentType, defContentType),
+		transformer:       mainflux.Env(envTransformer, defTransformer),
+		dlqSubject:        mainflux.Env(envDLQSubject, defDLQSubject),
+		dlqLimit:          dlqLimit,
+		batchConfig:       batchConfig,
+		pruneInterval:     loadPruneInterval(),
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		authURL:           mainflux.Env(envAuthURL, defAuthURL),
+		authTimeout:       authTimeout,
+		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
+		thingsAuthTimeout: thingsAuthTimeout,
+		dbConfig:          dbConfig,
+		policy:            loadPolicy(),
+	}
+}
+
+func connectToDB(dbConfig timescale.Config, policy timescale.Policy, logger logger.Logger) *sqlx.DB {
+	db, err := timescale.Connect(dbConfig, policy)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to Timescale: %s", err))
+		os.Exit(1)
+	}
+	return db
+}
+
+func newService(db *sqlx.DB, policy timescale.Policy, logger logger.Logger) consumers.Consumer {
+	svc := timescale.New(db, policy)
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "timescale",
+			Subsystem: "message_writer",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "timescale",
+			Subsystem: "message_writer",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "timescale",
+			Subsystem: "message_writer",
+			Name:      "batch_size",
+			Help:      "Number of messages stored per request.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func newDeadLetterPublisher(cfg config) (dlnats.Publisher, error) {
+	switch cfg.brokerType {
+	case kafkaBroker:
+		return dlkafka.NewPublisher(cfg.kafkaURL, cfg.dlqSubject)
+	case natsBroker:
+		return dlnats.NewPublisher(cfg.natsURL, cfg.dlqSubject)
+	default:
+		return nil, fmt.Errorf("unsupported message broker %s", cfg.brokerType)
+	}
+}
+
+// newDeadLetterRepository returns the repository which persists the dead
+// letters in the JetStream stream if JetStream is enabled. Otherwise, the
+// latest dead letters are kept in memory only.
+func newDeadLetterRepository(cfg config, logger logger.Logger) (deadletter.Repository, func(), error) {
+	if cfg.brokerType == natsBroker && cfg.jetStream {
+		repo, err := dljetstream.NewRepository(cfg.natsURL, cfg.dlqSubject, int64(cfg.dlqLimit))
+		if err != nil {
+			return nil, nil, err
+		}
+		return repo, repo.Close, nil
+	}
+
+	logger.Warn("Dead letters are kept in memory, enable JetStream to persist them")
+	return memory.NewRepository(cfg.dlqLimit), func() {}, nil
+}
+
+func newDeadLetterService(repo deadletter.Repository, pub deadletter.Publisher, h messaging.MessageHandler, logger logger.Logger) deadletter.Service {
+	svc := deadletter.New(repo, pub, h, uuid.New())
+	svc = dlapi.LoggingMiddleware(svc, logger)
+	svc = dlapi.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "timescale",
+			Subsystem: "dead_letters",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "timescale",
+			Subsystem: "dead_letters",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func newRetentionService(repo retention.Repository, pruner retention.Pruner, logger logger.Logger) retention.Service {
+	svc := retention.New(repo, pruner)
+	svc = retapi.LoggingMiddleware(svc, logger)
+	svc = retapi.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "timescale",
+			Subsystem: "retention",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "timescale",
+			Subsystem: "retention",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func makeTransformer(cfg config, logger logger.Logger) transformers.Transformer {
+	var def transformers.Transformer
+	switch strings.ToUpper(cfg.transformer) {
+	case "SENML":
+		logger.Info("Using SenML transformer")
+		def = senml.New(cfg.contentType)
+	case "JSON":
+		logger.Info("Using JSON transformer")
+		def = json.New()
+	default:
+		logger.Error(fmt.Sprintf("Can't create transformer: unknown transformer type %s", cfg.transformer))
+		os.Exit(1)
+	}
+
+	// Messages that carry their content type are transformed by the matching
+	// transformer, regardless of the configured one.
+	return transformers.New(def, map[string]transformers.Transformer{
+		senml.JSON:       senml.New(senml.JSON),
+		senml.CBOR:       senml.New(senml.CBOR),
+		json.ContentType: json.New(),
+	})
+}
+
+func startHTTPServer(port string, ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient, dls deadletter.Service, rs retention.Service, errs chan error, logger logger.Logger) {
+	p := fmt.Sprintf(":%s", port)
+	logger.Info(fmt.Sprintf("Timescale writer service started, exposed port %s", port))
+	errs <- http.ListenAndServe(p, api.MakeHandler(svcName, ac, tc, dls, rs))
+}
+
+func loadBatchConfig() writers.BatchConfig {
+	size, err := strconv.Atoi(mainflux.Env(envBatchSize, defBatchSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchSize, err.Error())
+	}
+
+	interval, err := time.ParseDuration(mainflux.Env(envBatchInterval, defBatchInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchInterval, err.Error())
+	}
+
+	retryTimeout, err := time.ParseDuration(mainflux.Env(envBatchRetryTimeout, defBatchRetryTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchRetryTimeout, err.Error())
+	}
+
+	return writers.BatchConfig{
+		Size:         size,
+		Interval:     interval,
+		RetryTimeout: retryTimeout,
+	}
+}
+
+func loadPruneInterval() time.Duration {
+	interval, err := time.ParseDuration(mainflux.Env(envPruneInterval, defPruneInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envPruneInterval, err.Error())
+	}
+
+	return interval
+}
+
+func loadPolicy() timescale.Policy {
+	chunkInterval, err := time.ParseDuration(mainflux.Env(envChunkInterval, defChunkInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envChunkInterval, err.Error())
+	}
+
+	retention, err := time.ParseDuration(mainflux.Env(envRetention, defRetention))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRetention, err.Error())
+	}
+
+	var aggregates []time.Duration
+	if val := mainflux.Env(envAggregates, defAggregates); val != "" {
+		for _, a := range strings.Split(val, sep) {
+			width, err := time.ParseDuration(strings.TrimSpace(a))
+			if err != nil {
+				log.Fatalf("Invalid %s value: %s", envAggregates, err.Error())
+			}
+			aggregates = append(aggregates, width)
+		}
+	}
+
+	return timescale.Policy{
+		ChunkInterval: chunkInterval,
+		Retention:     retention,
+		Aggregates:    aggregates,
+	}
+}
+
+// newHandler returns the handler of the subscribed messages. Unlike
+// JetStream and Kafka, core NATS delivers the messages one at a time and
+// doesn't redeliver the failed ones, so the messages are handled in the
+// background to let the batches fill.
+func newHandler(cfg config, h messaging.MessageHandler, logger logger.Logger) messaging.MessageHandler {
+	if cfg.brokerType != natsBroker || cfg.jetStream {
+		return h
+	}
+
+	return consumers.AsyncHandler(h, cfg.batchConfig.Size, logger)
+}
+
+// newPubSub returns the PubSub of the configured message broker. JetStream
+// is used instead of core NATS if it's enabled, so that the messages
+// published while the service is down are not lost.
+func newPubSub(cfg config, logger logger.Logger) (nats.PubSub, error) {
+	switch cfg.brokerType {
+	case kafkaBroker:
+		logger.Info("Using Kafka")
+		return kafka.NewPubSub(cfg.kafkaURL, svcName, cfg.kafkaConfig, logger)
+	case natsBroker:
+		if !cfg.jetStream {
+			return nats.NewPubSub(cfg.natsURL, "", logger)
+		}
+		logger.Info("Using NATS JetStream")
+		return jetstream.NewPubSub(cfg.natsURL, svcName, cfg.jsConfig, logger)
+	default:
+		return nil, fmt.Errorf("unsupported message broker %s", cfg.brokerType)
+	}
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsAuthURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	return conn
+}
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.authURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+	return conn
+}
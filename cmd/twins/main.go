@@ -21,6 +21,7 @@ import (
 	authapi "github.com/mainflux/mainflux/auth/api/grpc"
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/kafka"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	localusers "github.com/mainflux/mainflux/things/users"
@@ -40,6 +41,9 @@ import (
 )
 
 const (
+	natsBroker  = "nats"
+	kafkaBroker = "kafka"
+
 	queue = "twins"
 
 	defLogLevel        = "error"
@@ -59,6 +63,9 @@ const (
 	defCACerts         = ""
 	defChannelID       = ""
 	defNatsURL         = "nats://localhost:4222"
+	defBrokerType      = "nats"
+	defKafkaURL        = "localhost:9092"
+	defKafkaTopic      = "mainflux"
 	defAuthURL         = "localhost:8181"
 	defAuthTimeout     = "1s"
 
@@ -79,6 +86,9 @@ const (
 	envCACerts         = "MF_TWINS_CA_CERTS"
 	envChannelID       = "MF_TWINS_CHANNEL_ID"
 	envNatsURL         = "MF_NATS_URL"
+	envBrokerType      = "MF_BROKER_TYPE"
+	envKafkaURL        = "MF_KAFKA_URL"
+	envKafkaTopic      = "MF_KAFKA_TOPIC"
 	envAuthURL         = "MF_AUTH_GRPC_URL"
 	envAuthTimeout     = "MF_AUTH_GRPC_TIMEOUT"
 )
@@ -99,6 +109,9 @@ type config struct {
 	caCerts         string
 	channelID       string
 	natsURL         string
+	brokerType      string
+	kafkaURL        string
+	kafkaConfig     kafka.Config
 
 	authURL     string
 	authTimeout time.Duration
@@ -128,9 +141,9 @@ func main() {
 	defer authCloser.Close()
 	auth, _ := createAuthClient(cfg, authTracer, logger)
 
-	pubSub, err := nats.NewPubSub(cfg.natsURL, queue, logger)
+	pubSub, err := newPubSub(cfg, queue, logger)
 	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
 	}
 	defer pubSub.Close()
@@ -185,6 +198,9 @@ func loadConfig() config {
 		caCerts:         mainflux.Env(envCACerts, defCACerts),
 		channelID:       mainflux.Env(envChannelID, defChannelID),
 		natsURL:         mainflux.Env(envNatsURL, defNatsURL),
+		brokerType:      mainflux.Env(envBrokerType, defBrokerType),
+		kafkaURL:        mainflux.Env(envKafkaURL, defKafkaURL),
+		kafkaConfig:     kafka.Config{Topic: mainflux.Env(envKafkaTopic, defKafkaTopic)},
 		authURL:         mainflux.Env(envAuthURL, defAuthURL),
 		authTimeout:     authTimeout,
 	}
@@ -322,3 +338,16 @@ func startHTTPServer(handler http.Handler, port string, cfg config, logger logge
 	logger.Info(fmt.Sprintf("Twins service started using http on port %s", cfg.httpPort))
 	errs <- http.ListenAndServe(p, handler)
 }
+
+// newPubSub returns the PubSub of the configured message broker. Parameter
+// queue is used as the Kafka consumer group.
+func newPubSub(cfg config, queue string, logger logger.Logger) (nats.PubSub, error) {
+	switch cfg.brokerType {
+	case kafkaBroker:
+		return kafka.NewPubSub(cfg.kafkaURL, queue, cfg.kafkaConfig, logger)
+	case natsBroker:
+		return nats.NewPubSub(cfg.natsURL, queue, logger)
+	default:
+		return nil, fmt.Errorf("unsupported message broker %s", cfg.brokerType)
+	}
+}
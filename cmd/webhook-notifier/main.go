@@ -0,0 +1,485 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/jmoiron/sqlx"
+	"github.com/mainflux/mainflux"
+	authapi "github.com/mainflux/mainflux/auth/api/grpc"
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/consumers/notifiers/api"
+	"github.com/mainflux/mainflux/consumers/notifiers/postgres"
+	"github.com/mainflux/mainflux/consumers/notifiers/tracing"
+	"github.com/mainflux/mainflux/consumers/notifiers/webhook"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging/jetstream"
+	"github.com/mainflux/mainflux/pkg/messaging/kafka"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/mainflux/mainflux/pkg/ulid"
+	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	natsBroker  = "nats"
+	kafkaBroker = "kafka"
+
+	// notifsPruneInterval is the interval the notifications older than the
+	// retention period are removed in.
+	notifsPruneInterval = time.Hour
+
+	defLogLevel      = "error"
+	defDBHost        = "localhost"
+	defDBPort        = "5432"
+	defDBUser        = "mainflux"
+	defDBPass        = "mainflux"
+	defDB            = "subscriptions"
+	defConfigPath    = "/config.toml"
+	defDBSSLMode     = "disable"
+	defDBSSLCert     = ""
+	defDBSSLKey      = ""
+	defDBSSLRootCert = ""
+	defHTTPPort      = "8907"
+	defServerCert    = ""
+	defServerKey     = ""
+	defJaegerURL     = ""
+	defNatsURL       = "nats://localhost:4222"
+	defBrokerType    = "nats"
+	defKafkaURL      = "localhost:9092"
+	defKafkaTopic    = "mainflux"
+
+	defNotifsRetention = "720h"
+
+	defWebhookSecret     = ""
+	defWebhookTemplate   = ""
+	defWebhookTimeout    = "5s"
+	defWebhookRetries    = "3"
+	defWebhookBackoff    = "1s"
+	defWebhookMaxBackoff = "30s"
+	defWebhookAllow      = ""
+	defWebhookDeny       = ""
+
+	defAuthTLS     = "false"
+	defAuthCACerts = ""
+	defAuthURL     = "localhost:8181"
+	defAuthTimeout = "1s"
+
+	defThingsAuthURL     = "localhost:8181"
+	defThingsAuthTimeout = "1s"
+
+	envLogLevel      = "MF_WEBHOOK_NOTIFIER_LOG_LEVEL"
+	envDBHost        = "MF_WEBHOOK_NOTIFIER_DB_HOST"
+	envDBPort        = "MF_WEBHOOK_NOTIFIER_DB_PORT"
+	envDBUser        = "MF_WEBHOOK_NOTIFIER_DB_USER"
+	envDBPass        = "MF_WEBHOOK_NOTIFIER_DB_PASS"
+	envDB            = "MF_WEBHOOK_NOTIFIER_DB"
+	envConfigPath    = "MF_WEBHOOK_NOTIFIER_CONFIG_PATH"
+	envDBSSLMode     = "MF_WEBHOOK_NOTIFIER_DB_SSL_MODE"
+	envDBSSLCert     = "MF_WEBHOOK_NOTIFIER_DB_SSL_CERT"
+	envDBSSLKey      = "MF_WEBHOOK_NOTIFIER_DB_SSL_KEY"
+	envDBSSLRootCert = "MF_WEBHOOK_NOTIFIER_DB_SSL_ROOT_CERT"
+	envHTTPPort      = "MF_WEBHOOK_NOTIFIER_PORT"
+	envServerCert    = "MF_WEBHOOK_NOTIFIER_SERVER_CERT"
+	envServerKey     = "MF_WEBHOOK_NOTIFIER_SERVER_KEY"
+	envJaegerURL     = "MF_JAEGER_URL"
+	envNatsURL       = "MF_NATS_URL"
+	envBrokerType    = "MF_BROKER_TYPE"
+	envKafkaURL      = "MF_KAFKA_URL"
+	envKafkaTopic    = "MF_KAFKA_TOPIC"
+
+	envNotifsRetention = "MF_WEBHOOK_NOTIFIER_HISTORY_MAX_AGE"
+
+	envWebhookSecret     = "MF_WEBHOOK_NOTIFIER_SECRET"
+	envWebhookTemplate   = "MF_WEBHOOK_NOTIFIER_TEMPLATE"
+	envWebhookTimeout    = "MF_WEBHOOK_NOTIFIER_TIMEOUT"
+	envWebhookRetries    = "MF_WEBHOOK_NOTIFIER_RETRIES"
+	envWebhookBackoff    = "MF_WEBHOOK_NOTIFIER_BACKOFF"
+	envWebhookMaxBackoff = "MF_WEBHOOK_NOTIFIER_MAX_BACKOFF"
+	envWebhookAllow      = "MF_WEBHOOK_NOTIFIER_ALLOW"
+	envWebhookDeny       = "MF_WEBHOOK_NOTIFIER_DENY"
+
+	envAuthTLS     = "MF_AUTH_CLIENT_TLS"
+	envAuthCACerts = "MF_AUTH_CA_CERTS"
+	envAuthURL     = "MF_AUTH_GRPC_URL"
+	envAuthTimeout = "MF_AUTH_GRPC_TIMEOUT"
+
+	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+)
+
+type config struct {
+	natsURL           string
+	brokerType        string
+	kafkaURL          string
+	kafkaConfig       kafka.Config
+	jetStream         bool
+	jsConfig          jetstream.Config
+	configPath        string
+	logLevel          string
+	dbConfig          postgres.Config
+	webhookConf       webhook.Config
+	httpPort          string
+	serverCert        string
+	serverKey         string
+	jaegerURL         string
+	authTLS           bool
+	authCACerts       string
+	authURL           string
+	authTimeout       time.Duration
+	thingsAuthURL     string
+	thingsAuthTimeout time.Duration
+	notifsRetention   time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	db := connectToDB(cfg.dbConfig, logger)
+	defer db.Close()
+
+	pubSub, err := newPubSub(cfg, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	authTracer, closer := initJaeger("auth", cfg.jaegerURL, logger)
+	defer closer.Close()
+
+	auth, close := connectToAuth(cfg, authTracer, logger)
+	if close != nil {
+		defer close()
+	}
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	things, thingsClose := connectToThings(cfg, thingsTracer, logger)
+	defer thingsClose()
+
+	tracer, closer := initJaeger("webhook-notifier", cfg.jaegerURL, logger)
+	defer closer.Close()
+
+	dbTracer, dbCloser := initJaeger("webhook-notifier_db", cfg.jaegerURL, logger)
+	defer dbCloser.Close()
+
+	svc := newService(db, dbTracer, auth, things, cfg, logger)
+	if cfg.notifsRetention > 0 {
+		notifs := postgres.NewNotifications(postgres.NewDatabase(db))
+		stop := notifiers.StartPruning(notifs, cfg.notifsRetention, notifsPruneInterval, logger)
+		defer stop()
+	}
+	errs := make(chan error, 2)
+
+	if err = consumers.Start(pubSub, svc, nil, cfg.configPath, logger); err != nil {
+		logger.Error(fmt.Sprintf("Failed to create Postgres writer: %s", err))
+	}
+
+	go startHTTPServer(tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, errs)
+
+	go func() {
+		c := make(chan os.Signal)
+		signal.Notify(c, syscall.SIGINT)
+		errs <- fmt.Errorf("%s", <-c)
+	}()
+
+	err = <-errs
+	logger.Error(fmt.Sprintf("Users service terminated: %s", err))
+}
+
+func loadConfig() config {
+	js, jsConfig, err := jetstream.LoadConfig()
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	authTimeout, err := time.ParseDuration(mainflux.Env(envAuthTimeout, defAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthTimeout, err.Error())
+	}
+
+	thingsAuthTimeout, err := time.ParseDuration(mainflux.Env(envThingsAuthTimeout, defThingsAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envAuthTLS, defAuthTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envAuthTLS)
+	}
+
+	dbConfig := postgres.Config{
+		Host:        mainflux.Env(envDBHost, defDBHost),
+		Port:        mainflux.Env(envDBPort, defDBPort),
+		User:        mainflux.Env(envDBUser, defDBUser),
+		Pass:        mainflux.Env(envDBPass, defDBPass),
+		Name:        mainflux.Env(envDB, defDB),
+		SSLMode:     mainflux.Env(envDBSSLMode, defDBSSLMode),
+		SSLCert:     mainflux.Env(envDBSSLCert, defDBSSLCert),
+		SSLKey:      mainflux.Env(envDBSSLKey, defDBSSLKey),
+		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
+	}
+
+	webhookConf := loadWebhookConfig()
+
+	notifsRetention, err := time.ParseDuration(mainflux.Env(envNotifsRetention, defNotifsRetention))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envNotifsRetention, err.Error())
+	}
+
+	return config{
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
+		brokerType:        mainflux.Env(envBrokerType, defBrokerType),
+		kafkaURL:          mainflux.Env(envKafkaURL, defKafkaURL),
+		kafkaConfig:       kafka.Config{Topic: mainflux.Env(envKafkaTopic, defKafkaTopic)},
+		jetStream:         js,
+		jsConfig:          jsConfig,
+		configPath:        mainflux.Env(envConfigPath, defConfigPath),
+		dbConfig:          dbConfig,
+		webhookConf:       webhookConf,
+		httpPort:          mainflux.Env(envHTTPPort, defHTTPPort),
+		serverCert:        mainflux.Env(envServerCert, defServerCert),
+		serverKey:         mainflux.Env(envServerKey, defServerKey),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		authTLS:           tls,
+		authCACerts:       mainflux.Env(envAuthCACerts, defAuthCACerts),
+		authURL:           mainflux.Env(envAuthURL, defAuthURL),
+		authTimeout:       authTimeout,
+		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
+		thingsAuthTimeout: thingsAuthTimeout,
+		notifsRetention:   notifsRetention,
+	}
+
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
+	db, err := postgres.Connect(dbConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to postgres: %s", err))
+		os.Exit(1)
+	}
+	return db
+}
+
+func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.AuthServiceClient, func() error) {
+	var opts []grpc.DialOption
+	if cfg.authTLS {
+		if cfg.authCACerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.authCACerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+
+	return authapi.NewClient(tracer, conn, cfg.authTimeout), conn.Close
+}
+
+func connectToThings(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.ThingsServiceClient, func() error) {
+	var opts []grpc.DialOption
+	if cfg.authTLS {
+		if cfg.authCACerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.authCACerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsAuthURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+
+	return thingsapi.NewClient(conn, tracer, cfg.thingsAuthTimeout), conn.Close
+}
+
+func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, c config, logger logger.Logger) notifiers.Service {
+	database := postgres.NewDatabase(db)
+	repo := tracing.New(postgres.New(database), tracer)
+	notifs := tracing.NewNotifications(postgres.NewNotifications(database), tracer)
+	idp := ulid.New()
+
+	notifier, err := webhook.New(c.webhookConf)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create webhook notifier: %s", err))
+		os.Exit(1)
+	}
+
+	svc := notifiers.New(auth, things, repo, notifs, idp, notifier)
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "notifier",
+			Subsystem: "webhook",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "notifier",
+			Subsystem: "webhook",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+	return svc
+}
+
+func startHTTPServer(tracer opentracing.Tracer, svc notifiers.Service, port string, certFile string, keyFile string, logger logger.Logger, errs chan error) {
+	p := fmt.Sprintf(":%s", port)
+	if certFile != "" || keyFile != "" {
+		logger.Info(fmt.Sprintf("Webhook notifier service started using https, cert %s key %s, exposed port %s", certFile, keyFile, port))
+		errs <- http.ListenAndServeTLS(p, certFile, keyFile, api.MakeHandler(svc, tracer))
+	} else {
+		logger.Info(fmt.Sprintf("Webhook notifier service started using http, exposed port %s", port))
+		errs <- http.ListenAndServe(p, api.MakeHandler(svc, tracer))
+	}
+}
+
+// loadWebhookConfig reads the webhook notifier configuration. The body
+// template is read from the file at the configured path, and the default
+// body is sent if the path is empty.
+func loadWebhookConfig() webhook.Config {
+	timeout, err := time.ParseDuration(mainflux.Env(envWebhookTimeout, defWebhookTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envWebhookTimeout, err.Error())
+	}
+
+	retries, err := strconv.ParseUint(mainflux.Env(envWebhookRetries, defWebhookRetries), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envWebhookRetries, err.Error())
+	}
+
+	backoff, err := time.ParseDuration(mainflux.Env(envWebhookBackoff, defWebhookBackoff))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envWebhookBackoff, err.Error())
+	}
+
+	maxBackoff, err := time.ParseDuration(mainflux.Env(envWebhookMaxBackoff, defWebhookMaxBackoff))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envWebhookMaxBackoff, err.Error())
+	}
+
+	var tmpl string
+	if path := mainflux.Env(envWebhookTemplate, defWebhookTemplate); path != "" {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			log.Fatalf("Failed to read %s file: %s", envWebhookTemplate, err.Error())
+		}
+		tmpl = string(b)
+	}
+
+	return webhook.Config{
+		Secret:     mainflux.Env(envWebhookSecret, defWebhookSecret),
+		Template:   tmpl,
+		Timeout:    timeout,
+		Retries:    retries,
+		Backoff:    backoff,
+		MaxBackoff: maxBackoff,
+		Allow:      loadNets(envWebhookAllow, defWebhookAllow),
+		Deny:       loadNets(envWebhookDeny, defWebhookDeny),
+	}
+}
+
+// loadNets parses the comma-separated list of the networks in CIDR notation.
+func loadNets(key, fallback string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, c := range strings.Split(mainflux.Env(key, fallback), ",") {
+		if c = strings.TrimSpace(c); c == "" {
+			continue
+		}
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			log.Fatalf("Invalid %s value: %s", key, err.Error())
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
+
+// newPubSub returns the PubSub of the configured message broker. JetStream
+// is used instead of core NATS if it's enabled, so that the messages
+// published while the service is down are not lost.
+func newPubSub(cfg config, logger logger.Logger) (nats.PubSub, error) {
+	switch cfg.brokerType {
+	case kafkaBroker:
+		logger.Info("Using Kafka")
+		return kafka.NewPubSub(cfg.kafkaURL, "webhook-notifier", cfg.kafkaConfig, logger)
+	case natsBroker:
+		if !cfg.jetStream {
+			return nats.NewPubSub(cfg.natsURL, "", logger)
+		}
+		logger.Info("Using NATS JetStream")
+		return jetstream.NewPubSub(cfg.natsURL, "webhook-notifier", cfg.jsConfig, logger)
+	default:
+		return nil, fmt.Errorf("unsupported message broker %s", cfg.brokerType)
+	}
+}
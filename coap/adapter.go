@@ -11,12 +11,11 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/gogo/protobuf/proto"
 	"github.com/mainflux/mainflux/pkg/errors"
-	broker "github.com/nats-io/nats.go"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things"
 )
 
 const chansPrefix = "channels"
@@ -45,16 +44,21 @@ var _ Service = (*adapterService)(nil)
 // Observers is a map of maps,
 type adapterService struct {
 	auth      mainflux.ThingsServiceClient
-	conn      *broker.Conn
+	pubsub    messaging.PubSub
 	observers map[string]observers
 	obsLock   sync.Mutex
+	// subLock serializes subscribing to and unsubscribing from the
+	// endpoints, without blocking the delivery of the messages.
+	subLock sync.Mutex
 }
 
-// New instantiates the CoAP adapter implementation.
-func New(auth mainflux.ThingsServiceClient, nc *broker.Conn) Service {
+// New instantiates the CoAP adapter implementation. The adapter subscribes
+// to each observed endpoint once, and forwards the received messages to
+// all of its observers.
+func New(auth mainflux.ThingsServiceClient, pubsub messaging.PubSub) Service {
 	as := &adapterService{
 		auth:      auth,
-		conn:      nc,
+		pubsub:    pubsub,
 		observers: make(map[string]observers),
 		obsLock:   sync.Mutex{},
 	}
@@ -64,8 +68,10 @@ func New(auth mainflux.ThingsServiceClient, nc *broker.Conn) Service {
 
 func (svc *adapterService) Publish(ctx context.Context, key string, msg messaging.Message) error {
 	ar := &mainflux.AccessByKeyReq{
-		Token:  key,
-		ChanID: msg.Channel,
+		Token:    key,
+		ChanID:   msg.Channel,
+		Action:   things.PublishAction,
+		Subtopic: msg.Subtopic,
 	}
 	thid, err := svc.auth.CanAccessByKey(ctx, ar)
 	if err != nil {
@@ -73,23 +79,15 @@ func (svc *adapterService) Publish(ctx context.Context, key string, msg messagin
 	}
 	msg.Publisher = thid.GetValue()
 
-	data, err := proto.Marshal(&msg)
-	if err != nil {
-		return err
-	}
-
-	subject := fmt.Sprintf("%s.%s", chansPrefix, msg.Channel)
-	if msg.Subtopic != "" {
-		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
-	}
-
-	return svc.conn.Publish(subject, data)
+	return svc.pubsub.Publish(msg.Channel, msg)
 }
 
 func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic string, c Client) error {
 	ar := &mainflux.AccessByKeyReq{
-		Token:  key,
-		ChanID: chanID,
+		Token:    key,
+		ChanID:   chanID,
+		Action:   things.SubscribeAction,
+		Subtopic: subtopic,
 	}
 	if _, err := svc.auth.CanAccessByKey(ctx, ar); err != nil {
 		return errors.Wrap(ErrUnauthorized, err)
@@ -105,18 +103,19 @@ func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic
 		svc.remove(subject, c.Token())
 	}()
 
-	obs, err := NewObserver(subject, c, svc.conn)
-	if err != nil {
+	if err := svc.put(subject, c.Token(), c); err != nil {
 		c.Cancel()
 		return err
 	}
-	return svc.put(subject, c.Token(), obs)
+	return nil
 }
 
 func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopic, token string) error {
 	ar := &mainflux.AccessByKeyReq{
-		Token:  key,
-		ChanID: chanID,
+		Token:    key,
+		ChanID:   chanID,
+		Action:   things.SubscribeAction,
+		Subtopic: subtopic,
 	}
 	if _, err := svc.auth.CanAccessByKey(ctx, ar); err != nil {
 		return errors.Wrap(ErrUnauthorized, err)
@@ -129,44 +128,85 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopi
 	return svc.remove(subject, token)
 }
 
-func (svc *adapterService) put(endpoint, token string, o Observer) error {
-	svc.obsLock.Lock()
-	defer svc.obsLock.Unlock()
+func (svc *adapterService) put(endpoint, token string, c Client) error {
+	svc.subLock.Lock()
+	defer svc.subLock.Unlock()
 
+	svc.obsLock.Lock()
 	obs, ok := svc.observers[endpoint]
-	// If there are no observers, create map and assign it to the endpoint.
+	svc.obsLock.Unlock()
+
+	// If there are no observers, subscribe to the endpoint, and create map
+	// and assign it to the endpoint.
 	if !ok {
-		obs = observers{token: o}
-		svc.observers[endpoint] = obs
+		if err := svc.pubsub.Subscribe(endpoint, svc.handler(endpoint)); err != nil {
+			return err
+		}
+		svc.obsLock.Lock()
+		svc.observers[endpoint] = observers{token: c}
+		svc.obsLock.Unlock()
 		return nil
 	}
-	// If observer exists, cancel subscription and replace it.
-	if sub, ok := obs[token]; ok {
-		if err := sub.Cancel(); err != nil {
+
+	svc.obsLock.Lock()
+	defer svc.obsLock.Unlock()
+	// If observer exists, cancel it and replace it.
+	if current, ok := obs[token]; ok {
+		if err := current.Cancel(); err != nil {
 			return errors.Wrap(ErrUnsubscribe, err)
 		}
 	}
-	obs[token] = o
+	obs[token] = c
 	return nil
 }
 
 func (svc *adapterService) remove(endpoint, token string) error {
-	svc.obsLock.Lock()
-	defer svc.obsLock.Unlock()
+	svc.subLock.Lock()
+	defer svc.subLock.Unlock()
 
+	svc.obsLock.Lock()
 	obs, ok := svc.observers[endpoint]
 	if !ok {
+		svc.obsLock.Unlock()
 		return nil
 	}
-	if current, ok := obs[token]; ok {
+	current, ok := obs[token]
+	delete(obs, token)
+	// If there are no observers left for the endpint, remove the map.
+	empty := len(obs) == 0
+	if empty {
+		delete(svc.observers, endpoint)
+	}
+	svc.obsLock.Unlock()
+
+	if ok {
 		if err := current.Cancel(); err != nil {
 			return errors.Wrap(ErrUnsubscribe, err)
 		}
 	}
-	delete(obs, token)
-	// If there are no observers left for the endpint, remove the map.
-	if len(obs) == 0 {
-		delete(svc.observers, endpoint)
+	// Unsubscribe from the endpoint once it's not observed anymore.
+	if empty {
+		if err := svc.pubsub.Unsubscribe(endpoint); err != nil {
+			return errors.Wrap(ErrUnsubscribe, err)
+		}
 	}
 	return nil
 }
+
+// handler forwards the messages received on the endpoint to its observers.
+func (svc *adapterService) handler(endpoint string) messaging.MessageHandler {
+	return func(msg messaging.Message) error {
+		svc.obsLock.Lock()
+		clients := make([]Client, 0, len(svc.observers[endpoint]))
+		for _, c := range svc.observers[endpoint] {
+			clients = append(clients, c)
+		}
+		svc.obsLock.Unlock()
+
+		for _, c := range clients {
+			// There is no error handling, but the client takes care to log the error.
+			c.SendMessage(msg)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package coap_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/coap"
+	"github.com/mainflux/mainflux/http/mocks"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	thingKey   = "thing_key"
+	thingID    = "513d02d2-16c1-4f23-98be-9e12f8fee898"
+	chanID     = "1"
+	subtopic   = "temperature"
+	invalidKey = "invalid_key"
+	timeout    = time.Second
+)
+
+var payload = []byte("payload")
+
+type client struct {
+	token string
+	msgs  chan messaging.Message
+	done  chan struct{}
+}
+
+func newClient(token string) *client {
+	return &client{
+		token: token,
+		msgs:  make(chan messaging.Message, 1),
+		done:  make(chan struct{}),
+	}
+}
+
+func (c *client) Token() string {
+	return c.token
+}
+
+func (c *client) SendMessage(msg messaging.Message) error {
+	c.msgs <- msg
+	return nil
+}
+
+func (c *client) Cancel() error {
+	return nil
+}
+
+func (c *client) Done() <-chan struct{} {
+	return c.done
+}
+
+func newService(t *testing.T) coap.Service {
+	logs, err := logger.New(os.Stdout, "error")
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	things := mocks.NewThingsClient(map[string]string{thingKey: thingID})
+	pubsub := memory.NewPubSub(memory.NewBroker(), "", logs)
+	return coap.New(things, pubsub)
+}
+
+func TestPublish(t *testing.T) {
+	svc := newService(t)
+
+	c := newClient("token")
+	err := svc.Subscribe(context.Background(), thingKey, chanID, subtopic, c)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		key      string
+		msg      messaging.Message
+		err      error
+		received bool
+	}{
+		{
+			desc:     "publish message to observed subtopic",
+			key:      thingKey,
+			msg:      messaging.Message{Channel: chanID, Subtopic: subtopic, Payload: payload},
+			err:      nil,
+			received: true,
+		},
+		{
+			desc:     "publish message to other subtopic",
+			key:      thingKey,
+			msg:      messaging.Message{Channel: chanID, Subtopic: "humidity", Payload: payload},
+			err:      nil,
+			received: false,
+		},
+		{
+			desc:     "publish message with invalid key",
+			key:      invalidKey,
+			msg:      messaging.Message{Channel: chanID, Subtopic: subtopic, Payload: payload},
+			err:      coap.ErrUnauthorized,
+			received: false,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Publish(context.Background(), tc.key, tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		select {
+		case msg := <-c.msgs:
+			tc.msg.Publisher = thingID
+			assert.True(t, tc.received, fmt.Sprintf("%s: unexpected message %+v", tc.desc, msg))
+			assert.Equal(t, tc.msg, msg, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, tc.msg, msg))
+		case <-time.After(timeout):
+			assert.False(t, tc.received, fmt.Sprintf("%s: message not delivered", tc.desc))
+		}
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	svc := newService(t)
+
+	c1 := newClient("token1")
+	err := svc.Subscribe(context.Background(), thingKey, chanID, subtopic, c1)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	c2 := newClient("token2")
+	err = svc.Subscribe(context.Background(), thingKey, chanID, subtopic, c2)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = svc.Unsubscribe(context.Background(), invalidKey, chanID, subtopic, c1.Token())
+	assert.True(t, errors.Contains(err, coap.ErrUnauthorized), fmt.Sprintf("unsubscribe with invalid key: expected %s got %s\n", coap.ErrUnauthorized, err))
+
+	err = svc.Unsubscribe(context.Background(), thingKey, chanID, subtopic, c1.Token())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// The remaining observer keeps receiving the messages.
+	msg := messaging.Message{Channel: chanID, Subtopic: subtopic, Payload: payload}
+	err = svc.Publish(context.Background(), thingKey, msg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	select {
+	case <-c2.msgs:
+	case <-time.After(timeout):
+		assert.Fail(t, "publish to remaining observer: message not delivered")
+	}
+	select {
+	case m := <-c1.msgs:
+		assert.Fail(t, fmt.Sprintf("publish to unsubscribed observer: unexpected message %+v", m))
+	case <-time.After(timeout / 10):
+	}
+
+	// Once the last observer leaves, the endpoint can be observed again.
+	err = svc.Unsubscribe(context.Background(), thingKey, chanID, subtopic, c2.Token())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = svc.Subscribe(context.Background(), thingKey, chanID, subtopic, newClient("token3"))
+	assert.Nil(t, err, fmt.Sprintf("subscribe after last observer left: expected nil got %s", err))
+}
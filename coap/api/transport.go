@@ -31,6 +31,22 @@ const (
 	authQuery = "auth"
 )
 
+// SenML content formats registered in RFC 8428.
+const (
+	senMLJSON message.MediaType = 110
+	senMLCBOR message.MediaType = 112
+)
+
+// contentTypes maps CoAP content formats to the content types of messages.
+var contentTypes = map[message.MediaType]string{
+	message.TextPlain: "text/plain",
+	message.AppOctets: "application/octet-stream",
+	message.AppJSON:   "application/json",
+	message.AppCBOR:   "application/cbor",
+	senMLJSON:         "application/senml+json",
+	senMLCBOR:         "application/senml+cbor",
+}
+
 var channelPartRegExp = regexp.MustCompile(`^channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
 
 var errMalformedSubtopic = errors.New("malformed subtopic")
@@ -142,6 +158,10 @@ func decodeMessage(msg *mux.Message) (messaging.Message, error) {
 		Created:  time.Now().UnixNano(),
 	}
 
+	if cf, err := msg.Options.ContentFormat(); err == nil {
+		ret.ContentType = contentTypes[cf]
+	}
+
 	if msg.Body != nil {
 		buff, err := ioutil.ReadAll(msg.Body)
 		if err != nil {
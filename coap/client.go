@@ -24,7 +24,8 @@ type Client interface {
 	Done() <-chan struct{}
 }
 
-type observers map[string]Observer
+// observers maps the CoAP tokens to the clients observing the endpoint.
+type observers map[string]Client
 
 // ErrOption indicates an error when adding an option.
 var ErrOption = errors.New("unable to set option")
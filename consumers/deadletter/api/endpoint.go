@@ -0,0 +1,177 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/pkg/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+func listDeadLettersEndpoint(svc deadletter.Service, ac mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, ac, req.token); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListDeadLetters(ctx, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listDeadLettersRes{
+			Total:       page.Total,
+			Offset:      page.Offset,
+			Limit:       page.Limit,
+			DeadLetters: []viewDeadLetterRes{},
+		}
+		for _, dl := range page.DeadLetters {
+			res.DeadLetters = append(res.DeadLetters, toRes(dl))
+		}
+
+		return res, nil
+	}
+}
+
+func viewDeadLetterEndpoint(svc deadletter.Service, ac mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, ac, req.token); err != nil {
+			return nil, err
+		}
+
+		dl, err := svc.ViewDeadLetter(ctx, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toRes(dl), nil
+	}
+}
+
+func replayDeadLetterEndpoint(svc deadletter.Service, ac mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, ac, req.token); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ReplayDeadLetter(ctx, req.id); err != nil {
+			return nil, err
+		}
+
+		return replayRes{Replayed: 1}, nil
+	}
+}
+
+func replayDeadLettersEndpoint(svc deadletter.Service, ac mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(adminReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, ac, req.token); err != nil {
+			return nil, err
+		}
+
+		replayed, err := svc.ReplayDeadLetters(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return replayRes{Replayed: replayed}, nil
+	}
+}
+
+func removeDeadLetterEndpoint(svc deadletter.Service, ac mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, ac, req.token); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveDeadLetter(ctx, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func purgeDeadLettersEndpoint(svc deadletter.Service, ac mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(adminReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, ac, req.token); err != nil {
+			return nil, err
+		}
+
+		if err := svc.PurgeDeadLetters(ctx); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+// authorize checks that the token belongs to the member of the authorities.
+func authorize(ctx context.Context, ac mainflux.AuthServiceClient, token string) error {
+	user, err := ac.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errUnauthorizedAccess, err)
+	}
+
+	admin, err := auth.IsAdmin(ctx, ac, user.GetId())
+	if err != nil {
+		return errors.Wrap(errPermissionDenied, err)
+	}
+	if !admin {
+		return errPermissionDenied
+	}
+
+	return nil
+}
+
+func toRes(dl deadletter.DeadLetter) viewDeadLetterRes {
+	return viewDeadLetterRes{
+		ID:      dl.ID,
+		Reason:  dl.Reason,
+		Created: dl.Created,
+		Message: messageRes{
+			Channel:     dl.Message.Channel,
+			Subtopic:    dl.Message.Subtopic,
+			Publisher:   dl.Message.Publisher,
+			Protocol:    dl.Message.Protocol,
+			ContentType: dl.Message.ContentType,
+			Headers:     dl.Message.Headers,
+			Payload:     dl.Message.Payload,
+			Created:     dl.Message.Created,
+		},
+	}
+}
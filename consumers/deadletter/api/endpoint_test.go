@@ -0,0 +1,318 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/consumers/deadletter/api"
+	"github.com/mainflux/mainflux/consumers/deadletter/memory"
+	"github.com/mainflux/mainflux/consumers/deadletter/mocks"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	invalid    = "invalid"
+	adminToken = "admin-token"
+	userToken  = "user-token"
+	adminID    = "admin"
+	userID     = "user"
+)
+
+var (
+	errConsume = errors.New("failed to consume message")
+	msg        = messaging.Message{
+		Channel:  "50e6b371-60ff-45cf-bb52-8200e7cde536",
+		Protocol: "http",
+		Payload:  []byte(`[{"n":"temperature","v":22}]`),
+	}
+)
+
+type testRequest struct {
+	client *http.Client
+	method string
+	url    string
+	token  string
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+type listRes struct {
+	Total       uint64            `json:"total"`
+	Offset      uint64            `json:"offset"`
+	Limit       uint64            `json:"limit"`
+	DeadLetters []json.RawMessage `json:"dead_letters"`
+}
+
+func handle(msg messaging.Message) error {
+	if string(msg.Payload) == invalid {
+		return errConsume
+	}
+	return nil
+}
+
+func newService() deadletter.Service {
+	return deadletter.New(memory.NewRepository(0), mocks.NewPublisher(), handle, uuid.NewMock())
+}
+
+func newServer(svc deadletter.Service) *httptest.Server {
+	auth := mocks.NewAuth(map[string]string{adminToken: adminID, userToken: userID}, adminID)
+	mux := api.MakeHandler(svc, auth, bone.New())
+	return httptest.NewServer(mux)
+}
+
+func TestListDeadLetters(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	n := 20
+	for i := 0; i < n; i++ {
+		_, err := svc.Add(context.Background(), msg, errConsume.Error())
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list dead letters with default limit",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  adminToken,
+			status: http.StatusOK,
+			size:   10,
+		},
+		{
+			desc:   "list dead letters with offset",
+			url:    fmt.Sprintf("%s/deadletters?offset=15&limit=10", ts.URL),
+			token:  adminToken,
+			status: http.StatusOK,
+			size:   5,
+		},
+		{
+			desc:   "list dead letters with zero limit",
+			url:    fmt.Sprintf("%s/deadletters?limit=0", ts.URL),
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters with too big limit",
+			url:    fmt.Sprintf("%s/deadletters?limit=1000", ts.URL),
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters with invalid offset",
+			url:    fmt.Sprintf("%s/deadletters?offset=invalid", ts.URL),
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters without token",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list dead letters with invalid token",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list dead letters as non-admin user",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var page listRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, uint64(n), page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, n, page.Total))
+		assert.Len(t, page.DeadLetters, tc.size, fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.size, len(page.DeadLetters)))
+	}
+}
+
+func TestViewDeadLetter(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	id, err := svc.Add(context.Background(), msg, errConsume.Error())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		status int
+	}{
+		{
+			desc:   "view existing dead letter",
+			id:     id,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view non-existing dead letter",
+			id:     "non-existing",
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/deadletters/%s", ts.URL, tc.id),
+			token:  adminToken,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	id, err := svc.Add(context.Background(), msg, errConsume.Error())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	invalidMsg := msg
+	invalidMsg.Payload = []byte(invalid)
+	invalidID, err := svc.Add(context.Background(), invalidMsg, errConsume.Error())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		url    string
+		status int
+	}{
+		{
+			desc:   "replay dead letter",
+			url:    fmt.Sprintf("%s/deadletters/%s/replay", ts.URL, id),
+			status: http.StatusOK,
+		},
+		{
+			desc:   "replay replayed dead letter",
+			url:    fmt.Sprintf("%s/deadletters/%s/replay", ts.URL, id),
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "replay dead letter failing again",
+			url:    fmt.Sprintf("%s/deadletters/%s/replay", ts.URL, invalidID),
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			desc:   "replay all dead letters",
+			url:    fmt.Sprintf("%s/deadletters/replay", ts.URL),
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    tc.url,
+			token:  adminToken,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRemoveDeadLetters(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	id, err := svc.Add(context.Background(), msg, errConsume.Error())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Add(context.Background(), msg, errConsume.Error())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		url    string
+		status int
+		total  uint64
+	}{
+		{
+			desc:   "remove dead letter",
+			url:    fmt.Sprintf("%s/deadletters/%s", ts.URL, id),
+			status: http.StatusNoContent,
+			total:  1,
+		},
+		{
+			desc:   "remove removed dead letter",
+			url:    fmt.Sprintf("%s/deadletters/%s", ts.URL, id),
+			status: http.StatusNotFound,
+			total:  1,
+		},
+		{
+			desc:   "purge dead letters",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			status: http.StatusNoContent,
+			total:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    tc.url,
+			token:  adminToken,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		page, err := svc.ListDeadLetters(context.Background(), 0, 10)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.total, page.Total))
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ deadletter.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    deadletter.Service
+}
+
+// LoggingMiddleware adds logging facilities to the dead-letter service.
+func LoggingMiddleware(svc deadletter.Service, logger log.Logger) deadletter.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) Add(ctx context.Context, msg messaging.Message, reason string) (id string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_dead_letter with the id %s for channel %s and reason %s took %s to complete", id, msg.Channel, reason, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Add(ctx, msg, reason)
+}
+
+func (lm *loggingMiddleware) ListDeadLetters(ctx context.Context, offset, limit uint64) (page deadletter.Page, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_dead_letters with offset %d and limit %d took %s to complete", offset, limit, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListDeadLetters(ctx, offset, limit)
+}
+
+func (lm *loggingMiddleware) ViewDeadLetter(ctx context.Context, id string) (dl deadletter.DeadLetter, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_dead_letter with the id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewDeadLetter(ctx, id)
+}
+
+func (lm *loggingMiddleware) ReplayDeadLetter(ctx context.Context, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method replay_dead_letter with the id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ReplayDeadLetter(ctx, id)
+}
+
+func (lm *loggingMiddleware) ReplayDeadLetters(ctx context.Context) (replayed uint64, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method replay_dead_letters replayed %d messages and took %s to complete", replayed, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ReplayDeadLetters(ctx)
+}
+
+func (lm *loggingMiddleware) RemoveDeadLetter(ctx context.Context, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_dead_letter with the id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveDeadLetter(ctx, id)
+}
+
+func (lm *loggingMiddleware) PurgeDeadLetters(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method purge_dead_letters took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PurgeDeadLetters(ctx)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ deadletter.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     deadletter.Service
+}
+
+// MetricsMiddleware instruments dead-letter service by tracking request
+// count and latency.
+func MetricsMiddleware(svc deadletter.Service, counter metrics.Counter, latency metrics.Histogram) deadletter.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) Add(ctx context.Context, msg messaging.Message, reason string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_dead_letter").Add(1)
+		ms.latency.With("method", "add_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Add(ctx, msg, reason)
+}
+
+func (ms *metricsMiddleware) ListDeadLetters(ctx context.Context, offset, limit uint64) (deadletter.Page, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_dead_letters").Add(1)
+		ms.latency.With("method", "list_dead_letters").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListDeadLetters(ctx, offset, limit)
+}
+
+func (ms *metricsMiddleware) ViewDeadLetter(ctx context.Context, id string) (deadletter.DeadLetter, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_dead_letter").Add(1)
+		ms.latency.With("method", "view_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewDeadLetter(ctx, id)
+}
+
+func (ms *metricsMiddleware) ReplayDeadLetter(ctx context.Context, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "replay_dead_letter").Add(1)
+		ms.latency.With("method", "replay_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ReplayDeadLetter(ctx, id)
+}
+
+func (ms *metricsMiddleware) ReplayDeadLetters(ctx context.Context) (uint64, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "replay_dead_letters").Add(1)
+		ms.latency.With("method", "replay_dead_letters").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ReplayDeadLetters(ctx)
+}
+
+func (ms *metricsMiddleware) RemoveDeadLetter(ctx context.Context, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_dead_letter").Add(1)
+		ms.latency.With("method", "remove_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveDeadLetter(ctx, id)
+}
+
+func (ms *metricsMiddleware) PurgeDeadLetters(ctx context.Context) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "purge_dead_letters").Add(1)
+		ms.latency.With("method", "purge_dead_letters").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.PurgeDeadLetters(ctx)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import "github.com/mainflux/mainflux/pkg/errors"
+
+const maxLimitSize = 100
+
+var (
+	errInvalidID          = errors.New("invalid or empty dead letter id")
+	errUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+	errPermissionDenied   = errors.New("dead letters are managed by the authorities only")
+)
+
+type adminReq struct {
+	token string
+}
+
+func (req adminReq) validate() error {
+	if req.token == "" {
+		return errUnauthorizedAccess
+	}
+	return nil
+}
+
+type listReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return errUnauthorizedAccess
+	}
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return errors.ErrInvalidQueryParams
+	}
+	return nil
+}
+
+type deadLetterReq struct {
+	token string
+	id    string
+}
+
+func (req deadLetterReq) validate() error {
+	if req.token == "" {
+		return errUnauthorizedAccess
+	}
+	if req.id == "" {
+		return errInvalidID
+	}
+	return nil
+}
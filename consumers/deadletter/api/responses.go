@@ -0,0 +1,101 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*viewDeadLetterRes)(nil)
+	_ mainflux.Response = (*listDeadLettersRes)(nil)
+	_ mainflux.Response = (*replayRes)(nil)
+	_ mainflux.Response = (*removeRes)(nil)
+)
+
+type messageRes struct {
+	Channel     string            `json:"channel"`
+	Subtopic    string            `json:"subtopic,omitempty"`
+	Publisher   string            `json:"publisher"`
+	Protocol    string            `json:"protocol"`
+	ContentType string            `json:"content_type,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Payload     []byte            `json:"payload"`
+	Created     int64             `json:"created"`
+}
+
+type viewDeadLetterRes struct {
+	ID      string     `json:"id"`
+	Reason  string     `json:"reason"`
+	Created time.Time  `json:"created"`
+	Message messageRes `json:"message"`
+}
+
+func (res viewDeadLetterRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewDeadLetterRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewDeadLetterRes) Empty() bool {
+	return false
+}
+
+type listDeadLettersRes struct {
+	Total       uint64              `json:"total"`
+	Offset      uint64              `json:"offset"`
+	Limit       uint64              `json:"limit"`
+	DeadLetters []viewDeadLetterRes `json:"dead_letters"`
+}
+
+func (res listDeadLettersRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listDeadLettersRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listDeadLettersRes) Empty() bool {
+	return false
+}
+
+type replayRes struct {
+	Replayed uint64 `json:"replayed"`
+}
+
+func (res replayRes) Code() int {
+	return http.StatusOK
+}
+
+func (res replayRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res replayRes) Empty() bool {
+	return false
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
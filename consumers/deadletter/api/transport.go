@@ -0,0 +1,153 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/internal/httputil"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	contentType = "application/json"
+	defLimit    = 10
+)
+
+// MakeHandler registers the dead letters API endpoints on the mux. The
+// requests are authorized by the auth service.
+func MakeHandler(svc deadletter.Service, ac mainflux.AuthServiceClient, mux *bone.Mux) *bone.Mux {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(encodeError),
+	}
+
+	mux.Get("/deadletters", kithttp.NewServer(
+		listDeadLettersEndpoint(svc, ac),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/deadletters", kithttp.NewServer(
+		purgeDeadLettersEndpoint(svc, ac),
+		decodeAdmin,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/deadletters/replay", kithttp.NewServer(
+		replayDeadLettersEndpoint(svc, ac),
+		decodeAdmin,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/deadletters/:id", kithttp.NewServer(
+		viewDeadLetterEndpoint(svc, ac),
+		decodeDeadLetter,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/deadletters/:id", kithttp.NewServer(
+		removeDeadLetterEndpoint(svc, ac),
+		decodeDeadLetter,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/deadletters/:id/replay", kithttp.NewServer(
+		replayDeadLetterEndpoint(svc, ac),
+		decodeDeadLetter,
+		encodeResponse,
+		opts...,
+	))
+
+	return mux
+}
+
+func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := httputil.ReadUintQuery(r, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := httputil.ReadUintQuery(r, "limit", defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listReq{
+		token:  r.Header.Get("Authorization"),
+		offset: offset,
+		limit:  limit,
+	}
+	return req, nil
+}
+
+func decodeDeadLetter(_ context.Context, r *http.Request) (interface{}, error) {
+	req := deadLetterReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	return req, nil
+}
+
+func decodeAdmin(_ context.Context, r *http.Request) (interface{}, error) {
+	req := adminReq{
+		token: r.Header.Get("Authorization"),
+	}
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch errorVal := err.(type) {
+	case errors.Error:
+		w.Header().Set("Content-Type", contentType)
+		switch {
+		case errors.Contains(errorVal, errors.ErrInvalidQueryParams),
+			errors.Contains(errorVal, errInvalidID):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, errUnauthorizedAccess):
+			w.WriteHeader(http.StatusUnauthorized)
+		case errors.Contains(errorVal, errPermissionDenied):
+			w.WriteHeader(http.StatusForbidden)
+		case errors.Contains(errorVal, deadletter.ErrNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Contains(errorVal, deadletter.ErrReplay):
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		if errorVal.Msg() != "" {
+			if err := json.NewEncoder(w).Encode(errorRes{Err: errorVal.Msg()}); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
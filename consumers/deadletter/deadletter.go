@@ -0,0 +1,57 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package deadletter
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// ReasonHeader is the message header which carries the reason the message
+// was moved to the dead-letter queue.
+const ReasonHeader = "deadletter-reason"
+
+// DeadLetter represents the message that failed to be consumed.
+type DeadLetter struct {
+	ID      string
+	Reason  string
+	Created time.Time
+	Message messaging.Message
+}
+
+// Page represents a page of dead letters.
+type Page struct {
+	Total       uint64
+	Offset      uint64
+	Limit       uint64
+	DeadLetters []DeadLetter
+}
+
+// Repository specifies dead letters persistence API.
+type Repository interface {
+	// Save persists the dead letter.
+	Save(ctx context.Context, dl DeadLetter) error
+
+	// RetrieveByID retrieves the dead letter having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (DeadLetter, error)
+
+	// RetrieveAll retrieves the subset of dead letters, oldest first. Zero
+	// limit retrieves all the dead letters starting from the offset.
+	RetrieveAll(ctx context.Context, offset, limit uint64) (Page, error)
+
+	// Remove removes the dead letter having the provided identifier.
+	Remove(ctx context.Context, id string) error
+
+	// RemoveAll removes all the dead letters.
+	RemoveAll(ctx context.Context) error
+}
+
+// Publisher specifies the API for publishing dead letters to the
+// dead-letter queue.
+type Publisher interface {
+	// Publish publishes the message to the dead-letter queue.
+	Publish(msg messaging.Message) error
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package deadletter contains the domain concept definitions needed to
+// support handling of the messages which consumers failed to consume.
+package deadletter
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jetstream contains dead letters repository implementation backed
+// by the NATS JetStream stream, so that the dead letters survive the writer
+// restarts.
+package jetstream
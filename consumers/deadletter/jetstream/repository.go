@@ -0,0 +1,261 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	broker "github.com/nats-io/nats.go"
+)
+
+const (
+	subjectPrefix = "deadletters"
+
+	idHeader      = "Deadletter-Id"
+	reasonHeader  = "Deadletter-Reason"
+	createdHeader = "Deadletter-Created"
+
+	errMsgNotFound = "no message found"
+)
+
+var (
+	// ErrSave indicates error storing the dead letter in the stream.
+	ErrSave = errors.New("failed to save dead letter")
+
+	// ErrRetrieve indicates error reading the dead letters from the stream.
+	ErrRetrieve = errors.New("failed to retrieve dead letters")
+
+	// ErrRemove indicates error removing the dead letters from the stream.
+	ErrRemove = errors.New("failed to remove dead letters")
+)
+
+// streamReplacer replaces the characters which stream names can't contain.
+var streamReplacer = strings.NewReplacer(".", "_", "*", "any", ">", "all", " ", "_")
+
+var _ deadletter.Repository = (*repository)(nil)
+
+// Repository wraps dead letters Repository exposing
+// Close() method for NATS connection.
+type Repository interface {
+	deadletter.Repository
+	Close()
+}
+
+type repository struct {
+	conn    *broker.Conn
+	js      broker.JetStreamContext
+	stream  string
+	subject string
+}
+
+// NewRepository returns dead letters repository which stores the dead
+// letters in the JetStream stream of the given name. The stream is created
+// if it doesn't exist, and keeps at most limit dead letters, discarding the
+// oldest ones. Zero limit is unlimited. The existing stream is used as is.
+func NewRepository(url, name string, limit int64) (Repository, error) {
+	conn, err := broker.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	stream := streamReplacer.Replace(name)
+	subject := fmt.Sprintf("%s.%s", subjectPrefix, stream)
+	if _, err := js.StreamInfo(stream); err != nil {
+		if errors.Contains(err, broker.ErrStreamNotFound) {
+			_, err = js.AddStream(&broker.StreamConfig{
+				Name:     stream,
+				Subjects: []string{subject},
+				MaxMsgs:  maxMsgs(limit),
+				Discard:  broker.DiscardOld,
+				Storage:  broker.FileStorage,
+			})
+		}
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	ret := &repository{
+		conn:    conn,
+		js:      js,
+		stream:  stream,
+		subject: subject,
+	}
+	return ret, nil
+}
+
+func (repo *repository) Save(_ context.Context, dl deadletter.DeadLetter) error {
+	data, err := proto.Marshal(&dl.Message)
+	if err != nil {
+		return errors.Wrap(ErrSave, err)
+	}
+
+	m := broker.NewMsg(repo.subject)
+	m.Header.Set(idHeader, dl.ID)
+	m.Header.Set(reasonHeader, dl.Reason)
+	m.Header.Set(createdHeader, dl.Created.Format(time.RFC3339Nano))
+	m.Data = data
+	if _, err := repo.js.PublishMsg(m); err != nil {
+		return errors.Wrap(ErrSave, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveByID(_ context.Context, id string) (deadletter.DeadLetter, error) {
+	dl, _, err := repo.find(id)
+	return dl, err
+}
+
+func (repo *repository) RetrieveAll(_ context.Context, offset, limit uint64) (deadletter.Page, error) {
+	info, err := repo.js.StreamInfo(repo.stream)
+	if err != nil {
+		return deadletter.Page{}, errors.Wrap(ErrRetrieve, err)
+	}
+
+	page := deadletter.Page{
+		Total:       info.State.Msgs,
+		Offset:      offset,
+		Limit:       limit,
+		DeadLetters: []deadletter.DeadLetter{},
+	}
+
+	var skipped uint64
+	err = repo.scan(func(dl deadletter.DeadLetter, _ uint64) bool {
+		if skipped < offset {
+			skipped++
+			return true
+		}
+		page.DeadLetters = append(page.DeadLetters, dl)
+		return limit == 0 || uint64(len(page.DeadLetters)) < limit
+	})
+	if err != nil {
+		return deadletter.Page{}, err
+	}
+
+	return page, nil
+}
+
+func (repo *repository) Remove(_ context.Context, id string) error {
+	_, seq, err := repo.find(id)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.js.DeleteMsg(repo.stream, seq); err != nil {
+		return errors.Wrap(ErrRemove, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RemoveAll(_ context.Context) error {
+	if err := repo.js.PurgeStream(repo.stream); err != nil {
+		return errors.Wrap(ErrRemove, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) Close() {
+	repo.conn.Close()
+}
+
+// find returns the dead letter having the provided identifier and its
+// stream sequence.
+func (repo *repository) find(id string) (deadletter.DeadLetter, uint64, error) {
+	var found deadletter.DeadLetter
+	var seq uint64
+	err := repo.scan(func(dl deadletter.DeadLetter, s uint64) bool {
+		if dl.ID != id {
+			return true
+		}
+		found, seq = dl, s
+		return false
+	})
+	if err != nil {
+		return deadletter.DeadLetter{}, 0, err
+	}
+	if seq == 0 {
+		return deadletter.DeadLetter{}, 0, deadletter.ErrNotFound
+	}
+
+	return found, seq, nil
+}
+
+// scan passes the stored dead letters to fn, oldest first, until fn
+// returns false. The removed dead letters leave the gaps in the stream
+// sequence, which are skipped.
+func (repo *repository) scan(fn func(dl deadletter.DeadLetter, seq uint64) bool) error {
+	info, err := repo.js.StreamInfo(repo.stream)
+	if err != nil {
+		return errors.Wrap(ErrRetrieve, err)
+	}
+
+	if info.State.Msgs == 0 {
+		return nil
+	}
+
+	for seq := info.State.FirstSeq; seq <= info.State.LastSeq; seq++ {
+		m, err := repo.js.GetMsg(repo.stream, seq)
+		if err != nil {
+			if err.Error() == errMsgNotFound {
+				continue
+			}
+			return errors.Wrap(ErrRetrieve, err)
+		}
+
+		dl, err := toDeadLetter(m)
+		if err != nil {
+			return errors.Wrap(ErrRetrieve, err)
+		}
+		if !fn(dl, seq) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func toDeadLetter(m *broker.RawStreamMsg) (deadletter.DeadLetter, error) {
+	var msg messaging.Message
+	if err := proto.Unmarshal(m.Data, &msg); err != nil {
+		return deadletter.DeadLetter{}, err
+	}
+
+	created, err := time.Parse(time.RFC3339Nano, m.Header.Get(createdHeader))
+	if err != nil {
+		created = m.Time
+	}
+
+	dl := deadletter.DeadLetter{
+		ID:      m.Header.Get(idHeader),
+		Reason:  m.Header.Get(reasonHeader),
+		Created: created,
+		Message: msg,
+	}
+	return dl, nil
+}
+
+// maxMsgs converts zero limit to the JetStream representation of unlimited.
+func maxMsgs(limit int64) int64 {
+	if limit <= 0 {
+		return -1
+	}
+	return limit
+}
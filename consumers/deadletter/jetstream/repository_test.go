@@ -0,0 +1,152 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/consumers/deadletter/jetstream"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var msg = messaging.Message{
+	Channel:  "50e6b371-60ff-45cf-bb52-8200e7cde536",
+	Protocol: "http",
+	Payload:  []byte(`[{"n":"temperature","v":22}]`),
+	Headers:  map[string]string{"unit": "celsius"},
+}
+
+func newDeadLetter(id string) deadletter.DeadLetter {
+	return deadletter.DeadLetter{
+		ID:      id,
+		Reason:  "failed to transform message",
+		Created: time.Now().UTC().Round(time.Millisecond),
+		Message: msg,
+	}
+}
+
+func TestSaveAndRetrieve(t *testing.T) {
+	repo, err := jetstream.NewRepository(address, "deadletter.save", 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer repo.Close()
+
+	dl := newDeadLetter("1")
+	err = repo.Save(context.Background(), dl)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		dl   deadletter.DeadLetter
+		err  error
+	}{
+		{
+			desc: "retrieve saved dead letter",
+			id:   dl.ID,
+			dl:   dl,
+			err:  nil,
+		},
+		{
+			desc: "retrieve non-existing dead letter",
+			id:   "non-existing",
+			dl:   deadletter.DeadLetter{},
+			err:  deadletter.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		dl, err := repo.RetrieveByID(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.dl.ID, dl.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, tc.dl.ID, dl.ID))
+		assert.Equal(t, tc.dl.Reason, dl.Reason, fmt.Sprintf("%s: expected reason %s got %s", tc.desc, tc.dl.Reason, dl.Reason))
+		assert.True(t, tc.dl.Created.Equal(dl.Created), fmt.Sprintf("%s: expected created %s got %s", tc.desc, tc.dl.Created, dl.Created))
+		assert.Equal(t, tc.dl.Message.Payload, dl.Message.Payload, fmt.Sprintf("%s: expected payload %s got %s", tc.desc, tc.dl.Message.Payload, dl.Message.Payload))
+	}
+}
+
+func TestRetrieveAllAndRemove(t *testing.T) {
+	repo, err := jetstream.NewRepository(address, "deadletter.remove", 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer repo.Close()
+
+	for i := 0; i < 5; i++ {
+		err := repo.Save(context.Background(), newDeadLetter(fmt.Sprintf("%d", i)))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	err = repo.Remove(context.Background(), "1")
+	assert.Nil(t, err, fmt.Sprintf("remove dead letter: expected nil got %s", err))
+	err = repo.Remove(context.Background(), "1")
+	assert.True(t, errors.Contains(err, deadletter.ErrNotFound), fmt.Sprintf("remove removed dead letter: expected %s got %s", deadletter.ErrNotFound, err))
+
+	cases := []struct {
+		desc   string
+		offset uint64
+		limit  uint64
+		ids    []string
+	}{
+		{
+			desc:   "retrieve all dead letters",
+			offset: 0,
+			limit:  0,
+			ids:    []string{"0", "2", "3", "4"},
+		},
+		{
+			desc:   "retrieve dead letters with offset and limit",
+			offset: 1,
+			limit:  2,
+			ids:    []string{"2", "3"},
+		},
+		{
+			desc:   "retrieve dead letters with offset out of range",
+			offset: 4,
+			limit:  2,
+			ids:    []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.offset, tc.limit)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, uint64(4), page.Total, fmt.Sprintf("%s: expected total 4 got %d", tc.desc, page.Total))
+		ids := []string{}
+		for _, dl := range page.DeadLetters {
+			ids = append(ids, dl.ID)
+		}
+		assert.Equal(t, tc.ids, ids, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.ids, ids))
+	}
+
+	err = repo.RemoveAll(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("purge dead letters: expected nil got %s", err))
+	page, err := repo.RetrieveAll(context.Background(), 0, 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(0), page.Total, fmt.Sprintf("purge dead letters: expected no dead letters got %d", page.Total))
+}
+
+func TestSaveOverLimit(t *testing.T) {
+	limit := int64(3)
+	repo, err := jetstream.NewRepository(address, "deadletter.limit", limit)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer repo.Close()
+
+	for i := 0; i < 5; i++ {
+		err := repo.Save(context.Background(), newDeadLetter(fmt.Sprintf("%d", i)))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	page, err := repo.RetrieveAll(context.Background(), 0, 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ids := []string{}
+	for _, dl := range page.DeadLetters {
+		ids = append(ids, dl.ID)
+	}
+	assert.Equal(t, []string{"2", "3", "4"}, ids, "expected the oldest dead letters to be discarded")
+}
@@ -0,0 +1,5 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package kafka contains Kafka dead-letter publisher implementation.
+package kafka
@@ -0,0 +1,43 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/kafka"
+)
+
+var _ deadletter.Publisher = (*publisher)(nil)
+
+// Publisher wraps dead-letter Publisher exposing
+// Close() method for Kafka connection.
+type Publisher interface {
+	deadletter.Publisher
+	Close()
+}
+
+type publisher struct {
+	pub kafka.Publisher
+}
+
+// NewPublisher returns dead-letter publisher which publishes messages to
+// the Kafka topics prefixed by the topic. The prefix should differ from the
+// one the consumers read messages from, so that the dead letters are not
+// consumed again.
+func NewPublisher(url, topic string) (Publisher, error) {
+	pub, err := kafka.NewPublisher(url, kafka.Config{Topic: topic})
+	if err != nil {
+		return nil, err
+	}
+	return &publisher{pub: pub}, nil
+}
+
+func (pub *publisher) Publish(msg messaging.Message) error {
+	return pub.pub.Publish(msg.Channel, msg)
+}
+
+func (pub *publisher) Close() {
+	pub.pub.Close()
+}
@@ -0,0 +1,99 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mainflux/mainflux/consumers/deadletter"
+)
+
+var _ deadletter.Repository = (*repository)(nil)
+
+type repository struct {
+	mu          sync.Mutex
+	capacity    int
+	deadLetters []deadletter.DeadLetter
+}
+
+// NewRepository returns in-memory dead letters repository which keeps at
+// most capacity dead letters. Once it's full, the oldest dead letter is
+// dropped to make room for the new one. Zero capacity is unlimited.
+func NewRepository(capacity int) deadletter.Repository {
+	return &repository{
+		capacity: capacity,
+	}
+}
+
+func (repo *repository) Save(_ context.Context, dl deadletter.DeadLetter) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.capacity > 0 && len(repo.deadLetters) >= repo.capacity {
+		repo.deadLetters = repo.deadLetters[len(repo.deadLetters)-repo.capacity+1:]
+	}
+	repo.deadLetters = append(repo.deadLetters, dl)
+
+	return nil
+}
+
+func (repo *repository) RetrieveByID(_ context.Context, id string) (deadletter.DeadLetter, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, dl := range repo.deadLetters {
+		if dl.ID == id {
+			return dl, nil
+		}
+	}
+
+	return deadletter.DeadLetter{}, deadletter.ErrNotFound
+}
+
+func (repo *repository) RetrieveAll(_ context.Context, offset, limit uint64) (deadletter.Page, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	total := uint64(len(repo.deadLetters))
+	page := deadletter.Page{
+		Total:       total,
+		Offset:      offset,
+		Limit:       limit,
+		DeadLetters: []deadletter.DeadLetter{},
+	}
+	if offset >= total {
+		return page, nil
+	}
+
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+	page.DeadLetters = append(page.DeadLetters, repo.deadLetters[offset:end]...)
+
+	return page, nil
+}
+
+func (repo *repository) Remove(_ context.Context, id string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for i, dl := range repo.deadLetters {
+		if dl.ID == id {
+			repo.deadLetters = append(repo.deadLetters[:i], repo.deadLetters[i+1:]...)
+			return nil
+		}
+	}
+
+	return deadletter.ErrNotFound
+}
+
+func (repo *repository) RemoveAll(_ context.Context) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.deadLetters = nil
+	return nil
+}
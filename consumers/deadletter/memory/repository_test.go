@@ -0,0 +1,35 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package memory_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/consumers/deadletter/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveOverCapacity(t *testing.T) {
+	capacity := 3
+	repo := memory.NewRepository(capacity)
+
+	for i := 0; i < 5; i++ {
+		err := repo.Save(context.Background(), deadletter.DeadLetter{ID: fmt.Sprintf("%d", i)})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	page, err := repo.RetrieveAll(context.Background(), 0, 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(capacity), page.Total, fmt.Sprintf("expected %d dead letters got %d", capacity, page.Total))
+
+	ids := []string{}
+	for _, dl := range page.DeadLetters {
+		ids = append(ids, dl.ID)
+	}
+	assert.Equal(t, []string{"2", "3", "4"}, ids, "expected the oldest dead letters to be dropped")
+}
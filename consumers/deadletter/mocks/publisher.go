@@ -0,0 +1,46 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ deadletter.Publisher = (*publisherMock)(nil)
+
+// Publisher is dead-letter publisher mock which keeps published messages.
+type Publisher interface {
+	deadletter.Publisher
+
+	// Messages returns the published messages.
+	Messages() []messaging.Message
+}
+
+type publisherMock struct {
+	mu   sync.Mutex
+	msgs []messaging.Message
+}
+
+// NewPublisher returns dead-letter publisher mock.
+func NewPublisher() Publisher {
+	return &publisherMock{}
+}
+
+func (pm *publisherMock) Publish(msg messaging.Message) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.msgs = append(pm.msgs, msg)
+	return nil
+}
+
+func (pm *publisherMock) Messages() []messaging.Message {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	return append([]messaging.Message{}, pm.msgs...)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	broker "github.com/nats-io/nats.go"
+)
+
+var _ deadletter.Publisher = (*publisher)(nil)
+
+// Publisher wraps dead-letter Publisher exposing
+// Close() method for NATS connection.
+type Publisher interface {
+	deadletter.Publisher
+	Close()
+}
+
+type publisher struct {
+	conn    *broker.Conn
+	subject string
+}
+
+// NewPublisher returns dead-letter publisher which publishes messages to
+// the NATS subject. The subject should not be under the channels subject,
+// so that the dead letters are not consumed again.
+func NewPublisher(url, subject string) (Publisher, error) {
+	conn, err := broker.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+	ret := &publisher{
+		conn:    conn,
+		subject: subject,
+	}
+	return ret, nil
+}
+
+func (pub *publisher) Publish(msg messaging.Message) error {
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	return pub.conn.Publish(pub.subject, data)
+}
+
+func (pub *publisher) Close() {
+	pub.conn.Close()
+}
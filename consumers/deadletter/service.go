@@ -0,0 +1,187 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package deadletter
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var (
+	// ErrNotFound indicates a non-existent dead letter request.
+	ErrNotFound = errors.New("non-existent dead letter")
+
+	// ErrCreateID indicates error in creating id for the dead letter.
+	ErrCreateID = errors.New("failed to create id")
+
+	// ErrPublish indicates error publishing the dead letter to the
+	// dead-letter queue.
+	ErrPublish = errors.New("failed to publish dead letter")
+
+	// ErrReplay indicates that the replayed message failed to be consumed
+	// again.
+	ErrReplay = errors.New("failed to replay dead letter")
+)
+
+// Service specifies an API for managing the messages which failed to be
+// consumed.
+type Service interface {
+	// Add publishes the message with the failure reason to the dead-letter
+	// queue and keeps it for later inspection. It returns the dead letter ID.
+	Add(ctx context.Context, msg messaging.Message, reason string) (string, error)
+
+	// ListDeadLetters retrieves the subset of dead letters.
+	ListDeadLetters(ctx context.Context, offset, limit uint64) (Page, error)
+
+	// ViewDeadLetter retrieves the dead letter having the provided identifier.
+	ViewDeadLetter(ctx context.Context, id string) (DeadLetter, error)
+
+	// ReplayDeadLetter passes the message back to the consumer. The dead
+	// letter is removed once the message is consumed.
+	ReplayDeadLetter(ctx context.Context, id string) error
+
+	// ReplayDeadLetters replays all the dead letters and returns the number
+	// of messages which were consumed.
+	ReplayDeadLetters(ctx context.Context) (uint64, error)
+
+	// RemoveDeadLetter removes the dead letter having the provided identifier.
+	RemoveDeadLetter(ctx context.Context, id string) error
+
+	// PurgeDeadLetters removes all the dead letters.
+	PurgeDeadLetters(ctx context.Context) error
+}
+
+var _ Service = (*deadLetterService)(nil)
+
+type deadLetterService struct {
+	repo    Repository
+	pub     Publisher
+	handler messaging.MessageHandler
+	idp     mainflux.IDProvider
+}
+
+// New instantiates the dead-letter service implementation. Replayed
+// messages are passed to the handler.
+func New(repo Repository, pub Publisher, handler messaging.MessageHandler, idp mainflux.IDProvider) Service {
+	return &deadLetterService{
+		repo:    repo,
+		pub:     pub,
+		handler: handler,
+		idp:     idp,
+	}
+}
+
+// Handler returns the message handler which adds the messages that the
+// handler h fails to handle with one of the permanent errors to the
+// dead-letter service. Message is handled successfully once it's added, so
+// it's not redelivered. The other errors, such as the unavailable storage,
+// are returned, so that the message is redelivered.
+func Handler(svc Service, h messaging.MessageHandler, permanent ...error) messaging.MessageHandler {
+	return func(msg messaging.Message) error {
+		err := h(msg)
+		if err == nil || !isPermanent(err, permanent) {
+			return err
+		}
+		if _, dlErr := svc.Add(context.Background(), msg, err.Error()); dlErr != nil {
+			return errors.Wrap(dlErr, err)
+		}
+		return nil
+	}
+}
+
+func isPermanent(err error, permanent []error) bool {
+	for _, p := range permanent {
+		if errors.Contains(err, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (svc *deadLetterService) Add(ctx context.Context, msg messaging.Message, reason string) (string, error) {
+	id, err := svc.idp.ID()
+	if err != nil {
+		return "", errors.Wrap(ErrCreateID, err)
+	}
+
+	dl := DeadLetter{
+		ID:      id,
+		Reason:  reason,
+		Created: time.Now().UTC(),
+		Message: msg,
+	}
+	if err := svc.repo.Save(ctx, dl); err != nil {
+		return "", err
+	}
+
+	headers := map[string]string{}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[ReasonHeader] = reason
+	msg.Headers = headers
+
+	if err := svc.pub.Publish(msg); err != nil {
+		return "", errors.Wrap(ErrPublish, err)
+	}
+
+	return id, nil
+}
+
+func (svc *deadLetterService) ListDeadLetters(ctx context.Context, offset, limit uint64) (Page, error) {
+	return svc.repo.RetrieveAll(ctx, offset, limit)
+}
+
+func (svc *deadLetterService) ViewDeadLetter(ctx context.Context, id string) (DeadLetter, error) {
+	return svc.repo.RetrieveByID(ctx, id)
+}
+
+func (svc *deadLetterService) ReplayDeadLetter(ctx context.Context, id string) error {
+	dl, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return svc.replay(ctx, dl)
+}
+
+func (svc *deadLetterService) ReplayDeadLetters(ctx context.Context) (uint64, error) {
+	page, err := svc.repo.RetrieveAll(ctx, 0, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	var replayed uint64
+	for _, dl := range page.DeadLetters {
+		if err := svc.replay(ctx, dl); err != nil {
+			if errors.Contains(err, ErrReplay) {
+				continue
+			}
+			return replayed, err
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+func (svc *deadLetterService) RemoveDeadLetter(ctx context.Context, id string) error {
+	return svc.repo.Remove(ctx, id)
+}
+
+func (svc *deadLetterService) PurgeDeadLetters(ctx context.Context) error {
+	return svc.repo.RemoveAll(ctx)
+}
+
+func (svc *deadLetterService) replay(ctx context.Context, dl DeadLetter) error {
+	if err := svc.handler(dl.Message); err != nil {
+		return errors.Wrap(ErrReplay, err)
+	}
+
+	return svc.repo.Remove(ctx, dl.ID)
+}
@@ -0,0 +1,303 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package deadletter_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/consumers/deadletter/memory"
+	"github.com/mainflux/mainflux/consumers/deadletter/mocks"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID      = "50e6b371-60ff-45cf-bb52-8200e7cde536"
+	invalid     = "invalid"
+	unavailable = "unavailable"
+)
+
+var (
+	errConsume     = errors.New("failed to consume message")
+	errUnavailable = errors.New("storage unavailable")
+	msg            = messaging.Message{
+		Channel:  chanID,
+		Protocol: "http",
+		Payload:  []byte(`[{"n":"temperature","v":22}]`),
+		Headers:  map[string]string{"unit": "celsius"},
+	}
+)
+
+// consumer fails to handle the messages with invalid payload permanently,
+// and the messages with unavailable payload temporarily. It keeps the
+// handled messages.
+type consumer struct {
+	msgs []messaging.Message
+}
+
+func (c *consumer) handle(msg messaging.Message) error {
+	switch string(msg.Payload) {
+	case invalid:
+		return errConsume
+	case unavailable:
+		return errUnavailable
+	}
+	c.msgs = append(c.msgs, msg)
+	return nil
+}
+
+func newService(c *consumer, pub deadletter.Publisher) deadletter.Service {
+	return deadletter.New(memory.NewRepository(0), pub, c.handle, uuid.NewMock())
+}
+
+func TestHandler(t *testing.T) {
+	c := &consumer{}
+	pub := mocks.NewPublisher()
+	svc := newService(c, pub)
+	h := deadletter.Handler(svc, c.handle, errConsume)
+
+	invalidMsg := msg
+	invalidMsg.Payload = []byte(invalid)
+	unavailableMsg := msg
+	unavailableMsg.Payload = []byte(unavailable)
+
+	cases := []struct {
+		desc        string
+		msg         messaging.Message
+		err         error
+		deadLetters uint64
+	}{
+		{
+			desc:        "handle valid message",
+			msg:         msg,
+			err:         nil,
+			deadLetters: 0,
+		},
+		{
+			desc:        "handle invalid message",
+			msg:         invalidMsg,
+			err:         nil,
+			deadLetters: 1,
+		},
+		{
+			desc:        "handle message failing temporarily",
+			msg:         unavailableMsg,
+			err:         errUnavailable,
+			deadLetters: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		err := h(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		page, err := svc.ListDeadLetters(context.Background(), 0, 10)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.deadLetters, page.Total, fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.deadLetters, page.Total))
+	}
+
+	published := pub.Messages()
+	require.Len(t, published, 1, fmt.Sprintf("expected one published dead letter got %d", len(published)))
+	assert.Equal(t, errConsume.Error(), published[0].Headers[deadletter.ReasonHeader], "expected failure reason in the published dead letter headers")
+	assert.Equal(t, "celsius", published[0].Headers["unit"], "expected original headers in the published dead letter")
+	_, ok := msg.Headers[deadletter.ReasonHeader]
+	assert.False(t, ok, "expected original message headers to remain unchanged")
+}
+
+func TestViewDeadLetter(t *testing.T) {
+	svc := newService(&consumer{}, mocks.NewPublisher())
+	id, err := svc.Add(context.Background(), msg, errConsume.Error())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "view existing dead letter",
+			id:   id,
+			err:  nil,
+		},
+		{
+			desc: "view non-existing dead letter",
+			id:   "non-existing",
+			err:  deadletter.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		dl, err := svc.ViewDeadLetter(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, errConsume.Error(), dl.Reason, fmt.Sprintf("%s: expected reason %s got %s", tc.desc, errConsume, dl.Reason))
+		assert.Equal(t, msg, dl.Message, fmt.Sprintf("%s: expected %v got %v", tc.desc, msg, dl.Message))
+	}
+}
+
+func TestListDeadLetters(t *testing.T) {
+	svc := newService(&consumer{}, mocks.NewPublisher())
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		_, err := svc.Add(context.Background(), msg, errConsume.Error())
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		offset uint64
+		limit  uint64
+		size   int
+	}{
+		{
+			desc:   "list all dead letters",
+			offset: 0,
+			limit:  n,
+			size:   int(n),
+		},
+		{
+			desc:   "list half of dead letters",
+			offset: n / 2,
+			limit:  n,
+			size:   int(n / 2),
+		},
+		{
+			desc:   "list last dead letter",
+			offset: n - 1,
+			limit:  1,
+			size:   1,
+		},
+		{
+			desc:   "list dead letters with offset out of range",
+			offset: n,
+			limit:  n,
+			size:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListDeadLetters(context.Background(), tc.offset, tc.limit)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s", tc.desc, err))
+		assert.Equal(t, n, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, n, page.Total))
+		assert.Len(t, page.DeadLetters, tc.size, fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.size, len(page.DeadLetters)))
+	}
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	c := &consumer{}
+	svc := newService(c, mocks.NewPublisher())
+
+	id, err := svc.Add(context.Background(), msg, errConsume.Error())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	invalidMsg := msg
+	invalidMsg.Payload = []byte(invalid)
+	invalidID, err := svc.Add(context.Background(), invalidMsg, errConsume.Error())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		id       string
+		err      error
+		consumed int
+	}{
+		{
+			desc:     "replay dead letter",
+			id:       id,
+			err:      nil,
+			consumed: 1,
+		},
+		{
+			desc:     "replay already replayed dead letter",
+			id:       id,
+			err:      deadletter.ErrNotFound,
+			consumed: 1,
+		},
+		{
+			desc:     "replay dead letter failing again",
+			id:       invalidID,
+			err:      deadletter.ErrReplay,
+			consumed: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ReplayDeadLetter(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Len(t, c.msgs, tc.consumed, fmt.Sprintf("%s: expected %d consumed messages got %d", tc.desc, tc.consumed, len(c.msgs)))
+	}
+
+	_, err = svc.ViewDeadLetter(context.Background(), invalidID)
+	assert.Nil(t, err, fmt.Sprintf("expected failed replay to keep the dead letter got %s", err))
+}
+
+func TestReplayDeadLetters(t *testing.T) {
+	c := &consumer{}
+	svc := newService(c, mocks.NewPublisher())
+
+	invalidMsg := msg
+	invalidMsg.Payload = []byte(invalid)
+	for _, m := range []messaging.Message{msg, invalidMsg, msg} {
+		_, err := svc.Add(context.Background(), m, errConsume.Error())
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	replayed, err := svc.ReplayDeadLetters(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("expected nil got %s", err))
+	assert.Equal(t, uint64(2), replayed, fmt.Sprintf("expected 2 replayed messages got %d", replayed))
+
+	page, err := svc.ListDeadLetters(context.Background(), 0, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("expected one remaining dead letter got %d", page.Total))
+}
+
+func TestRemoveDeadLetter(t *testing.T) {
+	svc := newService(&consumer{}, mocks.NewPublisher())
+	id, err := svc.Add(context.Background(), msg, errConsume.Error())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove existing dead letter",
+			id:   id,
+			err:  nil,
+		},
+		{
+			desc: "remove removed dead letter",
+			id:   id,
+			err:  deadletter.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveDeadLetter(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestPurgeDeadLetters(t *testing.T) {
+	svc := newService(&consumer{}, mocks.NewPublisher())
+	for i := 0; i < 5; i++ {
+		_, err := svc.Add(context.Background(), msg, errConsume.Error())
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	err := svc.PurgeDeadLetters(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("expected nil got %s", err))
+
+	page, err := svc.ListDeadLetters(context.Background(), 0, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(0), page.Total, fmt.Sprintf("expected no dead letters got %d", page.Total))
+}
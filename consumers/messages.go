@@ -17,41 +17,80 @@ import (
 )
 
 var (
+	// ErrTransform indicates that the message failed to be transformed.
+	// Such messages are malformed, so handling them again fails as well.
+	ErrTransform = errors.New("failed to transform message")
+
 	errOpenConfFile  = errors.New("unable to open configuration file")
 	errParseConfFile = errors.New("unable to parse configuration file")
 )
 
 // Start method starts consuming messages received from NATS.
 // This method transforms messages to SenML format before
-// using MessageRepository to store them.
+// using MessageRepository to store them. Messages are handled
+// successfully only once they are consumed, so subscribers which
+// guarantee delivery redeliver the messages that failed to be
+// transformed or consumed.
 func Start(sub messaging.Subscriber, consumer Consumer, transformer transformers.Transformer, subjectsCfgPath string, logger logger.Logger) error {
+	return Subscribe(sub, Handler(transformer, consumer), subjectsCfgPath, logger)
+}
+
+// Subscribe subscribes the handler to the subjects listed in the subjects
+// configuration file.
+func Subscribe(sub messaging.Subscriber, handler messaging.MessageHandler, subjectsCfgPath string, logger logger.Logger) error {
 	subjects, err := loadSubjectsConfig(subjectsCfgPath)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Failed to load subjects: %s", err))
 	}
 
 	for _, subject := range subjects {
-		if err := sub.Subscribe(subject, handler(transformer, consumer)); err != nil {
+		if err := sub.Subscribe(subject, handler); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func handler(t transformers.Transformer, c Consumer) messaging.MessageHandler {
+// Handler returns the message handler which transforms the message,
+// if the transformer is provided, and passes it to the consumer. The
+// transformation errors are wrapped with ErrTransform.
+func Handler(t transformers.Transformer, c Consumer) messaging.MessageHandler {
 	return func(msg messaging.Message) error {
 		m := interface{}(msg)
 		var err error
 		if t != nil {
 			m, err = t.Transform(msg)
 			if err != nil {
-				return err
+				return errors.Wrap(ErrTransform, err)
 			}
 		}
 		return c.Consume(m)
 	}
 }
 
+// AsyncHandler returns the message handler which passes the messages to the
+// handler in the background, handling up to n of them concurrently. Since it
+// returns before the message is handled, it's only meant to be used with the
+// subscribers which don't redeliver the messages, such as core NATS. The
+// handler errors are logged.
+func AsyncHandler(h messaging.MessageHandler, n int, logger logger.Logger) messaging.MessageHandler {
+	if n < 1 {
+		n = 1
+	}
+	sem := make(chan struct{}, n)
+
+	return func(msg messaging.Message) error {
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem }()
+			if err := h(msg); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
+			}
+		}()
+		return nil
+	}
+}
+
 type filterConfig struct {
 	Filter []string `toml:"filter"`
 }
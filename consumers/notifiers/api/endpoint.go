@@ -16,9 +16,21 @@ func createSubscriptionEndpoint(svc notifiers.Service) endpoint.Endpoint {
 		if err := req.validate(); err != nil {
 			return createSubRes{}, err
 		}
+		interval, _ := req.minInterval()
 		sub := notifiers.Subscription{
-			Contact: req.Contact,
-			Topic:   req.Topic,
+			Contact:     req.Contact,
+			Topic:       req.Topic,
+			Hysteresis:  req.Hysteresis,
+			MinInterval: interval,
+			Template:    req.Template,
+			Secret:      req.Secret,
+		}
+		for _, c := range req.Conditions {
+			sub.Conditions = append(sub.Conditions, notifiers.Condition{
+				Name:       c.Name,
+				Comparator: c.Comparator,
+				Value:      *c.Value,
+			})
 		}
 		id, err := svc.CreateSubscription(ctx, req.token, sub)
 		if err != nil {
@@ -42,13 +54,7 @@ func viewSubscriptionEndpint(svc notifiers.Service) endpoint.Endpoint {
 		if err != nil {
 			return viewSubRes{}, err
 		}
-		res := viewSubRes{
-			ID:      sub.ID,
-			OwnerID: sub.OwnerID,
-			Contact: sub.Contact,
-			Topic:   sub.Topic,
-		}
-		return res, nil
+		return toViewSubRes(sub), nil
 	}
 }
 
@@ -74,13 +80,7 @@ func listSubscriptionsEndpoint(svc notifiers.Service) endpoint.Endpoint {
 			Total:  page.Total,
 		}
 		for _, sub := range page.Subscriptions {
-			r := viewSubRes{
-				ID:      sub.ID,
-				OwnerID: sub.OwnerID,
-				Contact: sub.Contact,
-				Topic:   sub.Topic,
-			}
-			res.Subscriptions = append(res.Subscriptions, r)
+			res.Subscriptions = append(res.Subscriptions, toViewSubRes(sub))
 		}
 		return res, nil
 	}
@@ -98,3 +98,57 @@ func deleteSubscriptionEndpint(svc notifiers.Service) endpoint.Endpoint {
 		return removeSubRes{}, nil
 	}
 }
+
+func listNotificationsEndpoint(svc notifiers.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listNotifsReq)
+		if err := req.validate(); err != nil {
+			return listNotifsRes{}, err
+		}
+		page, err := svc.ListNotifications(ctx, req.token, req.id, req.offset, req.limit)
+		if err != nil {
+			return listNotifsRes{}, err
+		}
+		res := listNotifsRes{
+			Offset:        page.Offset,
+			Limit:         page.Limit,
+			Total:         page.Total,
+			Notifications: []notifRes{},
+		}
+		for _, n := range page.Notifications {
+			res.Notifications = append(res.Notifications, notifRes{
+				ID:             n.ID,
+				SubscriptionID: n.SubscriptionID,
+				Contact:        n.Contact,
+				Channel:        n.Channel,
+				Subtopic:       n.Subtopic,
+				Status:         n.Status,
+				Error:          n.Error,
+				Created:        n.Created,
+			})
+		}
+		return res, nil
+	}
+}
+
+func toViewSubRes(sub notifiers.Subscription) viewSubRes {
+	res := viewSubRes{
+		ID:         sub.ID,
+		OwnerID:    sub.OwnerID,
+		Contact:    sub.Contact,
+		Topic:      sub.Topic,
+		Hysteresis: sub.Hysteresis,
+		Template:   sub.Template,
+	}
+	for _, c := range sub.Conditions {
+		res.Conditions = append(res.Conditions, conditionRes{
+			Name:       c.Name,
+			Comparator: c.Comparator,
+			Value:      c.Value,
+		})
+	}
+	if sub.MinInterval > 0 {
+		res.MinInterval = sub.MinInterval.String()
+	}
+	return res
+}
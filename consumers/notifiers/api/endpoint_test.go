@@ -18,6 +18,7 @@ import (
 	httpapi "github.com/mainflux/mainflux/consumers/notifiers/api"
 	"github.com/mainflux/mainflux/consumers/notifiers/mocks"
 	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
@@ -65,10 +66,11 @@ func (tr testRequest) make() (*http.Response, error) {
 
 func newService(tokens map[string]string) notifiers.Service {
 	auth := mocks.NewAuth(tokens)
+	things := mocks.NewThingsService(map[string]string{topic: email})
 	repo := mocks.NewRepo(make(map[string]notifiers.Subscription))
 	idp := uuid.NewMock()
 	notif := mocks.NewNotifier()
-	return notifiers.New(auth, repo, idp, notif)
+	return notifiers.New(auth, things, repo, mocks.NewNotificationsRepo(), idp, notif)
 }
 
 func newServer(svc notifiers.Service) *httptest.Server {
@@ -95,6 +97,16 @@ func TestCreate(t *testing.T) {
 
 	emptyTopic := toJSON(notifiers.Subscription{Contact: contact1})
 	emptyContact := toJSON(notifiers.Subscription{Topic: "topic123"})
+	withConds := fmt.Sprintf(`{"topic":"%s","contact":"%s","conditions":[{"name":"temperature","comparator":"gt","value":30}],"hysteresis":2,"min_interval":"5m"}`, topic, contact2)
+	invalidName := fmt.Sprintf(`{"topic":"%s","contact":"%s","conditions":[{"comparator":"gt","value":30}]}`, topic, contact2)
+	invalidComparator := fmt.Sprintf(`{"topic":"%s","contact":"%s","conditions":[{"name":"temperature","comparator":"ne","value":30}]}`, topic, contact2)
+	emptyValue := fmt.Sprintf(`{"topic":"%s","contact":"%s","conditions":[{"name":"temperature","comparator":"gt"}]}`, topic, contact2)
+	negativeHysteresis := fmt.Sprintf(`{"topic":"%s","contact":"%s","hysteresis":-1}`, topic, contact2)
+	invalidInterval := fmt.Sprintf(`{"topic":"%s","contact":"%s","min_interval":"5 minutes"}`, topic, contact2)
+	withTemplate := fmt.Sprintf(`{"topic":"%s","contact":"%s","template":"{{.Channel}}: {{.Payload}}"}`, topic, "email3@example.com")
+	invalidTemplate := fmt.Sprintf(`{"topic":"%s","contact":"%s","template":"{{.Channel"}`, topic, "email3@example.com")
+	notOwned := toJSON(notifiers.Subscription{Topic: "other.subtopic", Contact: contact1})
+	negativeInterval := fmt.Sprintf(`{"topic":"%s","contact":"%s","min_interval":"-5m"}`, topic, contact2)
 
 	cases := []struct {
 		desc        string
@@ -136,6 +148,86 @@ func TestCreate(t *testing.T) {
 			status:      http.StatusBadRequest,
 			location:    "",
 		},
+		{
+			desc:        "add with condition without name",
+			req:         invalidName,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with invalid condition comparator",
+			req:         invalidComparator,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with condition without value",
+			req:         emptyValue,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with negative hysteresis",
+			req:         negativeHysteresis,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with invalid minimum interval",
+			req:         invalidInterval,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with negative minimum interval",
+			req:         negativeInterval,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with conditions",
+			req:         withConds,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/subscriptions/%s%012d", uuid.Prefix, 3),
+		},
+		{
+			desc:        "add with invalid template",
+			req:         invalidTemplate,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with template",
+			req:         withTemplate,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/subscriptions/%s%012d", uuid.Prefix, 4),
+		},
+		{
+			desc:        "add to channel owned by another user",
+			req:         notOwned,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusForbidden,
+			location:    "",
+		},
 		{
 			desc:        "add with invalid auth token",
 			req:         data,
@@ -447,6 +539,102 @@ func TestRemove(t *testing.T) {
 	}
 }
 
+func TestListNotifications(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ss := newServer(svc)
+	defer ss.Close()
+
+	sub := notifiers.Subscription{
+		Topic:   topic,
+		Contact: contact1,
+	}
+	id, err := svc.CreateSubscription(context.Background(), token, sub)
+	require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
+
+	msg := messaging.Message{Channel: topic}
+	for i := 0; i < 5; i++ {
+		err := svc.Consume(msg)
+		require.Nil(t, err, fmt.Sprintf("got an error consuming message: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		query  map[string]string
+		status int
+		total  uint
+		size   int
+	}{
+		{
+			desc:   "list notifications successfully",
+			id:     id,
+			auth:   token,
+			status: http.StatusOK,
+			total:  5,
+			size:   5,
+		},
+		{
+			desc: "list notifications with offset and limit",
+			id:   id,
+			auth: token,
+			query: map[string]string{
+				"offset": "1",
+				"limit":  "2",
+			},
+			status: http.StatusOK,
+			total:  5,
+			size:   2,
+		},
+		{
+			desc: "list notifications with invalid limit",
+			id:   id,
+			auth: token,
+			query: map[string]string{
+				"limit": "101",
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list notifications of non-existing subscription",
+			id:     "not existing",
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "list notifications with invalid auth token",
+			id:     id,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ss.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/subscriptions/%s/notifications%s", ss.URL, tc.id, makeQuery(tc.query)),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var np notifsPage
+		err = json.NewDecoder(res.Body).Decode(&np)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.total, np.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, np.Total))
+		assert.Len(t, np.Notifications, tc.size, fmt.Sprintf("%s: expected %d notifications got %d", tc.desc, tc.size, len(np.Notifications)))
+		for _, n := range np.Notifications {
+			assert.Equal(t, id, n.SubscriptionID, fmt.Sprintf("%s: expected subscription %s got %s", tc.desc, id, n.SubscriptionID))
+			assert.Equal(t, notifiers.StatusSent, n.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, notifiers.StatusSent, n.Status))
+		}
+	}
+}
+
 func makeQuery(m map[string]string) string {
 	var ret string
 	for k, v := range m {
@@ -474,3 +662,18 @@ type page struct {
 	Total         uint     `json:"total,omitempty"`
 	Subscriptions []subRes `json:"subscriptions,omitempty"`
 }
+
+type notifRes struct {
+	ID             string `json:"id"`
+	SubscriptionID string `json:"subscription_id"`
+	Contact        string `json:"contact"`
+	Status         string `json:"status"`
+	Error          string `json:"error,omitempty"`
+}
+
+type notifsPage struct {
+	Offset        uint       `json:"offset"`
+	Limit         uint       `json:"limit"`
+	Total         uint       `json:"total"`
+	Notifications []notifRes `json:"notifications"`
+}
@@ -76,6 +76,19 @@ func (lm *loggingMiddleware) RemoveSubscription(ctx context.Context, token, id s
 	return lm.svc.RemoveSubscription(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) ListNotifications(ctx context.Context, token, subID string, offset, limit uint) (page notifiers.NotificationsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_notifications for subscription %s took %s to complete", subID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListNotifications(ctx, token, subID, offset, limit)
+}
+
 func (lm *loggingMiddleware) Consume(msg interface{}) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method consume took %s to complete", time.Since(begin))
@@ -64,6 +64,15 @@ func (ms *metricsMiddleware) RemoveSubscription(ctx context.Context, token, id s
 	return ms.svc.RemoveSubscription(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) ListNotifications(ctx context.Context, token, subID string, offset, limit uint) (notifiers.NotificationsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_notifications").Add(1)
+		ms.latency.With("method", "list_notifications").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListNotifications(ctx, token, subID, offset, limit)
+}
+
 func (ms *metricsMiddleware) Consume(msg interface{}) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "consume").Add(1)
@@ -4,6 +4,8 @@
 package api
 
 import (
+	"time"
+
 	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
 	"github.com/mainflux/mainflux/pkg/errors"
 )
@@ -12,12 +14,26 @@ var (
 	errInvalidTopic   = errors.New("invalid Subscription topic")
 	errInvalidContact = errors.New("invalid Subscription contact")
 	errNotFound       = errors.New("invalid or empty Subscription id")
+	errInvalidCond    = errors.New("invalid Subscription condition")
 )
 
+const maxLimitSize = 100
+
+type conditionReq struct {
+	Name       string   `json:"name"`
+	Comparator string   `json:"comparator,omitempty"`
+	Value      *float64 `json:"value"`
+}
+
 type createSubReq struct {
-	token   string
-	Topic   string `json:"topic,omitempty"`
-	Contact string `json:"contact,omitempty"`
+	token       string
+	Topic       string         `json:"topic,omitempty"`
+	Contact     string         `json:"contact,omitempty"`
+	Conditions  []conditionReq `json:"conditions,omitempty"`
+	Hysteresis  float64        `json:"hysteresis,omitempty"`
+	MinInterval string         `json:"min_interval,omitempty"`
+	Template    string         `json:"template,omitempty"`
+	Secret      string         `json:"secret,omitempty"`
 }
 
 func (req createSubReq) validate() error {
@@ -30,9 +46,39 @@ func (req createSubReq) validate() error {
 	if req.Contact == "" {
 		return errInvalidContact
 	}
+	for _, c := range req.Conditions {
+		if c.Name == "" || c.Value == nil || !notifiers.ValidComparator(c.Comparator) {
+			return errInvalidCond
+		}
+	}
+	if req.Hysteresis < 0 {
+		return errInvalidCond
+	}
+	if _, err := req.minInterval(); err != nil {
+		return errInvalidCond
+	}
+	if req.Template != "" {
+		if _, err := notifiers.ParseTemplate(req.Template); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func (req createSubReq) minInterval() (time.Duration, error) {
+	if req.MinInterval == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(req.MinInterval)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, errInvalidCond
+	}
+	return d, nil
+}
+
 type subReq struct {
 	token string
 	id    string
@@ -62,3 +108,23 @@ func (req listSubsReq) validate() error {
 	}
 	return nil
 }
+
+type listNotifsReq struct {
+	token  string
+	id     string
+	offset uint
+	limit  uint
+}
+
+func (req listNotifsReq) validate() error {
+	if req.token == "" {
+		return notifiers.ErrUnauthorizedAccess
+	}
+	if req.id == "" {
+		return errNotFound
+	}
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return errors.ErrInvalidQueryParams
+	}
+	return nil
+}
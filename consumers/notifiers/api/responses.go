@@ -6,6 +6,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/mainflux/mainflux"
 )
@@ -15,6 +16,7 @@ var (
 	_ mainflux.Response = (*viewSubRes)(nil)
 	_ mainflux.Response = (*listSubsRes)(nil)
 	_ mainflux.Response = (*removeSubRes)(nil)
+	_ mainflux.Response = (*listNotifsRes)(nil)
 )
 
 type createSubRes struct {
@@ -35,11 +37,21 @@ func (res createSubRes) Empty() bool {
 	return true
 }
 
+type conditionRes struct {
+	Name       string  `json:"name"`
+	Comparator string  `json:"comparator,omitempty"`
+	Value      float64 `json:"value"`
+}
+
 type viewSubRes struct {
-	ID      string `json:"id"`
-	OwnerID string `json:"owner_id"`
-	Contact string `json:"contact"`
-	Topic   string `json:"topic"`
+	ID          string         `json:"id"`
+	OwnerID     string         `json:"owner_id"`
+	Contact     string         `json:"contact"`
+	Topic       string         `json:"topic"`
+	Conditions  []conditionRes `json:"conditions,omitempty"`
+	Hysteresis  float64        `json:"hysteresis,omitempty"`
+	MinInterval string         `json:"min_interval,omitempty"`
+	Template    string         `json:"template,omitempty"`
 }
 
 func (res viewSubRes) Code() int {
@@ -91,3 +103,33 @@ func (res removeSubRes) Empty() bool {
 type errorRes struct {
 	Err string `json:"error"`
 }
+
+type notifRes struct {
+	ID             string    `json:"id"`
+	SubscriptionID string    `json:"subscription_id"`
+	Contact        string    `json:"contact"`
+	Channel        string    `json:"channel"`
+	Subtopic       string    `json:"subtopic,omitempty"`
+	Status         string    `json:"status"`
+	Error          string    `json:"error,omitempty"`
+	Created        time.Time `json:"created"`
+}
+
+type listNotifsRes struct {
+	Offset        uint       `json:"offset"`
+	Limit         uint       `json:"limit"`
+	Total         uint       `json:"total"`
+	Notifications []notifRes `json:"notifications"`
+}
+
+func (res listNotifsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listNotifsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listNotifsRes) Empty() bool {
+	return false
+}
@@ -52,6 +52,13 @@ func MakeHandler(svc notifiers.Service, tracer opentracing.Tracer) http.Handler
 		opts...,
 	))
 
+	mux.Get("/subscriptions/:id/notifications", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_notifications")(listNotificationsEndpoint(svc)),
+		decodeListNotifications,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Delete("/subscriptions/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "delete_subscription")(deleteSubscriptionEndpint(svc)),
 		decodeSubscription,
@@ -116,6 +123,27 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeListNotifications(_ context.Context, r *http.Request) (interface{}, error) {
+	req := listNotifsReq{
+		id:    bone.GetValue(r, "id"),
+		token: r.Header.Get("Authorization"),
+	}
+
+	offset, err := httputil.ReadUintQuery(r, "offset", 0)
+	if err != nil {
+		return listNotifsReq{}, err
+	}
+	req.offset = uint(offset)
+
+	limit, err := httputil.ReadUintQuery(r, "limit", 10)
+	if err != nil {
+		return listNotifsReq{}, err
+	}
+	req.limit = uint(limit)
+
+	return req, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	if ar, ok := response.(mainflux.Response); ok {
 		for k, v := range ar.Headers() {
@@ -140,6 +168,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		case errors.Contains(errorVal, errors.ErrMalformedEntity),
 			errors.Contains(errorVal, errInvalidContact),
 			errors.Contains(errorVal, errInvalidTopic),
+			errors.Contains(errorVal, errInvalidCond),
+			errors.Contains(errorVal, notifiers.ErrTemplate),
 			errors.Contains(errorVal, errors.ErrInvalidQueryParams):
 			w.WriteHeader(http.StatusBadRequest)
 		case errors.Contains(errorVal, notifiers.ErrNotFound),
@@ -147,6 +177,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 			w.WriteHeader(http.StatusNotFound)
 		case errors.Contains(errorVal, notifiers.ErrUnauthorizedAccess):
 			w.WriteHeader(http.StatusUnauthorized)
+		case errors.Contains(errorVal, notifiers.ErrAuthorization):
+			w.WriteHeader(http.StatusForbidden)
 		case errors.Contains(errorVal, notifiers.ErrConflict):
 			w.WriteHeader(http.StatusConflict)
 		case errors.Contains(errorVal, errors.ErrUnsupportedContentType):
@@ -0,0 +1,209 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+)
+
+// Condition represents the comparison of the values of the SenML records
+// with the given name. Comparators are the ones of the readers value
+// filter, and the empty comparator stands for equality.
+type Condition struct {
+	Name       string
+	Comparator string
+	Value      float64
+}
+
+// ValidComparator reports whether the comparator is supported.
+func ValidComparator(comparator string) bool {
+	switch comparator {
+	case "",
+		readers.EqualKey,
+		readers.LowerThanKey,
+		readers.LowerThanEqualKey,
+		readers.GreaterThanKey,
+		readers.GreaterThanEqualKey:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c Condition) met(v float64) bool {
+	switch c.Comparator {
+	case readers.LowerThanKey:
+		return v < c.Value
+	case readers.LowerThanEqualKey:
+		return v <= c.Value
+	case readers.GreaterThanKey:
+		return v > c.Value
+	case readers.GreaterThanEqualKey:
+		return v >= c.Value
+	default:
+		return v == c.Value
+	}
+}
+
+// cleared reports whether the value moved past the condition value by the
+// hysteresis, to the side where the condition isn't met.
+func (c Condition) cleared(v, hysteresis float64) bool {
+	switch c.Comparator {
+	case readers.LowerThanKey:
+		return v >= c.Value+hysteresis
+	case readers.LowerThanEqualKey:
+		return v > c.Value+hysteresis
+	case readers.GreaterThanKey:
+		return v <= c.Value-hysteresis
+	case readers.GreaterThanEqualKey:
+		return v < c.Value-hysteresis
+	default:
+		return math.Abs(v-c.Value) > hysteresis
+	}
+}
+
+// values maps the names of the SenML records to their values.
+type values map[string][]float64
+
+// decode returns the SenML records of the message, decoded using the format
+// of its content type. Messages that aren't SenML have no records.
+func decode(msg messaging.Message) []senml.Message {
+	format := senml.JSON
+	if transformers.MediaType(msg.ContentType) == senml.CBOR {
+		format = senml.CBOR
+	}
+
+	res, err := senml.New(format).Transform(msg)
+	if err != nil {
+		return []senml.Message{}
+	}
+	return res.([]senml.Message)
+}
+
+// valuesOf returns the values of the records.
+func valuesOf(recs []senml.Message) values {
+	vals := values{}
+	for _, r := range recs {
+		if r.Value != nil {
+			vals[r.Name] = append(vals[r.Name], *r.Value)
+		}
+	}
+	return vals
+}
+
+// trigger tracks whether the conditions of the subscriptions are met, and
+// when the subscriptions were last notified.
+type trigger struct {
+	mu     sync.Mutex
+	states map[string]*triggerState
+}
+
+type triggerState struct {
+	active   bool
+	notified time.Time
+	// skipped is set once the notification within the minimum interval
+	// is skipped, so that it's recorded once per interval.
+	skipped bool
+}
+
+func newTrigger() *trigger {
+	return &trigger{states: map[string]*triggerState{}}
+}
+
+// fire reports whether the subscription is notified of the message with the
+// given values, created at the given time. The subscription with conditions
+// is notified once they're met, and isn't notified again until the values
+// clear any of the conditions by the hysteresis. Notifications within the
+// minimum interval since the previous one are skipped, and the conditions
+// met within it are evaluated again on the following messages, so that they
+// are notified once the interval passes. It also reports whether the skipped
+// notification is the first one skipped within the interval. The state is
+// committed by sent once the notification is sent, so the notifications that
+// fail to be sent are fired again by the following messages.
+func (t *trigger) fire(sub Subscription, vals values, now time.Time) (bool, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	st, ok := t.states[sub.ID]
+	if !ok {
+		st = &triggerState{}
+		t.states[sub.ID] = st
+	}
+
+	if len(sub.Conditions) > 0 {
+		if st.active {
+			if clearedAny(sub.Conditions, sub.Hysteresis, vals) {
+				st.active = false
+			}
+			return false, false
+		}
+		if !metAll(sub.Conditions, vals) {
+			return false, false
+		}
+	}
+
+	if sub.MinInterval > 0 && !st.notified.IsZero() && now.Sub(st.notified) < sub.MinInterval {
+		first := !st.skipped
+		st.skipped = true
+		return false, first
+	}
+
+	return true, false
+}
+
+// sent records that the subscription is notified of the message created at
+// the given time.
+func (t *trigger) sent(sub Subscription, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	st, ok := t.states[sub.ID]
+	if !ok {
+		st = &triggerState{}
+		t.states[sub.ID] = st
+	}
+	st.active = len(sub.Conditions) > 0
+	st.notified = now
+	st.skipped = false
+}
+
+func (t *trigger) remove(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.states, id)
+}
+
+func metAll(conds []Condition, vals values) bool {
+	for _, c := range conds {
+		met := false
+		for _, v := range vals[c.Name] {
+			if c.met(v) {
+				met = true
+				break
+			}
+		}
+		if !met {
+			return false
+		}
+	}
+	return true
+}
+
+func clearedAny(conds []Condition, hysteresis float64, vals values) bool {
+	for _, c := range conds {
+		for _, v := range vals[c.Name] {
+			if c.cleared(v, hysteresis) {
+				return true
+			}
+		}
+	}
+	return false
+}
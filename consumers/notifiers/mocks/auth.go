@@ -15,12 +15,18 @@ import (
 var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
 
 type authServiceMock struct {
-	users map[string]string
+	users  map[string]string
+	admins map[string]bool
 }
 
-// NewAuth creates mock of auth service.
-func NewAuth(users map[string]string) mainflux.AuthServiceClient {
-	return &authServiceMock{users}
+// NewAuth creates mock of auth service. Admins are the IDs of the users
+// that are members of the authorities.
+func NewAuth(users map[string]string, admins ...string) mainflux.AuthServiceClient {
+	svc := &authServiceMock{users: users, admins: make(map[string]bool)}
+	for _, id := range admins {
+		svc.admins[id] = true
+	}
+	return svc
 }
 
 func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
@@ -41,7 +47,8 @@ func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opt
 }
 
 func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
-	panic("not implemented")
+	authorized := req.GetObj() == "authorities" && req.GetAct() == "member" && svc.admins[req.GetSub()]
+	return &mainflux.AuthorizeRes{Authorized: authorized}, nil
 }
 
 func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
@@ -51,3 +58,15 @@ func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq
 func (svc authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc authServiceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) ListObjects(ctx context.Context, req *mainflux.ListObjectsReq, _ ...grpc.CallOption) (r *mainflux.ListObjectsRes, err error) {
+	panic("not implemented")
+}
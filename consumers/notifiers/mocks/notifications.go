@@ -0,0 +1,70 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+)
+
+var _ notifiers.NotificationsRepository = (*notifRepoMock)(nil)
+
+type notifRepoMock struct {
+	mu     sync.Mutex
+	notifs []notifiers.Notification
+}
+
+// NewNotificationsRepo returns a new Notifications repository mock.
+func NewNotificationsRepo() notifiers.NotificationsRepository {
+	return &notifRepoMock{}
+}
+
+func (nrm *notifRepoMock) Save(_ context.Context, n notifiers.Notification) error {
+	nrm.mu.Lock()
+	defer nrm.mu.Unlock()
+	nrm.notifs = append(nrm.notifs, n)
+	return nil
+}
+
+func (nrm *notifRepoMock) RetrieveAll(_ context.Context, subID string, offset, limit uint) (notifiers.NotificationsPage, error) {
+	nrm.mu.Lock()
+	defer nrm.mu.Unlock()
+
+	page := notifiers.NotificationsPage{
+		Offset:        offset,
+		Limit:         limit,
+		Notifications: []notifiers.Notification{},
+	}
+	// Notifications are saved in order, so the latest are the last ones.
+	for i := len(nrm.notifs) - 1; i >= 0; i-- {
+		n := nrm.notifs[i]
+		if n.SubscriptionID != subID {
+			continue
+		}
+		if page.Total >= offset && uint(len(page.Notifications)) < limit {
+			page.Notifications = append(page.Notifications, n)
+		}
+		page.Total++
+	}
+
+	return page, nil
+}
+
+func (nrm *notifRepoMock) RemoveBefore(_ context.Context, before time.Time) error {
+	nrm.mu.Lock()
+	defer nrm.mu.Unlock()
+
+	var notifs []notifiers.Notification
+	for _, n := range nrm.notifs {
+		if !n.Created.Before(before) {
+			notifs = append(notifs, n)
+		}
+	}
+	nrm.notifs = notifs
+
+	return nil
+}
@@ -4,26 +4,83 @@
 package mocks
 
 import (
+	"sync"
+
 	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
 	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
-var _ notifiers.Notifier = (*notifier)(nil)
+var _ notifiers.SigningNotifier = (*notifier)(nil)
 
 const invalidSender = "invalid@example.com"
 
-type notifier struct{}
+// Notifier is the Notifier mock that records the notified contacts.
+type Notifier interface {
+	notifiers.SigningNotifier
+
+	// Notified returns the contacts notified since the previous call.
+	Notified() []string
+
+	// Content returns the content of the latest notification sent to the
+	// contact.
+	Content(contact string) string
+
+	// Secret returns the secret the latest notification sent to the
+	// contact is signed with.
+	Secret(contact string) string
+}
+
+type notifier struct {
+	mu       sync.Mutex
+	notified []string
+	contents map[string]string
+	secrets  map[string]string
+}
 
 // NewNotifier returns a new Notifier mock.
-func NewNotifier() notifiers.Notifier {
-	return notifier{}
+func NewNotifier() Notifier {
+	return &notifier{contents: make(map[string]string), secrets: make(map[string]string)}
 }
 
-func (n notifier) Notify(from string, to []string, msg messaging.Message) error {
+func (n *notifier) Notify(from string, to []string, msg messaging.Message, content string) error {
+	return n.NotifySigned("", to, msg, content)
+}
+
+func (n *notifier) NotifySigned(secret string, to []string, msg messaging.Message, content string) error {
 	for _, t := range to {
 		if t == invalidSender {
 			return notifiers.ErrNotify
 		}
 	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.notified = append(n.notified, to...)
+	for _, t := range to {
+		n.contents[t] = content
+		n.secrets[t] = secret
+	}
+
 	return nil
 }
+
+func (n *notifier) Notified() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	ret := n.notified
+	n.notified = nil
+	return ret
+}
+
+func (n *notifier) Content(contact string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.contents[contact]
+}
+
+func (n *notifier) Secret(contact string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.secrets[contact]
+}
@@ -67,6 +67,9 @@ func (srm *subRepoMock) RetrieveAll(_ context.Context, pm notifiers.PageMetadata
 	offset := int(pm.Offset)
 	for _, k := range keys {
 		v := srm.subs[k]
+		if pm.OwnerID != "" && pm.OwnerID != v.OwnerID {
+			continue
+		}
 		if pm.Topic == "" {
 			if pm.Contact == "" {
 				if total < offset {
@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/logger"
+)
+
+const (
+	// StatusSent marks the notifications delivered to the notifier.
+	StatusSent = "sent"
+	// StatusFailed marks the notifications that failed to be rendered or sent.
+	StatusFailed = "failed"
+	// StatusSkipped marks the notifications that weren't sent, with the
+	// reason recorded as the error.
+	StatusSkipped = "skipped"
+)
+
+// ReasonMinInterval is the reason of the notifications skipped within the
+// minimum interval since the previous one. Only the first notification
+// skipped within the interval is recorded.
+const ReasonMinInterval = "within minimum interval since the previous notification"
+
+// Notification represents the attempt to notify the subscription contact
+// of the message.
+type Notification struct {
+	ID             string
+	SubscriptionID string
+	Contact        string
+	Channel        string
+	Subtopic       string
+	Status         string
+	Error          string
+	Created        time.Time
+}
+
+// NotificationsPage represents the page of the subscription delivery history.
+type NotificationsPage struct {
+	Offset        uint
+	Limit         uint
+	Total         uint
+	Notifications []Notification
+}
+
+// NotificationsRepository specifies a Notification persistence API.
+type NotificationsRepository interface {
+	// Save persists the notification.
+	Save(ctx context.Context, n Notification) error
+
+	// RetrieveAll retrieves the notifications of the subscription, the
+	// latest first.
+	RetrieveAll(ctx context.Context, subID string, offset, limit uint) (NotificationsPage, error)
+
+	// RemoveBefore removes the notifications created before the given time.
+	RemoveBefore(ctx context.Context, before time.Time) error
+}
+
+// StartPruning removes the notifications older than the retention period in
+// the background with the given interval, until the returned function is
+// called. Failures are logged and the pruning is retried with the next tick.
+func StartPruning(repo NotificationsRepository, retention, interval time.Duration, logger logger.Logger) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := repo.RemoveBefore(context.Background(), time.Now().Add(-retention)); err != nil {
+					logger.Warn(fmt.Sprintf("Failed to prune notifications: %s", err))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
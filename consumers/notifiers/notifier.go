@@ -16,5 +16,17 @@ var ErrNotify = errors.New("Error sending notification")
 type Notifier interface {
 	// Notify method is used to send notification for the
 	// received message to the provided list of receivers.
-	Notify(from string, to []string, msg messaging.Message) error
+	// Non-empty content is sent instead of the notification
+	// formatted by the notifier.
+	Notify(from string, to []string, msg messaging.Message, content string) error
+}
+
+// SigningNotifier is implemented by the notifiers which sign the
+// notifications, so that the receivers can verify them.
+type SigningNotifier interface {
+	Notifier
+
+	// NotifySigned sends the notification signed with the secret of the
+	// subscription instead of the secret the notifier is configured with.
+	NotifySigned(secret string, to []string, msg messaging.Message, content string) error
 }
@@ -60,6 +60,58 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE IF EXISTS subscriptions",
 				},
 			},
+			{
+				Id: "subscriptions_2",
+				Up: []string{
+					`ALTER TABLE subscriptions ADD COLUMN secret TEXT NOT NULL DEFAULT ''`,
+				},
+				Down: []string{
+					"ALTER TABLE subscriptions DROP COLUMN secret",
+				},
+			},
+			{
+				Id: "subscriptions_3",
+				Up: []string{
+					`ALTER TABLE subscriptions
+                        ADD COLUMN conditions   JSONB NOT NULL DEFAULT '[]',
+                        ADD COLUMN hysteresis   DOUBLE PRECISION NOT NULL DEFAULT 0,
+                        ADD COLUMN min_interval BIGINT NOT NULL DEFAULT 0`,
+				},
+				Down: []string{
+					`ALTER TABLE subscriptions
+                        DROP COLUMN conditions,
+                        DROP COLUMN hysteresis,
+                        DROP COLUMN min_interval`,
+				},
+			},
+			{
+				Id: "subscriptions_4",
+				Up: []string{
+					`ALTER TABLE subscriptions ADD COLUMN template TEXT NOT NULL DEFAULT ''`,
+				},
+				Down: []string{
+					"ALTER TABLE subscriptions DROP COLUMN template",
+				},
+			},
+			{
+				Id: "notifications_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS notifications (
+                        id              VARCHAR(254) PRIMARY KEY,
+                        subscription_id VARCHAR(254) NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+                        contact         VARCHAR(254),
+                        channel         VARCHAR(254),
+                        subtopic        VARCHAR(254),
+                        status          VARCHAR(16) NOT NULL,
+                        error           TEXT NOT NULL DEFAULT '',
+                        created         TIMESTAMPTZ NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS notifications_subscription_created ON notifications (subscription_id, created DESC)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS notifications",
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,114 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var _ notifiers.NotificationsRepository = (*notificationsRepo)(nil)
+
+type notificationsRepo struct {
+	db Database
+}
+
+// NewNotifications instantiates a PostgreSQL implementation of Notifications
+// repository.
+func NewNotifications(db Database) notifiers.NotificationsRepository {
+	return &notificationsRepo{
+		db: db,
+	}
+}
+
+func (repo notificationsRepo) Save(ctx context.Context, n notifiers.Notification) error {
+	q := `INSERT INTO notifications (id, subscription_id, contact, channel, subtopic, status, error, created)
+          VALUES (:id, :subscription_id, :contact, :channel, :subtopic, :status, :error, :created)`
+
+	dbn := dbNotification{
+		ID:             n.ID,
+		SubscriptionID: n.SubscriptionID,
+		Contact:        n.Contact,
+		Channel:        n.Channel,
+		Subtopic:       n.Subtopic,
+		Status:         n.Status,
+		Error:          n.Error,
+		Created:        n.Created,
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbn); err != nil {
+		return errors.Wrap(notifiers.ErrSave, err)
+	}
+
+	return nil
+}
+
+func (repo notificationsRepo) RetrieveAll(ctx context.Context, subID string, offset, limit uint) (notifiers.NotificationsPage, error) {
+	q := `SELECT id, subscription_id, contact, channel, subtopic, status, error, created FROM notifications
+          WHERE subscription_id = :subscription_id ORDER BY created DESC OFFSET :offset LIMIT :limit`
+	params := map[string]interface{}{
+		"subscription_id": subID,
+		"offset":          offset,
+		"limit":           limit,
+	}
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return notifiers.NotificationsPage{}, errors.Wrap(notifiers.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	notifs := []notifiers.Notification{}
+	for rows.Next() {
+		dbn := dbNotification{}
+		if err := rows.StructScan(&dbn); err != nil {
+			return notifiers.NotificationsPage{}, errors.Wrap(notifiers.ErrSelectEntity, err)
+		}
+		notifs = append(notifs, notifiers.Notification{
+			ID:             dbn.ID,
+			SubscriptionID: dbn.SubscriptionID,
+			Contact:        dbn.Contact,
+			Channel:        dbn.Channel,
+			Subtopic:       dbn.Subtopic,
+			Status:         dbn.Status,
+			Error:          dbn.Error,
+			Created:        dbn.Created,
+		})
+	}
+
+	cq := `SELECT COUNT(*) FROM notifications WHERE subscription_id = :subscription_id`
+	total, err := total(ctx, repo.db, cq, params)
+	if err != nil {
+		return notifiers.NotificationsPage{}, errors.Wrap(notifiers.ErrSelectEntity, err)
+	}
+
+	return notifiers.NotificationsPage{
+		Offset:        offset,
+		Limit:         limit,
+		Total:         total,
+		Notifications: notifs,
+	}, nil
+}
+
+func (repo notificationsRepo) RemoveBefore(ctx context.Context, before time.Time) error {
+	q := `DELETE FROM notifications WHERE created < :before`
+	if _, err := repo.db.NamedExecContext(ctx, q, map[string]interface{}{"before": before}); err != nil {
+		return errors.Wrap(notifiers.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+type dbNotification struct {
+	ID             string    `db:"id"`
+	SubscriptionID string    `db:"subscription_id"`
+	Contact        string    `db:"contact"`
+	Channel        string    `db:"channel"`
+	Subtopic       string    `db:"subtopic"`
+	Status         string    `db:"status"`
+	Error          string    `db:"error"`
+	Created        time.Time `db:"created"`
+}
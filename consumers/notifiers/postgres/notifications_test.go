@@ -0,0 +1,137 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/consumers/notifiers/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const numNotifs = 10
+
+func TestSaveNotification(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	subs := postgres.New(dbMiddleware)
+	repo := postgres.NewNotifications(dbMiddleware)
+
+	subID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = subs.Save(context.Background(), notifiers.Subscription{ID: subID, OwnerID: subID, Contact: owner, Topic: "save.notification"})
+	require.Nil(t, err, fmt.Sprintf("creating subscription must not fail: %s", err))
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		notif notifiers.Notification
+		err   error
+	}{
+		{
+			desc:  "save successfully",
+			notif: notifiers.Notification{ID: id, SubscriptionID: subID, Contact: owner, Status: notifiers.StatusSent, Created: time.Now()},
+			err:   nil,
+		},
+		{
+			desc:  "save duplicate",
+			notif: notifiers.Notification{ID: id, SubscriptionID: subID, Contact: owner, Status: notifiers.StatusSent, Created: time.Now()},
+			err:   notifiers.ErrSave,
+		},
+		{
+			desc:  "save for non-existing subscription",
+			notif: notifiers.Notification{ID: subID, SubscriptionID: "non-existing", Contact: owner, Status: notifiers.StatusSent, Created: time.Now()},
+			err:   notifiers.ErrSave,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.notif)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRetrieveAllNotifications(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	subs := postgres.New(dbMiddleware)
+	repo := postgres.NewNotifications(dbMiddleware)
+
+	subID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = subs.Save(context.Background(), notifiers.Subscription{ID: subID, OwnerID: subID, Contact: owner, Topic: "list.notifications"})
+	require.Nil(t, err, fmt.Sprintf("creating subscription must not fail: %s", err))
+
+	now := time.Now()
+	var ids []string
+	for i := 0; i < numNotifs; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		n := notifiers.Notification{
+			ID:             id,
+			SubscriptionID: subID,
+			Contact:        owner,
+			Channel:        "list",
+			Subtopic:       "notifications",
+			Status:         notifiers.StatusSent,
+			Created:        now.Add(time.Duration(i) * time.Second),
+		}
+		if i%2 == 0 {
+			n.Status = notifiers.StatusFailed
+			n.Error = "failed to send"
+		}
+		err = repo.Save(context.Background(), n)
+		require.Nil(t, err, fmt.Sprintf("saving notification must not fail: %s", err))
+		ids = append([]string{id}, ids...)
+	}
+
+	cases := []struct {
+		desc   string
+		subID  string
+		offset uint
+		limit  uint
+		total  uint
+		ids    []string
+	}{
+		{
+			desc:  "retrieve latest",
+			subID: subID,
+			limit: 3,
+			total: numNotifs,
+			ids:   ids[:3],
+		},
+		{
+			desc:   "retrieve with offset",
+			subID:  subID,
+			offset: 8,
+			limit:  5,
+			total:  numNotifs,
+			ids:    ids[8:],
+		},
+		{
+			desc:  "retrieve for non-existing subscription",
+			subID: "non-existing",
+			limit: 5,
+			total: 0,
+			ids:   []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.subID, tc.offset, tc.limit)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		got := []string{}
+		for _, n := range page.Notifications {
+			got = append(got, n.ID)
+		}
+		assert.Equal(t, tc.ids, got, fmt.Sprintf("%s: expected notifications %v got %v\n", tc.desc, tc.ids, got))
+	}
+}
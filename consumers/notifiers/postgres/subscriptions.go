@@ -6,8 +6,10 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/lib/pq"
 	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
@@ -30,13 +32,12 @@ func New(db Database) notifiers.SubscriptionsRepository {
 }
 
 func (repo subscriptionsRepo) Save(ctx context.Context, sub notifiers.Subscription) (string, error) {
-	q := `INSERT INTO subscriptions (id, owner_id, contact, topic) VALUES (:id, :owner_id, :contact, :topic) RETURNING id`
+	q := `INSERT INTO subscriptions (id, owner_id, contact, topic, conditions, hysteresis, min_interval, template, secret)
+          VALUES (:id, :owner_id, :contact, :topic, :conditions, :hysteresis, :min_interval, :template, :secret) RETURNING id`
 
-	dbSub := dbSubscription{
-		ID:      sub.ID,
-		OwnerID: sub.OwnerID,
-		Contact: sub.Contact,
-		Topic:   sub.Topic,
+	dbSub, err := toDBSub(sub)
+	if err != nil {
+		return "", errors.Wrap(notifiers.ErrSave, err)
 	}
 
 	row, err := repo.db.NamedQueryContext(ctx, q, dbSub)
@@ -52,7 +53,8 @@ func (repo subscriptionsRepo) Save(ctx context.Context, sub notifiers.Subscripti
 }
 
 func (repo subscriptionsRepo) Retrieve(ctx context.Context, id string) (notifiers.Subscription, error) {
-	q := `SELECT id, owner_id, contact, topic FROM subscriptions WHERE id = $1`
+	q := `SELECT id, owner_id, contact, topic, conditions, hysteresis, min_interval, template, secret FROM subscriptions
+          WHERE id = $1`
 	sub := dbSubscription{}
 	if err := repo.db.QueryRowxContext(ctx, q, id).StructScan(&sub); err != nil {
 		if err == sql.ErrNoRows {
@@ -61,11 +63,11 @@ func (repo subscriptionsRepo) Retrieve(ctx context.Context, id string) (notifier
 		return notifiers.Subscription{}, errors.Wrap(notifiers.ErrSelectEntity, err)
 	}
 
-	return fromDBSub(sub), nil
+	return fromDBSub(sub)
 }
 
 func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.PageMetadata) (notifiers.Page, error) {
-	q := `SELECT id, owner_id, contact, topic FROM subscriptions`
+	q := `SELECT id, owner_id, contact, topic, conditions, hysteresis, min_interval, template, secret FROM subscriptions`
 	args := make(map[string]interface{})
 	if pm.Topic != "" {
 		args["topic"] = pm.Topic
@@ -73,6 +75,9 @@ func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.Page
 	if pm.Contact != "" {
 		args["contact"] = pm.Contact
 	}
+	if pm.OwnerID != "" {
+		args["owner_id"] = pm.OwnerID
+	}
 	var condition string
 	if len(args) > 0 {
 		var cond []string
@@ -101,7 +106,11 @@ func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.Page
 		if err := rows.StructScan(&sub); err != nil {
 			return notifiers.Page{}, errors.Wrap(notifiers.ErrSelectEntity, err)
 		}
-		subs = append(subs, fromDBSub(sub))
+		s, err := fromDBSub(sub)
+		if err != nil {
+			return notifiers.Page{}, errors.Wrap(notifiers.ErrSelectEntity, err)
+		}
+		subs = append(subs, s)
 	}
 
 	if len(subs) == 0 {
@@ -148,17 +157,65 @@ func total(ctx context.Context, db Database, query string, params interface{}) (
 }
 
 type dbSubscription struct {
-	ID      string `db:"id"`
-	OwnerID string `db:"owner_id"`
-	Contact string `db:"contact"`
-	Topic   string `db:"topic"`
+	ID          string  `db:"id"`
+	OwnerID     string  `db:"owner_id"`
+	Contact     string  `db:"contact"`
+	Topic       string  `db:"topic"`
+	Conditions  []byte  `db:"conditions"`
+	Hysteresis  float64 `db:"hysteresis"`
+	MinInterval int64   `db:"min_interval"`
+	Template    string  `db:"template"`
+	Secret      string  `db:"secret"`
+}
+
+type dbCondition struct {
+	Name       string  `json:"name"`
+	Comparator string  `json:"comparator,omitempty"`
+	Value      float64 `json:"value"`
+}
+
+func toDBSub(sub notifiers.Subscription) (dbSubscription, error) {
+	conds := []dbCondition{}
+	for _, c := range sub.Conditions {
+		conds = append(conds, dbCondition(c))
+	}
+	b, err := json.Marshal(conds)
+	if err != nil {
+		return dbSubscription{}, err
+	}
+
+	return dbSubscription{
+		ID:          sub.ID,
+		OwnerID:     sub.OwnerID,
+		Contact:     sub.Contact,
+		Topic:       sub.Topic,
+		Conditions:  b,
+		Hysteresis:  sub.Hysteresis,
+		MinInterval: int64(sub.MinInterval),
+		Template:    sub.Template,
+		Secret:      sub.Secret,
+	}, nil
 }
 
-func fromDBSub(sub dbSubscription) notifiers.Subscription {
-	return notifiers.Subscription{
-		ID:      sub.ID,
-		OwnerID: sub.OwnerID,
-		Contact: sub.Contact,
-		Topic:   sub.Topic,
+func fromDBSub(sub dbSubscription) (notifiers.Subscription, error) {
+	var conds []dbCondition
+	if err := json.Unmarshal(sub.Conditions, &conds); err != nil {
+		return notifiers.Subscription{}, err
+	}
+
+	ret := notifiers.Subscription{
+		ID:          sub.ID,
+		OwnerID:     sub.OwnerID,
+		Contact:     sub.Contact,
+		Topic:       sub.Topic,
+		Hysteresis:  sub.Hysteresis,
+		MinInterval: time.Duration(sub.MinInterval),
+		Template:    sub.Template,
+		Secret:      sub.Secret,
+	}
+	for _, c := range conds {
+		ret.Conditions = append(ret.Conditions, notifiers.Condition(c))
 	}
+
+	return ret, nil
 }
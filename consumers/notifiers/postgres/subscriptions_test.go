@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
 	"github.com/mainflux/mainflux/consumers/notifiers/postgres"
@@ -80,6 +81,13 @@ func TestView(t *testing.T) {
 		ID:      id,
 		Contact: owner,
 		Topic:   "view.subtopic",
+		Conditions: []notifiers.Condition{
+			{Name: "temperature", Comparator: "gt", Value: 30},
+		},
+		Hysteresis:  2,
+		MinInterval: 5 * time.Minute,
+		Template:    "{{.Channel}}: {{.Payload}}",
+		Secret:      "secret",
 	}
 
 	ret, err := repo.Save(context.Background(), sub)
@@ -132,6 +140,9 @@ func TestRetrieveAll(t *testing.T) {
 			Contact: owner,
 			Topic:   fmt.Sprintf("list.subtopic.%d", i),
 		}
+		if i%2 == 0 {
+			sub.OwnerID = "another"
+		}
 
 		ret, err := repo.Save(context.Background(), sub)
 		require.Nil(t, err, fmt.Sprintf("creating subscription must not fail: %s", err))
@@ -197,6 +208,24 @@ func TestRetrieveAll(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc: "retrieve with owner",
+			pageMeta: notifiers.PageMetadata{
+				Offset:  0,
+				Limit:   2,
+				OwnerID: "owner",
+			},
+			page: notifiers.Page{
+				Total: numSubs / 2,
+				PageMetadata: notifiers.PageMetadata{
+					Offset:  0,
+					Limit:   2,
+					OwnerID: "owner",
+				},
+				Subscriptions: []notifiers.Subscription{subs[1], subs[3]},
+			},
+			err: nil,
+		},
 		{
 			desc: "retrieve with no limit",
 			pageMeta: notifiers.PageMetadata{
@@ -6,11 +6,16 @@ package notifiers
 import (
 	"context"
 	"fmt"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/pkg/auth"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
 )
 
 var (
@@ -18,6 +23,10 @@ var (
 	// when accessing a protected resource.
 	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
 
+	// ErrAuthorization indicates that the user isn't allowed to subscribe
+	// to the topic.
+	ErrAuthorization = errors.New("missing or invalid permissions")
+
 	// ErrCreateID indicates error in creating id for entity creation
 	ErrCreateID = errors.New("failed to create id")
 
@@ -44,36 +53,50 @@ var (
 type Service interface {
 	// CreateSubscription persists a subscription.
 	// Successful operation is indicated by non-nil error response.
+	// The user has to own the channel of the subscription topic.
 	CreateSubscription(ctx context.Context, token string, sub Subscription) (string, error)
 
 	// ViewSubscription retrieves the subscription for the given user and id.
 	ViewSubscription(ctx context.Context, token, id string) (Subscription, error)
 
 	// ListSubscriptions lists subscriptions having the provided user token and search params.
+	// Admins list the subscriptions of all the users.
 	ListSubscriptions(ctx context.Context, token string, pm PageMetadata) (Page, error)
 
 	// RemoveSubscription removes the subscription having the provided identifier.
 	RemoveSubscription(ctx context.Context, token, id string) error
 
+	// ListNotifications lists the delivery history of the subscription
+	// having the provided identifier.
+	ListNotifications(ctx context.Context, token, subID string, offset, limit uint) (NotificationsPage, error)
+
 	consumers.Consumer
 }
 
 var _ Service = (*notifierService)(nil)
 
 type notifierService struct {
-	auth     mainflux.AuthServiceClient
-	subs     SubscriptionsRepository
-	idp      mainflux.IDProvider
-	notifier Notifier
+	auth      mainflux.AuthServiceClient
+	things    mainflux.ThingsServiceClient
+	subs      SubscriptionsRepository
+	notifs    NotificationsRepository
+	idp       mainflux.IDProvider
+	notifier  Notifier
+	trigger   *trigger
+	templates *templates
 }
 
 // New instantiates the subscriptions service implementation.
-func New(auth mainflux.AuthServiceClient, subs SubscriptionsRepository, idp mainflux.IDProvider, notifier Notifier) Service {
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, subs SubscriptionsRepository, notifs NotificationsRepository, idp mainflux.IDProvider, notifier Notifier) Service {
 	return &notifierService{
-		auth:     auth,
-		subs:     subs,
-		idp:      idp,
-		notifier: notifier,
+		auth:      auth,
+		things:    things,
+		subs:      subs,
+		notifs:    notifs,
+		idp:       idp,
+		notifier:  notifier,
+		trigger:   newTrigger(),
+		templates: newTemplates(),
 	}
 }
 
@@ -82,6 +105,11 @@ func (ns *notifierService) CreateSubscription(ctx context.Context, token string,
 	if err != nil {
 		return "", errors.Wrap(ErrUnauthorizedAccess, err)
 	}
+	chanID := strings.SplitN(sub.Topic, ".", 2)[0]
+	req := &mainflux.ChannelOwnerReq{Owner: res.GetEmail(), ChanID: chanID}
+	if _, err := ns.things.IsChannelOwner(ctx, req); err != nil {
+		return "", errors.Wrap(ErrAuthorization, err)
+	}
 	sub.ID, err = ns.idp.ID()
 	if err != nil {
 		return "", errors.Wrap(ErrCreateID, err)
@@ -92,27 +120,48 @@ func (ns *notifierService) CreateSubscription(ctx context.Context, token string,
 }
 
 func (ns *notifierService) ViewSubscription(ctx context.Context, token, id string) (Subscription, error) {
-	if _, err := ns.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
-		return Subscription{}, errors.Wrap(ErrUnauthorizedAccess, err)
-	}
-
-	return ns.subs.Retrieve(ctx, id)
+	return ns.retrieve(ctx, token, id)
 }
 
 func (ns *notifierService) ListSubscriptions(ctx context.Context, token string, pm PageMetadata) (Page, error) {
-	if _, err := ns.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
-		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	userID, admin, err := ns.identify(ctx, token)
+	if err != nil {
+		return Page{}, err
+	}
+	pm.OwnerID = userID
+	if admin {
+		// Admins list the subscriptions of all the owners.
+		pm.OwnerID = ""
 	}
 
 	return ns.subs.RetrieveAll(ctx, pm)
 }
 
 func (ns *notifierService) RemoveSubscription(ctx context.Context, token, id string) error {
-	if _, err := ns.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
-		return errors.Wrap(ErrUnauthorizedAccess, err)
+	// Removing a subscription that doesn't exist or belongs to another user
+	// succeeds without removing anything.
+	if _, err := ns.retrieve(ctx, token, id); err != nil {
+		if errors.Contains(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if err := ns.subs.Remove(ctx, id); err != nil {
+		return err
 	}
+	ns.trigger.remove(id)
+	ns.templates.remove(id)
 
-	return ns.subs.Remove(ctx, id)
+	return nil
+}
+
+func (ns *notifierService) ListNotifications(ctx context.Context, token, subID string, offset, limit uint) (NotificationsPage, error) {
+	if _, err := ns.retrieve(ctx, token, subID); err != nil {
+		return NotificationsPage{}, err
+	}
+
+	return ns.notifs.RetrieveAll(ctx, subID, offset, limit)
 }
 
 func (ns *notifierService) Consume(message interface{}) error {
@@ -131,19 +180,143 @@ func (ns *notifierService) Consume(message interface{}) error {
 	}
 	page, err := ns.subs.RetrieveAll(context.Background(), pm)
 	if err != nil {
+		if errors.Contains(err, ErrNotFound) {
+			return nil
+		}
 		return err
 	}
 
-	var to []string
+	// The failures to notify a subscription are recorded in its delivery
+	// history rather than returned, so that redelivering the message doesn't
+	// notify the other subscriptions again.
+	//
+	// Time between notifications is measured using the message creation
+	// time, and the records are decoded only if the conditions or the
+	// templates need them.
+	now := time.Now()
+	if msg.Created != 0 {
+		now = time.Unix(0, msg.Created)
+	}
+	var recs []senml.Message
+	var vals values
 	for _, sub := range page.Subscriptions {
-		to = append(to, sub.Contact)
+		if recs == nil && (len(sub.Conditions) > 0 || sub.Template != "") {
+			recs = decode(msg)
+			vals = valuesOf(recs)
+		}
+		fire, skipped := ns.trigger.fire(sub, vals, now)
+		if skipped {
+			ns.record(sub, msg, StatusSkipped, ReasonMinInterval)
+		}
+		if !fire {
+			continue
+		}
+		if err := ns.notify(sub, msg, recs); err != nil {
+			continue
+		}
+		ns.trigger.sent(sub, now)
 	}
-	if len(to) > 0 {
-		err := ns.notifier.Notify("", to, msg)
-		if err != nil {
-			return errors.Wrap(ErrNotify, err)
+
+	return nil
+}
+
+// notify sends the notification of the message to the subscription contact,
+// and records the outcome in the delivery history. The returned error is the
+// failure to send the notification.
+func (ns *notifierService) notify(sub Subscription, msg messaging.Message, recs []senml.Message) error {
+	var content string
+	var err error
+	if sub.Template != "" {
+		var tmpl *template.Template
+		if tmpl, err = ns.templates.get(sub); err == nil {
+			content, err = render(tmpl, msg, recs)
 		}
 	}
+	if err == nil {
+		err = ns.send(sub, msg, content)
+	}
+
+	if err != nil {
+		ns.record(sub, msg, StatusFailed, err.Error())
+		return errors.Wrap(ErrNotify, err)
+	}
 
+	ns.record(sub, msg, StatusSent, "")
 	return nil
 }
+
+// record saves the notification of the message with the given status and
+// error in the delivery history.
+func (ns *notifierService) record(sub Subscription, msg messaging.Message, status, reason string) error {
+	id, err := ns.idp.ID()
+	if err != nil {
+		return errors.Wrap(ErrCreateID, err)
+	}
+
+	n := Notification{
+		ID:             id,
+		SubscriptionID: sub.ID,
+		Contact:        sub.Contact,
+		Channel:        msg.Channel,
+		Subtopic:       msg.Subtopic,
+		Status:         status,
+		Error:          reason,
+		Created:        time.Now(),
+	}
+	if err := ns.notifs.Save(context.Background(), n); err != nil {
+		return errors.Wrap(ErrSave, err)
+	}
+
+	return nil
+}
+
+// send sends the notification to the subscription contact, signed with the
+// subscription secret if the notifier signs the notifications.
+func (ns *notifierService) send(sub Subscription, msg messaging.Message, content string) error {
+	to := []string{sub.Contact}
+	if sn, ok := ns.notifier.(SigningNotifier); ok && sub.Secret != "" {
+		return sn.NotifySigned(sub.Secret, to, msg, content)
+	}
+	return ns.notifier.Notify("", to, msg, content)
+}
+
+// identify returns the ID of the user and whether the user is an admin,
+// whose access isn't limited to their own subscriptions.
+func (ns *notifierService) identify(ctx context.Context, token string) (string, bool, error) {
+	res, err := ns.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return "", false, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if res.GetId() == "" {
+		return "", false, ErrUnauthorizedAccess
+	}
+
+	// Failing to check the membership only limits the user to their own
+	// subscriptions.
+	admin, err := auth.IsAdmin(ctx, ns.auth, res.GetId())
+	if err != nil {
+		return res.GetId(), false, nil
+	}
+
+	return res.GetId(), admin, nil
+}
+
+// retrieve retrieves the subscription if it belongs to the user or the user
+// is an admin. The subscriptions of the other users are reported as not
+// found.
+func (ns *notifierService) retrieve(ctx context.Context, token, id string) (Subscription, error) {
+	userID, admin, err := ns.identify(ctx, token)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	sub, err := ns.subs.Retrieve(ctx, id)
+	if err != nil {
+		return Subscription{}, err
+	}
+	if !admin && sub.OwnerID != userID {
+		return Subscription{}, ErrNotFound
+	}
+
+	return sub, nil
+}
@@ -6,13 +6,18 @@ package notifiers_test
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
+	"time"
 
 	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
 	"github.com/mainflux/mainflux/consumers/notifiers/mocks"
+	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/pkg/uuid"
+	mfsenml "github.com/mainflux/senml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -22,14 +27,23 @@ const (
 	exampleUser1 = "email1@example.com"
 	exampleUser2 = "email2@example.com"
 	invalidUser  = "invalid@example.com"
+	exampleAdmin = "admin@example.com"
 )
 
+var owners = map[string]string{
+	"valid": exampleUser1,
+	"topic": exampleUser1,
+	"chan1": exampleUser1,
+	"chan2": exampleUser2,
+}
+
 func newService() notifiers.Service {
 	repo := mocks.NewRepo(make(map[string]notifiers.Subscription))
-	auth := mocks.NewAuth(map[string]string{exampleUser1: exampleUser1, exampleUser2: exampleUser2, invalidUser: invalidUser})
+	auth := mocks.NewAuth(map[string]string{exampleUser1: exampleUser1, exampleUser2: exampleUser2, invalidUser: invalidUser, exampleAdmin: exampleAdmin}, exampleAdmin)
+	things := mocks.NewThingsService(owners)
 	notifier := mocks.NewNotifier()
 	idp := uuid.NewMock()
-	return notifiers.New(auth, repo, idp, notifier)
+	return notifiers.New(auth, things, repo, mocks.NewNotificationsRepo(), idp, notifier)
 }
 
 func TestCreateSubscription(t *testing.T) {
@@ -63,6 +77,13 @@ func TestCreateSubscription(t *testing.T) {
 			id:    "",
 			err:   notifiers.ErrUnauthorizedAccess,
 		},
+		{
+			desc:  "test channel owned by another user",
+			token: exampleUser2,
+			sub:   notifiers.Subscription{Contact: exampleUser2, Topic: "valid.topic"},
+			id:    "",
+			err:   notifiers.ErrAuthorization,
+		},
 	}
 
 	for _, tc := range cases {
@@ -101,6 +122,20 @@ func TestViewSubscription(t *testing.T) {
 			sub:   notifiers.Subscription{},
 			err:   notifiers.ErrNotFound,
 		},
+		{
+			desc:  "test subscription of another user",
+			token: exampleUser2,
+			id:    id,
+			sub:   notifiers.Subscription{},
+			err:   notifiers.ErrNotFound,
+		},
+		{
+			desc:  "test admin",
+			token: exampleAdmin,
+			id:    id,
+			sub:   sub,
+			err:   nil,
+		},
 		{
 			desc:  "test unauthorized access",
 			token: "",
@@ -120,21 +155,24 @@ func TestViewSubscription(t *testing.T) {
 func TestListSubscriptions(t *testing.T) {
 	svc := newService()
 	sub := notifiers.Subscription{Contact: exampleUser1, OwnerID: exampleUser1}
-	topic := "topic.subtopic"
-	var subs []notifiers.Subscription
+	var subs, subs1 []notifiers.Subscription
 	for i := 0; i < total; i++ {
 		tmp := sub
 		token := exampleUser1
+		tmp.Topic = fmt.Sprintf("chan1.subtopic.%d", i)
 		if i%2 == 0 {
 			tmp.Contact = exampleUser2
 			tmp.OwnerID = exampleUser2
+			tmp.Topic = fmt.Sprintf("chan2.subtopic.%d", i)
 			token = exampleUser2
 		}
-		tmp.Topic = fmt.Sprintf("%s.%d", topic, i)
 		id, err := svc.CreateSubscription(context.Background(), token, tmp)
 		require.Nil(t, err, "Saving a Subscription must succeed")
 		tmp.ID = id
 		subs = append(subs, tmp)
+		if tmp.OwnerID == exampleUser1 {
+			subs1 = append(subs1, tmp)
+		}
 	}
 
 	var offsetSubs []notifiers.Subscription
@@ -159,11 +197,12 @@ func TestListSubscriptions(t *testing.T) {
 			err: nil,
 			page: notifiers.Page{
 				PageMetadata: notifiers.PageMetadata{
-					Offset: 0,
-					Limit:  3,
+					Offset:  0,
+					Limit:   3,
+					OwnerID: exampleUser1,
 				},
-				Subscriptions: subs[:3],
-				Total:         total,
+				Subscriptions: subs1[:3],
+				Total:         total / 2,
 			},
 		},
 		{
@@ -182,7 +221,7 @@ func TestListSubscriptions(t *testing.T) {
 			pageMeta: notifiers.PageMetadata{
 				Offset: 2,
 				Limit:  12,
-				Topic:  "topic.subtopic.13",
+				Topic:  "chan1.subtopic.13",
 			},
 			page: notifiers.Page{},
 			err:  notifiers.ErrUnauthorizedAccess,
@@ -192,21 +231,49 @@ func TestListSubscriptions(t *testing.T) {
 			token: exampleUser1,
 			pageMeta: notifiers.PageMetadata{
 				Limit: 10,
-				Topic: fmt.Sprintf("%s.%d", topic, 4),
+				Topic: "chan1.subtopic.5",
 			},
 			page: notifiers.Page{
 				PageMetadata: notifiers.PageMetadata{
-					Limit: 10,
-					Topic: fmt.Sprintf("%s.%d", topic, 4),
+					Limit:   10,
+					Topic:   "chan1.subtopic.5",
+					OwnerID: exampleUser1,
 				},
-				Subscriptions: subs[4:5],
+				Subscriptions: subs[5:6],
 				Total:         1,
 			},
 			err: nil,
 		},
 		{
-			desc:  "test with contact and offset",
+			desc:  "test with topic of another user",
 			token: exampleUser1,
+			pageMeta: notifiers.PageMetadata{
+				Limit: 10,
+				Topic: "chan2.subtopic.4",
+			},
+			page: notifiers.Page{},
+			err:  notifiers.ErrNotFound,
+		},
+		{
+			desc:  "test admin",
+			token: exampleAdmin,
+			pageMeta: notifiers.PageMetadata{
+				Offset: 0,
+				Limit:  3,
+			},
+			page: notifiers.Page{
+				PageMetadata: notifiers.PageMetadata{
+					Offset: 0,
+					Limit:  3,
+				},
+				Subscriptions: subs[:3],
+				Total:         total,
+			},
+			err: nil,
+		},
+		{
+			desc:  "test admin with contact and offset",
+			token: exampleAdmin,
 			pageMeta: notifiers.PageMetadata{
 				Offset:  10,
 				Limit:   10,
@@ -240,6 +307,18 @@ func TestRemoveSubscription(t *testing.T) {
 	sub.ID = id
 	sub.OwnerID = exampleUser1
 
+	err = svc.RemoveSubscription(context.Background(), exampleUser2, id)
+	require.Nil(t, err, "Removing a Subscription of another user must not fail")
+	_, err = svc.ViewSubscription(context.Background(), exampleUser1, id)
+	require.Nil(t, err, "Removing a Subscription of another user must not remove it")
+
+	otherID, err := svc.CreateSubscription(context.Background(), exampleUser2, notifiers.Subscription{Contact: exampleUser2, Topic: "chan2.topic"})
+	require.Nil(t, err, "Saving a Subscription must succeed")
+	err = svc.RemoveSubscription(context.Background(), exampleAdmin, otherID)
+	require.Nil(t, err, "Removing a Subscription as admin must not fail")
+	_, err = svc.ViewSubscription(context.Background(), exampleUser2, otherID)
+	require.True(t, errors.Contains(err, notifiers.ErrNotFound), "Removing a Subscription as admin must remove it")
+
 	cases := []struct {
 		desc  string
 		token string
@@ -313,9 +392,9 @@ func TestConsume(t *testing.T) {
 			msg:  msg,
 		},
 		{
-			desc: "test fail",
+			desc: "test failed delivery",
 			msg:  errMsg,
-			err:  notifiers.ErrNotify,
+			err:  nil,
 		},
 	}
 
@@ -324,3 +403,449 @@ func TestConsume(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestConsumeConditions(t *testing.T) {
+	repo := mocks.NewRepo(make(map[string]notifiers.Subscription))
+	auth := mocks.NewAuth(map[string]string{exampleUser1: exampleUser1})
+	notifier := mocks.NewNotifier()
+	svc := notifiers.New(auth, mocks.NewThingsService(owners), repo, mocks.NewNotificationsRepo(), uuid.NewMock(), notifier)
+
+	subs := []notifiers.Subscription{
+		{
+			Contact:    "threshold@example.com",
+			Topic:      "topic.subtopic",
+			Conditions: []notifiers.Condition{{Name: "temperature", Comparator: "gt", Value: 30}},
+			Hysteresis: 2,
+		},
+		{
+			Contact:     "interval@example.com",
+			Topic:       "topic.subtopic",
+			MinInterval: 10 * time.Second,
+		},
+		{
+			Contact:     "both@example.com",
+			Topic:       "topic.subtopic",
+			Conditions:  []notifiers.Condition{{Name: "temperature", Comparator: "gt", Value: 30}},
+			MinInterval: time.Minute,
+		},
+	}
+	for _, sub := range subs {
+		_, err := svc.CreateSubscription(context.Background(), exampleUser1, sub)
+		require.Nil(t, err, "Saving a Subscription must succeed")
+	}
+
+	cases := []struct {
+		desc        string
+		created     int64
+		payload     string
+		contentType string
+		notified    []string
+	}{
+		{
+			desc:     "consume message with the conditions not met",
+			created:  0,
+			payload:  `[{"n":"temperature","v":25}]`,
+			notified: []string{"interval@example.com"},
+		},
+		{
+			desc:     "consume message meeting the conditions",
+			created:  1,
+			payload:  `[{"n":"temperature","v":31}]`,
+			notified: []string{"threshold@example.com", "both@example.com"},
+		},
+		{
+			desc:    "consume message with the conditions still met",
+			created: 2,
+			payload: `[{"n":"temperature","v":32}]`,
+		},
+		{
+			desc:    "consume message within the hysteresis",
+			created: 3,
+			payload: `[{"n":"temperature","v":29}]`,
+		},
+		{
+			desc:    "consume message meeting the conditions within the hysteresis",
+			created: 4,
+			payload: `[{"n":"temperature","v":31}]`,
+		},
+		{
+			desc:     "consume message clearing the conditions",
+			created:  11,
+			payload:  `[{"n":"temperature","v":27}]`,
+			notified: []string{"interval@example.com"},
+		},
+		{
+			desc:     "consume message meeting the conditions again",
+			created:  12,
+			payload:  `[{"n":"temperature","v":35}]`,
+			notified: []string{"threshold@example.com"},
+		},
+		{
+			desc:     "consume message without SenML payload",
+			created:  22,
+			payload:  "text",
+			notified: []string{"interval@example.com"},
+		},
+		{
+			desc:     "consume message meeting the conditions once the minimum interval passes",
+			created:  62,
+			payload:  `[{"n":"temperature","v":36}]`,
+			notified: []string{"interval@example.com", "both@example.com"},
+		},
+		{
+			desc:        "consume SenML CBOR message clearing the conditions",
+			created:     73,
+			payload:     senmlCBOR(t, 20),
+			contentType: senml.CBOR,
+			notified:    []string{"interval@example.com"},
+		},
+		{
+			desc:        "consume SenML CBOR message meeting the conditions",
+			created:     74,
+			payload:     senmlCBOR(t, 40),
+			contentType: senml.CBOR,
+			notified:    []string{"threshold@example.com"},
+		},
+	}
+
+	start := time.Now().Unix()
+	for _, tc := range cases {
+		msg := messaging.Message{
+			Channel:     "topic",
+			Subtopic:    "subtopic",
+			Created:     time.Unix(start+tc.created, 0).UnixNano(),
+			ContentType: tc.contentType,
+			Payload:     []byte(tc.payload),
+		}
+		err := svc.Consume(msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.ElementsMatch(t, tc.notified, notifier.Notified(), fmt.Sprintf("%s: got unexpected notified contacts\n", tc.desc))
+	}
+}
+
+// failingNotifier fails to send the notifications while fail is set.
+type failingNotifier struct {
+	mocks.Notifier
+	fail bool
+}
+
+func (n *failingNotifier) Notify(from string, to []string, msg messaging.Message, content string) error {
+	return n.NotifySigned("", to, msg, content)
+}
+
+func (n *failingNotifier) NotifySigned(secret string, to []string, msg messaging.Message, content string) error {
+	if n.fail {
+		return notifiers.ErrNotify
+	}
+	return n.Notifier.NotifySigned(secret, to, msg, content)
+}
+
+func TestConsumeFailedDelivery(t *testing.T) {
+	repo := mocks.NewRepo(make(map[string]notifiers.Subscription))
+	auth := mocks.NewAuth(map[string]string{exampleUser1: exampleUser1})
+	notifier := &failingNotifier{Notifier: mocks.NewNotifier()}
+	svc := notifiers.New(auth, mocks.NewThingsService(owners), repo, mocks.NewNotificationsRepo(), uuid.NewMock(), notifier)
+
+	sub := notifiers.Subscription{
+		Contact:     "threshold@example.com",
+		Topic:       "topic.subtopic",
+		Conditions:  []notifiers.Condition{{Name: "temperature", Comparator: "gt", Value: 30}},
+		MinInterval: time.Minute,
+	}
+	_, err := svc.CreateSubscription(context.Background(), exampleUser1, sub)
+	require.Nil(t, err, "Saving a Subscription must succeed")
+
+	cases := []struct {
+		desc     string
+		fail     bool
+		created  int64
+		notified []string
+	}{
+		{
+			desc:     "consume message meeting the conditions failing to notify",
+			fail:     true,
+			created:  0,
+			notified: nil,
+		},
+		{
+			desc:     "consume message meeting the conditions after the failed notification",
+			fail:     false,
+			created:  1,
+			notified: []string{"threshold@example.com"},
+		},
+		{
+			desc:     "consume message meeting the conditions after the notification",
+			fail:     false,
+			created:  2,
+			notified: nil,
+		},
+	}
+
+	start := time.Now().Unix()
+	for _, tc := range cases {
+		notifier.fail = tc.fail
+		msg := messaging.Message{
+			Channel:  "topic",
+			Subtopic: "subtopic",
+			Created:  time.Unix(start+tc.created, 0).UnixNano(),
+			Payload:  []byte(`[{"n":"temperature","v":35}]`),
+		}
+		err := svc.Consume(msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.ElementsMatch(t, tc.notified, notifier.Notified(), fmt.Sprintf("%s: got unexpected notified contacts\n", tc.desc))
+	}
+}
+
+func senmlCBOR(t *testing.T, temperature float64) string {
+	pack := mfsenml.Pack{Records: []mfsenml.Record{{Name: "temperature", Value: &temperature}}}
+	payload, err := mfsenml.Encode(pack, mfsenml.CBOR)
+	require.Nil(t, err, fmt.Sprintf("unexpected error encoding SenML CBOR: %s", err))
+	return string(payload)
+}
+
+func TestConsumeTemplates(t *testing.T) {
+	repo := mocks.NewRepo(make(map[string]notifiers.Subscription))
+	auth := mocks.NewAuth(map[string]string{exampleUser1: exampleUser1})
+	notifier := mocks.NewNotifier()
+	svc := notifiers.New(auth, mocks.NewThingsService(owners), repo, mocks.NewNotificationsRepo(), uuid.NewMock(), notifier)
+
+	subs := []notifiers.Subscription{
+		{
+			Contact:  "records@example.com",
+			Topic:    "topic.subtopic",
+			Template: "{{.Channel}}:{{range .Records}} {{.Name}}={{.Value}}{{end}}",
+		},
+		{
+			Contact:  "payload@example.com",
+			Topic:    "topic.subtopic",
+			Template: "{{.Publisher}} sent {{.Payload}}",
+		},
+		{
+			Contact: "default@example.com",
+			Topic:   "topic.subtopic",
+		},
+	}
+	for _, sub := range subs {
+		_, err := svc.CreateSubscription(context.Background(), exampleUser1, sub)
+		require.Nil(t, err, "Saving a Subscription must succeed")
+	}
+
+	msg := messaging.Message{
+		Channel:   "topic",
+		Subtopic:  "subtopic",
+		Publisher: "publisher",
+		Payload:   []byte(`[{"n":"temperature","v":25},{"n":"humidity","v":40}]`),
+	}
+	err := svc.Consume(msg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		contact string
+		content string
+	}{
+		{
+			desc:    "notify with records template",
+			contact: "records@example.com",
+			content: "topic: temperature=25 humidity=40",
+		},
+		{
+			desc:    "notify with payload template",
+			contact: "payload@example.com",
+			content: fmt.Sprintf("publisher sent %s", msg.Payload),
+		},
+		{
+			desc:    "notify without template",
+			contact: "default@example.com",
+			content: "",
+		},
+	}
+
+	for _, tc := range cases {
+		content := notifier.Content(tc.contact)
+		assert.Equal(t, tc.content, content, fmt.Sprintf("%s: expected content %s got %s\n", tc.desc, tc.content, content))
+	}
+}
+
+func TestConsumeSecrets(t *testing.T) {
+	repo := mocks.NewRepo(make(map[string]notifiers.Subscription))
+	auth := mocks.NewAuth(map[string]string{exampleUser1: exampleUser1})
+	notifier := mocks.NewNotifier()
+	svc := notifiers.New(auth, mocks.NewThingsService(owners), repo, mocks.NewNotificationsRepo(), uuid.NewMock(), notifier)
+
+	subs := []notifiers.Subscription{
+		{
+			Contact: "http://example.com/signed",
+			Topic:   "topic",
+			Secret:  "secret",
+		},
+		{
+			Contact: "http://example.com/default",
+			Topic:   "topic",
+		},
+	}
+	for _, sub := range subs {
+		_, err := svc.CreateSubscription(context.Background(), exampleUser1, sub)
+		require.Nil(t, err, "Saving a Subscription must succeed")
+	}
+
+	err := svc.Consume(messaging.Message{Channel: "topic", Payload: []byte(`{}`)})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		contact string
+		secret  string
+	}{
+		{
+			desc:    "notify with subscription secret",
+			contact: "http://example.com/signed",
+			secret:  "secret",
+		},
+		{
+			desc:    "notify without subscription secret",
+			contact: "http://example.com/default",
+			secret:  "",
+		},
+	}
+
+	for _, tc := range cases {
+		secret := notifier.Secret(tc.contact)
+		assert.Equal(t, tc.secret, secret, fmt.Sprintf("%s: expected secret %s got %s\n", tc.desc, tc.secret, secret))
+	}
+}
+
+func TestListNotifications(t *testing.T) {
+	svc := newService()
+
+	sent := notifiers.Subscription{Contact: exampleUser1, Topic: "topic.subtopic"}
+	sentID, err := svc.CreateSubscription(context.Background(), exampleUser1, sent)
+	require.Nil(t, err, "Saving a Subscription must succeed")
+	failed := notifiers.Subscription{Contact: invalidUser, Topic: "topic.subtopic"}
+	failedID, err := svc.CreateSubscription(context.Background(), exampleUser1, failed)
+	require.Nil(t, err, "Saving a Subscription must succeed")
+	tmpl := notifiers.Subscription{Contact: exampleUser2, Topic: "topic.subtopic", Template: "{{.Missing}}"}
+	tmplID, err := svc.CreateSubscription(context.Background(), exampleUser1, tmpl)
+	require.Nil(t, err, "Saving a Subscription must succeed")
+	interval := notifiers.Subscription{Contact: exampleAdmin, Topic: "topic.subtopic", MinInterval: time.Hour}
+	intervalID, err := svc.CreateSubscription(context.Background(), exampleUser1, interval)
+	require.Nil(t, err, "Saving a Subscription must succeed")
+
+	msg := messaging.Message{Channel: "topic", Subtopic: "subtopic"}
+	for i := 0; i < 3; i++ {
+		err := svc.Consume(msg)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		offset uint
+		limit  uint
+		total  uint
+		size   int
+		status string
+		err    error
+	}{
+		{
+			desc:   "list sent notifications",
+			token:  exampleUser1,
+			id:     sentID,
+			limit:  10,
+			total:  3,
+			size:   3,
+			status: notifiers.StatusSent,
+		},
+		{
+			desc:   "list failed notifications",
+			token:  exampleUser1,
+			id:     failedID,
+			offset: 1,
+			limit:  10,
+			total:  3,
+			size:   2,
+			status: notifiers.StatusFailed,
+		},
+		{
+			desc:   "list notifications with invalid template",
+			token:  exampleUser1,
+			id:     tmplID,
+			limit:  1,
+			total:  3,
+			size:   1,
+			status: notifiers.StatusFailed,
+		},
+		{
+			desc:   "list skipped notifications",
+			token:  exampleUser1,
+			id:     intervalID,
+			limit:  1,
+			total:  2,
+			size:   1,
+			status: notifiers.StatusSkipped,
+		},
+		{
+			desc:  "list notifications as admin",
+			token: exampleAdmin,
+			id:    sentID,
+			limit: 10,
+			total: 3,
+			size:  3,
+		},
+		{
+			desc:  "list notifications of another user",
+			token: exampleUser2,
+			id:    sentID,
+			limit: 10,
+			err:   notifiers.ErrNotFound,
+		},
+		{
+			desc:  "list notifications of non-existing subscription",
+			token: exampleUser1,
+			id:    "not_exist",
+			limit: 10,
+			err:   notifiers.ErrNotFound,
+		},
+		{
+			desc:  "list notifications with unauthorized access",
+			token: "",
+			id:    sentID,
+			limit: 10,
+			err:   notifiers.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListNotifications(context.Background(), tc.token, tc.id, tc.offset, tc.limit)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		assert.Len(t, page.Notifications, tc.size, fmt.Sprintf("%s: expected %d notifications got %d\n", tc.desc, tc.size, len(page.Notifications)))
+		for _, n := range page.Notifications {
+			if tc.status != "" {
+				assert.Equal(t, tc.status, n.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, n.Status))
+			}
+			assert.Equal(t, n.Status != notifiers.StatusSent, n.Error != "", fmt.Sprintf("%s: expected error only for failed and skipped notifications\n", tc.desc))
+		}
+	}
+}
+
+func TestStartPruning(t *testing.T) {
+	notifs := mocks.NewNotificationsRepo()
+	old := notifiers.Notification{ID: "old", SubscriptionID: "sub", Status: notifiers.StatusSent, Created: time.Now().Add(-2 * time.Hour)}
+	recent := notifiers.Notification{ID: "recent", SubscriptionID: "sub", Status: notifiers.StatusSent, Created: time.Now()}
+	for _, n := range []notifiers.Notification{old, recent} {
+		err := notifs.Save(context.Background(), n)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	logs, err := logger.New(os.Stdout, "error")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	stop := notifiers.StartPruning(notifs, time.Hour, 10*time.Millisecond, logs)
+	assert.Eventually(t, func() bool {
+		page, err := notifs.RetrieveAll(context.Background(), "sub", 0, 10)
+		return err == nil && page.Total == 1 && page.Notifications[0].ID == recent.ID
+	}, time.Second, 10*time.Millisecond, "expected old notifications to be pruned in the background")
+	stop()
+}
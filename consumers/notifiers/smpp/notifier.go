@@ -0,0 +1,362 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package smpp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"text/template"
+	"time"
+	"unicode/utf16"
+
+	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// DefaultTemplate is the text of the SMS unless configured otherwise.
+const DefaultTemplate = `A publisher with an id {{.Publisher}} sent the message over {{.Protocol}} with the following values {{.Payload}}`
+
+var (
+	errTemplate      = errors.New("failed to parse SMS template")
+	errMessage       = errors.New("failed to create SMS text")
+	errMessageSize   = errors.New("SMS text exceeds the maximum PDU length")
+	errBind          = errors.New("failed to bind to SMSC")
+	errSubmit        = errors.New("failed to submit SMS")
+	errSessionClosed = errors.New("session closed by SMSC")
+	errTimeout       = errors.New("SMSC response timed out")
+	errResponse      = errors.New("unexpected SMSC response")
+	errStatus        = errors.New("SMSC response with error status")
+)
+
+// Config represents the SMPP notifier configuration.
+type Config struct {
+	// Address of the SMSC, as host:port.
+	Address    string
+	Username   string
+	Password   string
+	SystemType string
+
+	// SourceAddr is the sender address, used unless the sender is given
+	// when notifying.
+	SourceAddr string
+	SourceTON  uint8
+	SourceNPI  uint8
+	DestTON    uint8
+	DestNPI    uint8
+
+	// Template is the text/template of the SMS text. It's executed with
+	// the message, whose payload is given as string.
+	Template string
+
+	// Timeout of connecting and of waiting for the SMSC response.
+	Timeout time.Duration
+
+	// EnquireLink is the interval of the enquire links sent to the SMSC to
+	// keep the session open. Enquire links are not sent if it's zero.
+	EnquireLink time.Duration
+}
+
+// text holds the message values available to the SMS template.
+type text struct {
+	Channel   string
+	Subtopic  string
+	Publisher string
+	Protocol  string
+	Created   int64
+	Payload   string
+}
+
+var _ notifiers.Notifier = (*notifier)(nil)
+
+type notifier struct {
+	cfg  Config
+	tmpl *template.Template
+
+	mu   sync.Mutex
+	sess *session
+	seq  uint32
+}
+
+// New instantiates SMPP message notifier, which sends the messages as SMS
+// to the phone numbers of the subscriptions. The notifier binds to the
+// SMSC as transmitter on the first notification, and keeps the session
+// open until it fails.
+func New(cfg Config) (notifiers.Notifier, error) {
+	if cfg.Template == "" {
+		cfg.Template = DefaultTemplate
+	}
+	tmpl, err := template.New("sms").Parse(cfg.Template)
+	if err != nil {
+		return nil, errors.Wrap(errTemplate, err)
+	}
+
+	return &notifier{cfg: cfg, tmpl: tmpl}, nil
+}
+
+func (n *notifier) Notify(from string, to []string, msg messaging.Message, content string) error {
+	if content == "" {
+		var buf bytes.Buffer
+		data := text{
+			Channel:   msg.Channel,
+			Subtopic:  msg.Subtopic,
+			Publisher: msg.Publisher,
+			Protocol:  msg.Protocol,
+			Created:   msg.Created,
+			Payload:   string(msg.Payload),
+		}
+		if err := n.tmpl.Execute(&buf, data); err != nil {
+			return errors.Wrap(errMessage, err)
+		}
+		content = buf.String()
+	}
+	coding, sm := encode(content)
+
+	if from == "" {
+		from = n.cfg.SourceAddr
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, dest := range to {
+		s := Submit{
+			SourceTON:  n.cfg.SourceTON,
+			SourceNPI:  n.cfg.SourceNPI,
+			Source:     from,
+			DestTON:    n.cfg.DestTON,
+			DestNPI:    n.cfg.DestNPI,
+			Dest:       dest,
+			DataCoding: coding,
+			Message:    sm,
+		}
+		body := s.body()
+		if headerLen+len(body) > maxPDULen {
+			return errors.Wrap(errSubmit, errMessageSize)
+		}
+		if err := n.submit(body); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// submit submits the message, binding the session first unless it's open.
+// The session which turns out to be stale, because the SMSC or the network
+// dropped it while the notifier was idle, is bound again and the message is
+// submitted once more. Messages whose response timed out are not submitted
+// again, since the SMSC may have accepted them.
+func (n *notifier) submit(body []byte) error {
+	reused := n.sess != nil
+	if err := n.bind(); err != nil {
+		return errors.Wrap(errBind, err)
+	}
+
+	_, err := n.call(SubmitSM, body)
+	if err != nil && reused && n.sess == nil && !errors.Contains(err, errTimeout) {
+		if err := n.bind(); err != nil {
+			return errors.Wrap(errBind, err)
+		}
+		_, err = n.call(SubmitSM, body)
+	}
+	if err != nil {
+		return errors.Wrap(errSubmit, err)
+	}
+
+	return nil
+}
+
+// bind opens the transmitter session unless it's already open.
+func (n *notifier) bind() error {
+	if n.sess != nil {
+		return nil
+	}
+
+	conn, err := net.DialTimeout("tcp", n.cfg.Address, n.cfg.Timeout)
+	if err != nil {
+		return err
+	}
+	n.sess = newSession(conn, n.cfg.Timeout)
+
+	if _, err := n.call(BindTransmitter, bindBody(n.cfg.Username, n.cfg.Password, n.cfg.SystemType)); err != nil {
+		n.close()
+		return err
+	}
+	if n.cfg.EnquireLink > 0 {
+		go n.enquireLink(n.sess)
+	}
+
+	return nil
+}
+
+// enquireLink sends the enquire links over the session until it's closed,
+// so that neither the SMSC nor the network drop the idle session.
+func (n *notifier) enquireLink(s *session) {
+	ticker := time.NewTicker(n.cfg.EnquireLink)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			n.mu.Lock()
+			if n.sess == s {
+				n.call(EnquireLink, nil)
+			}
+			n.mu.Unlock()
+		}
+	}
+}
+
+// call sends the request and waits for its response. The session is closed
+// if the request or the response fails, so that the next notification binds
+// again, while the error responses of the SMSC keep the session open.
+func (n *notifier) call(id uint32, body []byte) (PDU, error) {
+	s := n.sess
+	n.seq++
+	req := PDU{ID: id, Sequence: n.seq, Body: body}
+	if err := s.write(req); err != nil {
+		n.close()
+		return PDU{}, err
+	}
+
+	var timeout <-chan time.Time
+	if n.cfg.Timeout > 0 {
+		timer := time.NewTimer(n.cfg.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for {
+		select {
+		case resp := <-s.resps:
+			switch {
+			case resp.Sequence != req.Sequence:
+				continue
+			case resp.ID == GenericNack, resp.ID != id|GenericNack:
+				return PDU{}, errors.Wrap(errResponse, errors.New(fmt.Sprintf("0x%08x with status 0x%08x", resp.ID, resp.Status)))
+			case resp.Status != 0:
+				return PDU{}, errors.Wrap(errStatus, errors.New(fmt.Sprintf("0x%08x", resp.Status)))
+			default:
+				return resp, nil
+			}
+		case <-s.done:
+			n.close()
+			return PDU{}, s.err
+		case <-timeout:
+			n.close()
+			return PDU{}, errTimeout
+		}
+	}
+}
+
+// close closes the session unless it's already closed.
+func (n *notifier) close() {
+	if n.sess == nil {
+		return
+	}
+	n.sess.close()
+	n.sess = nil
+}
+
+// session is the SMPP session bound to the SMSC. It reads the PDUs sent by
+// the SMSC as soon as they arrive, answering the enquire links even while
+// the notifier is idle, and passes the responses to the pending request.
+type session struct {
+	conn    net.Conn
+	timeout time.Duration
+	resps   chan PDU
+	done    chan struct{}
+	closed  chan struct{}
+	err     error
+
+	mu sync.Mutex
+}
+
+func newSession(conn net.Conn, timeout time.Duration) *session {
+	s := &session{
+		conn:    conn,
+		timeout: timeout,
+		resps:   make(chan PDU, 1),
+		done:    make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+	go s.read()
+
+	return s
+}
+
+// read reads the PDUs until the session fails or the SMSC closes it. The
+// error it fails with is available once the done channel is closed.
+func (s *session) read() {
+	defer close(s.done)
+
+	for {
+		p, err := ReadPDU(s.conn)
+		if err != nil {
+			s.err = err
+			return
+		}
+
+		switch p.ID {
+		case EnquireLink:
+			if err := s.write(PDU{ID: EnquireLinkResp, Sequence: p.Sequence}); err != nil {
+				s.err = err
+				return
+			}
+		case Unbind:
+			s.write(PDU{ID: UnbindResp, Sequence: p.Sequence})
+			s.err = errSessionClosed
+			return
+		default:
+			select {
+			case s.resps <- p:
+			case <-s.closed:
+				return
+			}
+		}
+	}
+}
+
+func (s *session) close() {
+	close(s.closed)
+	s.conn.Close()
+}
+
+func (s *session) write(p PDU) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.timeout > 0 {
+		s.conn.SetWriteDeadline(time.Now().Add(s.timeout))
+	}
+	return WritePDU(s.conn, p)
+}
+
+// encode returns the data coding and the encoded text. Text that isn't
+// ASCII is encoded as UCS2.
+func encode(s string) (uint8, []byte) {
+	ascii := true
+	for _, r := range s {
+		if r > 0x7f {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return codingDefault, []byte(s)
+	}
+
+	units := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(units))
+	for i, u := range units {
+		binary.BigEndian.PutUint16(b[2*i:], u)
+	}
+	return codingUCS2, b
+}
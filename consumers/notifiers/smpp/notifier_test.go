@@ -0,0 +1,340 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package smpp_test
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/notifiers/smpp"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	username  = "mainflux"
+	password  = "secret"
+	source    = "Mainflux"
+	phone     = "+381600000000"
+	otherPh   = "+381600000001"
+	rejected  = "+381600000002"
+	sourceTON = 5
+	sourceNPI = 0
+	destTON   = 1
+	destNPI   = 1
+
+	statusInvalidPassword = 0x0000000E
+	statusInvalidDest     = 0x0000000B
+
+	// idleSequence is the first sequence number of the enquire links the
+	// simulator sends while the session is idle.
+	idleSequence = 1 << 16
+)
+
+var msg = messaging.Message{
+	Channel:   "channel",
+	Publisher: "publisher",
+	Protocol:  "mqtt",
+	Payload:   []byte(`[{"n":"temperature","v":25}]`),
+}
+
+// simulator is the SMSC which accepts the transmitter binds, and records
+// the submitted messages. Enquire link is sent before each response, and
+// the messages to the rejected number fail.
+type simulator struct {
+	listener net.Listener
+
+	// idle is the time after the response the enquire link is sent in,
+	// unless it's zero.
+	idle time.Duration
+
+	mu        sync.Mutex
+	conns     []net.Conn
+	binds     int
+	submits   []smpp.Submit
+	enquiries int
+	answers   int
+}
+
+func newSimulator(t *testing.T) *simulator {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	s := &simulator{listener: l}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns = append(s.conns, conn)
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *simulator) serve(conn net.Conn) {
+	defer conn.Close()
+	var wmu sync.Mutex
+	write := func(p smpp.PDU) error {
+		wmu.Lock()
+		defer wmu.Unlock()
+		return smpp.WritePDU(conn, p)
+	}
+
+	for {
+		req, err := smpp.ReadPDU(conn)
+		if err != nil {
+			return
+		}
+		if req.ID == smpp.EnquireLinkResp {
+			s.mu.Lock()
+			if req.Sequence >= idleSequence {
+				s.answers++
+			}
+			s.mu.Unlock()
+			continue
+		}
+		if err := write(smpp.PDU{ID: smpp.EnquireLink, Sequence: 1000 + req.Sequence}); err != nil {
+			return
+		}
+
+		res := smpp.PDU{ID: req.ID | smpp.GenericNack, Sequence: req.Sequence}
+		switch req.ID {
+		case smpp.BindTransmitter:
+			s.mu.Lock()
+			s.binds++
+			s.mu.Unlock()
+			fields := strings.Split(string(req.Body), "\x00")
+			if fields[0] != username || fields[1] != password {
+				res.Status = statusInvalidPassword
+			}
+			res.Body = []byte("simulator\x00")
+		case smpp.SubmitSM:
+			sm, err := smpp.ParseSubmit(req.Body)
+			if err != nil || sm.Dest == rejected {
+				res.Status = statusInvalidDest
+				break
+			}
+			s.mu.Lock()
+			s.submits = append(s.submits, sm)
+			res.Body = []byte(fmt.Sprintf("%d\x00", len(s.submits)))
+			s.mu.Unlock()
+		case smpp.EnquireLink:
+			s.mu.Lock()
+			s.enquiries++
+			s.mu.Unlock()
+		default:
+			res.ID = smpp.GenericNack
+		}
+		if err := write(res); err != nil {
+			return
+		}
+
+		if s.idle > 0 {
+			time.AfterFunc(s.idle, func() {
+				write(smpp.PDU{ID: smpp.EnquireLink, Sequence: idleSequence + req.Sequence})
+			})
+		}
+	}
+}
+
+func (s *simulator) sent() (int, []smpp.Submit) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.binds, append([]smpp.Submit{}, s.submits...)
+}
+
+func (s *simulator) enquired() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enquiries, s.answers
+}
+
+// drop closes the sessions, as if the SMSC dropped them while idle.
+func (s *simulator) drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+func newConfig(address string) smpp.Config {
+	return smpp.Config{
+		Address:    address,
+		Username:   username,
+		Password:   password,
+		SourceAddr: source,
+		SourceTON:  sourceTON,
+		SourceNPI:  sourceNPI,
+		DestTON:    destTON,
+		DestNPI:    destNPI,
+		Timeout:    time.Second,
+	}
+}
+
+func TestNotify(t *testing.T) {
+	sim := newSimulator(t)
+	defer sim.listener.Close()
+
+	n, err := smpp.New(newConfig(sim.listener.Addr().String()))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = n.Notify("", []string{phone, otherPh}, msg, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	text := fmt.Sprintf("A publisher with an id %s sent the message over %s with the following values %s", msg.Publisher, msg.Protocol, msg.Payload)
+	want := []smpp.Submit{
+		{SourceTON: sourceTON, SourceNPI: sourceNPI, Source: source, DestTON: destTON, DestNPI: destNPI, Dest: phone, Message: []byte(text)},
+		{SourceTON: sourceTON, SourceNPI: sourceNPI, Source: source, DestTON: destTON, DestNPI: destNPI, Dest: otherPh, Message: []byte(text)},
+	}
+	binds, got := sim.sent()
+	assert.Equal(t, 1, binds, fmt.Sprintf("expected single bind got %d", binds))
+	assert.Equal(t, want, got, fmt.Sprintf("expected %v got %v", want, got))
+
+	err = n.Notify("Sender", []string{phone}, msg, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	binds, got = sim.sent()
+	assert.Equal(t, 1, binds, fmt.Sprintf("expected session to be reused got %d binds", binds))
+	assert.Equal(t, "Sender", got[2].Source, fmt.Sprintf("expected given sender got %s", got[2].Source))
+
+	err = n.Notify("", []string{phone}, msg, "Temperature alert")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, got = sim.sent()
+	assert.Equal(t, "Temperature alert", string(got[3].Message), fmt.Sprintf("expected given content got %s", got[3].Message))
+}
+
+func TestNotifyTemplate(t *testing.T) {
+	sim := newSimulator(t)
+	defer sim.listener.Close()
+
+	long := strings.Repeat("a", 300)
+	cases := []struct {
+		desc   string
+		tmpl   string
+		coding uint8
+		text   []byte
+	}{
+		{
+			desc: "notify with custom template",
+			tmpl: "Alert on {{.Channel}}: {{.Payload}}",
+			text: []byte(fmt.Sprintf("Alert on %s: %s", msg.Channel, msg.Payload)),
+		},
+		{
+			desc:   "notify with text that isn't ASCII",
+			tmpl:   "Ćao",
+			coding: 0x08,
+			text:   []byte{0x01, 0x06, 0x00, 'a', 0x00, 'o'},
+		},
+		{
+			desc: "notify with long text",
+			tmpl: long,
+			text: []byte(long),
+		},
+	}
+
+	for i, tc := range cases {
+		cfg := newConfig(sim.listener.Addr().String())
+		cfg.Template = tc.tmpl
+		n, err := smpp.New(cfg)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		err = n.Notify("", []string{phone}, msg, "")
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		_, got := sim.sent()
+		require.Len(t, got, i+1, fmt.Sprintf("%s: expected message to be sent", tc.desc))
+		assert.Equal(t, tc.coding, got[i].DataCoding, fmt.Sprintf("%s: expected coding %d got %d", tc.desc, tc.coding, got[i].DataCoding))
+		assert.Equal(t, tc.text, got[i].Message, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.text, got[i].Message))
+	}
+
+	_, err := smpp.New(smpp.Config{Template: "{{.Channel"})
+	assert.NotNil(t, err, "expected error for malformed template")
+}
+
+func TestNotifyFailure(t *testing.T) {
+	sim := newSimulator(t)
+	defer sim.listener.Close()
+
+	cfg := newConfig(sim.listener.Addr().String())
+	cfg.Password = "invalid"
+	n, err := smpp.New(cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = n.Notify("", []string{phone}, msg, "")
+	assert.NotNil(t, err, "expected error for invalid credentials")
+
+	n, err = smpp.New(newConfig(sim.listener.Addr().String()))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = n.Notify("", []string{rejected}, msg, "")
+	assert.NotNil(t, err, "expected error for rejected message")
+
+	// The session is kept after the message is rejected.
+	err = n.Notify("", []string{phone}, msg, "")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	binds, got := sim.sent()
+	assert.Equal(t, 2, binds, fmt.Sprintf("expected 2 binds got %d", binds))
+	assert.Len(t, got, 1, fmt.Sprintf("expected single message got %d", len(got)))
+
+	err = n.Notify("", []string{phone}, msg, strings.Repeat("a", 64*1024))
+	assert.NotNil(t, err, "expected error for text exceeding the maximum PDU length")
+	_, got = sim.sent()
+	assert.Len(t, got, 1, fmt.Sprintf("expected single message got %d", len(got)))
+
+	n, err = smpp.New(newConfig("127.0.0.1:1"))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = n.Notify("", []string{phone}, msg, "")
+	assert.NotNil(t, err, "expected error for unavailable SMSC")
+}
+
+func TestNotifyStaleSession(t *testing.T) {
+	sim := newSimulator(t)
+	defer sim.listener.Close()
+
+	n, err := smpp.New(newConfig(sim.listener.Addr().String()))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = n.Notify("", []string{phone}, msg, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// The message is submitted again once the dropped session is bound
+	// again.
+	sim.drop()
+	err = n.Notify("", []string{phone}, msg, "")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	binds, got := sim.sent()
+	assert.Equal(t, 2, binds, fmt.Sprintf("expected 2 binds got %d", binds))
+	assert.Len(t, got, 2, fmt.Sprintf("expected 2 messages got %d", len(got)))
+}
+
+func TestEnquireLink(t *testing.T) {
+	sim := newSimulator(t)
+	sim.idle = 50 * time.Millisecond
+	defer sim.listener.Close()
+
+	cfg := newConfig(sim.listener.Addr().String())
+	cfg.EnquireLink = 50 * time.Millisecond
+	n, err := smpp.New(cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = n.Notify("", []string{phone}, msg, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// Enquire links are sent and answered while the notifier is idle.
+	time.Sleep(300 * time.Millisecond)
+	enquiries, answers := sim.enquired()
+	assert.NotZero(t, enquiries, "expected enquire links to be sent while idle")
+	assert.NotZero(t, answers, "expected enquire links to be answered while idle")
+
+	err = n.Notify("", []string{phone}, msg, "")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	binds, _ := sim.sent()
+	assert.Equal(t, 1, binds, fmt.Sprintf("expected session to be kept got %d binds", binds))
+}
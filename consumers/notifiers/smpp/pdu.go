@@ -0,0 +1,232 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package smpp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// SMPP v3.4 command IDs.
+const (
+	GenericNack         uint32 = 0x80000000
+	BindTransmitter     uint32 = 0x00000002
+	BindTransmitterResp uint32 = 0x80000002
+	SubmitSM            uint32 = 0x00000004
+	SubmitSMResp        uint32 = 0x80000004
+	Unbind              uint32 = 0x00000006
+	UnbindResp          uint32 = 0x80000006
+	EnquireLink         uint32 = 0x00000015
+	EnquireLinkResp     uint32 = 0x80000015
+)
+
+const (
+	headerLen        = 16
+	maxPDULen        = 64 * 1024
+	interfaceVersion = 0x34
+
+	// Messages longer than maxShortMessage are sent in the message_payload
+	// optional parameter.
+	maxShortMessage   = 254
+	tagMessagePayload = 0x0424
+
+	codingDefault = 0x00
+	codingUCS2    = 0x08
+)
+
+var (
+	errPDULength       = errors.New("invalid PDU length")
+	errMalformedSubmit = errors.New("malformed submit_sm")
+)
+
+// PDU represents the SMPP protocol data unit.
+type PDU struct {
+	ID       uint32
+	Status   uint32
+	Sequence uint32
+	Body     []byte
+}
+
+// ReadPDU reads the PDU from the reader.
+func ReadPDU(r io.Reader) (PDU, error) {
+	var hdr [headerLen]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return PDU{}, err
+	}
+
+	n := binary.BigEndian.Uint32(hdr[0:4])
+	if n < headerLen || n > maxPDULen {
+		return PDU{}, errPDULength
+	}
+	p := PDU{
+		ID:       binary.BigEndian.Uint32(hdr[4:8]),
+		Status:   binary.BigEndian.Uint32(hdr[8:12]),
+		Sequence: binary.BigEndian.Uint32(hdr[12:16]),
+		Body:     make([]byte, n-headerLen),
+	}
+	if _, err := io.ReadFull(r, p.Body); err != nil {
+		return PDU{}, err
+	}
+
+	return p, nil
+}
+
+// WritePDU writes the PDU to the writer.
+func WritePDU(w io.Writer, p PDU) error {
+	b := make([]byte, headerLen, headerLen+len(p.Body))
+	binary.BigEndian.PutUint32(b[0:4], uint32(headerLen+len(p.Body)))
+	binary.BigEndian.PutUint32(b[4:8], p.ID)
+	binary.BigEndian.PutUint32(b[8:12], p.Status)
+	binary.BigEndian.PutUint32(b[12:16], p.Sequence)
+	_, err := w.Write(append(b, p.Body...))
+	return err
+}
+
+// Submit represents the fields of the submit_sm PDU set by the notifier.
+type Submit struct {
+	SourceTON  uint8
+	SourceNPI  uint8
+	Source     string
+	DestTON    uint8
+	DestNPI    uint8
+	Dest       string
+	DataCoding uint8
+	Message    []byte
+}
+
+// body encodes the submit_sm body. Fields that aren't set by the notifier
+// use the SMSC defaults.
+func (s Submit) body() []byte {
+	var buf bytes.Buffer
+	writeCString(&buf, "") // service_type
+	buf.WriteByte(s.SourceTON)
+	buf.WriteByte(s.SourceNPI)
+	writeCString(&buf, s.Source)
+	buf.WriteByte(s.DestTON)
+	buf.WriteByte(s.DestNPI)
+	writeCString(&buf, s.Dest)
+	buf.Write([]byte{0, 0, 0}) // esm_class, protocol_id, priority_flag
+	writeCString(&buf, "")     // schedule_delivery_time
+	writeCString(&buf, "")     // validity_period
+	buf.Write([]byte{0, 0})    // registered_delivery, replace_if_present_flag
+	buf.WriteByte(s.DataCoding)
+	buf.WriteByte(0) // sm_default_msg_id
+
+	if len(s.Message) <= maxShortMessage {
+		buf.WriteByte(uint8(len(s.Message)))
+		buf.Write(s.Message)
+		return buf.Bytes()
+	}
+
+	buf.WriteByte(0)
+	var tlv [4]byte
+	binary.BigEndian.PutUint16(tlv[0:2], tagMessagePayload)
+	binary.BigEndian.PutUint16(tlv[2:4], uint16(len(s.Message)))
+	buf.Write(tlv[:])
+	buf.Write(s.Message)
+
+	return buf.Bytes()
+}
+
+// ParseSubmit decodes the submit_sm body.
+func ParseSubmit(body []byte) (Submit, error) {
+	r := bufio.NewReader(bytes.NewReader(body))
+	var s Submit
+	var err error
+	fail := func() (Submit, error) {
+		return Submit{}, errors.Wrap(errMalformedSubmit, err)
+	}
+
+	if _, err = readCString(r); err != nil {
+		return fail()
+	}
+	if s.SourceTON, err = r.ReadByte(); err != nil {
+		return fail()
+	}
+	if s.SourceNPI, err = r.ReadByte(); err != nil {
+		return fail()
+	}
+	if s.Source, err = readCString(r); err != nil {
+		return fail()
+	}
+	if s.DestTON, err = r.ReadByte(); err != nil {
+		return fail()
+	}
+	if s.DestNPI, err = r.ReadByte(); err != nil {
+		return fail()
+	}
+	if s.Dest, err = readCString(r); err != nil {
+		return fail()
+	}
+	if _, err = io.ReadFull(r, make([]byte, 3)); err != nil {
+		return fail()
+	}
+	for i := 0; i < 2; i++ {
+		if _, err = readCString(r); err != nil {
+			return fail()
+		}
+	}
+	if _, err = io.ReadFull(r, make([]byte, 2)); err != nil {
+		return fail()
+	}
+	if s.DataCoding, err = r.ReadByte(); err != nil {
+		return fail()
+	}
+	if _, err = r.ReadByte(); err != nil {
+		return fail()
+	}
+	n, err := r.ReadByte()
+	if err != nil {
+		return fail()
+	}
+	s.Message = make([]byte, n)
+	if _, err = io.ReadFull(r, s.Message); err != nil {
+		return fail()
+	}
+
+	// Optional parameters follow the short message.
+	for {
+		var tlv [4]byte
+		if _, err = io.ReadFull(r, tlv[:]); err == io.EOF {
+			return s, nil
+		}
+		if err != nil {
+			return fail()
+		}
+		val := make([]byte, binary.BigEndian.Uint16(tlv[2:4]))
+		if _, err = io.ReadFull(r, val); err != nil {
+			return fail()
+		}
+		if binary.BigEndian.Uint16(tlv[0:2]) == tagMessagePayload {
+			s.Message = val
+		}
+	}
+}
+
+func bindBody(systemID, password, systemType string) []byte {
+	var buf bytes.Buffer
+	writeCString(&buf, systemID)
+	writeCString(&buf, password)
+	writeCString(&buf, systemType)
+	buf.Write([]byte{interfaceVersion, 0, 0}) // interface_version, addr_ton, addr_npi
+	writeCString(&buf, "")                    // address_range
+	return buf.Bytes()
+}
+
+func writeCString(buf *bytes.Buffer, s string) {
+	buf.WriteString(s)
+	buf.WriteByte(0)
+}
+
+func readCString(r *bufio.Reader) (string, error) {
+	s, err := r.ReadString(0)
+	if err != nil {
+		return "", err
+	}
+	return s[:len(s)-1], nil
+}
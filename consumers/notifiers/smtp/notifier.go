@@ -27,14 +27,16 @@ func New(agent *email.Agent) notifiers.Notifier {
 	return &notifier{agent: agent}
 }
 
-func (n *notifier) Notify(from string, to []string, msg messaging.Message) error {
+func (n *notifier) Notify(from string, to []string, msg messaging.Message, content string) error {
 	subject := fmt.Sprintf(`Notification for Channel %s`, msg.Channel)
 	if msg.Subtopic != "" {
 		subject = fmt.Sprintf("%s and subtopic %s", subject, msg.Subtopic)
 	}
 
-	values := string(msg.Payload)
-	content := fmt.Sprintf(contentTemplate, msg.Publisher, msg.Protocol, values)
+	if content == "" {
+		values := string(msg.Payload)
+		content = fmt.Sprintf(contentTemplate, msg.Publisher, msg.Protocol, values)
+	}
 
 	return n.agent.Send(to, from, subject, "", content, footer)
 }
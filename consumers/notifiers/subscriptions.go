@@ -3,7 +3,10 @@
 
 package notifiers
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Subscription represents a user Subscription.
 type Subscription struct {
@@ -11,6 +14,28 @@ type Subscription struct {
 	OwnerID string
 	Contact string
 	Topic   string
+
+	// Conditions select the messages that trigger the notification. All of
+	// them have to be met by the SenML records of the message, and the
+	// subscription without conditions matches every message.
+	Conditions []Condition
+
+	// Hysteresis is the margin by which the values have to move back past
+	// the condition values before the subscription is triggered again.
+	Hysteresis float64
+
+	// MinInterval is the minimum interval between notifications.
+	MinInterval time.Duration
+
+	// Template is the text/template of the notification content, executed
+	// over the TemplateData of the message. The notifier formats the
+	// notifications of the subscription without template.
+	Template string
+
+	// Secret is the key the notifiers which sign the notifications, such
+	// as the webhook notifier, use for the subscription. It's never
+	// returned to the users.
+	Secret string
 }
 
 // Page represents page metadata with content.
@@ -27,6 +52,9 @@ type PageMetadata struct {
 	Limit   int
 	Topic   string
 	Contact string
+	// OwnerID limits the page to the subscriptions of the owner. It is
+	// left empty only when listing the subscriptions of all the owners.
+	OwnerID string
 }
 
 // SubscriptionsRepository specifies a Subscription persistence API.
@@ -35,12 +63,14 @@ type SubscriptionsRepository interface {
 	// error response.
 	Save(ctx context.Context, sub Subscription) (string, error)
 
-	// Retrieve retrieves the subscription for the given id.
+	// Retrieve retrieves the subscription for the given ID regardless of its
+	// owner. The access to the subscription is checked by the service.
 	Retrieve(ctx context.Context, id string) (Subscription, error)
 
 	// RetrieveAll retrieves all the subscriptions for the given page metadata.
 	RetrieveAll(ctx context.Context, pm PageMetadata) (Page, error)
 
-	// Remove removes the subscription for the given ID.
+	// Remove removes the subscription for the given ID regardless of its
+	// owner. The access to the subscription is checked by the service.
 	Remove(ctx context.Context, id string) error
 }
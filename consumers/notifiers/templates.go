@@ -0,0 +1,101 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"bytes"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// ErrTemplate indicates the subscription template that fails to parse or
+// execute.
+var ErrTemplate = errors.New("invalid subscription template")
+
+// TemplateData holds the values available to the subscription templates.
+// Records are the SenML records of the message, and are empty for the
+// messages that aren't SenML.
+type TemplateData struct {
+	Channel   string
+	Subtopic  string
+	Publisher string
+	Protocol  string
+	Created   time.Time
+	Payload   string
+	Records   []senml.Message
+}
+
+// ParseTemplate parses the subscription template.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("notification").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(ErrTemplate, err)
+	}
+	return tmpl, nil
+}
+
+// templates caches the parsed templates of the subscriptions, so that they're
+// parsed once rather than for each message.
+type templates struct {
+	mu      sync.Mutex
+	entries map[string]cachedTemplate
+}
+
+type cachedTemplate struct {
+	text string
+	tmpl *template.Template
+}
+
+func newTemplates() *templates {
+	return &templates{entries: map[string]cachedTemplate{}}
+}
+
+// get returns the parsed template of the subscription. The template is
+// parsed again if the text differs from the cached one.
+func (t *templates) get(sub Subscription) (*template.Template, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if e, ok := t.entries[sub.ID]; ok && e.text == sub.Template {
+		return e.tmpl, nil
+	}
+	tmpl, err := ParseTemplate(sub.Template)
+	if err != nil {
+		return nil, err
+	}
+	t.entries[sub.ID] = cachedTemplate{text: sub.Template, tmpl: tmpl}
+
+	return tmpl, nil
+}
+
+func (t *templates) remove(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, id)
+}
+
+// render executes the subscription template over the message and its
+// records.
+func render(tmpl *template.Template, msg messaging.Message, recs []senml.Message) (string, error) {
+	data := TemplateData{
+		Channel:   msg.Channel,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Created:   time.Unix(0, msg.Created),
+		Payload:   string(msg.Payload),
+		Records:   recs,
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(ErrTemplate, err)
+	}
+
+	return buf.String(), nil
+}
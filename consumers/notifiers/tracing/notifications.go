@@ -0,0 +1,58 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveNotificationOp         = "save_notification_op"
+	retrieveAllNotificationsOp = "retrieve_all_notifications_op"
+	removeNotificationsOp      = "remove_notifications_op"
+)
+
+var _ notifiers.NotificationsRepository = (*notifRepositoryMiddleware)(nil)
+
+type notifRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   notifiers.NotificationsRepository
+}
+
+// NewNotifications instantiates a new Notifications repository that
+// tracks request and their latency, and adds spans to context.
+func NewNotifications(repo notifiers.NotificationsRepository, tracer opentracing.Tracer) notifiers.NotificationsRepository {
+	return notifRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (nrm notifRepositoryMiddleware) Save(ctx context.Context, n notifiers.Notification) error {
+	span := createSpan(ctx, nrm.tracer, saveNotificationOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return nrm.repo.Save(ctx, n)
+}
+
+func (nrm notifRepositoryMiddleware) RetrieveAll(ctx context.Context, subID string, offset, limit uint) (notifiers.NotificationsPage, error) {
+	span := createSpan(ctx, nrm.tracer, retrieveAllNotificationsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return nrm.repo.RetrieveAll(ctx, subID, offset, limit)
+}
+
+func (nrm notifRepositoryMiddleware) RemoveBefore(ctx context.Context, before time.Time) error {
+	span := createSpan(ctx, nrm.tracer, removeNotificationsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return nrm.repo.RemoveBefore(ctx, before)
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook contains the domain concept definitions needed to
+// support Mainflux webhook notifications.
+package webhook
@@ -0,0 +1,328 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const (
+	// SignatureHeader is the header of the HMAC-SHA256 signature of the
+	// request timestamp and body, given as sha256=<hex digest>.
+	SignatureHeader = "X-Mainflux-Signature"
+
+	// TimestampHeader is the header of the Unix time in seconds the request
+	// is signed at.
+	TimestampHeader = "X-Mainflux-Timestamp"
+
+	// DefaultTemplate is the body sent to the webhook unless configured
+	// otherwise.
+	DefaultTemplate = `{"channel":{{json .Channel}},"subtopic":{{json .Subtopic}},"publisher":{{json .Publisher}},"protocol":{{json .Protocol}},"created":{{.Created}},"payload":{{json .Payload}}}`
+
+	contentType = "application/json"
+)
+
+var (
+	errTemplate = errors.New("failed to parse webhook body template")
+	errBody     = errors.New("webhook body template must produce valid JSON")
+	errURL      = errors.New("invalid webhook URL")
+	errStatus   = errors.New("webhook responded with error status")
+	errBlocked  = errors.New("webhook address is not allowed")
+)
+
+// blockedNets are the loopback, link-local, private and unspecified
+// networks, which the webhooks aren't posted to unless they're allowed.
+var blockedNets = parseNets(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+// Config represents the webhook notifier configuration.
+type Config struct {
+	// Secret is the key of the HMAC signature of the subscriptions without
+	// their own secret. Requests aren't signed if it's empty.
+	Secret string
+
+	// Template is the text/template of the JSON body. It's executed with
+	// the message, and the json function encodes the values as JSON.
+	Template string
+
+	// Timeout of a single request.
+	Timeout time.Duration
+
+	// Retries is the number of retries of the failed request.
+	Retries uint64
+
+	// Backoff is the initial interval between retries, which doubles after
+	// every retry up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// Allow lists the networks the webhooks are posted to even though
+	// they're loopback, link-local or private networks.
+	Allow []*net.IPNet
+
+	// Deny lists the networks the webhooks aren't posted to, in addition
+	// to the loopback, link-local and private ones. It takes precedence
+	// over Allow.
+	Deny []*net.IPNet
+}
+
+// body holds the message values available to the body template. Payload
+// is embedded as is if it's valid JSON, and as string otherwise.
+type body struct {
+	Channel   string
+	Subtopic  string
+	Publisher string
+	Protocol  string
+	Created   int64
+	Payload   interface{}
+}
+
+var _ notifiers.SigningNotifier = (*notifier)(nil)
+
+type notifier struct {
+	client *http.Client
+	tmpl   *template.Template
+	cfg    Config
+}
+
+// New instantiates webhook message notifier, which posts the messages to
+// the subscription URLs. The addresses the URLs resolve to are checked when
+// connecting, so that the webhooks can't reach the internal services through
+// DNS records or redirects either.
+func New(cfg Config) (notifiers.Notifier, error) {
+	if cfg.Template == "" {
+		cfg.Template = DefaultTemplate
+	}
+	if cfg.MaxBackoff < cfg.Backoff {
+		cfg.MaxBackoff = cfg.Backoff
+	}
+	tmpl, err := template.New("body").Funcs(template.FuncMap{"json": toJSON}).Parse(cfg.Template)
+	if err != nil {
+		return nil, errors.Wrap(errTemplate, err)
+	}
+
+	dialer := &net.Dialer{
+		Timeout: cfg.Timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			return checkAddress(address, cfg.Allow, cfg.Deny)
+		},
+	}
+	// Requests don't go through the proxy, whose address would be checked
+	// instead of the webhook one.
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: cfg.Timeout,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+	}
+
+	return &notifier{
+		client: &http.Client{Timeout: cfg.Timeout, Transport: transport},
+		tmpl:   tmpl,
+		cfg:    cfg,
+	}, nil
+}
+
+// Notify posts the message to each of the URLs, signed with the configured
+// secret. Delivery continues after the failure, and the last error is
+// returned. Non-empty content is posted instead of the configured template
+// body, and has to be valid JSON too.
+func (n *notifier) Notify(_ string, to []string, msg messaging.Message, content string) error {
+	return n.NotifySigned(n.cfg.Secret, to, msg, content)
+}
+
+func (n *notifier) NotifySigned(secret string, to []string, msg messaging.Message, content string) error {
+	b := []byte(content)
+	if content == "" {
+		var err error
+		if b, err = n.body(msg); err != nil {
+			return err
+		}
+	}
+	if !json.Valid(b) {
+		return errBody
+	}
+
+	var ret error
+	for _, u := range to {
+		if err := n.send(u, secret, b); err != nil {
+			ret = errors.Wrap(fmt.Errorf("failed to notify %s", u), err)
+		}
+	}
+
+	return ret
+}
+
+func (n *notifier) body(msg messaging.Message) ([]byte, error) {
+	data := body{
+		Channel:   msg.Channel,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Created:   msg.Created,
+		Payload:   string(msg.Payload),
+	}
+	if json.Valid(msg.Payload) {
+		data.Payload = json.RawMessage(msg.Payload)
+	}
+
+	var buf bytes.Buffer
+	if err := n.tmpl.Execute(&buf, data); err != nil {
+		return nil, errors.Wrap(errBody, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// send posts the body to the URL, and retries with exponential backoff if
+// the request fails or the server responds with 429 or 5xx status. Requests
+// to the addresses which aren't allowed aren't retried.
+func (n *notifier) send(rawURL, secret string, b []byte) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errURL
+	}
+
+	op := func() error {
+		req, err := http.NewRequest(http.MethodPost, u.String(), bytes.NewReader(b))
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		req.Header.Set("Content-Type", contentType)
+		if secret != "" {
+			ts := time.Now().Unix()
+			req.Header.Set(TimestampHeader, strconv.FormatInt(ts, 10))
+			req.Header.Set(SignatureHeader, Sign(secret, ts, b))
+		}
+
+		res, err := n.client.Do(req)
+		if err != nil {
+			if blocked(err) {
+				return backoff.Permanent(errBlocked)
+			}
+			return err
+		}
+		res.Body.Close()
+
+		switch {
+		case res.StatusCode < http.StatusMultipleChoices:
+			return nil
+		case res.StatusCode == http.StatusTooManyRequests, res.StatusCode >= http.StatusInternalServerError:
+			return errors.Wrap(errStatus, fmt.Errorf("status %d", res.StatusCode))
+		default:
+			return backoff.Permanent(errors.Wrap(errStatus, fmt.Errorf("status %d", res.StatusCode)))
+		}
+	}
+
+	eb := backoff.NewExponentialBackOff()
+	eb.InitialInterval = n.cfg.Backoff
+	eb.MaxInterval = n.cfg.MaxBackoff
+	eb.RandomizationFactor = 0
+	eb.MaxElapsedTime = 0
+
+	return backoff.Retry(op, backoff.WithMaxRetries(eb, n.cfg.Retries))
+}
+
+// Sign returns the signature header value of the timestamp and the body,
+// joined by a dot and signed with the secret. Receivers use it to verify the
+// requests, and the timestamp to reject the replayed ones.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(body)
+	return fmt.Sprintf("sha256=%s", hex.EncodeToString(mac.Sum(nil)))
+}
+
+// checkAddress checks whether the webhook can be posted to the IP address.
+// Denied networks take precedence over the allowed ones, which take
+// precedence over the networks blocked by default.
+func checkAddress(address string, allow, deny []*net.IPNet) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errBlocked
+	}
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil, contains(deny, ip):
+		return errBlocked
+	case contains(allow, ip):
+		return nil
+	case contains(blockedNets, ip), ip.IsMulticast():
+		return errBlocked
+	default:
+		return nil
+	}
+}
+
+// blocked checks whether the request failed since the webhook address isn't
+// allowed.
+func blocked(err error) bool {
+	for err != nil {
+		if err == errBlocked {
+			return true
+		}
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return false
+		}
+		err = u.Unwrap()
+	}
+	return false
+}
+
+func contains(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNets(cidrs ...string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,306 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/consumers/notifiers/webhook"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "secret"
+
+var _, loopback, _ = net.ParseCIDR("127.0.0.0/8")
+
+var msg = messaging.Message{
+	Channel:   "channel",
+	Subtopic:  "subtopic",
+	Publisher: "publisher",
+	Protocol:  "http",
+	Created:   1609455600000000000,
+	Payload:   []byte(`{"n":"temperature","v":25}`),
+}
+
+// receiver records the requests, and responds with the given statuses in
+// turn. Once they run out, it responds with 200.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b, _ := ioutil.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.bodies = append(rc.bodies, b)
+	rc.headers = append(rc.headers, r.Header)
+
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (rc *receiver) requests() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.bodies)
+}
+
+func newNotifier(t *testing.T, tmpl string) notifiers.Notifier {
+	n, err := webhook.New(webhook.Config{
+		Secret:     secret,
+		Template:   tmpl,
+		Timeout:    time.Second,
+		Retries:    2,
+		Backoff:    time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+		Allow:      []*net.IPNet{loopback},
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return n
+}
+
+// signature returns the signature of the recorded request signed with the
+// secret, and checks that the request is signed recently.
+func signature(t *testing.T, rc *receiver, i int, secret string) string {
+	ts, err := strconv.ParseInt(rc.headers[i].Get(webhook.TimestampHeader), 10, 64)
+	require.Nil(t, err, fmt.Sprintf("unexpected error parsing timestamp: %s", err))
+	assert.InDelta(t, time.Now().Unix(), ts, 5, "expected recent timestamp")
+	return webhook.Sign(secret, ts, rc.bodies[i])
+}
+
+func TestNotify(t *testing.T) {
+	rc := &receiver{}
+	ts := httptest.NewServer(rc)
+	defer ts.Close()
+
+	n := newNotifier(t, "")
+	err := n.Notify("", []string{ts.URL}, msg, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Equal(t, 1, rc.requests(), "expected single request")
+
+	body := rc.bodies[0]
+	assert.Equal(t, "application/json", rc.headers[0].Get("Content-Type"), "expected JSON content type")
+	assert.Equal(t, signature(t, rc, 0, secret), rc.headers[0].Get(webhook.SignatureHeader), "expected valid signature")
+
+	var got map[string]interface{}
+	err = json.Unmarshal(body, &got)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	want := map[string]interface{}{
+		"channel":   msg.Channel,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"protocol":  msg.Protocol,
+		"created":   float64(msg.Created),
+		"payload":   map[string]interface{}{"n": "temperature", "v": float64(25)},
+	}
+	assert.Equal(t, want, got, fmt.Sprintf("expected body %v got %v", want, got))
+}
+
+func TestNotifySigned(t *testing.T) {
+	rc := &receiver{}
+	ts := httptest.NewServer(rc)
+	defer ts.Close()
+
+	n, ok := newNotifier(t, "").(notifiers.SigningNotifier)
+	require.True(t, ok, "expected signing notifier")
+
+	err := n.NotifySigned("subscription", []string{ts.URL}, msg, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Equal(t, 1, rc.requests(), "expected single request")
+	assert.Equal(t, signature(t, rc, 0, "subscription"), rc.headers[0].Get(webhook.SignatureHeader), "expected signature with subscription secret")
+
+	// Timestamp is signed as well, so the signature of the body doesn't
+	// verify a request sent at another time.
+	ts0, err := strconv.ParseInt(rc.headers[0].Get(webhook.TimestampHeader), 10, 64)
+	require.Nil(t, err, fmt.Sprintf("unexpected error parsing timestamp: %s", err))
+	assert.NotEqual(t, webhook.Sign("subscription", ts0-1, rc.bodies[0]), rc.headers[0].Get(webhook.SignatureHeader), "expected signature of timestamp")
+}
+
+func TestNotifyBlocked(t *testing.T) {
+	rc := &receiver{}
+	ts := httptest.NewServer(rc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc  string
+		allow []*net.IPNet
+		deny  []*net.IPNet
+		err   bool
+	}{
+		{
+			desc: "notify loopback address",
+			err:  true,
+		},
+		{
+			desc:  "notify allowed loopback address",
+			allow: []*net.IPNet{loopback},
+		},
+		{
+			desc:  "notify allowed and denied loopback address",
+			allow: []*net.IPNet{loopback},
+			deny:  []*net.IPNet{loopback},
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		n, err := webhook.New(webhook.Config{
+			Timeout: time.Second,
+			Retries: 2,
+			Backoff: time.Millisecond,
+			Allow:   tc.allow,
+			Deny:    tc.deny,
+		})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		before := rc.requests()
+		err = n.Notify("", []string{ts.URL}, msg, "")
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+		requests := 1
+		if tc.err {
+			requests = 0
+			assert.Contains(t, err.Error(), "webhook address is not allowed", fmt.Sprintf("%s: expected blocked address error got %s", tc.desc, err))
+		}
+		assert.Equal(t, requests, rc.requests()-before, fmt.Sprintf("%s: expected %d requests got %d", tc.desc, requests, rc.requests()-before))
+	}
+}
+
+func TestNotifyContent(t *testing.T) {
+	rc := &receiver{}
+	ts := httptest.NewServer(rc)
+	defer ts.Close()
+
+	n := newNotifier(t, "")
+	content := `{"alert":"temperature"}`
+	err := n.Notify("", []string{ts.URL}, msg, content)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Equal(t, 1, rc.requests(), "expected single request")
+	assert.Equal(t, content, string(rc.bodies[0]), fmt.Sprintf("expected body %s got %s", content, rc.bodies[0]))
+
+	err = n.Notify("", []string{ts.URL}, msg, "temperature")
+	assert.NotNil(t, err, "expected error for content that isn't JSON")
+	assert.Equal(t, 1, rc.requests(), "expected no request for content that isn't JSON")
+}
+
+func TestNotifyTemplate(t *testing.T) {
+	rc := &receiver{}
+	ts := httptest.NewServer(rc)
+	defer ts.Close()
+
+	text := msg
+	text.Payload = []byte("plain text")
+
+	cases := []struct {
+		desc string
+		tmpl string
+		msg  messaging.Message
+		body string
+		err  bool
+	}{
+		{
+			desc: "notify with custom template",
+			tmpl: `{"text":"Alert on {{.Channel}}","data":{{json .Payload}}}`,
+			msg:  msg,
+			body: `{"text":"Alert on channel","data":{"n":"temperature","v":25}}`,
+		},
+		{
+			desc: "notify with payload that isn't JSON",
+			tmpl: `{"data":{{json .Payload}}}`,
+			msg:  text,
+			body: `{"data":"plain text"}`,
+		},
+		{
+			desc: "notify with template that isn't JSON",
+			tmpl: `data: {{.Payload}}`,
+			msg:  msg,
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		before := rc.requests()
+		err := newNotifier(t, tc.tmpl).Notify("", []string{ts.URL}, tc.msg, "")
+		if tc.err {
+			assert.NotNil(t, err, fmt.Sprintf("%s: expected error", tc.desc))
+			assert.Equal(t, before, rc.requests(), fmt.Sprintf("%s: expected no request", tc.desc))
+			continue
+		}
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.JSONEq(t, tc.body, string(rc.bodies[len(rc.bodies)-1]), fmt.Sprintf("%s: unexpected body", tc.desc))
+	}
+
+	_, err := webhook.New(webhook.Config{Template: `{{.Channel`})
+	assert.NotNil(t, err, "expected error for malformed template")
+}
+
+func TestNotifyRetry(t *testing.T) {
+	cases := []struct {
+		desc     string
+		statuses []int
+		requests int
+		err      bool
+	}{
+		{
+			desc:     "notify after server errors",
+			statuses: []int{http.StatusInternalServerError, http.StatusTooManyRequests},
+			requests: 3,
+		},
+		{
+			desc:     "notify with retries exhausted",
+			statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			requests: 3,
+			err:      true,
+		},
+		{
+			desc:     "notify with client error",
+			statuses: []int{http.StatusBadRequest},
+			requests: 1,
+			err:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		rc := &receiver{statuses: tc.statuses}
+		ts := httptest.NewServer(rc)
+
+		err := newNotifier(t, "").Notify("", []string{ts.URL}, msg, "")
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.requests, rc.requests(), fmt.Sprintf("%s: expected %d requests got %d", tc.desc, tc.requests, rc.requests()))
+		ts.Close()
+	}
+}
+
+func TestNotifyMultiple(t *testing.T) {
+	failing := &receiver{statuses: []int{http.StatusNotFound}}
+	fts := httptest.NewServer(failing)
+	defer fts.Close()
+
+	rc := &receiver{}
+	ts := httptest.NewServer(rc)
+	defer ts.Close()
+
+	err := newNotifier(t, "").Notify("", []string{"ftp://example.com", fts.URL, ts.URL}, msg, "")
+	assert.NotNil(t, err, "expected error for failed webhooks")
+	assert.Equal(t, 1, failing.requests(), "expected single request to failing webhook")
+	assert.Equal(t, 1, rc.requests(), "expected webhook to be notified after failures")
+}
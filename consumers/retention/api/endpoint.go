@@ -0,0 +1,134 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+func listPoliciesEndpoint(svc retention.Service, ac mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(adminReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorizeAdmin(ctx, ac, req.token); err != nil {
+			return nil, err
+		}
+
+		policies, err := svc.ListPolicies(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listPoliciesRes{Policies: []policyRes{}}
+		for _, p := range policies {
+			res.Policies = append(res.Policies, policyRes{Channel: p.Channel, Days: p.Days})
+		}
+
+		return res, nil
+	}
+}
+
+func savePolicyEndpoint(svc retention.Service, ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(savePolicyReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorizeOwner(ctx, ac, tc, req.token, req.chanID); err != nil {
+			return nil, err
+		}
+
+		p := retention.Policy{
+			Channel: req.chanID,
+			Days:    req.Days,
+		}
+		if err := svc.SavePolicy(ctx, p); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func removePolicyEndpoint(svc retention.Service, ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policyReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorizeOwner(ctx, ac, tc, req.token, req.chanID); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemovePolicy(ctx, req.chanID); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func pruneEndpoint(svc retention.Service, ac mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(adminReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorizeAdmin(ctx, ac, req.token); err != nil {
+			return nil, err
+		}
+
+		if err := svc.Prune(ctx); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+// authorizeAdmin checks that the token belongs to the member of the
+// authorities.
+func authorizeAdmin(ctx context.Context, ac mainflux.AuthServiceClient, token string) error {
+	user, err := ac.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errUnauthorizedAccess, err)
+	}
+
+	admin, err := auth.IsAdmin(ctx, ac, user.GetId())
+	if err != nil {
+		return errors.Wrap(errPermissionDenied, err)
+	}
+	if !admin {
+		return errPermissionDenied
+	}
+
+	return nil
+}
+
+// authorizeOwner checks that the token belongs to the owner of the channel.
+func authorizeOwner(ctx context.Context, ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient, token, chanID string) error {
+	user, err := ac.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errUnauthorizedAccess, err)
+	}
+
+	req := &mainflux.ChannelOwnerReq{Owner: user.GetEmail(), ChanID: chanID}
+	if _, err := tc.IsChannelOwner(ctx, req); err != nil {
+		return errors.Wrap(errPermissionDenied, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,333 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/consumers/retention/api"
+	"github.com/mainflux/mainflux/consumers/retention/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contentType = "application/json"
+	chanID      = "50e6b371-60ff-45cf-bb52-8200e7cde536"
+	chanID2     = "c6dd35e4-c45f-4f8b-a4f0-b0e2d3d1a1f4"
+	ownerToken  = "owner-token"
+	otherToken  = "other-token"
+	adminToken  = "admin-token"
+	owner       = "owner@example.com"
+	other       = "other@example.com"
+	admin       = "admin@example.com"
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+type policyRes struct {
+	Channel string `json:"channel"`
+	Days    uint64 `json:"days"`
+}
+
+type listRes struct {
+	Policies []policyRes `json:"policies"`
+}
+
+func newServer(svc retention.Service) *httptest.Server {
+	auth := mocks.NewAuth(map[string]string{ownerToken: owner, otherToken: other, adminToken: admin}, admin)
+	things := mocks.NewThingsService(map[string]string{chanID: owner, chanID2: owner})
+	mux := api.MakeHandler(svc, auth, things, bone.New())
+	return httptest.NewServer(mux)
+}
+
+func TestSavePolicy(t *testing.T) {
+	svc := retention.New(mocks.NewRepository(), mocks.NewPruner(""))
+	ts := newServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		chanID      string
+		contentType string
+		token       string
+		body        string
+		status      int
+	}{
+		{
+			desc:        "save policy",
+			chanID:      chanID,
+			contentType: contentType,
+			token:       ownerToken,
+			body:        `{"days":30}`,
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:        "save policy with zero days",
+			chanID:      chanID,
+			contentType: contentType,
+			token:       ownerToken,
+			body:        `{"days":0}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "save policy with negative days",
+			chanID:      chanID,
+			contentType: contentType,
+			token:       ownerToken,
+			body:        `{"days":-1}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "save policy with days overflowing duration",
+			chanID:      chanID,
+			contentType: contentType,
+			token:       ownerToken,
+			body:        fmt.Sprintf(`{"days":%d}`, retention.MaxDays+1),
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "save policy with max days",
+			chanID:      chanID2,
+			contentType: contentType,
+			token:       ownerToken,
+			body:        fmt.Sprintf(`{"days":%d}`, retention.MaxDays),
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:        "save policy without token",
+			chanID:      chanID,
+			contentType: contentType,
+			token:       "",
+			body:        `{"days":30}`,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "save policy with invalid token",
+			chanID:      chanID,
+			contentType: contentType,
+			token:       "invalid",
+			body:        `{"days":30}`,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "save policy of the channel owned by other user",
+			chanID:      chanID,
+			contentType: contentType,
+			token:       otherToken,
+			body:        `{"days":1}`,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "save policy with malformed body",
+			chanID:      chanID,
+			contentType: contentType,
+			token:       ownerToken,
+			body:        `{"days":`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "save policy without content type",
+			chanID:      chanID,
+			contentType: "",
+			token:       ownerToken,
+			body:        `{"days":30}`,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/retention/%s", ts.URL, tc.chanID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	policies, err := svc.ListPolicies(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	expected := []retention.Policy{{Channel: chanID, Days: 30}, {Channel: chanID2, Days: retention.MaxDays}}
+	assert.ElementsMatch(t, expected, policies, fmt.Sprintf("expected %v got %v", expected, policies))
+}
+
+func TestListPolicies(t *testing.T) {
+	svc := retention.New(mocks.NewRepository(), mocks.NewPruner(""))
+	ts := newServer(svc)
+	defer ts.Close()
+
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/retention", ts.URL),
+		token:  otherToken,
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusForbidden, res.StatusCode, fmt.Sprintf("list policies as non-admin: expected status code %d got %d", http.StatusForbidden, res.StatusCode))
+
+	req.token = adminToken
+	res, err = req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	var page listRes
+	err = json.NewDecoder(res.Body).Decode(&page)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, listRes{Policies: []policyRes{}}, page, fmt.Sprintf("expected no policies got %v", page))
+
+	for _, p := range []retention.Policy{{Channel: chanID, Days: 30}, {Channel: chanID2, Days: 7}} {
+		err := svc.SavePolicy(context.Background(), p)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	res, err = req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusOK, res.StatusCode))
+	err = json.NewDecoder(res.Body).Decode(&page)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := []policyRes{{Channel: chanID, Days: 30}, {Channel: chanID2, Days: 7}}
+	assert.ElementsMatch(t, expected, page.Policies, fmt.Sprintf("expected %v got %v", expected, page.Policies))
+}
+
+func TestRemovePolicy(t *testing.T) {
+	svc := retention.New(mocks.NewRepository(), mocks.NewPruner(""))
+	ts := newServer(svc)
+	defer ts.Close()
+
+	err := svc.SavePolicy(context.Background(), retention.Policy{Channel: chanID, Days: 30})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		chanID string
+		token  string
+		status int
+	}{
+		{
+			desc:   "remove policy without token",
+			chanID: chanID,
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove policy of the channel owned by other user",
+			chanID: chanID,
+			token:  otherToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove policy",
+			chanID: chanID,
+			token:  ownerToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove non-existing policy",
+			chanID: chanID2,
+			token:  ownerToken,
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/retention/%s", ts.URL, tc.chanID),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	policies, err := svc.ListPolicies(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, policies, fmt.Sprintf("expected no policies got %v", policies))
+}
+
+func TestPrune(t *testing.T) {
+	cases := []struct {
+		desc   string
+		failed string
+		token  string
+		status int
+	}{
+		{
+			desc:   "prune messages",
+			token:  adminToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "prune messages with failing channel",
+			failed: chanID,
+			token:  adminToken,
+			status: http.StatusInternalServerError,
+		},
+		{
+			desc:   "prune messages without token",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "prune messages as non-admin user",
+			token:  ownerToken,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		pruner := mocks.NewPruner(tc.failed)
+		svc := retention.New(mocks.NewRepository(), pruner)
+		ts := newServer(svc)
+
+		err := svc.SavePolicy(context.Background(), retention.Policy{Channel: chanID, Days: 30})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/retention/prune", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		ts.Close()
+	}
+}
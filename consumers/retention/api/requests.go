@@ -0,0 +1,60 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	errInvalidChannel     = errors.New("invalid or empty channel id")
+	errUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+	errPermissionDenied   = errors.New("not allowed to manage the retention policy")
+)
+
+type adminReq struct {
+	token string
+}
+
+func (req adminReq) validate() error {
+	if req.token == "" {
+		return errUnauthorizedAccess
+	}
+	return nil
+}
+
+type savePolicyReq struct {
+	token  string
+	chanID string
+	Days   uint64 `json:"days"`
+}
+
+func (req savePolicyReq) validate() error {
+	if req.token == "" {
+		return errUnauthorizedAccess
+	}
+	if req.chanID == "" {
+		return errInvalidChannel
+	}
+	if req.Days == 0 || req.Days > retention.MaxDays {
+		return errors.ErrMalformedEntity
+	}
+	return nil
+}
+
+type policyReq struct {
+	token  string
+	chanID string
+}
+
+func (req policyReq) validate() error {
+	if req.token == "" {
+		return errUnauthorizedAccess
+	}
+	if req.chanID == "" {
+		return errInvalidChannel
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/mainflux/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*listPoliciesRes)(nil)
+	_ mainflux.Response = (*emptyRes)(nil)
+)
+
+type policyRes struct {
+	Channel string `json:"channel"`
+	Days    uint64 `json:"days"`
+}
+
+type listPoliciesRes struct {
+	Policies []policyRes `json:"policies"`
+}
+
+func (res listPoliciesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listPoliciesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listPoliciesRes) Empty() bool {
+	return false
+}
+
+type emptyRes struct{}
+
+func (res emptyRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res emptyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res emptyRes) Empty() bool {
+	return true
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
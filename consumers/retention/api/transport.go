@@ -0,0 +1,133 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const contentType = "application/json"
+
+// MakeHandler registers the retention policies API endpoints on the mux.
+// The policy of the channel is managed by the channel owner, while listing
+// all the policies and pruning is allowed to the authorities.
+func MakeHandler(svc retention.Service, ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient, mux *bone.Mux) *bone.Mux {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(encodeError),
+	}
+
+	mux.Get("/retention", kithttp.NewServer(
+		listPoliciesEndpoint(svc, ac),
+		decodeAdmin,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/retention/prune", kithttp.NewServer(
+		pruneEndpoint(svc, ac),
+		decodeAdmin,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Put("/retention/:chanID", kithttp.NewServer(
+		savePolicyEndpoint(svc, ac, tc),
+		decodeSavePolicy,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/retention/:chanID", kithttp.NewServer(
+		removePolicyEndpoint(svc, ac, tc),
+		decodePolicy,
+		encodeResponse,
+		opts...,
+	))
+
+	return mux
+}
+
+func decodeSavePolicy(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	req := savePolicyReq{
+		token:  r.Header.Get("Authorization"),
+		chanID: bone.GetValue(r, "chanID"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodePolicy(_ context.Context, r *http.Request) (interface{}, error) {
+	req := policyReq{
+		token:  r.Header.Get("Authorization"),
+		chanID: bone.GetValue(r, "chanID"),
+	}
+	return req, nil
+}
+
+func decodeAdmin(_ context.Context, r *http.Request) (interface{}, error) {
+	req := adminReq{
+		token: r.Header.Get("Authorization"),
+	}
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch errorVal := err.(type) {
+	case errors.Error:
+		w.Header().Set("Content-Type", contentType)
+		switch {
+		case errors.Contains(errorVal, errors.ErrMalformedEntity),
+			errors.Contains(errorVal, errInvalidChannel),
+			errors.Contains(errorVal, retention.ErrInvalidDays):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, errUnauthorizedAccess):
+			w.WriteHeader(http.StatusUnauthorized)
+		case errors.Contains(errorVal, errPermissionDenied):
+			w.WriteHeader(http.StatusForbidden)
+		case errors.Contains(errorVal, errors.ErrUnsupportedContentType):
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		if errorVal.Msg() != "" {
+			if err := json.NewEncoder(w).Encode(errorRes{Err: errorVal.Msg()}); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package retention contains the domain concept definitions needed to
+// support removal of the stored messages which outlived the retention
+// policies of their channels.
+package retention
@@ -0,0 +1,71 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+var (
+	_ mainflux.AuthServiceClient = (*authServiceMock)(nil)
+
+	errUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+)
+
+type authServiceMock struct {
+	users  map[string]string
+	admins map[string]bool
+}
+
+// NewAuth creates mock of auth service. Users map the tokens to the user
+// IDs, and admins are the IDs of the users that are members of the
+// authorities.
+func NewAuth(users map[string]string, admins ...string) mainflux.AuthServiceClient {
+	svc := &authServiceMock{users: users, admins: make(map[string]bool)}
+	for _, id := range admins {
+		svc.admins[id] = true
+	}
+	return svc
+}
+
+func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	if id, ok := svc.users[in.GetValue()]; ok {
+		return &mainflux.UserIdentity{Id: id, Email: id}, nil
+	}
+	return nil, errUnauthorizedAccess
+}
+
+func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (*mainflux.AuthorizeRes, error) {
+	authorized := req.GetObj() == "authorities" && req.GetAct() == "member" && svc.admins[req.GetSub()]
+	return &mainflux.AuthorizeRes{Authorized: authorized}, nil
+}
+
+func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (*mainflux.MembersRes, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) ListObjects(ctx context.Context, req *mainflux.ListObjectsReq, _ ...grpc.CallOption) (*mainflux.ListObjectsRes, error) {
+	panic("not implemented")
+}
@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var _ retention.Pruner = (*prunerMock)(nil)
+
+// ErrPrune is returned when the channel which the pruner mock fails to
+// prune is pruned.
+var ErrPrune = errors.New("failed to prune channel")
+
+// Pruner is the pruner mock which keeps the times the channels were pruned
+// before.
+type Pruner interface {
+	retention.Pruner
+
+	// Pruned returns the time the channel was last pruned before.
+	Pruned(chanID string) (time.Time, bool)
+}
+
+type prunerMock struct {
+	mu     sync.Mutex
+	failed string
+	pruned map[string]time.Time
+}
+
+// NewPruner returns the pruner mock which fails to prune the given channel.
+func NewPruner(failed string) Pruner {
+	return &prunerMock{
+		failed: failed,
+		pruned: make(map[string]time.Time),
+	}
+}
+
+func (pm *prunerMock) Prune(_ context.Context, chanID string, before time.Time) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if chanID == pm.failed {
+		return ErrPrune
+	}
+	pm.pruned[chanID] = before
+	return nil
+}
+
+func (pm *prunerMock) Pruned(chanID string) (time.Time, bool) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	before, ok := pm.pruned[chanID]
+	return before, ok
+}
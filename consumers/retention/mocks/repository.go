@@ -0,0 +1,57 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+)
+
+var _ retention.Repository = (*repositoryMock)(nil)
+
+type repositoryMock struct {
+	mu       sync.Mutex
+	policies map[string]retention.Policy
+}
+
+// NewRepository returns retention policies repository mock.
+func NewRepository() retention.Repository {
+	return &repositoryMock{
+		policies: make(map[string]retention.Policy),
+	}
+}
+
+func (rm *repositoryMock) Save(_ context.Context, p retention.Policy) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.policies[p.Channel] = p
+	return nil
+}
+
+func (rm *repositoryMock) RetrieveAll(context.Context) ([]retention.Policy, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	policies := []retention.Policy{}
+	for _, p := range rm.policies {
+		policies = append(policies, p)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Channel < policies[j].Channel
+	})
+
+	return policies, nil
+}
+
+func (rm *repositoryMock) Remove(_ context.Context, chanID string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	delete(rm.policies, chanID)
+	return nil
+}
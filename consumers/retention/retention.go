@@ -0,0 +1,36 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"context"
+	"time"
+)
+
+// Policy represents the retention policy of the channel. Messages of the
+// channel are kept for the given number of days.
+type Policy struct {
+	Channel string
+	Days    uint64
+}
+
+// Repository specifies retention policies persistence API.
+type Repository interface {
+	// Save persists the policy, replacing the existing policy of the
+	// channel.
+	Save(ctx context.Context, p Policy) error
+
+	// RetrieveAll retrieves the policies of all the channels.
+	RetrieveAll(ctx context.Context) ([]Policy, error)
+
+	// Remove removes the policy of the channel.
+	Remove(ctx context.Context, chanID string) error
+}
+
+// Pruner specifies the API for removing the stored messages.
+type Pruner interface {
+	// Prune removes the messages of the channel which were created before
+	// the given time, regardless of their format.
+	Prune(ctx context.Context, chanID string, before time.Time) error
+}
@@ -0,0 +1,133 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const day = 24 * time.Hour
+
+// MaxDays is the longest retention period, in days, which can be
+// represented as the duration.
+const MaxDays = uint64(math.MaxInt64 / int64(day))
+
+var (
+	// ErrPrune indicates failure to prune the messages of the channel.
+	ErrPrune = errors.New("failed to prune messages")
+
+	// ErrInvalidDays indicates the retention period which is zero or
+	// longer than MaxDays.
+	ErrInvalidDays = errors.New("retention days must be between 1 and max days")
+)
+
+// Service specifies an API for managing the retention policies and pruning
+// the messages which outlived them.
+type Service interface {
+	// SavePolicy saves the retention policy of the channel. The policy has
+	// to keep the messages for at least one and at most MaxDays days.
+	SavePolicy(ctx context.Context, p Policy) error
+
+	// ListPolicies retrieves the retention policies of all the channels.
+	ListPolicies(ctx context.Context) ([]Policy, error)
+
+	// RemovePolicy removes the retention policy of the channel, so that its
+	// messages are kept forever.
+	RemovePolicy(ctx context.Context, chanID string) error
+
+	// Prune removes the messages which are older than the retention policies
+	// of their channels. Channels are pruned independently, so the failure
+	// to prune one channel doesn't stop pruning of the others.
+	Prune(ctx context.Context) error
+}
+
+var _ Service = (*retentionService)(nil)
+
+type retentionService struct {
+	repo   Repository
+	pruner Pruner
+}
+
+// New instantiates the retention service implementation.
+func New(repo Repository, pruner Pruner) Service {
+	return &retentionService{
+		repo:   repo,
+		pruner: pruner,
+	}
+}
+
+func (svc *retentionService) SavePolicy(ctx context.Context, p Policy) error {
+	if p.Days == 0 || p.Days > MaxDays {
+		return ErrInvalidDays
+	}
+	return svc.repo.Save(ctx, p)
+}
+
+func (svc *retentionService) ListPolicies(ctx context.Context) ([]Policy, error) {
+	return svc.repo.RetrieveAll(ctx)
+}
+
+func (svc *retentionService) RemovePolicy(ctx context.Context, chanID string) error {
+	return svc.repo.Remove(ctx, chanID)
+}
+
+func (svc *retentionService) Prune(ctx context.Context) error {
+	policies, err := svc.repo.RetrieveAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var pruneErr error
+	for _, p := range policies {
+		// Messages are kept forever if the policy is too long to be
+		// represented, which the policies saved through the service are not.
+		if p.Days > MaxDays {
+			continue
+		}
+		before := now.Add(-time.Duration(p.Days) * day)
+		if err := svc.pruner.Prune(ctx, p.Channel, before); err != nil {
+			pruneErr = errors.Wrap(ErrPrune, err)
+		}
+	}
+
+	return pruneErr
+}
+
+// StartPruning prunes the messages in the background with the given
+// interval, until the returned function is called. Failures are logged and
+// the pruning is retried with the next tick.
+func StartPruning(svc Service, interval time.Duration, logger logger.Logger) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := svc.Prune(context.Background()); err != nil {
+					logger.Warn(fmt.Sprintf("Failed to prune messages: %s", err))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
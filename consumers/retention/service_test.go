@@ -0,0 +1,143 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/consumers/retention/mocks"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID  = "50e6b371-60ff-45cf-bb52-8200e7cde536"
+	chanID2 = "c6dd35e4-c45f-4f8b-a4f0-b0e2d3d1a1f4"
+	failed  = "2b7f5c4d-5b8a-4d8e-9b4b-0c4c1c1e3e0a"
+)
+
+func TestPolicies(t *testing.T) {
+	svc := retention.New(mocks.NewRepository(), mocks.NewPruner(""))
+
+	err := svc.SavePolicy(context.Background(), retention.Policy{Channel: chanID, Days: 30})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.SavePolicy(context.Background(), retention.Policy{Channel: chanID2, Days: 7})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// Saving the policy again replaces it.
+	err = svc.SavePolicy(context.Background(), retention.Policy{Channel: chanID, Days: 90})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	policies, err := svc.ListPolicies(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	expected := []retention.Policy{{Channel: chanID, Days: 90}, {Channel: chanID2, Days: 7}}
+	assert.ElementsMatch(t, expected, policies, fmt.Sprintf("expected %v got %v", expected, policies))
+
+	err = svc.RemovePolicy(context.Background(), chanID2)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	policies, err = svc.ListPolicies(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	expected = []retention.Policy{{Channel: chanID, Days: 90}}
+	assert.Equal(t, expected, policies, fmt.Sprintf("expected %v got %v", expected, policies))
+}
+
+func TestSavePolicyDays(t *testing.T) {
+	svc := retention.New(mocks.NewRepository(), mocks.NewPruner(""))
+
+	cases := []struct {
+		desc string
+		days uint64
+		err  error
+	}{
+		{
+			desc: "save policy with one day",
+			days: 1,
+			err:  nil,
+		},
+		{
+			desc: "save policy with max days",
+			days: retention.MaxDays,
+			err:  nil,
+		},
+		{
+			desc: "save policy with zero days",
+			days: 0,
+			err:  retention.ErrInvalidDays,
+		},
+		{
+			desc: "save policy with days overflowing duration",
+			days: retention.MaxDays + 1,
+			err:  retention.ErrInvalidDays,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.SavePolicy(context.Background(), retention.Policy{Channel: chanID, Days: tc.days})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestPrune(t *testing.T) {
+	pruner := mocks.NewPruner(failed)
+	svc := retention.New(mocks.NewRepository(), pruner)
+
+	for _, p := range []retention.Policy{{Channel: chanID, Days: 30}, {Channel: failed, Days: 1}, {Channel: chanID2, Days: 7}} {
+		err := svc.SavePolicy(context.Background(), p)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	start := time.Now()
+	err := svc.Prune(context.Background())
+	assert.True(t, errors.Contains(err, retention.ErrPrune), fmt.Sprintf("expected %s got %s", retention.ErrPrune, err))
+	assert.True(t, errors.Contains(err, mocks.ErrPrune), fmt.Sprintf("expected %s got %s", mocks.ErrPrune, err))
+
+	cases := []struct {
+		desc   string
+		chanID string
+		days   int
+	}{
+		{
+			desc:   "prune channel with 30 days policy",
+			chanID: chanID,
+			days:   30,
+		},
+		{
+			desc:   "prune channel after the channel that failed",
+			chanID: chanID2,
+			days:   7,
+		},
+	}
+
+	for _, tc := range cases {
+		before, ok := pruner.Pruned(tc.chanID)
+		require.True(t, ok, fmt.Sprintf("%s: expected channel to be pruned", tc.desc))
+		expected := start.Add(-time.Duration(tc.days) * 24 * time.Hour)
+		assert.WithinDuration(t, expected, before, time.Second, fmt.Sprintf("%s: expected to prune before %s got %s", tc.desc, expected, before))
+	}
+}
+
+func TestStartPruning(t *testing.T) {
+	pruner := mocks.NewPruner("")
+	svc := retention.New(mocks.NewRepository(), pruner)
+	err := svc.SavePolicy(context.Background(), retention.Policy{Channel: chanID, Days: 1})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	logs, err := logger.New(os.Stdout, "error")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	stop := retention.StartPruning(svc, 10*time.Millisecond, logs)
+	assert.Eventually(t, func() bool {
+		_, ok := pruner.Pruned(chanID)
+		return ok
+	}, time.Second, 10*time.Millisecond, "expected channel to be pruned in the background")
+	stop()
+}
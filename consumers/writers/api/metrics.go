@@ -8,23 +8,28 @@ import (
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
 )
 
 var _ consumers.Consumer = (*metricsMiddleware)(nil)
 
 type metricsMiddleware struct {
-	counter  metrics.Counter
-	latency  metrics.Histogram
-	consumer consumers.Consumer
+	counter   metrics.Counter
+	latency   metrics.Histogram
+	batchSize metrics.Histogram
+	consumer  consumers.Consumer
 }
 
 // MetricsMiddleware returns new message repository
-// with Save method wrapped to expose metrics.
-func MetricsMiddleware(consumer consumers.Consumer, counter metrics.Counter, latency metrics.Histogram) consumers.Consumer {
+// with Save method wrapped to expose metrics. Batch size
+// tracks the number of messages stored per Consume call.
+func MetricsMiddleware(consumer consumers.Consumer, counter metrics.Counter, latency, batchSize metrics.Histogram) consumers.Consumer {
 	return &metricsMiddleware{
-		counter:  counter,
-		latency:  latency,
-		consumer: consumer,
+		counter:   counter,
+		latency:   latency,
+		batchSize: batchSize,
+		consumer:  consumer,
 	}
 }
 
@@ -32,6 +37,18 @@ func (mm *metricsMiddleware) Consume(msgs interface{}) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "consume").Add(1)
 		mm.latency.With("method", "consume").Observe(time.Since(begin).Seconds())
+		mm.batchSize.With("method", "consume").Observe(float64(count(msgs)))
 	}(time.Now())
 	return mm.consumer.Consume(msgs)
 }
+
+func count(msgs interface{}) int {
+	switch m := msgs.(type) {
+	case []senml.Message:
+		return len(m)
+	case json.Messages:
+		return len(m.Data)
+	default:
+		return 1
+	}
+}
@@ -10,12 +10,19 @@ import (
 
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	dlapi "github.com/mainflux/mainflux/consumers/deadletter/api"
+	"github.com/mainflux/mainflux/consumers/retention"
+	retapi "github.com/mainflux/mainflux/consumers/retention/api"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-// MakeHandler returns a HTTP API handler with version and metrics.
-func MakeHandler(svcName string) http.Handler {
+// MakeHandler returns a HTTP API handler with version, metrics, dead
+// letters and retention policies endpoints.
+func MakeHandler(svcName string, ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient, dls deadletter.Service, rs retention.Service) http.Handler {
 	r := bone.New()
+	r = dlapi.MakeHandler(dls, ac, r)
+	r = retapi.MakeHandler(rs, ac, tc, r)
 	r.GetFunc("/version", mainflux.Version(svcName))
 	r.Handle("/metrics", promhttp.Handler())
 
@@ -0,0 +1,227 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package writers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+const defBatchInterval = time.Second
+
+var (
+	_ consumers.Consumer = (*batchConsumer)(nil)
+
+	errClosed = errors.New("batch consumer closed")
+)
+
+// BatchConfig represents the batching configuration.
+type BatchConfig struct {
+	// Size is the number of messages which triggers the flush.
+	Size int
+
+	// Interval is the longest time the messages are kept in the buffer.
+	Interval time.Duration
+
+	// RetryTimeout is the longest time the failed batch is retried for,
+	// before each of its messages is stored separately. Zero retries the
+	// batch until it's stored.
+	RetryTimeout time.Duration
+}
+
+// BatchConsumer wraps consumers Consumer exposing
+// Close() method which flushes the buffered messages.
+type BatchConsumer interface {
+	consumers.Consumer
+	Close()
+}
+
+// pending represents the messages passed to a single Consume call, which
+// waits for the result of storing them.
+type pending struct {
+	msgs interface{}
+	done chan error
+}
+
+type batchConsumer struct {
+	consumer consumers.Consumer
+	cfg      BatchConfig
+	logger   logger.Logger
+
+	mu      sync.Mutex
+	closed  bool
+	size    int
+	pending []pending
+
+	flush chan struct{}
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewBatchConsumer returns the consumer which buffers the SenML and JSON
+// messages and passes them to the consumer in batches, so that they can be
+// stored using bulk inserts. The batch is flushed once it reaches the
+// configured size or once the interval expires, and failed batches are
+// retried with exponential backoff. Consume blocks until the messages are
+// stored and returns the error of storing them, so the messages are never
+// acknowledged before they're stored. Batches are therefore only filled if
+// the messages are consumed concurrently.
+func NewBatchConsumer(consumer consumers.Consumer, cfg BatchConfig, logger logger.Logger) BatchConsumer {
+	if cfg.Size < 1 {
+		cfg.Size = 1
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defBatchInterval
+	}
+
+	bc := &batchConsumer{
+		consumer: consumer,
+		cfg:      cfg,
+		logger:   logger,
+		flush:    make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+
+	bc.wg.Add(1)
+	go bc.run()
+
+	return bc
+}
+
+func (bc *batchConsumer) Consume(messages interface{}) error {
+	var n int
+	switch m := messages.(type) {
+	case []senml.Message:
+		n = len(m)
+	case json.Messages:
+		n = len(m.Data)
+	default:
+		return bc.consumer.Consume(messages)
+	}
+
+	p := pending{
+		msgs: messages,
+		done: make(chan error, 1),
+	}
+
+	bc.mu.Lock()
+	if bc.closed {
+		bc.mu.Unlock()
+		return errClosed
+	}
+	bc.pending = append(bc.pending, p)
+	bc.size += n
+	full := bc.size >= bc.cfg.Size
+	bc.mu.Unlock()
+
+	if full {
+		select {
+		case bc.flush <- struct{}{}:
+		default:
+		}
+	}
+
+	return <-p.done
+}
+
+func (bc *batchConsumer) Close() {
+	bc.mu.Lock()
+	bc.closed = true
+	bc.mu.Unlock()
+
+	close(bc.done)
+	bc.wg.Wait()
+}
+
+func (bc *batchConsumer) run() {
+	defer bc.wg.Done()
+
+	ticker := time.NewTicker(bc.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-bc.flush:
+		case <-ticker.C:
+		case <-bc.done:
+			bc.flushBatch()
+			return
+		}
+		bc.flushBatch()
+	}
+}
+
+func (bc *batchConsumer) flushBatch() {
+	bc.mu.Lock()
+	batch := bc.pending
+	bc.pending, bc.size = nil, 0
+	bc.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	var senmlMsgs []senml.Message
+	jsonMsgs := make(map[string][]json.Message)
+	for _, p := range batch {
+		switch m := p.msgs.(type) {
+		case []senml.Message:
+			senmlMsgs = append(senmlMsgs, m...)
+		case json.Messages:
+			jsonMsgs[m.Format] = append(jsonMsgs[m.Format], m.Data...)
+		}
+	}
+
+	var senmlErr error
+	if len(senmlMsgs) > 0 {
+		senmlErr = bc.consume(senmlMsgs, len(senmlMsgs))
+	}
+	jsonErrs := make(map[string]error)
+	for format, data := range jsonMsgs {
+		jsonErrs[format] = bc.consume(json.Messages{Data: data, Format: format}, len(data))
+	}
+
+	for _, p := range batch {
+		err := senmlErr
+		if m, ok := p.msgs.(json.Messages); ok {
+			err = jsonErrs[m.Format]
+		}
+		if err == nil {
+			p.done <- nil
+			continue
+		}
+
+		// Storing the messages of each Consume call of the failed batch
+		// separately prevents a single malformed message from failing
+		// all of the others.
+		p.done <- bc.consumer.Consume(p.msgs)
+	}
+}
+
+func (bc *batchConsumer) consume(msgs interface{}, n int) error {
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = bc.cfg.RetryTimeout
+
+	op := func() error {
+		return bc.consumer.Consume(msgs)
+	}
+	notify := func(err error, d time.Duration) {
+		bc.logger.Warn(fmt.Sprintf("Failed to consume batch of %d messages, retrying in %s: %s", n, d, err))
+	}
+
+	err := backoff.RetryNotify(op, b, notify)
+	if err != nil {
+		bc.logger.Warn(fmt.Sprintf("Failed to consume batch of %d messages, storing them separately: %s", n, err))
+	}
+
+	return err
+}
@@ -0,0 +1,164 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package writers_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/writers"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	batchSize = 10
+	format    = "some_json"
+)
+
+var (
+	errConsume = errors.New("failed to consume")
+	testLog, _ = log.New(os.Stdout, log.Info.String())
+)
+
+// consumerMock keeps the consumed batches and fails the first fails calls.
+type consumerMock struct {
+	mu      sync.Mutex
+	fails   int
+	batches []interface{}
+}
+
+func (cm *consumerMock) Consume(msgs interface{}) error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if cm.fails > 0 {
+		cm.fails--
+		return errConsume
+	}
+	cm.batches = append(cm.batches, msgs)
+	return nil
+}
+
+func (cm *consumerMock) consumed() []interface{} {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	return append([]interface{}{}, cm.batches...)
+}
+
+func senmlPack(n int) []senml.Message {
+	msgs := []senml.Message{}
+	for i := 0; i < n; i++ {
+		msgs = append(msgs, senml.Message{Channel: "1", Name: fmt.Sprintf("sensor_%d", i)})
+	}
+	return msgs
+}
+
+func TestBatchBySize(t *testing.T) {
+	cm := &consumerMock{}
+	bc := writers.NewBatchConsumer(cm, writers.BatchConfig{Size: batchSize, Interval: time.Hour}, testLog)
+	defer bc.Close()
+
+	errs := consume(bc, senmlPack(2), senmlPack(2), senmlPack(2), senmlPack(2), senmlPack(2))
+	for _, err := range errs {
+		assert.Nil(t, err, fmt.Sprintf("consume SenML pack: expected nil got %s", err))
+	}
+
+	assert.Len(t, cm.consumed(), 1, "expected full batch to be flushed")
+	batch, ok := cm.consumed()[0].([]senml.Message)
+	assert.True(t, ok, "expected batch of SenML messages")
+	assert.Len(t, batch, batchSize, fmt.Sprintf("expected batch of %d messages got %d", batchSize, len(batch)))
+}
+
+func TestBatchByInterval(t *testing.T) {
+	cm := &consumerMock{}
+	bc := writers.NewBatchConsumer(cm, writers.BatchConfig{Size: batchSize, Interval: 50 * time.Millisecond}, testLog)
+	defer bc.Close()
+
+	errs := consume(bc, senmlPack(1), json.Messages{Data: []json.Message{{Channel: "1"}, {Channel: "2"}}, Format: format})
+	for _, err := range errs {
+		assert.Nil(t, err, fmt.Sprintf("consume messages: expected nil got %s", err))
+	}
+
+	assert.Len(t, cm.consumed(), 2, "expected batches to be flushed once interval expires")
+	for _, b := range cm.consumed() {
+		switch batch := b.(type) {
+		case []senml.Message:
+			assert.Len(t, batch, 1, fmt.Sprintf("expected one SenML message got %d", len(batch)))
+		case json.Messages:
+			assert.Equal(t, format, batch.Format, fmt.Sprintf("expected format %s got %s", format, batch.Format))
+			assert.Len(t, batch.Data, 2, fmt.Sprintf("expected two JSON messages got %d", len(batch.Data)))
+		default:
+			t.Errorf("unexpected batch type %T", b)
+		}
+	}
+}
+
+func TestBatchRetry(t *testing.T) {
+	cm := &consumerMock{fails: 2}
+	bc := writers.NewBatchConsumer(cm, writers.BatchConfig{Size: 1, Interval: time.Hour, RetryTimeout: 10 * time.Second}, testLog)
+	defer bc.Close()
+
+	err := bc.Consume(senmlPack(1))
+	assert.Nil(t, err, fmt.Sprintf("consume SenML pack: expected nil got %s", err))
+	assert.Len(t, cm.consumed(), 1, "expected failed batch to be retried")
+}
+
+func TestBatchFailure(t *testing.T) {
+	cm := &consumerMock{fails: math.MaxInt32}
+	bc := writers.NewBatchConsumer(cm, writers.BatchConfig{Size: 2, Interval: time.Hour, RetryTimeout: 100 * time.Millisecond}, testLog)
+	defer bc.Close()
+
+	errs := consume(bc, senmlPack(1), senmlPack(1))
+	for _, err := range errs {
+		assert.True(t, errors.Is(err, errConsume), fmt.Sprintf("consume SenML pack: expected %s got %s", errConsume, err))
+	}
+	assert.Empty(t, cm.consumed(), "expected failed batch not to be consumed")
+}
+
+func TestBatchClose(t *testing.T) {
+	cm := &consumerMock{}
+	bc := writers.NewBatchConsumer(cm, writers.BatchConfig{Size: batchSize, Interval: time.Hour}, testLog)
+
+	errs := make(chan error)
+	go func() {
+		errs <- bc.Consume(senmlPack(3))
+	}()
+
+	// Let the messages be buffered before closing the consumer.
+	time.Sleep(50 * time.Millisecond)
+	bc.Close()
+
+	err := <-errs
+	assert.Nil(t, err, fmt.Sprintf("consume SenML pack: expected nil got %s", err))
+	assert.Len(t, cm.consumed(), 1, "expected buffered messages to be flushed on close")
+
+	err = bc.Consume(senmlPack(1))
+	assert.NotNil(t, err, "consume SenML pack after close: expected error got nil")
+}
+
+// consume passes the messages to the batch consumer concurrently, and
+// returns the errors once all of the calls return.
+func consume(bc writers.BatchConsumer, msgs ...interface{}) []error {
+	errs := make([]error, len(msgs))
+	var wg sync.WaitGroup
+	for i, m := range msgs {
+		wg.Add(1)
+		go func(i int, m interface{}) {
+			defer wg.Done()
+			errs[i] = bc.Consume(m)
+		}(i, m)
+	}
+	wg.Wait()
+
+	return errs
+}
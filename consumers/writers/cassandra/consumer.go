@@ -14,10 +14,22 @@ import (
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 )
 
+// maxBatchStatements limits the number of inserts in a single batch, keeping
+// the batch size below the Cassandra batch size thresholds.
+const maxBatchStatements = 50
+
 var (
+	// ErrInvalidMessage indicates that the message can't be stored since its
+	// representation is invalid, so storing it again would fail as well.
+	ErrInvalidMessage = errors.New("invalid message representation")
+
+	// ErrNoTable indicates that the table of the JSON messages doesn't
+	// exist and can't be created.
+	ErrNoTable = errors.New("table does not exist")
+
 	errSaveMessage = errors.New("failed to save message to cassandra database")
-	errNoTable     = errors.New("table does not exist")
 )
+
 var _ consumers.Consumer = (*cassandraRepository)(nil)
 
 type cassandraRepository struct {
@@ -41,21 +53,26 @@ func (cr *cassandraRepository) Consume(message interface{}) error {
 func (cr *cassandraRepository) saveSenml(messages interface{}) error {
 	msgs, ok := messages.([]senml.Message)
 	if !ok {
-		return errSaveMessage
+		return errors.Wrap(errSaveMessage, ErrInvalidMessage)
 	}
 	cql := `INSERT INTO messages (id, channel, subtopic, publisher, protocol,
             name, unit, value, string_value, bool_value, data_value, sum,
             time, update_time)
             VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	id := gocql.TimeUUID()
 
-	for _, msg := range msgs {
-		err := cr.session.Query(cql, id, msg.Channel, msg.Subtopic, msg.Publisher,
+	batch := cr.session.NewBatch(gocql.UnloggedBatch)
+	for i, msg := range msgs {
+		batch.Query(cql, gocql.TimeUUID(), msg.Channel, msg.Subtopic, msg.Publisher,
 			msg.Protocol, msg.Name, msg.Unit, msg.Value, msg.StringValue,
-			msg.BoolValue, msg.DataValue, msg.Sum, msg.Time, msg.UpdateTime).Exec()
-		if err != nil {
+			msg.BoolValue, msg.DataValue, msg.Sum, msg.Time, msg.UpdateTime)
+		if batch.Size() < maxBatchStatements && i < len(msgs)-1 {
+			continue
+		}
+
+		if err := cr.session.ExecuteBatch(batch); err != nil {
 			return errors.Wrap(errSaveMessage, err)
 		}
+		batch = cr.session.NewBatch(gocql.UnloggedBatch)
 	}
 
 	return nil
@@ -63,7 +80,7 @@ func (cr *cassandraRepository) saveSenml(messages interface{}) error {
 
 func (cr *cassandraRepository) saveJSON(msgs mfjson.Messages) error {
 	if err := cr.insertJSON(msgs); err != nil {
-		if err == errNoTable {
+		if err == ErrNoTable {
 			if err := cr.createTable(msgs.Format); err != nil {
 				return err
 			}
@@ -75,22 +92,27 @@ func (cr *cassandraRepository) saveJSON(msgs mfjson.Messages) error {
 }
 
 func (cr *cassandraRepository) insertJSON(msgs mfjson.Messages) error {
-	for _, msg := range msgs.Data {
+	cql := `INSERT INTO %s (id, channel, created, subtopic, publisher, protocol, payload) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	cql = fmt.Sprintf(cql, msgs.Format)
+
+	batch := cr.session.NewBatch(gocql.UnloggedBatch)
+	for i, msg := range msgs.Data {
 		pld, err := json.Marshal(msg.Payload)
 		if err != nil {
-			return err
+			return errors.Wrap(errSaveMessage, errors.Wrap(ErrInvalidMessage, err))
+		}
+		batch.Query(cql, gocql.TimeUUID(), msg.Channel, msg.Created, msg.Subtopic, msg.Publisher, msg.Protocol, string(pld))
+		if batch.Size() < maxBatchStatements && i < len(msgs.Data)-1 {
+			continue
 		}
-		cql := `INSERT INTO %s (id, channel, created, subtopic, publisher, protocol, payload) VALUES (?, ?, ?, ?, ?, ?, ?)`
-		cql = fmt.Sprintf(cql, msgs.Format)
-		id := gocql.TimeUUID()
 
-		err = cr.session.Query(cql, id, msg.Channel, msg.Created, msg.Subtopic, msg.Publisher, msg.Protocol, string(pld)).Exec()
-		if err != nil {
+		if err := cr.session.ExecuteBatch(batch); err != nil {
 			if err.Error() == fmt.Sprintf("unconfigured table %s", msgs.Format) {
-				return errNoTable
+				return ErrNoTable
 			}
 			return errors.Wrap(errSaveMessage, err)
 		}
+		batch = cr.session.NewBatch(gocql.UnloggedBatch)
 	}
 	return nil
 }
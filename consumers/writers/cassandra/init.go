@@ -34,6 +34,12 @@ const (
         payload text,
         PRIMARY KEY (channel, created, id)
     ) WITH CLUSTERING ORDER BY (created DESC)`
+
+	retentionTable = `CREATE TABLE IF NOT EXISTS retention_policies (
+        channel text,
+        days bigint,
+        PRIMARY KEY (channel)
+    )`
 )
 
 // DBConfig contains Cassandra DB specific parameters.
@@ -65,5 +71,9 @@ func Connect(cfg DBConfig) (*gocql.Session, error) {
 		return nil, err
 	}
 
+	if err := session.Query(retentionTable).Exec(); err != nil {
+		return nil, err
+	}
+
 	return session, nil
 }
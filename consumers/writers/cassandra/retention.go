@@ -0,0 +1,118 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/mainflux/mainflux/consumers/retention"
+)
+
+var (
+	_ retention.Repository = (*retentionRepo)(nil)
+	_ retention.Pruner     = (*pruner)(nil)
+)
+
+type retentionRepo struct {
+	session *gocql.Session
+}
+
+// NewRetentionRepository returns the retention policies repository which
+// keeps the policies in the retention_policies table.
+func NewRetentionRepository(session *gocql.Session) retention.Repository {
+	return &retentionRepo{session: session}
+}
+
+func (rr *retentionRepo) Save(ctx context.Context, p retention.Policy) error {
+	cql := `INSERT INTO retention_policies (channel, days) VALUES (?, ?)`
+	return rr.session.Query(cql, p.Channel, int64(p.Days)).WithContext(ctx).Exec()
+}
+
+func (rr *retentionRepo) RetrieveAll(ctx context.Context) ([]retention.Policy, error) {
+	cql := `SELECT channel, days FROM retention_policies`
+	iter := rr.session.Query(cql).WithContext(ctx).Iter()
+
+	policies := []retention.Policy{}
+	var chanID string
+	var days int64
+	for iter.Scan(&chanID, &days) {
+		policies = append(policies, retention.Policy{Channel: chanID, Days: uint64(days)})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	// Cassandra orders the policies by the token of the channel.
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Channel < policies[j].Channel
+	})
+
+	return policies, nil
+}
+
+func (rr *retentionRepo) Remove(ctx context.Context, chanID string) error {
+	cql := `DELETE FROM retention_policies WHERE channel = ?`
+	return rr.session.Query(cql, chanID).WithContext(ctx).Exec()
+}
+
+type pruner struct {
+	session  *gocql.Session
+	keyspace string
+}
+
+// NewPruner returns the pruner which removes the messages from the SenML
+// table and all the JSON tables of the keyspace.
+func NewPruner(session *gocql.Session, keyspace string) retention.Pruner {
+	return &pruner{
+		session:  session,
+		keyspace: keyspace,
+	}
+}
+
+func (pr *pruner) Prune(ctx context.Context, chanID string, before time.Time) error {
+	tables, err := pr.jsonTables(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Messages are clustered by the time within the channel partition, so
+	// they're removed using range deletions. SenML time is in seconds, and
+	// JSON creation time in nanoseconds.
+	cql := `DELETE FROM messages WHERE channel = ? AND time < ?`
+	if err := pr.session.Query(cql, chanID, float64(before.UnixNano())/1e9).WithContext(ctx).Exec(); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		cql := fmt.Sprintf(`DELETE FROM %s WHERE channel = ? AND created < ?`, table)
+		if err := pr.session.Query(cql, chanID, before.UnixNano()).WithContext(ctx).Exec(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jsonTables returns the names of the tables of the JSON messages, which
+// are created per message format.
+func (pr *pruner) jsonTables(ctx context.Context) ([]string, error) {
+	cql := `SELECT table_name, column_name FROM system_schema.columns WHERE keyspace_name = ?`
+	iter := pr.session.Query(cql, pr.keyspace).WithContext(ctx).Iter()
+
+	tables := []string{}
+	var table, column string
+	for iter.Scan(&table, &column) {
+		if column == "payload" {
+			tables = append(tables, table)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
@@ -17,7 +17,13 @@ import (
 
 const senmlPoints = "messages"
 
-var errSaveMessage = errors.New("failed to save message to influxdb database")
+var (
+	// ErrInvalidMessage indicates that the message can't be stored since its
+	// representation is invalid, so storing it again would fail as well.
+	ErrInvalidMessage = errors.New("invalid message representation")
+
+	errSaveMessage = errors.New("failed to save message to influxdb database")
+)
 
 var _ consumers.Consumer = (*influxRepo)(nil)
 
@@ -60,7 +66,7 @@ func (repo *influxRepo) Consume(message interface{}) error {
 func (repo *influxRepo) senmlPoints(pts influxdata.BatchPoints, messages interface{}) (influxdata.BatchPoints, error) {
 	msgs, ok := messages.([]senml.Message)
 	if !ok {
-		return nil, errSaveMessage
+		return nil, errors.Wrap(errSaveMessage, ErrInvalidMessage)
 	}
 
 	for _, msg := range msgs {
@@ -71,7 +77,7 @@ func (repo *influxRepo) senmlPoints(pts influxdata.BatchPoints, messages interfa
 
 		pt, err := influxdata.NewPoint(senmlPoints, tgs, flds, t)
 		if err != nil {
-			return nil, errors.Wrap(errSaveMessage, err)
+			return nil, errors.Wrap(errSaveMessage, errors.Wrap(ErrInvalidMessage, err))
 		}
 		pts.AddPoint(pt)
 	}
@@ -92,7 +98,7 @@ func (repo *influxRepo) jsonPoints(pts influxdata.BatchPoints, msgs json.Message
 		fields["protocol"] = m.Protocol
 		pt, err := influxdata.NewPoint(msgs.Format, jsonTags(m), fields, t)
 		if err != nil {
-			return nil, errors.Wrap(errSaveMessage, err)
+			return nil, errors.Wrap(errSaveMessage, errors.Wrap(ErrInvalidMessage, err))
 		}
 		pts.AddPoint(pt)
 	}
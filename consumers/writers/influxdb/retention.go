@@ -0,0 +1,131 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	influxdata "github.com/influxdata/influxdb/client/v2"
+	"github.com/mainflux/mainflux/consumers/retention"
+)
+
+// Policies are kept as the points of the retention_policies measurement.
+// All the points share the zero time, so saving the policy of the channel
+// overwrites the previous one.
+const retentionPoints = "retention_policies"
+
+var (
+	_ retention.Repository = (*retentionRepo)(nil)
+	_ retention.Pruner     = (*pruner)(nil)
+)
+
+type retentionRepo struct {
+	client   influxdata.Client
+	database string
+}
+
+// NewRetentionRepository returns the retention policies repository which
+// keeps the policies in the retention_policies measurement.
+func NewRetentionRepository(client influxdata.Client, database string) retention.Repository {
+	return &retentionRepo{
+		client:   client,
+		database: database,
+	}
+}
+
+func (rr *retentionRepo) Save(_ context.Context, p retention.Policy) error {
+	pts, err := influxdata.NewBatchPoints(influxdata.BatchPointsConfig{Database: rr.database})
+	if err != nil {
+		return err
+	}
+
+	// The policy isn't tagged with the channel, so that it's not pruned
+	// along with the messages of the channel.
+	tags := map[string]string{"id": p.Channel}
+	fields := map[string]interface{}{"days": int64(p.Days)}
+	pt, err := influxdata.NewPoint(retentionPoints, tags, fields, time.Unix(0, 0))
+	if err != nil {
+		return err
+	}
+	pts.AddPoint(pt)
+
+	return rr.client.Write(pts)
+}
+
+func (rr *retentionRepo) RetrieveAll(context.Context) ([]retention.Policy, error) {
+	cmd := fmt.Sprintf(`SELECT "id", "days" FROM %s`, retentionPoints)
+	resp, err := rr.client.Query(influxdata.NewQuery(cmd, rr.database, ""))
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error() != nil {
+		return nil, resp.Error()
+	}
+
+	policies := []retention.Policy{}
+	if len(resp.Results) < 1 || len(resp.Results[0].Series) < 1 {
+		return policies, nil
+	}
+
+	series := resp.Results[0].Series[0]
+	for _, values := range series.Values {
+		var p retention.Policy
+		for i, col := range series.Columns {
+			switch col {
+			case "id":
+				p.Channel, _ = values[i].(string)
+			case "days":
+				days, _ := values[i].(json.Number)
+				if p.Days, err = strconv.ParseUint(days.String(), 10, 64); err != nil {
+					return nil, err
+				}
+			}
+		}
+		policies = append(policies, p)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Channel < policies[j].Channel
+	})
+
+	return policies, nil
+}
+
+func (rr *retentionRepo) Remove(_ context.Context, chanID string) error {
+	cmd := fmt.Sprintf(`DELETE FROM %s WHERE "id" = $id`, retentionPoints)
+	params := map[string]interface{}{"id": chanID}
+	return exec(rr.client, influxdata.NewQueryWithParameters(cmd, rr.database, "", params))
+}
+
+type pruner struct {
+	client   influxdata.Client
+	database string
+}
+
+// NewPruner returns the pruner which removes the points of the messages
+// from all the measurements of the database.
+func NewPruner(client influxdata.Client, database string) retention.Pruner {
+	return &pruner{
+		client:   client,
+		database: database,
+	}
+}
+
+func (pr *pruner) Prune(_ context.Context, chanID string, before time.Time) error {
+	cmd := fmt.Sprintf(`DELETE WHERE "channel" = $channel AND time < %d`, before.UnixNano())
+	params := map[string]interface{}{"channel": chanID}
+	return exec(pr.client, influxdata.NewQueryWithParameters(cmd, pr.database, "", params))
+}
+
+func exec(client influxdata.Client, q influxdata.Query) error {
+	resp, err := client.Query(q)
+	if err != nil {
+		return err
+	}
+	return resp.Error()
+}
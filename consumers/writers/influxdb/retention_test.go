@@ -0,0 +1,147 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb_test
+
+import (
+	"context"
+	stdjson "encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/mainflux/mainflux/consumers/retention"
+	writer "github.com/mainflux/mainflux/consumers/writers/influxdb"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionPolicies(t *testing.T) {
+	repo := writer.NewRetentionRepository(client, testDB)
+
+	chanID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	chanID2, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		policy retention.Policy
+	}{
+		{
+			desc:   "save policy",
+			policy: retention.Policy{Channel: chanID.String(), Days: 30},
+		},
+		{
+			desc:   "save policy of another channel",
+			policy: retention.Policy{Channel: chanID2.String(), Days: 7},
+		},
+		{
+			desc:   "replace policy",
+			policy: retention.Policy{Channel: chanID.String(), Days: 90},
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.policy)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+	}
+
+	policies, err := repo.RetrieveAll(context.Background())
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Contains(t, policies, cases[1].policy, fmt.Sprintf("expected %v to contain %v", policies, cases[1].policy))
+	assert.Contains(t, policies, cases[2].policy, fmt.Sprintf("expected %v to contain %v", policies, cases[2].policy))
+	assert.NotContains(t, policies, cases[0].policy, fmt.Sprintf("expected %v not to contain %v", policies, cases[0].policy))
+
+	err = repo.Remove(context.Background(), chanID2.String())
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	policies, err = repo.RetrieveAll(context.Background())
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.NotContains(t, policies, cases[1].policy, fmt.Sprintf("expected %v not to contain %v", policies, cases[1].policy))
+}
+
+func TestPrune(t *testing.T) {
+	repo := writer.New(client, testDB)
+	pruner := writer.NewPruner(client, testDB)
+
+	var chanIDs []string
+	for i := 0; i < 2; i++ {
+		id, err := uuid.NewV4()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		chanIDs = append(chanIDs, id.String())
+	}
+	pubID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Each channel gets one expired and one recent message of each format.
+	now := time.Now()
+	var msgs []senml.Message
+	jsonMsgs := json.Messages{Format: jsonFormat}
+	for _, chanID := range chanIDs {
+		for _, created := range []time.Time{now.Add(-10 * 24 * time.Hour), now} {
+			msgs = append(msgs, senml.Message{
+				Channel:   chanID,
+				Publisher: pubID.String(),
+				Protocol:  "mqtt",
+				Name:      "temperature",
+				Time:      float64(created.UnixNano()) / 1e9,
+				Value:     &v,
+			})
+			jsonMsgs.Data = append(jsonMsgs.Data, json.Message{
+				Channel:   chanID,
+				Publisher: pubID.String(),
+				Protocol:  "mqtt",
+				Created:   created.UnixNano(),
+				Payload:   map[string]interface{}{"field_1": 123},
+			})
+		}
+	}
+	err = repo.Consume(msgs)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	err = repo.Consume(jsonMsgs)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	err = pruner.Prune(context.Background(), chanIDs[0], now.Add(-5*24*time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	cases := []struct {
+		desc   string
+		chanID string
+		count  uint64
+	}{
+		{
+			desc:   "count messages of pruned channel",
+			chanID: chanIDs[0],
+			count:  1,
+		},
+		{
+			desc:   "count messages of channel that wasn't pruned",
+			chanID: chanIDs[1],
+			count:  2,
+		},
+	}
+
+	for _, tc := range cases {
+		for _, table := range []string{"messages", jsonFormat} {
+			count := count(t, table, tc.chanID)
+			assert.Equal(t, tc.count, count, fmt.Sprintf("%s: expected %d messages in %s got %d", tc.desc, tc.count, table, count))
+		}
+	}
+}
+
+const jsonFormat = "retention_json"
+
+func count(t *testing.T, measurement, chanID string) uint64 {
+	rows, err := queryDB(fmt.Sprintf(`SELECT COUNT("protocol") FROM %s WHERE "channel" = '%s'`, measurement, chanID))
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	if len(rows) == 0 {
+		return 0
+	}
+	count, err := rows[0][1].(stdjson.Number).Int64()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	return uint64(count)
+}
@@ -16,7 +16,13 @@ import (
 
 const senmlCollection string = "messages"
 
-var errSaveMessage = errors.New("failed to save message to mongodb database")
+var (
+	// ErrInvalidMessage indicates that the message can't be stored since its
+	// representation is invalid, so storing it again would fail as well.
+	ErrInvalidMessage = errors.New("invalid message representation")
+
+	errSaveMessage = errors.New("failed to save message to mongodb database")
+)
 
 var _ consumers.Consumer = (*mongoRepo)(nil)
 
@@ -42,7 +48,7 @@ func (repo *mongoRepo) Consume(message interface{}) error {
 func (repo *mongoRepo) saveSenml(messages interface{}) error {
 	msgs, ok := messages.([]senml.Message)
 	if !ok {
-		return errSaveMessage
+		return errors.Wrap(errSaveMessage, ErrInvalidMessage)
 	}
 	coll := repo.db.Collection(senmlCollection)
 	var dbMsgs []interface{}
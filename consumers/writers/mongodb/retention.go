@@ -0,0 +1,114 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/retention"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const retentionCollection = "retention_policies"
+
+var (
+	_ retention.Repository = (*retentionRepo)(nil)
+	_ retention.Pruner     = (*pruner)(nil)
+)
+
+type policy struct {
+	Channel string `bson:"channel"`
+	Days    int64  `bson:"days"`
+}
+
+type retentionRepo struct {
+	db *mongo.Database
+}
+
+// NewRetentionRepository returns the retention policies repository which
+// keeps the policies in the retention_policies collection.
+func NewRetentionRepository(db *mongo.Database) retention.Repository {
+	return &retentionRepo{db: db}
+}
+
+func (rr *retentionRepo) Save(ctx context.Context, p retention.Policy) error {
+	coll := rr.db.Collection(retentionCollection)
+	filter := bson.D{{Key: "channel", Value: p.Channel}}
+	doc := policy{Channel: p.Channel, Days: int64(p.Days)}
+
+	_, err := coll.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (rr *retentionRepo) RetrieveAll(ctx context.Context) ([]retention.Policy, error) {
+	coll := rr.db.Collection(retentionCollection)
+	opts := options.Find().SetSort(bson.D{{Key: "channel", Value: 1}})
+
+	cursor, err := coll.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	policies := []retention.Policy{}
+	for cursor.Next(ctx) {
+		var p policy
+		if err := cursor.Decode(&p); err != nil {
+			return nil, err
+		}
+		policies = append(policies, retention.Policy{Channel: p.Channel, Days: uint64(p.Days)})
+	}
+
+	return policies, cursor.Err()
+}
+
+func (rr *retentionRepo) Remove(ctx context.Context, chanID string) error {
+	coll := rr.db.Collection(retentionCollection)
+	_, err := coll.DeleteMany(ctx, bson.D{{Key: "channel", Value: chanID}})
+	return err
+}
+
+type pruner struct {
+	db *mongo.Database
+}
+
+// NewPruner returns the pruner which removes the messages from the SenML
+// collection and all the JSON collections.
+func NewPruner(db *mongo.Database) retention.Pruner {
+	return &pruner{db: db}
+}
+
+func (pr *pruner) Prune(ctx context.Context, chanID string, before time.Time) error {
+	names, err := pr.db.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return err
+	}
+
+	// SenML time is in seconds, and JSON creation time in nanoseconds.
+	for _, name := range names {
+		var filter bson.D
+		switch name {
+		case retentionCollection:
+			continue
+		case senmlCollection:
+			filter = bson.D{
+				{Key: "channel", Value: chanID},
+				{Key: "time", Value: bson.M{"$lt": float64(before.UnixNano()) / 1e9}},
+			}
+		default:
+			filter = bson.D{
+				{Key: "channel", Value: chanID},
+				{Key: "created", Value: bson.M{"$lt": before.UnixNano()}},
+			}
+		}
+		if _, err := pr.db.Collection(name).DeleteMany(ctx, filter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -20,13 +20,23 @@ import (
 const (
 	errInvalid        = "invalid_text_representation"
 	errUndefinedTable = "undefined_table"
+
+	// maxInsertRows limits the number of rows inserted by a single statement,
+	// keeping the number of bind parameters within the Postgres limit.
+	maxInsertRows = 1000
 )
 
 var (
-	errInvalidMessage = errors.New("invalid message representation")
-	errSaveMessage    = errors.New("failed to save message to postgres database")
-	errTransRollback  = errors.New("failed to rollback transaction")
-	errNoTable        = errors.New("relation does not exist")
+	// ErrInvalidMessage indicates that the message can't be stored since its
+	// representation is invalid, so storing it again would fail as well.
+	ErrInvalidMessage = errors.New("invalid message representation")
+
+	// ErrNoTable indicates that the table of the JSON messages doesn't
+	// exist and can't be created.
+	ErrNoTable = errors.New("relation does not exist")
+
+	errSaveMessage   = errors.New("failed to save message to postgres database")
+	errTransRollback = errors.New("failed to rollback transaction")
 )
 
 var _ consumers.Consumer = (*postgresRepo)(nil)
@@ -52,7 +62,7 @@ func (pr postgresRepo) Consume(message interface{}) (err error) {
 func (pr postgresRepo) saveSenml(messages interface{}) (err error) {
 	msgs, ok := messages.([]senml.Message)
 	if !ok {
-		return errSaveMessage
+		return errors.Wrap(errSaveMessage, ErrInvalidMessage)
 	}
 	q := `INSERT INTO messages (id, channel, subtopic, publisher, protocol,
           name, unit, value, string_value, bool_value, data_value, sum,
@@ -78,18 +88,26 @@ func (pr postgresRepo) saveSenml(messages interface{}) (err error) {
 		}
 	}()
 
+	dbMsgs := []senmlMessage{}
 	for _, msg := range msgs {
 		id, err := uuid.NewV4()
 		if err != nil {
 			return err
 		}
-		m := senmlMessage{Message: msg, ID: id.String()}
-		if _, err := tx.NamedExec(q, m); err != nil {
+		dbMsgs = append(dbMsgs, senmlMessage{Message: msg, ID: id.String()})
+	}
+
+	for start := 0; start < len(dbMsgs); start += maxInsertRows {
+		end := start + maxInsertRows
+		if end > len(dbMsgs) {
+			end = len(dbMsgs)
+		}
+		if _, err = tx.NamedExec(q, dbMsgs[start:end]); err != nil {
 			pqErr, ok := err.(*pq.Error)
 			if ok {
 				switch pqErr.Code.Name() {
 				case errInvalid:
-					return errors.Wrap(errSaveMessage, errInvalidMessage)
+					return errors.Wrap(errSaveMessage, ErrInvalidMessage)
 				}
 			}
 
@@ -101,7 +119,7 @@ func (pr postgresRepo) saveSenml(messages interface{}) (err error) {
 
 func (pr postgresRepo) saveJSON(msgs mfjson.Messages) error {
 	if err := pr.insertJSON(msgs); err != nil {
-		if err == errNoTable {
+		if err == ErrNoTable {
 			if err := pr.createTable(msgs.Format); err != nil {
 				return err
 			}
@@ -134,20 +152,29 @@ func (pr postgresRepo) insertJSON(msgs mfjson.Messages) error {
           VALUES (:id, :channel, :created, :subtopic, :publisher, :protocol, :payload);`
 	q = fmt.Sprintf(q, msgs.Format)
 
+	dbMsgs := []jsonMessage{}
 	for _, m := range msgs.Data {
 		var dbmsg jsonMessage
 		dbmsg, err = toJSONMessage(m)
 		if err != nil {
 			return errors.Wrap(errSaveMessage, err)
 		}
-		if _, err = tx.NamedExec(q, dbmsg); err != nil {
+		dbMsgs = append(dbMsgs, dbmsg)
+	}
+
+	for start := 0; start < len(dbMsgs); start += maxInsertRows {
+		end := start + maxInsertRows
+		if end > len(dbMsgs) {
+			end = len(dbMsgs)
+		}
+		if _, err = tx.NamedExec(q, dbMsgs[start:end]); err != nil {
 			pqErr, ok := err.(*pq.Error)
 			if ok {
 				switch pqErr.Code.Name() {
 				case errInvalid:
-					return errors.Wrap(errSaveMessage, errInvalidMessage)
+					return errors.Wrap(errSaveMessage, ErrInvalidMessage)
 				case errUndefinedTable:
-					return errNoTable
+					return ErrNoTable
 				}
 			}
 			return err
@@ -198,7 +225,7 @@ func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
 	if msg.Payload != nil {
 		b, err := json.Marshal(msg.Payload)
 		if err != nil {
-			return jsonMessage{}, errors.Wrap(errSaveMessage, err)
+			return jsonMessage{}, errors.Wrap(ErrInvalidMessage, err)
 		}
 		data = b
 	}
@@ -0,0 +1,135 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/consumers/retention"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	_ retention.Repository = (*retentionRepo)(nil)
+	_ retention.Pruner     = (*pruner)(nil)
+)
+
+type retentionRepo struct {
+	db *sqlx.DB
+}
+
+// NewRetentionRepository returns the retention policies repository which
+// keeps the policies in the retention_policies table.
+func NewRetentionRepository(db *sqlx.DB) retention.Repository {
+	return &retentionRepo{db: db}
+}
+
+func (rr retentionRepo) Save(ctx context.Context, p retention.Policy) error {
+	q := `INSERT INTO retention_policies (channel, days) VALUES ($1, $2)
+          ON CONFLICT (channel) DO UPDATE SET days = EXCLUDED.days`
+
+	if _, err := rr.db.ExecContext(ctx, q, p.Channel, int64(p.Days)); err != nil {
+		return policyError(err)
+	}
+	return nil
+}
+
+func (rr retentionRepo) RetrieveAll(ctx context.Context) ([]retention.Policy, error) {
+	q := `SELECT channel, days FROM retention_policies ORDER BY channel`
+
+	rows, err := rr.db.QueryxContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := []retention.Policy{}
+	for rows.Next() {
+		var p retention.Policy
+		if err := rows.Scan(&p.Channel, &p.Days); err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+
+	return policies, rows.Err()
+}
+
+func (rr retentionRepo) Remove(ctx context.Context, chanID string) error {
+	q := `DELETE FROM retention_policies WHERE channel = $1`
+
+	if _, err := rr.db.ExecContext(ctx, q, chanID); err != nil {
+		return policyError(err)
+	}
+	return nil
+}
+
+type pruner struct {
+	db *sqlx.DB
+}
+
+// NewPruner returns the pruner which removes the messages from the SenML
+// table and all the JSON tables.
+func NewPruner(db *sqlx.DB) retention.Pruner {
+	return &pruner{db: db}
+}
+
+func (pr pruner) Prune(ctx context.Context, chanID string, before time.Time) (err error) {
+	tables, err := jsonTables(ctx, pr.db)
+	if err != nil {
+		return err
+	}
+
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// SenML time is in seconds, and JSON creation time in nanoseconds.
+	q := `DELETE FROM messages WHERE channel = $1 AND time < $2`
+	if _, err = tx.ExecContext(ctx, q, chanID, float64(before.UnixNano())/1e9); err != nil {
+		return policyError(err)
+	}
+	for _, table := range tables {
+		q := fmt.Sprintf(`DELETE FROM %s WHERE channel = $1 AND created < $2`, pq.QuoteIdentifier(table))
+		if _, err = tx.ExecContext(ctx, q, chanID, before.UnixNano()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jsonTables returns the names of the tables of the JSON messages, which
+// are created per message format.
+func jsonTables(ctx context.Context, db *sqlx.DB) ([]string, error) {
+	q := `SELECT table_name FROM information_schema.columns
+          WHERE table_schema = current_schema() AND column_name = 'payload'`
+
+	tables := []string{}
+	if err := db.SelectContext(ctx, &tables, q); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+func policyError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == errInvalid {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	return err
+}
@@ -0,0 +1,266 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq" // required for DB access
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/pkg/errors"
+	mfjson "github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+const (
+	errInvalid        = "invalid_text_representation"
+	errUndefinedTable = "undefined_table"
+
+	// maxInsertRows limits the number of rows inserted by a single statement,
+	// keeping the number of bind parameters within the Postgres limit.
+	maxInsertRows = 1000
+)
+
+var (
+	// ErrInvalidMessage indicates that the message can't be stored since its
+	// representation is invalid, so storing it again would fail as well.
+	ErrInvalidMessage = errors.New("invalid message representation")
+
+	// ErrNoTable indicates that the table of the JSON messages doesn't
+	// exist and can't be created.
+	ErrNoTable = errors.New("relation does not exist")
+
+	errSaveMessage   = errors.New("failed to save message to timescale database")
+	errTransRollback = errors.New("failed to rollback transaction")
+
+	errInvalidAggregate = errors.New("aggregate bucket width must be at least one second")
+)
+
+var _ consumers.Consumer = (*timescaleRepo)(nil)
+
+type timescaleRepo struct {
+	db     *sqlx.DB
+	policy Policy
+}
+
+// New returns new TimescaleDB writer. The policy is applied to the JSON
+// tables the writer creates.
+func New(db *sqlx.DB, policy Policy) consumers.Consumer {
+	return &timescaleRepo{
+		db:     db,
+		policy: policy,
+	}
+}
+
+func (tr timescaleRepo) Consume(message interface{}) (err error) {
+	switch m := message.(type) {
+	case mfjson.Messages:
+		return tr.saveJSON(m)
+	default:
+		return tr.saveSenml(m)
+	}
+}
+
+func (tr timescaleRepo) saveSenml(messages interface{}) (err error) {
+	msgs, ok := messages.([]senml.Message)
+	if !ok {
+		return errors.Wrap(errSaveMessage, ErrInvalidMessage)
+	}
+	q := `INSERT INTO messages (id, channel, subtopic, publisher, protocol,
+          name, unit, value, string_value, bool_value, data_value, sum,
+          time, update_time)
+          VALUES (:id, :channel, :subtopic, :publisher, :protocol, :name, :unit,
+          :value, :string_value, :bool_value, :data_value, :sum,
+          to_timestamp(:time), :update_time);`
+
+	tx, err := tr.db.BeginTxx(context.Background(), nil)
+	if err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
+			}
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errSaveMessage, err)
+		}
+	}()
+
+	dbMsgs := []senmlMessage{}
+	for _, msg := range msgs {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return err
+		}
+		dbMsgs = append(dbMsgs, senmlMessage{Message: msg, ID: id.String()})
+	}
+
+	for start := 0; start < len(dbMsgs); start += maxInsertRows {
+		end := start + maxInsertRows
+		if end > len(dbMsgs) {
+			end = len(dbMsgs)
+		}
+		if _, err = tx.NamedExec(q, dbMsgs[start:end]); err != nil {
+			pqErr, ok := err.(*pq.Error)
+			if ok {
+				switch pqErr.Code.Name() {
+				case errInvalid:
+					return errors.Wrap(errSaveMessage, ErrInvalidMessage)
+				}
+			}
+
+			return errors.Wrap(errSaveMessage, err)
+		}
+	}
+	return err
+}
+
+func (tr timescaleRepo) saveJSON(msgs mfjson.Messages) error {
+	if err := tr.insertJSON(msgs); err != nil {
+		if err == ErrNoTable {
+			if err := tr.createTable(msgs.Format); err != nil {
+				return err
+			}
+			return tr.insertJSON(msgs)
+		}
+		return err
+	}
+	return nil
+}
+
+func (tr timescaleRepo) insertJSON(msgs mfjson.Messages) error {
+	tx, err := tr.db.BeginTxx(context.Background(), nil)
+	if err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
+			}
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errSaveMessage, err)
+		}
+	}()
+
+	q := `INSERT INTO %s (id, channel, created, subtopic, publisher, protocol, payload)
+          VALUES (:id, :channel, :created, :subtopic, :publisher, :protocol, :payload);`
+	q = fmt.Sprintf(q, msgs.Format)
+
+	dbMsgs := []jsonMessage{}
+	for _, m := range msgs.Data {
+		var dbmsg jsonMessage
+		dbmsg, err = toJSONMessage(m)
+		if err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+		dbMsgs = append(dbMsgs, dbmsg)
+	}
+
+	for start := 0; start < len(dbMsgs); start += maxInsertRows {
+		end := start + maxInsertRows
+		if end > len(dbMsgs) {
+			end = len(dbMsgs)
+		}
+		if _, err = tx.NamedExec(q, dbMsgs[start:end]); err != nil {
+			pqErr, ok := err.(*pq.Error)
+			if ok {
+				switch pqErr.Code.Name() {
+				case errInvalid:
+					return errors.Wrap(errSaveMessage, ErrInvalidMessage)
+				case errUndefinedTable:
+					return ErrNoTable
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (tr timescaleRepo) createTable(name string) error {
+	q := `CREATE TABLE IF NOT EXISTS %s (
+                        id            UUID,
+                        created       BIGINT NOT NULL,
+                        channel       VARCHAR(254),
+                        subtopic      VARCHAR(254),
+                        publisher     VARCHAR(254),
+                        protocol      TEXT,
+                        payload       JSONB,
+                        PRIMARY KEY (id, created)
+                    )`
+	q = fmt.Sprintf(q, name)
+	if _, err := tr.db.Exec(q); err != nil {
+		return err
+	}
+
+	chunk := tr.policy.ChunkInterval
+	if chunk <= 0 {
+		chunk = defChunkInterval
+	}
+	q = `SELECT create_hypertable($1, 'created', chunk_time_interval => $2::BIGINT, if_not_exists => TRUE)`
+	if _, err := tr.db.Exec(q, name, chunk.Nanoseconds()); err != nil {
+		return err
+	}
+	q = `SELECT set_integer_now_func($1, 'unix_nano_now', replace_if_exists => TRUE)`
+	if _, err := tr.db.Exec(q, name); err != nil {
+		return err
+	}
+
+	return applyJSONPolicy(tr.db, name, tr.policy)
+}
+
+type senmlMessage struct {
+	senml.Message
+	ID string `db:"id"`
+}
+
+type jsonMessage struct {
+	ID        string `db:"id"`
+	Channel   string `db:"channel"`
+	Created   int64  `db:"created"`
+	Subtopic  string `db:"subtopic"`
+	Publisher string `db:"publisher"`
+	Protocol  string `db:"protocol"`
+	Payload   []byte `db:"payload"`
+}
+
+func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return jsonMessage{}, err
+	}
+
+	data := []byte("{}")
+	if msg.Payload != nil {
+		b, err := json.Marshal(msg.Payload)
+		if err != nil {
+			return jsonMessage{}, errors.Wrap(ErrInvalidMessage, err)
+		}
+		data = b
+	}
+
+	m := jsonMessage{
+		ID:        id.String(),
+		Channel:   msg.Channel,
+		Created:   msg.Created,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Payload:   data,
+	}
+
+	return m, nil
+}
@@ -0,0 +1,246 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+const (
+	// Table for SenML messages
+	defTable = "messages"
+
+	defChunkInterval = 7 * 24 * time.Hour
+)
+
+// Config defines the options that are used when connecting to a TimescaleDB instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+}
+
+// Policy defines how the stored messages are chunked, aggregated and
+// dropped.
+type Policy struct {
+	// ChunkInterval is the time range covered by a single hypertable chunk.
+	// Zero value falls back to seven days.
+	ChunkInterval time.Duration
+
+	// Retention is the age of the messages after which they're dropped.
+	// Zero value keeps messages forever.
+	Retention time.Duration
+
+	// Aggregates are the bucket widths of the continuous aggregates that
+	// are maintained over the SenML messages.
+	Aggregates []time.Duration
+}
+
+// Connect creates a connection to the TimescaleDB instance, applies any
+// unapplied database migrations and applies the given policy to the existing
+// message tables. A non-nil error is returned to indicate failure.
+func Connect(cfg Config, policy Policy) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	if err := applyPolicy(db, policy); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "messages_1",
+				Up: []string{
+					`CREATE EXTENSION IF NOT EXISTS timescaledb`,
+					`CREATE TABLE IF NOT EXISTS messages (
+                        id            UUID,
+                        channel       UUID,
+                        subtopic      VARCHAR(254),
+                        publisher     UUID,
+                        protocol      TEXT,
+                        name          TEXT,
+                        unit          TEXT,
+                        value         FLOAT,
+                        string_value  TEXT,
+                        bool_value    BOOL,
+                        data_value    BYTEA,
+                        sum           FLOAT,
+                        time          TIMESTAMPTZ NOT NULL,
+                        update_time   FLOAT,
+                        PRIMARY KEY (id, time)
+                    )`,
+					`SELECT create_hypertable('messages', 'time', if_not_exists => TRUE)`,
+					`CREATE INDEX IF NOT EXISTS messages_channel_time_idx ON messages (channel, time DESC)`,
+					`CREATE OR REPLACE FUNCTION unix_nano_now() RETURNS BIGINT
+                    LANGUAGE SQL STABLE AS $$
+                        SELECT (EXTRACT(EPOCH FROM now()) * 1000000000)::BIGINT
+                    $$`,
+				},
+				Down: []string{
+					"DROP TABLE messages",
+					"DROP FUNCTION unix_nano_now",
+				},
+			},
+			{
+				Id: "messages_2",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS retention_policies (
+                        channel       UUID,
+                        days          BIGINT NOT NULL,
+                        PRIMARY KEY (channel)
+                    )`,
+				},
+				Down: []string{
+					"DROP TABLE retention_policies",
+				},
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+	return err
+}
+
+// applyPolicy applies the policy to the SenML table, its continuous
+// aggregates and the JSON tables created so far.
+func applyPolicy(db *sqlx.DB, policy Policy) error {
+	chunk := policy.ChunkInterval
+	if chunk <= 0 {
+		chunk = defChunkInterval
+	}
+
+	if _, err := db.Exec(`SELECT set_chunk_time_interval($1, make_interval(secs => $2))`, defTable, chunk.Seconds()); err != nil {
+		return err
+	}
+	if _, err := db.Exec(`SELECT remove_retention_policy($1, if_exists => TRUE)`, defTable); err != nil {
+		return err
+	}
+	if policy.Retention > 0 {
+		if _, err := db.Exec(`SELECT add_retention_policy($1, make_interval(secs => $2))`, defTable, policy.Retention.Seconds()); err != nil {
+			return err
+		}
+	}
+
+	for _, width := range policy.Aggregates {
+		if err := createAggregate(db, width, policy.Retention); err != nil {
+			return err
+		}
+	}
+
+	q := `SELECT hypertable_name FROM timescaledb_information.hypertables
+          WHERE hypertable_schema = current_schema() AND hypertable_name <> $1`
+	var tables []string
+	if err := db.Select(&tables, q, defTable); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if err := applyJSONPolicy(db, table, policy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyJSONPolicy applies the policy to the JSON table. JSON tables are
+// partitioned by the creation time in nanoseconds, so intervals are passed
+// as integers.
+func applyJSONPolicy(db *sqlx.DB, table string, policy Policy) error {
+	chunk := policy.ChunkInterval
+	if chunk <= 0 {
+		chunk = defChunkInterval
+	}
+
+	if _, err := db.Exec(`SELECT set_chunk_time_interval($1, $2::BIGINT)`, table, chunk.Nanoseconds()); err != nil {
+		return err
+	}
+	if _, err := db.Exec(`SELECT remove_retention_policy($1, if_exists => TRUE)`, table); err != nil {
+		return err
+	}
+	if policy.Retention > 0 {
+		if _, err := db.Exec(`SELECT add_retention_policy($1, $2::BIGINT)`, table, policy.Retention.Nanoseconds()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createAggregate creates the continuous aggregate of the SenML values with
+// the given bucket width, unless it already exists. The aggregate isn't
+// refreshed past the retention period, so that the buckets of the dropped
+// messages are kept.
+func createAggregate(db *sqlx.DB, width, retention time.Duration) error {
+	view := AggregateName(width)
+	secs := int64(width.Seconds())
+	if secs < 1 {
+		return errInvalidAggregate
+	}
+
+	q := fmt.Sprintf(`CREATE MATERIALIZED VIEW IF NOT EXISTS %s
+          WITH (timescaledb.continuous) AS
+          SELECT time_bucket(INTERVAL '%d seconds', time) AS bucket,
+          channel, publisher, subtopic, name, unit,
+          COUNT(value) AS count, AVG(value) AS avg, MIN(value) AS min,
+          MAX(value) AS max, SUM(value) AS sum
+          FROM messages
+          GROUP BY bucket, channel, publisher, subtopic, name, unit
+          WITH NO DATA`, view, secs)
+	if _, err := db.Exec(q); err != nil {
+		return err
+	}
+
+	start := "NULL"
+	if retention > 0 {
+		start = fmt.Sprintf("INTERVAL '%d seconds'", int64(retention.Seconds()))
+	}
+	if _, err := db.Exec(`SELECT remove_continuous_aggregate_policy($1, TRUE)`, view); err != nil {
+		return err
+	}
+	q = fmt.Sprintf(`SELECT add_continuous_aggregate_policy('%s',
+          start_offset => %s,
+          end_offset => INTERVAL '%d seconds',
+          schedule_interval => INTERVAL '%d seconds')`, view, start, secs, secs)
+	_, err := db.Exec(q)
+	return err
+}
+
+// AggregateName returns the name of the continuous aggregate with the given
+// bucket width, e.g. messages_1h for hourly buckets.
+func AggregateName(width time.Duration) string {
+	switch {
+	case width%(24*time.Hour) == 0:
+		return fmt.Sprintf("%s_%dd", defTable, width/(24*time.Hour))
+	case width%time.Hour == 0:
+		return fmt.Sprintf("%s_%dh", defTable, width/time.Hour)
+	case width%time.Minute == 0:
+		return fmt.Sprintf("%s_%dm", defTable, width/time.Minute)
+	default:
+		return fmt.Sprintf("%s_%ds", defTable, int64(width.Seconds()))
+	}
+}
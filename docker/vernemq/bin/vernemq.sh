@@ -121,6 +121,14 @@ EOF
         echo "listener.ws.default = ${IP_ADDRESS}:8080" >>/vernemq/etc/vernemq.conf
     fi
 
+    if [ -z "$DOCKER_VERNEMQ_LISTENER__TCP__ALLOWED_PROTOCOL_VERSIONS" ]; then
+        echo "listener.tcp.allowed_protocol_versions = 3,4,5" >>/vernemq/etc/vernemq.conf
+    fi
+
+    if [ -z "$DOCKER_VERNEMQ_LISTENER__WS__ALLOWED_PROTOCOL_VERSIONS" ]; then
+        echo "listener.ws.allowed_protocol_versions = 3,4,5" >>/vernemq/etc/vernemq.conf
+    fi
+
     if [ -z "$DOCKER_VERNEMQ_LISTENER__VMQ__CLUSTERING" ]; then
         echo "listener.vmq.clustering = ${IP_ADDRESS}:44053" >>/vernemq/etc/vernemq.conf
     fi
@@ -10,6 +10,7 @@ import (
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things"
 )
 
 // Service specifies coap service API.
@@ -35,8 +36,10 @@ func New(publisher messaging.Publisher, things mainflux.ThingsServiceClient) Ser
 
 func (as *adapterService) Publish(ctx context.Context, token string, msg messaging.Message) error {
 	ar := &mainflux.AccessByKeyReq{
-		Token:  token,
-		ChanID: msg.Channel,
+		Token:    token,
+		ChanID:   msg.Channel,
+		Action:   things.PublishAction,
+		Subtopic: msg.Subtopic,
 	}
 	thid, err := as.things.CanAccessByKey(ctx, ar)
 	if err != nil {
@@ -34,6 +34,23 @@ var (
 	errMalformedSubtopic = errors.New("malformed subtopic")
 )
 
+// skippedHeaders are the request headers that are not forwarded with the
+// message, either because they carry credentials or because they describe
+// the HTTP transport rather than the message.
+var skippedHeaders = map[string]bool{
+	"Accept":            true,
+	"Accept-Encoding":   true,
+	"Authorization":     true,
+	"Connection":        true,
+	"Content-Length":    true,
+	"Content-Type":      true,
+	"Cookie":            true,
+	"Expect":            true,
+	"Host":              true,
+	"Transfer-Encoding": true,
+	"User-Agent":        true,
+}
+
 var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
 
 // MakeHandler returns a HTTP handler for API endpoints.
@@ -110,11 +127,13 @@ func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	}
 
 	msg := messaging.Message{
-		Protocol: protocol,
-		Channel:  chanID,
-		Subtopic: subtopic,
-		Payload:  payload,
-		Created:  time.Now().UnixNano(),
+		Protocol:    protocol,
+		Channel:     chanID,
+		Subtopic:    subtopic,
+		Payload:     payload,
+		ContentType: r.Header.Get("Content-Type"),
+		Headers:     decodeHeaders(r.Header),
+		Created:     time.Now().UnixNano(),
 	}
 
 	req := publishReq{
@@ -125,6 +144,21 @@ func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeHeaders(header http.Header) map[string]string {
+	headers := map[string]string{}
+	for key, values := range header {
+		if skippedHeaders[key] {
+			continue
+		}
+		headers[strings.ToLower(key)] = strings.Join(values, ",")
+	}
+
+	if len(headers) == 0 {
+		return nil
+	}
+	return headers
+}
+
 func decodePayload(body io.ReadCloser) ([]byte, error) {
 	payload, err := ioutil.ReadAll(body)
 	if err != nil {
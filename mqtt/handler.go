@@ -12,13 +12,18 @@ import (
 	"time"
 
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/mqtt/proxy"
 	"github.com/mainflux/mainflux/mqtt/redis"
 	"github.com/mainflux/mainflux/pkg/auth"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things"
 	"github.com/mainflux/mproxy/pkg/session"
 )
 
-var _ session.Handler = (*handler)(nil)
+var (
+	_ session.Handler         = (*handler)(nil)
+	_ proxy.PropertiesHandler = (*handler)(nil)
+)
 
 const protocol = "mqtt"
 
@@ -86,7 +91,7 @@ func (h *handler) AuthPublish(c *session.Client, topic *string, payload *[]byte)
 		return errNilTopicPub
 	}
 
-	return h.authAccess(c.Username, *topic)
+	return h.authAccess(c.Username, *topic, things.PublishAction)
 }
 
 // AuthSubscribe is called on device publish,
@@ -100,7 +105,7 @@ func (h *handler) AuthSubscribe(c *session.Client, topics *[]string) error {
 	}
 
 	for _, v := range *topics {
-		if err := h.authAccess(c.Username, v); err != nil {
+		if err := h.authAccess(c.Username, v, things.SubscribeAction); err != nil {
 			return err
 		}
 
@@ -120,13 +125,24 @@ func (h *handler) Connect(c *session.Client) {
 
 // Publish - after client successfully published
 func (h *handler) Publish(c *session.Client, topic *string, payload *[]byte) {
+	h.publish(c, topic, payload, proxy.Properties{})
+}
+
+// PublishProperties - after MQTT 5 client successfully published, the content
+// type and the user properties are passed as message content type and headers
+func (h *handler) PublishProperties(c *session.Client, topic *string, payload *[]byte, props proxy.Properties) {
+	h.publish(c, topic, payload, props)
+}
+
+func (h *handler) publish(c *session.Client, topic *string, payload *[]byte, props proxy.Properties) {
 	if c == nil {
 		h.logger.Error("Nil client publish")
 		return
 	}
 	h.logger.Info("Publish - client ID " + c.ID + " to the topic: " + *topic)
 	// Topics are in the format:
-	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
+	// channels/<channel_id>/messages/<subtopic>/...
+	// The content type and the headers are read from the MQTT 5 properties.
 
 	channelParts := channelRegExp.FindStringSubmatch(*topic)
 	if len(channelParts) < 1 {
@@ -144,12 +160,14 @@ func (h *handler) Publish(c *session.Client, topic *string, payload *[]byte) {
 	}
 
 	msg := messaging.Message{
-		Protocol:  protocol,
-		Channel:   chanID,
-		Subtopic:  subtopic,
-		Publisher: c.Username,
-		Payload:   *payload,
-		Created:   time.Now().UnixNano(),
+		Protocol:    protocol,
+		Channel:     chanID,
+		Subtopic:    subtopic,
+		Publisher:   c.Username,
+		Payload:     *payload,
+		ContentType: props.ContentType,
+		Headers:     props.User,
+		Created:     time.Now().UnixNano(),
 	}
 
 	for _, pub := range h.publishers {
@@ -157,6 +175,10 @@ func (h *handler) Publish(c *session.Client, topic *string, payload *[]byte) {
 			h.logger.Info("Error publishing to Mainflux " + err.Error())
 		}
 	}
+
+	if err := h.es.Publish(c.Username); err != nil {
+		h.logger.Warn("Failed to publish publish event: " + err.Error())
+	}
 }
 
 // Subscribe - after client successfully subscribed
@@ -189,9 +211,9 @@ func (h *handler) Disconnect(c *session.Client) {
 	}
 }
 
-func (h *handler) authAccess(username string, topic string) error {
+func (h *handler) authAccess(username, topic, action string) error {
 	// Topics are in the format:
-	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
+	// channels/<channel_id>/messages/<subtopic>/...
 	if !channelRegExp.Match([]byte(topic)) {
 		h.logger.Info("Malformed topic: " + topic)
 		return errMalformedTopic
@@ -203,7 +225,24 @@ func (h *handler) authAccess(username string, topic string) error {
 	}
 
 	chanID := channelParts[1]
-	return h.auth.Authorize(context.Background(), chanID, username)
+	subtopic, err := parseSubtopic(channelParts[2])
+	if err != nil {
+		return err
+	}
+
+	// Translate MQTT wildcards to the ones used by subtopic patterns.
+	elems := strings.Split(subtopic, ".")
+	for i, elem := range elems {
+		switch elem {
+		case "+":
+			elems[i] = "*"
+		case "#":
+			elems[i] = ">"
+		}
+	}
+	subtopic = strings.Join(elems, ".")
+
+	return h.auth.Authorize(context.Background(), chanID, username, action, subtopic)
 }
 
 func parseSubtopic(subtopic string) (string, error) {
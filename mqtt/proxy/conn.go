@@ -0,0 +1,86 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// replayConn is the connection that replays the already consumed bytes
+// before reading from the underlying connection.
+type replayConn struct {
+	net.Conn
+	r io.Reader
+}
+
+func (c replayConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// wsConn wraps the WebSocket connection so it satisfies the net.Conn
+// interface.
+type wsConn struct {
+	*websocket.Conn
+	r   io.Reader
+	rio sync.Mutex
+	wio sync.Mutex
+}
+
+func newWSConn(ws *websocket.Conn) net.Conn {
+	return &wsConn{Conn: ws}
+}
+
+// SetDeadline sets both the read and write deadlines.
+func (c *wsConn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
+
+// Write writes the data as a binary WebSocket message.
+func (c *wsConn) Write(p []byte) (int, error) {
+	c.wio.Lock()
+	defer c.wio.Unlock()
+
+	if err := c.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Read reads the current WebSocket message, advancing to the next one
+// when the current one is consumed.
+func (c *wsConn) Read(p []byte) (int, error) {
+	c.rio.Lock()
+	defer c.rio.Unlock()
+
+	for {
+		if c.r == nil {
+			var err error
+			if _, c.r, err = c.NextReader(); err != nil {
+				return 0, err
+			}
+		}
+
+		n, err := c.r.Read(p)
+		if err == io.EOF {
+			c.r = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+func (c *wsConn) Close() error {
+	return c.Conn.Close()
+}
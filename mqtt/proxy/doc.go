@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package proxy contains the MQTT and MQTT over WebSocket proxies that
+// support both MQTT 3.1.1 and MQTT 5 clients.
+package proxy
@@ -0,0 +1,82 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mproxy/pkg/session"
+	mptls "github.com/mainflux/mproxy/pkg/tls"
+)
+
+// Proxy proxies the MQTT traffic between the clients and the broker.
+type Proxy struct {
+	address string
+	target  string
+	handler session.Handler
+	logger  logger.Logger
+	dialer  net.Dialer
+}
+
+// New returns the MQTT proxy listening on the address and proxying the
+// traffic to the target broker.
+func New(address, target string, handler session.Handler, logger logger.Logger) *Proxy {
+	return &Proxy{
+		address: address,
+		target:  target,
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Listen accepts the client connections. It blocks until the listener fails.
+func (p Proxy) Listen() error {
+	l, err := net.Listen("tcp", p.address)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			p.logger.Warn(fmt.Sprintf("Failed to accept connection: %s", err))
+			continue
+		}
+
+		go p.handle(conn)
+	}
+}
+
+func (p Proxy) handle(inbound net.Conn) {
+	defer p.close(inbound)
+
+	outbound, err := p.dialer.Dial("tcp", p.target)
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("Failed to connect to broker %s: %s", p.target, err))
+		return
+	}
+	defer p.close(outbound)
+
+	cert, err := mptls.ClientCert(inbound)
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("Failed to get client certificate: %s", err))
+		return
+	}
+
+	id, err := stream(inbound, outbound, p.handler, p.logger, cert)
+	if !errors.Contains(err, io.EOF) {
+		p.logger.Warn(fmt.Sprintf("Broken connection for client %s: %s", id, err))
+	}
+}
+
+func (p Proxy) close(conn net.Conn) {
+	if err := conn.Close(); err != nil {
+		p.logger.Warn(fmt.Sprintf("Failed to close connection: %s", err))
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/eclipse/paho.golang/packets"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mproxy/pkg/session"
+)
+
+const (
+	up direction = iota
+	down
+)
+
+const (
+	protocolV5 = 5
+
+	// maxLengthBytes is the maximum number of bytes of the remaining length
+	// in the fixed header of the packet.
+	maxLengthBytes = 4
+)
+
+var (
+	errClient          = errors.New("failed proxying from MQTT client to MQTT broker")
+	errBroker          = errors.New("failed proxying from MQTT broker to MQTT client")
+	errConnectExpected = errors.New("first packet is not CONNECT")
+	errMalformedPacket = errors.New("malformed packet")
+)
+
+type direction int
+
+// Properties represents the properties of the message published by the
+// MQTT 5 client. The values of the repeated user properties are joined
+// using a comma.
+type Properties struct {
+	ContentType string
+	User        map[string]string
+}
+
+// PropertiesHandler is implemented by the handlers that consume the
+// properties of the messages published by the MQTT 5 clients. The handlers
+// that don't implement it are notified using Publish.
+type PropertiesHandler interface {
+	// PublishProperties is called instead of Publish after the MQTT 5 client
+	// successfully published.
+	PublishProperties(client *session.Client, topic *string, payload *[]byte, props Properties)
+}
+
+// stream proxies the traffic between the client and the broker until either
+// of the connections is broken, and returns the client ID and the error that
+// broke the connection. The protocol version is read from the CONNECT packet:
+// MQTT 3.1.1 clients are handled by the mProxy session, while MQTT 5 clients
+// are handled by the session that reads the message properties.
+func stream(inbound, outbound net.Conn, handler session.Handler, logger logger.Logger, cert x509.Certificate) (string, error) {
+	pkt, err := readPacket(inbound)
+	if err != nil {
+		return "", wrap(err, up)
+	}
+
+	version, err := protocolVersion(pkt)
+	if err != nil {
+		return "", wrap(err, up)
+	}
+
+	if version != protocolV5 {
+		in := replayConn{
+			Conn: inbound,
+			r:    io.MultiReader(bytes.NewReader(pkt), inbound),
+		}
+		s := session.New(in, outbound, handler, logger, cert)
+		err := s.Stream()
+		return s.Client.ID, err
+	}
+
+	s := mqtt5Session{
+		inbound:  inbound,
+		outbound: outbound,
+		handler:  handler,
+		client:   session.Client{Cert: cert},
+	}
+	err = s.stream(pkt)
+	return s.client.ID, err
+}
+
+// mqtt5Session proxies the traffic of the MQTT 5 client. Like the mProxy
+// session, it forwards the packets with the changes the handler makes to the
+// client credentials, the published topic and payload and the subscribed
+// topics while authorizing them. The unchanged packets are forwarded as read.
+type mqtt5Session struct {
+	inbound  net.Conn
+	outbound net.Conn
+	handler  session.Handler
+	client   session.Client
+}
+
+func (s *mqtt5Session) stream(connect []byte) error {
+	errs := make(chan error, 2)
+
+	go s.up(connect, errs)
+	go s.down(errs)
+
+	err := <-errs
+	s.handler.Disconnect(&s.client)
+	return err
+}
+
+func (s *mqtt5Session) up(pkt []byte, errs chan error) {
+	for {
+		cp, err := packets.ReadPacket(bytes.NewReader(pkt))
+		if err != nil {
+			errs <- wrap(errors.Wrap(errMalformedPacket, err), up)
+			return
+		}
+
+		changed, err := s.authorize(cp, pkt)
+		if err != nil {
+			errs <- wrap(err, up)
+			return
+		}
+		if changed {
+			// The content is packed with a new fixed header, as the one
+			// read holds the remaining length of the original packet.
+			var buf bytes.Buffer
+			if _, err := cp.Content.WriteTo(&buf); err != nil {
+				errs <- wrap(err, up)
+				return
+			}
+			pkt = buf.Bytes()
+		}
+
+		if _, err := s.outbound.Write(pkt); err != nil {
+			errs <- wrap(err, up)
+			return
+		}
+
+		s.notify(cp)
+
+		if pkt, err = readPacket(s.inbound); err != nil {
+			errs <- wrap(err, up)
+			return
+		}
+	}
+}
+
+func (s *mqtt5Session) down(errs chan error) {
+	if _, err := io.Copy(s.inbound, s.outbound); err != nil {
+		errs <- wrap(err, down)
+		return
+	}
+	errs <- wrap(io.EOF, down)
+}
+
+// authorize authorizes the packet read from the raw packet, and reports
+// whether the handler changed it.
+func (s *mqtt5Session) authorize(cp *packets.ControlPacket, pkt []byte) (bool, error) {
+	switch p := cp.Content.(type) {
+	case *packets.Connect:
+		s.client.ID = p.ClientID
+		s.client.Username = p.Username
+		s.client.Password = p.Password
+		if err := s.handler.AuthConnect(&s.client); err != nil {
+			return false, err
+		}
+		changed := s.client.ID != p.ClientID || s.client.Username != p.Username || !bytes.Equal(s.client.Password, p.Password)
+		p.ClientID = s.client.ID
+		p.Username = s.client.Username
+		p.UsernameFlag = p.Username != ""
+		p.Password = s.client.Password
+		p.PasswordFlag = len(p.Password) > 0
+		return changed, nil
+	case *packets.Publish:
+		// The payload is the tail of the raw packet, which stays unchanged
+		// even if the handler changes the payload in place.
+		topic, n := p.Topic, len(p.Payload)
+		if err := s.handler.AuthPublish(&s.client, &p.Topic, &p.Payload); err != nil {
+			return false, err
+		}
+		// The retain and duplicate flags aren't read into the packet, so
+		// they are copied to be kept if it's packed again.
+		p.Retain = cp.Flags&0x01 != 0
+		p.Duplicate = cp.Flags&0x08 != 0
+		return p.Topic != topic || !bytes.Equal(p.Payload, pkt[len(pkt)-n:]), nil
+	case *packets.Subscribe:
+		topics := subscriptions(p)
+		orig := append([]string{}, topics...)
+		if err := s.handler.AuthSubscribe(&s.client, &topics); err != nil {
+			return false, err
+		}
+		if equal(topics, orig) {
+			return false, nil
+		}
+		subs := make(map[string]packets.SubOptions, len(topics))
+		for i, topic := range topics {
+			var opts packets.SubOptions
+			if i < len(orig) {
+				opts = p.Subscriptions[orig[i]]
+			}
+			subs[topic] = opts
+		}
+		p.Subscriptions = subs
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func (s *mqtt5Session) notify(cp *packets.ControlPacket) {
+	switch p := cp.Content.(type) {
+	case *packets.Connect:
+		s.handler.Connect(&s.client)
+	case *packets.Publish:
+		if ph, ok := s.handler.(PropertiesHandler); ok {
+			ph.PublishProperties(&s.client, &p.Topic, &p.Payload, properties(p.Properties))
+			return
+		}
+		s.handler.Publish(&s.client, &p.Topic, &p.Payload)
+	case *packets.Subscribe:
+		topics := subscriptions(p)
+		s.handler.Subscribe(&s.client, &topics)
+	case *packets.Unsubscribe:
+		s.handler.Unsubscribe(&s.client, &p.Topics)
+	}
+}
+
+// readPacket reads the raw packet, including its fixed header.
+func readPacket(r io.Reader) ([]byte, error) {
+	header := make([]byte, 1, 1+maxLengthBytes)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	length := 0
+	for i := 0; ; i++ {
+		if i == maxLengthBytes {
+			return nil, errMalformedPacket
+		}
+		b := []byte{0}
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		header = append(header, b[0])
+		length |= int(b[0]&0x7f) << (7 * i)
+		if b[0]&0x80 == 0 {
+			break
+		}
+	}
+
+	pkt := make([]byte, len(header)+length)
+	copy(pkt, header)
+	if _, err := io.ReadFull(r, pkt[len(header):]); err != nil {
+		return nil, err
+	}
+
+	return pkt, nil
+}
+
+// protocolVersion returns the protocol level of the raw CONNECT packet.
+func protocolVersion(pkt []byte) (byte, error) {
+	if pkt[0]>>4 != packets.CONNECT {
+		return 0, errConnectExpected
+	}
+
+	// Skip the fixed header, which is already validated by readPacket.
+	i := 1
+	for pkt[i]&0x80 != 0 {
+		i++
+	}
+	body := pkt[i+1:]
+
+	// The variable header starts with the length-prefixed protocol name,
+	// followed by the protocol level.
+	if len(body) < 2 {
+		return 0, errMalformedPacket
+	}
+	n := int(binary.BigEndian.Uint16(body))
+	if len(body) < n+3 {
+		return 0, errMalformedPacket
+	}
+
+	return body[n+2], nil
+}
+
+func subscriptions(p *packets.Subscribe) []string {
+	topics := make([]string, 0, len(p.Subscriptions))
+	for topic := range p.Subscriptions {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func properties(p *packets.Properties) Properties {
+	props := Properties{}
+	if p == nil {
+		return props
+	}
+
+	props.ContentType = p.ContentType
+	if len(p.User) == 0 {
+		return props
+	}
+
+	values := make(map[string][]string, len(p.User))
+	for _, u := range p.User {
+		values[u.Key] = append(values[u.Key], u.Value)
+	}
+
+	props.User = make(map[string]string, len(values))
+	for key, vals := range values {
+		props.User[key] = strings.Join(vals, ",")
+	}
+	return props
+}
+
+func wrap(err error, dir direction) error {
+	switch dir {
+	case up:
+		return errors.Wrap(errClient, err)
+	case down:
+		return errors.Wrap(errBroker, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/eclipse/paho.golang/packets"
+	mqtt3 "github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mproxy/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	clientID = "client"
+	username = "thing"
+	password = "key"
+	topic    = "channels/1/messages/subtopic"
+)
+
+var payload = []byte(`[{"n":"temp","v":20}]`)
+
+type published struct {
+	topic   string
+	payload []byte
+	props   *Properties
+}
+
+type handlerMock struct {
+	mu        sync.Mutex
+	client    session.Client
+	published []published
+	topics    []string
+	// rewrite replaces the published and the subscribed topics if set.
+	rewrite string
+}
+
+func (h *handlerMock) AuthConnect(c *session.Client) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.client = *c
+	return nil
+}
+
+func (h *handlerMock) AuthPublish(c *session.Client, topic *string, payload *[]byte) error {
+	if h.rewrite != "" {
+		*topic = h.rewrite
+	}
+	return nil
+}
+
+func (h *handlerMock) AuthSubscribe(c *session.Client, topics *[]string) error {
+	if h.rewrite != "" {
+		for i := range *topics {
+			(*topics)[i] = h.rewrite
+		}
+	}
+	return nil
+}
+
+func (h *handlerMock) Connect(c *session.Client) {}
+
+func (h *handlerMock) Publish(c *session.Client, topic *string, payload *[]byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.published = append(h.published, published{topic: *topic, payload: *payload})
+}
+
+func (h *handlerMock) PublishProperties(c *session.Client, topic *string, payload *[]byte, props Properties) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.published = append(h.published, published{topic: *topic, payload: *payload, props: &props})
+}
+
+func (h *handlerMock) Subscribe(c *session.Client, topics *[]string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.topics = append(h.topics, *topics...)
+}
+
+func (h *handlerMock) Unsubscribe(c *session.Client, topics *[]string) {}
+
+func (h *handlerMock) Disconnect(c *session.Client) {}
+
+// startStream starts the stream between the client and the broker
+// connections and returns the raw packets received by the broker.
+func startStream(t *testing.T, h *handlerMock) (net.Conn, chan []byte, chan error) {
+	logs, err := logger.New(os.Stdout, "error")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	client, inbound := net.Pipe()
+	outbound, broker := net.Pipe()
+
+	pkts := make(chan []byte)
+	go func() {
+		defer close(pkts)
+		for {
+			pkt, err := readPacket(broker)
+			if err != nil {
+				return
+			}
+			pkts <- pkt
+		}
+	}()
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := stream(inbound, outbound, h, logs, x509.Certificate{})
+		broker.Close()
+		errs <- err
+	}()
+
+	return client, pkts, errs
+}
+
+func TestMQTT5Session(t *testing.T) {
+	h := &handlerMock{}
+	client, pkts, errs := startStream(t, h)
+
+	connect := packets.NewControlPacket(packets.CONNECT)
+	c := connect.Content.(*packets.Connect)
+	c.ClientID = clientID
+	c.UsernameFlag = true
+	c.Username = username
+	c.PasswordFlag = true
+	c.Password = []byte(password)
+
+	publish := packets.NewControlPacket(packets.PUBLISH)
+	p := publish.Content.(*packets.Publish)
+	p.Topic = topic
+	p.Payload = payload
+	p.Properties.ContentType = "application/senml+json"
+	p.Properties.User = []packets.User{
+		{Key: "unit", Value: "celsius"},
+		{Key: "tag", Value: "a"},
+		{Key: "tag", Value: "b"},
+	}
+
+	subscribe := packets.NewControlPacket(packets.SUBSCRIBE)
+	s := subscribe.Content.(*packets.Subscribe)
+	s.PacketID = 1
+	s.Subscriptions[topic] = packets.SubOptions{QoS: 1}
+
+	for _, cp := range []*packets.ControlPacket{connect, publish, subscribe} {
+		go func(cp *packets.ControlPacket) {
+			_, err := cp.WriteTo(client)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		}(cp)
+
+		pkt := <-pkts
+		fwd, err := packets.ReadPacket(bytes.NewReader(pkt))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, cp.Type, fwd.Type, fmt.Sprintf("expected forwarded packet type %d got %d", cp.Type, fwd.Type))
+	}
+
+	client.Close()
+	<-errs
+
+	assert.Equal(t, clientID, h.client.ID, fmt.Sprintf("expected client ID %s got %s", clientID, h.client.ID))
+	assert.Equal(t, username, h.client.Username, fmt.Sprintf("expected username %s got %s", username, h.client.Username))
+	assert.Equal(t, []byte(password), h.client.Password, fmt.Sprintf("expected password %s got %s", password, h.client.Password))
+
+	props := &Properties{
+		ContentType: "application/senml+json",
+		User: map[string]string{
+			"unit": "celsius",
+			"tag":  "a,b",
+		},
+	}
+	expected := []published{{topic: topic, payload: payload, props: props}}
+	assert.Equal(t, expected, h.published, fmt.Sprintf("expected published %v got %v", expected, h.published))
+	assert.Equal(t, []string{topic}, h.topics, fmt.Sprintf("expected topics %v got %v", []string{topic}, h.topics))
+}
+
+func TestMQTT3Session(t *testing.T) {
+	h := &handlerMock{}
+	client, pkts, errs := startStream(t, h)
+
+	connect := mqtt3.NewControlPacket(mqtt3.Connect).(*mqtt3.ConnectPacket)
+	connect.ProtocolName = "MQTT"
+	connect.ProtocolVersion = 4
+	connect.ClientIdentifier = clientID
+	connect.UsernameFlag = true
+	connect.Username = username
+	connect.PasswordFlag = true
+	connect.Password = []byte(password)
+
+	publish := mqtt3.NewControlPacket(mqtt3.Publish).(*mqtt3.PublishPacket)
+	publish.TopicName = topic
+	publish.Payload = payload
+
+	for _, cp := range []mqtt3.ControlPacket{connect, publish} {
+		go func(cp mqtt3.ControlPacket) {
+			err := cp.Write(client)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		}(cp)
+
+		pkt := <-pkts
+		fwd, err := mqtt3.ReadPacket(bytes.NewReader(pkt))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, cp.Details(), fwd.Details(), fmt.Sprintf("expected forwarded packet %s got %s", cp, fwd))
+	}
+
+	client.Close()
+	<-errs
+
+	assert.Equal(t, clientID, h.client.ID, fmt.Sprintf("expected client ID %s got %s", clientID, h.client.ID))
+	assert.Equal(t, username, h.client.Username, fmt.Sprintf("expected username %s got %s", username, h.client.Username))
+
+	expected := []published{{topic: topic, payload: payload}}
+	assert.Equal(t, expected, h.published, fmt.Sprintf("expected published %v got %v", expected, h.published))
+}
+
+func TestMQTT5SessionRewrite(t *testing.T) {
+	rewrite := "channels/1/messages/rewritten"
+	h := &handlerMock{rewrite: rewrite}
+	client, pkts, errs := startStream(t, h)
+
+	connect := packets.NewControlPacket(packets.CONNECT)
+	connect.Content.(*packets.Connect).ClientID = clientID
+
+	publish := packets.NewControlPacket(packets.PUBLISH)
+	p := publish.Content.(*packets.Publish)
+	p.Topic = topic
+	p.Payload = payload
+	publish.Flags |= 0x01
+	p.Properties.ContentType = "application/senml+json"
+
+	subscribe := packets.NewControlPacket(packets.SUBSCRIBE)
+	s := subscribe.Content.(*packets.Subscribe)
+	s.PacketID = 1
+	s.Subscriptions[topic] = packets.SubOptions{QoS: 1}
+
+	var fwd []*packets.ControlPacket
+	for _, cp := range []*packets.ControlPacket{connect, publish, subscribe} {
+		go func(cp *packets.ControlPacket) {
+			_, err := cp.WriteTo(client)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		}(cp)
+
+		pkt := <-pkts
+		cp, err := packets.ReadPacket(bytes.NewReader(pkt))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		fwd = append(fwd, cp)
+	}
+
+	client.Close()
+	<-errs
+
+	fp := fwd[1].Content.(*packets.Publish)
+	assert.Equal(t, rewrite, fp.Topic, fmt.Sprintf("expected forwarded topic %s got %s", rewrite, fp.Topic))
+	assert.Equal(t, payload, fp.Payload, fmt.Sprintf("expected forwarded payload %s got %s", payload, fp.Payload))
+	assert.Equal(t, byte(0x01), fwd[1].Flags&0x01, "expected forwarded message to be retained")
+	assert.Equal(t, "application/senml+json", fp.Properties.ContentType, fmt.Sprintf("expected forwarded content type %s got %s", "application/senml+json", fp.Properties.ContentType))
+
+	subs := map[string]packets.SubOptions{rewrite: {QoS: 1}}
+	fs := fwd[2].Content.(*packets.Subscribe)
+	assert.Equal(t, subs, fs.Subscriptions, fmt.Sprintf("expected forwarded subscriptions %v got %v", subs, fs.Subscriptions))
+}
+
+func TestMQTT3SessionRewrite(t *testing.T) {
+	rewrite := "channels/1/messages/rewritten"
+	h := &handlerMock{rewrite: rewrite}
+	client, pkts, errs := startStream(t, h)
+
+	connect := mqtt3.NewControlPacket(mqtt3.Connect).(*mqtt3.ConnectPacket)
+	connect.ProtocolName = "MQTT"
+	connect.ProtocolVersion = 4
+	connect.ClientIdentifier = clientID
+
+	publish := mqtt3.NewControlPacket(mqtt3.Publish).(*mqtt3.PublishPacket)
+	publish.TopicName = topic
+	publish.Payload = payload
+
+	subscribe := mqtt3.NewControlPacket(mqtt3.Subscribe).(*mqtt3.SubscribePacket)
+	subscribe.MessageID = 1
+	subscribe.Topics = []string{topic}
+	subscribe.Qoss = []byte{1}
+
+	var fwd []mqtt3.ControlPacket
+	for _, cp := range []mqtt3.ControlPacket{connect, publish, subscribe} {
+		go func(cp mqtt3.ControlPacket) {
+			err := cp.Write(client)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		}(cp)
+
+		pkt := <-pkts
+		cp, err := mqtt3.ReadPacket(bytes.NewReader(pkt))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		fwd = append(fwd, cp)
+	}
+
+	client.Close()
+	<-errs
+
+	fp := fwd[1].(*mqtt3.PublishPacket)
+	assert.Equal(t, rewrite, fp.TopicName, fmt.Sprintf("expected forwarded topic %s got %s", rewrite, fp.TopicName))
+	assert.Equal(t, payload, fp.Payload, fmt.Sprintf("expected forwarded payload %s got %s", payload, fp.Payload))
+
+	fs := fwd[2].(*mqtt3.SubscribePacket)
+	assert.Equal(t, []string{rewrite}, fs.Topics, fmt.Sprintf("expected forwarded topics %v got %v", []string{rewrite}, fs.Topics))
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mproxy/pkg/session"
+	mptls "github.com/mainflux/mproxy/pkg/tls"
+)
+
+var upgrader = websocket.Upgrader{
+	HandshakeTimeout: 10 * time.Second,
+	// Paho JS client expects the mqtt subprotocol in the upgrade response.
+	Subprotocols: []string{"mqttv3.1", "mqtt"},
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// WebSocket proxies the MQTT over WebSocket traffic between the clients and
+// the broker.
+type WebSocket struct {
+	target  string
+	path    string
+	scheme  string
+	handler session.Handler
+	logger  logger.Logger
+}
+
+// NewWebSocket returns the MQTT over WebSocket proxy that proxies the traffic
+// to the target broker URL, built from the scheme, the target host and the
+// path.
+func NewWebSocket(target, path, scheme string, handler session.Handler, logger logger.Logger) *WebSocket {
+	return &WebSocket{
+		target:  target,
+		path:    path,
+		scheme:  scheme,
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Handler returns the HTTP handler that upgrades the client connections.
+func (p WebSocket) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			p.logger.Error(fmt.Sprintf("Failed to upgrade connection: %s", err))
+			return
+		}
+
+		go p.pass(conn)
+	})
+}
+
+// Listen serves the registered HTTP handlers on the port.
+func (p WebSocket) Listen(port string) error {
+	return http.ListenAndServe(fmt.Sprintf(":%s", port), nil)
+}
+
+func (p WebSocket) pass(in *websocket.Conn) {
+	defer in.Close()
+
+	u := url.URL{
+		Scheme: p.scheme,
+		Host:   p.target,
+		Path:   p.path,
+	}
+	dialer := &websocket.Dialer{
+		Subprotocols: []string{"mqtt"},
+	}
+	srv, _, err := dialer.Dial(u.String(), nil)
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("Failed to connect to broker %s: %s", u.String(), err))
+		return
+	}
+	defer srv.Close()
+
+	cert, err := mptls.ClientCert(in.UnderlyingConn())
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("Failed to get client certificate: %s", err))
+		return
+	}
+
+	id, err := stream(newWSConn(in), newWSConn(srv), p.handler, p.logger, cert)
+	if !errors.Contains(err, io.EOF) {
+		p.logger.Warn(fmt.Sprintf("Broken connection for client %s: %s", id, err))
+	}
+}
@@ -63,3 +63,8 @@ func (es EventStore) Connect(clientID string) error {
 func (es EventStore) Disconnect(clientID string) error {
 	return es.storeEvent(clientID, "disconnect")
 }
+
+// Publish issues event on MQTT PUBLISH
+func (es EventStore) Publish(clientID string) error {
+	return es.storeEvent(clientID, "publish")
+}
@@ -0,0 +1,29 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux"
+)
+
+const (
+	// The administrators are the members of the authorities object.
+	authoritiesObject = "authorities"
+	memberRelation    = "member"
+)
+
+// IsAdmin reports whether the user with the given ID is a member of the
+// authorities, i.e. an administrator allowed to manage the resources of all
+// the users.
+func IsAdmin(ctx context.Context, ac mainflux.AuthServiceClient, userID string) (bool, error) {
+	req := &mainflux.AuthorizeReq{Sub: userID, Obj: authoritiesObject, Act: memberRelation}
+	res, err := ac.Authorize(ctx, req)
+	if err != nil {
+		return false, err
+	}
+
+	return res.GetAuthorized(), nil
+}
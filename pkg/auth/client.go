@@ -12,7 +12,7 @@ import (
 
 // Client represents Auth cache.
 type Client interface {
-	Authorize(ctx context.Context, chanID, thingID string) error
+	Authorize(ctx context.Context, chanID, thingID, action, subtopic string) error
 	Identify(ctx context.Context, thingKey string) (string, error)
 }
 
@@ -51,14 +51,20 @@ func (c client) Identify(ctx context.Context, thingKey string) (string, error) {
 	return thingID, nil
 }
 
-func (c client) Authorize(ctx context.Context, chanID, thingID string) error {
-	if c.redisClient.SIsMember(ctx, chanPrefix+":"+chanID, thingID).Val() {
+func (c client) Authorize(ctx context.Context, chanID, thingID, action, subtopic string) error {
+	ckey := chanPrefix + ":" + chanID
+	if action != "" {
+		ckey = ckey + ":" + action
+	}
+	if c.redisClient.SIsMember(ctx, ckey, thingID).Val() {
 		return nil
 	}
 
 	ar := &mainflux.AccessByIDReq{
-		ThingID: thingID,
-		ChanID:  chanID,
+		ThingID:  thingID,
+		ChanID:   chanID,
+		Action:   action,
+		Subtopic: subtopic,
 	}
 	_, err := c.thingsClient.CanAccessByID(ctx, ar)
 	return err
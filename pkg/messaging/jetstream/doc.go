@@ -0,0 +1,11 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jetstream holds the implementation of the Publisher and PubSub
+// interfaces backed by the NATS JetStream persistence layer. Messages
+// published to the channel subjects are stored in the stream, and each
+// subscription is bound to a durable consumer which acknowledges messages
+// only after they're handled. Messages that are not acknowledged are
+// redelivered, which provides at-least-once delivery to the subscribers
+// even if they are down while the messages are published.
+package jetstream
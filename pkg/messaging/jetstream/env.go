@@ -0,0 +1,64 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	defEnabled  = "false"
+	defMaxAge   = "24h"
+	defMaxBytes = "1073741824"
+	defAckWait  = "30s"
+
+	envEnabled  = "MF_NATS_JETSTREAM"
+	envStream   = "MF_NATS_JETSTREAM_STREAM"
+	envMaxAge   = "MF_NATS_JETSTREAM_MAX_AGE"
+	envMaxBytes = "MF_NATS_JETSTREAM_MAX_BYTES"
+	envAckWait  = "MF_NATS_JETSTREAM_ACK_WAIT"
+)
+
+// LoadConfig reads whether JetStream is enabled and its configuration from
+// the environment variables shared by the services consuming the messages.
+// Unless configured otherwise, the stream keeps the messages for a day and
+// up to 1GiB of them.
+func LoadConfig() (bool, Config, error) {
+	enabled, err := strconv.ParseBool(mainflux.Env(envEnabled, defEnabled))
+	if err != nil {
+		return false, Config{}, errors.Wrap(invalid(envEnabled), err)
+	}
+
+	maxAge, err := time.ParseDuration(mainflux.Env(envMaxAge, defMaxAge))
+	if err != nil {
+		return false, Config{}, errors.Wrap(invalid(envMaxAge), err)
+	}
+
+	maxBytes, err := strconv.ParseInt(mainflux.Env(envMaxBytes, defMaxBytes), 10, 64)
+	if err != nil {
+		return false, Config{}, errors.Wrap(invalid(envMaxBytes), err)
+	}
+
+	ackWait, err := time.ParseDuration(mainflux.Env(envAckWait, defAckWait))
+	if err != nil {
+		return false, Config{}, errors.Wrap(invalid(envAckWait), err)
+	}
+
+	cfg := Config{
+		Stream:   mainflux.Env(envStream, DefStream),
+		MaxAge:   maxAge,
+		MaxBytes: maxBytes,
+		AckWait:  ackWait,
+	}
+	return enabled, cfg, nil
+}
+
+func invalid(key string) error {
+	return errors.New(fmt.Sprintf("invalid %s value", key))
+}
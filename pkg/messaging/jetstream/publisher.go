@@ -0,0 +1,55 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	broker "github.com/nats-io/nats.go"
+)
+
+var _ messaging.Publisher = (*publisher)(nil)
+
+type publisher struct {
+	conn *broker.Conn
+	js   broker.JetStreamContext
+}
+
+// Publisher wraps messaging Publisher exposing
+// Close() method for NATS connection.
+type Publisher interface {
+	messaging.Publisher
+	Close()
+}
+
+// NewPublisher returns JetStream message Publisher. Publish returns once
+// the message is stored in the stream.
+func NewPublisher(url string, cfg Config) (Publisher, error) {
+	conn, js, err := connect(url, cfg)
+	if err != nil {
+		return nil, err
+	}
+	ret := &publisher{
+		conn: conn,
+		js:   js,
+	}
+	return ret, nil
+}
+
+func (pub *publisher) Publish(topic string, msg messaging.Message) error {
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	if _, err := pub.js.Publish(subject(topic, msg.Subtopic), data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (pub *publisher) Close() {
+	pub.conn.Close()
+}
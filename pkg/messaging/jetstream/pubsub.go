@@ -0,0 +1,202 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	broker "github.com/nats-io/nats.go"
+)
+
+// serverAckWait is the acknowledgement wait the JetStream server uses unless
+// configured otherwise.
+const serverAckWait = 30 * time.Second
+
+var (
+	errAlreadySubscribed = errors.New("already subscribed to topic")
+	errNotSubscribed     = errors.New("not subscribed")
+	errEmptyTopic        = errors.New("empty topic")
+)
+
+// durableReplacer replaces the characters which durable consumer names
+// can't contain.
+var durableReplacer = strings.NewReplacer(".", "_", "*", "any", ">", "all")
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+// PubSub wraps messaging Publisher exposing
+// Close() method for NATS connection.
+type PubSub interface {
+	messaging.PubSub
+	Close()
+}
+
+type pubsub struct {
+	publisher
+	logger        log.Logger
+	mu            sync.Mutex
+	consumer      string
+	ackWait       broker.AckWait
+	maxInFlight   int
+	subscriptions map[string]*broker.Subscription
+}
+
+// NewPubSub returns JetStream message publisher/subscriber.
+// Parameter consumer specifies the name of the durable consumer created
+// for each subscribed topic. Subscribers with the same consumer name
+// share the messages, and resume consuming where they left off after
+// restart. If the consumer is empty, Subscribe creates ephemeral consumers
+// which deliver only the messages published after the subscription.
+// Messages are acknowledged only after the handler succeeds, otherwise
+// they're redelivered once the acknowledgement wait expires. The wait is
+// extended while the handler runs, so the slow handlers don't get the
+// messages they're still handling redelivered.
+func NewPubSub(url, consumer string, cfg Config, logger log.Logger) (PubSub, error) {
+	conn, js, err := connect(url, cfg)
+	if err != nil {
+		return nil, err
+	}
+	ret := &pubsub{
+		publisher: publisher{
+			conn: conn,
+			js:   js,
+		},
+		consumer:      consumer,
+		ackWait:       broker.AckWait(cfg.AckWait),
+		maxInFlight:   cfg.MaxInFlight,
+		logger:        logger,
+		subscriptions: make(map[string]*broker.Subscription),
+	}
+	return ret, nil
+}
+
+func (ps *pubsub) Subscribe(topic string, handler messaging.MessageHandler) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if _, ok := ps.subscriptions[topic]; ok {
+		return errAlreadySubscribed
+	}
+	nh := ps.natsHandler(handler)
+
+	opts := []broker.SubOpt{broker.ManualAck(), broker.AckExplicit()}
+	if ps.ackWait > 0 {
+		opts = append(opts, ps.ackWait)
+	}
+
+	if ps.consumer != "" {
+		durable := durableReplacer.Replace(fmt.Sprintf("%s_%s", ps.consumer, topic))
+		opts = append(opts, broker.Durable(durable), broker.DeliverAll())
+		sub, err := ps.js.QueueSubscribe(topic, durable, nh, opts...)
+		if err != nil {
+			return err
+		}
+		ps.subscriptions[topic] = sub
+		return nil
+	}
+
+	opts = append(opts, broker.DeliverNew())
+	sub, err := ps.js.Subscribe(topic, nh, opts...)
+	if err != nil {
+		return err
+	}
+	ps.subscriptions[topic] = sub
+	return nil
+}
+
+func (ps *pubsub) Unsubscribe(topic string) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	sub, ok := ps.subscriptions[topic]
+	if !ok {
+		return errNotSubscribed
+	}
+
+	if err := sub.Unsubscribe(); err != nil {
+		return err
+	}
+
+	delete(ps.subscriptions, topic)
+	return nil
+}
+
+// natsHandler returns the NATS handler which handles up to maxInFlight
+// messages concurrently. Once the limit is reached, the delivery of the
+// subscription messages blocks until one of them is handled.
+func (ps *pubsub) natsHandler(h messaging.MessageHandler) broker.MsgHandler {
+	if ps.maxInFlight < 2 {
+		return func(m *broker.Msg) {
+			ps.handle(m, h)
+		}
+	}
+
+	sem := make(chan struct{}, ps.maxInFlight)
+	return func(m *broker.Msg) {
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem }()
+			ps.handle(m, h)
+		}()
+	}
+}
+
+func (ps *pubsub) handle(m *broker.Msg, h messaging.MessageHandler) {
+	var msg messaging.Message
+	if err := proto.Unmarshal(m.Data, &msg); err != nil {
+		ps.logger.Warn(fmt.Sprintf("Failed to unmarshal received message: %s", err))
+		// Malformed messages are never redelivered.
+		if err := m.Term(); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to terminate received message: %s", err))
+		}
+		return
+	}
+
+	done := make(chan struct{})
+	go ps.inProgress(m, done)
+	err := h(msg)
+	close(done)
+	if err != nil {
+		ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
+		return
+	}
+	if err := m.Ack(); err != nil {
+		ps.logger.Warn(fmt.Sprintf("Failed to acknowledge Mainflux message: %s", err))
+	}
+}
+
+// inProgress resets the acknowledgement wait of the message every half of
+// the wait until done is closed, so that the message isn't redelivered while
+// the handler is still handling it, e.g. retrying a failed delivery.
+func (ps *pubsub) inProgress(m *broker.Msg, done chan struct{}) {
+	wait := time.Duration(ps.ackWait)
+	if wait <= 0 {
+		wait = serverAckWait
+	}
+	ticker := time.NewTicker(wait / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := m.InProgress(); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to extend acknowledgement wait of Mainflux message: %s", err))
+			}
+		}
+	}
+}
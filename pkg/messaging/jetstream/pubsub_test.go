@@ -0,0 +1,201 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/jetstream"
+	broker "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	topic       = "topic"
+	chansPrefix = "channels"
+	channel     = "9b7b1b3f-b1b0-46a8-a717-b8213f9eda3b"
+	subtopic    = "engine"
+	consumer    = "writer"
+)
+
+var (
+	msgChan = make(chan messaging.Message)
+	data    = []byte("payload")
+)
+
+func TestPubsub(t *testing.T) {
+	err := pubsub.Subscribe(fmt.Sprintf("%s.%s", chansPrefix, topic), handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = pubsub.Subscribe(fmt.Sprintf("%s.%s.%s", chansPrefix, topic, subtopic), handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		channel  string
+		subtopic string
+		payload  []byte
+	}{
+		{
+			desc:    "publish message with nil payload",
+			payload: nil,
+		},
+		{
+			desc:    "publish message with string payload",
+			payload: data,
+		},
+		{
+			desc:    "publish message with channel",
+			payload: data,
+			channel: channel,
+		},
+		{
+			desc:     "publish message with subtopic",
+			payload:  data,
+			subtopic: subtopic,
+		},
+		{
+			desc:     "publish message with channel and subtopic",
+			payload:  data,
+			channel:  channel,
+			subtopic: subtopic,
+		},
+	}
+
+	for _, tc := range cases {
+		expectedMsg := messaging.Message{
+			Channel:  tc.channel,
+			Subtopic: tc.subtopic,
+			Payload:  tc.payload,
+		}
+
+		err = pubsub.Publish(topic, expectedMsg)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		receivedMsg := <-msgChan
+		assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, expectedMsg, receivedMsg))
+	}
+
+	err = pubsub.Unsubscribe(fmt.Sprintf("%s.%s", chansPrefix, topic))
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = pubsub.Unsubscribe(fmt.Sprintf("%s.%s.%s", chansPrefix, topic, subtopic))
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+}
+
+func TestRedelivery(t *testing.T) {
+	ps, err := jetstream.NewPubSub(address, consumer, jetstream.Config{AckWait: ackWait}, logs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer ps.Close()
+
+	subject := fmt.Sprintf("%s.%s.redelivery", chansPrefix, topic)
+	failed := false
+	failingHandler := func(msg messaging.Message) error {
+		if !failed {
+			failed = true
+			return errors.New("consume failed")
+		}
+		msgChan <- msg
+		return nil
+	}
+	err = ps.Subscribe(subject, failingHandler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	expectedMsg := messaging.Message{Channel: channel, Subtopic: "redelivery", Payload: data}
+	err = ps.Publish(topic, expectedMsg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	select {
+	case receivedMsg := <-msgChan:
+		assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("redeliver failed message: expected %+v got %+v\n", expectedMsg, receivedMsg))
+	case <-time.After(5 * ackWait):
+		assert.Fail(t, "redeliver failed message: message not redelivered")
+	}
+}
+
+func TestSlowHandler(t *testing.T) {
+	ps, err := jetstream.NewPubSub(address, consumer, jetstream.Config{AckWait: ackWait}, logs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer ps.Close()
+
+	// The message handled for longer than the acknowledgement wait isn't
+	// redelivered meanwhile.
+	subject := fmt.Sprintf("%s.%s.slow", chansPrefix, topic)
+	var mu sync.Mutex
+	deliveries := 0
+	slowHandler := func(msg messaging.Message) error {
+		mu.Lock()
+		deliveries++
+		mu.Unlock()
+		time.Sleep(3 * ackWait)
+		return nil
+	}
+	err = ps.Subscribe(subject, slowHandler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = ps.Publish(topic, messaging.Message{Channel: channel, Subtopic: "slow", Payload: data})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	time.Sleep(5 * ackWait)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, deliveries, fmt.Sprintf("handle slowly: expected single delivery got %d", deliveries))
+}
+
+func TestDurableConsumer(t *testing.T) {
+	subject := fmt.Sprintf("%s.%s.durable", chansPrefix, topic)
+
+	ps, err := jetstream.NewPubSub(address, consumer, jetstream.Config{AckWait: ackWait}, logs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = ps.Subscribe(subject, handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ps.Close()
+
+	// Messages published while the subscriber is down are delivered once
+	// it subscribes again using the same consumer.
+	expectedMsg := messaging.Message{Channel: channel, Subtopic: "durable", Payload: data}
+	err = pubsub.Publish(topic, expectedMsg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	ps, err = jetstream.NewPubSub(address, consumer, jetstream.Config{AckWait: ackWait}, logs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer ps.Close()
+	err = ps.Subscribe(subject, handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	select {
+	case receivedMsg := <-msgChan:
+		assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("deliver message published while down: expected %+v got %+v\n", expectedMsg, receivedMsg))
+	case <-time.After(5 * ackWait):
+		assert.Fail(t, "deliver message published while down: message not delivered")
+	}
+}
+
+func TestExistingStream(t *testing.T) {
+	// The limits of the existing stream are not changed by the services
+	// configured with other limits.
+	pub, err := jetstream.NewPublisher(address, jetstream.Config{MaxAge: time.Hour, MaxMsgs: 10})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer pub.Close()
+
+	conn, err := broker.Connect(address)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer conn.Close()
+	js, err := conn.JetStream()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	info, err := js.StreamInfo(jetstream.DefStream)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, time.Duration(0), info.Config.MaxAge, fmt.Sprintf("expected unchanged max age got %s", info.Config.MaxAge))
+	assert.Equal(t, int64(-1), info.Config.MaxMsgs, fmt.Sprintf("expected unchanged max messages got %d", info.Config.MaxMsgs))
+}
+
+func handler(msg messaging.Message) error {
+	msgChan <- msg
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	broker "github.com/nats-io/nats.go"
+)
+
+const (
+	chansPrefix = "channels"
+
+	// DefStream is the name of the stream used if no stream is configured.
+	DefStream = "mainflux"
+)
+
+// SubjectAllChannels represents subject to subscribe for all the channels.
+const SubjectAllChannels = "channels.>"
+
+// Config represents the configuration of the stream the messages are
+// stored in, and of the consumers reading them. The stream limits apply
+// only if the stream doesn't exist yet.
+type Config struct {
+	// Stream is the name of the stream.
+	Stream string
+
+	// MaxAge is the maximum age of the stored messages. Messages are
+	// kept until the other limits are reached if it's zero.
+	MaxAge time.Duration
+
+	// MaxBytes is the maximum size of the stream in bytes. The size is
+	// unlimited if it's zero.
+	MaxBytes int64
+
+	// MaxMsgs is the maximum number of the stored messages. The number is
+	// unlimited if it's zero.
+	MaxMsgs int64
+
+	// AckWait is the time the consumers wait for the message to be
+	// acknowledged before redelivering it. The JetStream default is used
+	// if it's zero.
+	AckWait time.Duration
+
+	// MaxInFlight is the number of messages of a subscription handled
+	// concurrently. Messages are handled one at a time, in the order they
+	// are delivered, if it's less than two.
+	MaxInFlight int
+}
+
+// connect connects to NATS and creates the stream of the channel subjects
+// unless it already exists. The existing stream is used as it is, so that
+// the services configured with different limits don't override each other's,
+// and the limits of the existing stream are changed by the operator.
+func connect(url string, cfg Config) (*broker.Conn, broker.JetStreamContext, error) {
+	if cfg.Stream == "" {
+		cfg.Stream = DefStream
+	}
+
+	conn, err := broker.Connect(url)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	sc := &broker.StreamConfig{
+		Name:     cfg.Stream,
+		Subjects: []string{SubjectAllChannels},
+		MaxAge:   cfg.MaxAge,
+		MaxBytes: limit(cfg.MaxBytes),
+		MaxMsgs:  limit(cfg.MaxMsgs),
+		Storage:  broker.FileStorage,
+	}
+
+	_, err = js.StreamInfo(cfg.Stream)
+	if errors.Is(err, broker.ErrStreamNotFound) {
+		_, err = js.AddStream(sc)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	return conn, js, nil
+}
+
+func subject(topic string, subtopic string) string {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, subtopic)
+	}
+	return subject
+}
+
+// limit converts zero limits to the JetStream representation of unlimited.
+func limit(n int64) int64 {
+	if n <= 0 {
+		return -1
+	}
+	return n
+}
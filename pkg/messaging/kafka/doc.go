@@ -0,0 +1,13 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package kafka holds the implementation of the Publisher and PubSub
+// interfaces backed by Apache Kafka. Messages of each channel subtopic are
+// published to their own Kafka topic, named after the configured prefix,
+// the channel and the subtopic, and keyed by the publisher so that the
+// messages of the same publisher keep their order. Subscriptions read the
+// topics matching their subject patterns, which are looked up periodically.
+// The channel subject is sent in the message header as well, and the
+// subscribers filter the messages using it, since the characters Kafka
+// topic names can't contain are replaced.
+package kafka
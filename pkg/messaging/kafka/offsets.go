@@ -0,0 +1,66 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"sync"
+
+	broker "github.com/segmentio/kafka-go"
+)
+
+type partition struct {
+	topic string
+	id    int
+}
+
+// offsets tracks the messages of the consumer group which are handled
+// concurrently, so that the offset of the message is committed only once
+// all the messages preceding it in its partition are handled as well.
+type offsets struct {
+	mu      sync.Mutex
+	pending map[partition][]broker.Message
+	handled map[partition]map[int64]bool
+}
+
+func newOffsets() *offsets {
+	return &offsets{
+		pending: make(map[partition][]broker.Message),
+		handled: make(map[partition]map[int64]bool),
+	}
+}
+
+// add tracks the fetched message. Messages of each partition are added in
+// the order they are fetched in.
+func (o *offsets) add(m broker.Message) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	p := partition{topic: m.Topic, id: m.Partition}
+	o.pending[p] = append(o.pending[p], m)
+}
+
+// done marks the message as handled, and returns the last message of its
+// partition which can be committed, if any.
+func (o *offsets) done(m broker.Message) (broker.Message, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	p := partition{topic: m.Topic, id: m.Partition}
+	if o.handled[p] == nil {
+		o.handled[p] = make(map[int64]bool)
+	}
+	o.handled[p][m.Offset] = true
+
+	var last broker.Message
+	ok := false
+	pending := o.pending[p]
+	for len(pending) > 0 && o.handled[p][pending[0].Offset] {
+		last, ok = pending[0], true
+		delete(o.handled[p], pending[0].Offset)
+		pending = pending[1:]
+	}
+	o.pending[p] = pending
+
+	return last, ok
+}
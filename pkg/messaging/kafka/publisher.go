@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	broker "github.com/segmentio/kafka-go"
+)
+
+// batchTimeout limits the time the writer waits for the batch to fill up
+// before sending it, which bounds the Publish latency.
+const batchTimeout = 10 * time.Millisecond
+
+var _ messaging.Publisher = (*publisher)(nil)
+
+type publisher struct {
+	writer  *broker.Writer
+	brokers []string
+	prefix  string
+	created sync.Map
+}
+
+// Publisher wraps messaging Publisher exposing
+// Close() method for Kafka connection.
+type Publisher interface {
+	messaging.Publisher
+	Close()
+}
+
+// NewPublisher returns Kafka message Publisher. Parameter url is the
+// comma-separated list of the Kafka broker addresses. Publish returns once
+// the message is acknowledged by all the in-sync replicas.
+func NewPublisher(url string, cfg Config) (Publisher, error) {
+	return newPublisher(url, cfg)
+}
+
+// Publish publishes the message to the Kafka topic of the channel subtopic,
+// creating the topic if it doesn't exist. Messages are keyed by their
+// publisher, so that the messages of each publisher keep their order.
+func (pub *publisher) Publish(topic string, msg messaging.Message) error {
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	subject := subject(topic, msg.Subtopic)
+	name := topicName(pub.prefix, subject)
+	if err := pub.createTopic(name); err != nil {
+		return err
+	}
+
+	m := broker.Message{
+		Topic: name,
+		Key:   []byte(msg.Publisher),
+		Value: data,
+		Headers: []broker.Header{
+			{Key: subjectKey, Value: []byte(subject)},
+		},
+	}
+
+	return pub.writer.WriteMessages(context.Background(), m)
+}
+
+func (pub *publisher) Close() {
+	pub.writer.Close()
+}
+
+// createTopic creates the topic unless it has already been created by the
+// publisher.
+func (pub *publisher) createTopic(name string) error {
+	if _, ok := pub.created.Load(name); ok {
+		return nil
+	}
+
+	if err := createTopic(pub.brokers, name); err != nil {
+		return err
+	}
+	pub.created.Store(name, true)
+
+	return nil
+}
+
+func newPublisher(url string, cfg Config) (*publisher, error) {
+	if cfg.Topic == "" {
+		cfg.Topic = DefTopic
+	}
+
+	addrs := brokers(url)
+	if err := ping(addrs); err != nil {
+		return nil, err
+	}
+
+	w := &broker.Writer{
+		Addr:         broker.TCP(addrs...),
+		Balancer:     &broker.Hash{},
+		BatchTimeout: batchTimeout,
+		RequiredAcks: broker.RequireAll,
+	}
+
+	ret := &publisher{
+		writer:  w,
+		brokers: addrs,
+		prefix:  cfg.Topic,
+	}
+	return ret, nil
+}
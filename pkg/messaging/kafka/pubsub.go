@@ -0,0 +1,422 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/gogo/protobuf/proto"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	broker "github.com/segmentio/kafka-go"
+)
+
+const (
+	// commitInterval is the interval the offsets of the handled messages
+	// are committed to the consumer group in.
+	commitInterval = time.Second
+
+	// refreshInterval is the interval the topics matching the wildcard
+	// subscriptions are looked up in.
+	refreshInterval = 10 * time.Second
+)
+
+var (
+	errAlreadySubscribed = errors.New("already subscribed to topic")
+	errNotSubscribed     = errors.New("not subscribed")
+	errEmptyTopic        = errors.New("empty topic")
+	errInvalidTopic      = errors.New("topic is not a channel subject")
+)
+
+// groupReplacer replaces the subject pattern wildcards in the consumer
+// group names.
+var groupReplacer = strings.NewReplacer("*", "any", ">", "all")
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+// PubSub wraps messaging Publisher exposing
+// Close() method for Kafka connection.
+type PubSub interface {
+	messaging.PubSub
+	Close()
+}
+
+type subscription struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type pubsub struct {
+	*publisher
+	group         string
+	maxInFlight   int
+	logger        log.Logger
+	mu            sync.Mutex
+	subscriptions map[string]subscription
+}
+
+// NewPubSub returns Kafka message publisher/subscriber. Parameter url is
+// the comma-separated list of the Kafka broker addresses.
+// Parameter group specifies the consumer group for each subscribed topic.
+// Subscribers in the same group share the messages, and resume consuming
+// where they left off after restart. The offsets are committed only once
+// the messages are handled, and the messages the handler fails to handle
+// are retried until they succeed. If the group is empty, the subscriptions
+// don't join any consumer group. Instead, each of them reads all of the
+// partitions, starting from the messages published after the subscription,
+// and the failed messages are not retried.
+func NewPubSub(url, group string, cfg Config, logger log.Logger) (PubSub, error) {
+	pub, err := newPublisher(url, cfg)
+	if err != nil {
+		return nil, err
+	}
+	ret := &pubsub{
+		publisher:     pub,
+		group:         group,
+		maxInFlight:   cfg.MaxInFlight,
+		logger:        logger,
+		subscriptions: make(map[string]subscription),
+	}
+	return ret, nil
+}
+
+func (ps *pubsub) Subscribe(topic string, handler messaging.MessageHandler) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	if !strings.HasPrefix(topic, chansPrefix+".") {
+		return errInvalidTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if _, ok := ps.subscriptions[topic]; ok {
+		return errAlreadySubscribed
+	}
+
+	// Topics of the subjects without wildcards are created up front, so
+	// that the messages published after the subscription are not missed.
+	pattern := topicPattern(ps.prefix, topic)
+	if !wildcard(pattern) {
+		if err := ps.createTopic(pattern); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := subscription{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	var wg sync.WaitGroup
+	read := make(map[string]bool)
+	if ps.group == "" {
+		// Partitions are read from their end offsets as of the subscription,
+		// looked up before returning so that no later message is missed.
+		topics, err := listTopics(ctx, ps.brokers, pattern)
+		if err != nil {
+			cancel()
+			return err
+		}
+		offsets, err := lastOffsets(ctx, ps.brokers, topics)
+		if err != nil {
+			cancel()
+			return err
+		}
+		ps.readTopics(ctx, &wg, topic, offsets, read, handler)
+	}
+	go ps.subscribe(ctx, &wg, topic, pattern, read, sub.done, handler)
+
+	ps.subscriptions[topic] = sub
+	return nil
+}
+
+func (ps *pubsub) Unsubscribe(topic string) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	sub, ok := ps.subscriptions[topic]
+	if !ok {
+		return errNotSubscribed
+	}
+
+	sub.stop()
+	delete(ps.subscriptions, topic)
+	return nil
+}
+
+func (ps *pubsub) Close() {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	for topic, sub := range ps.subscriptions {
+		sub.stop()
+		delete(ps.subscriptions, topic)
+	}
+	ps.publisher.Close()
+}
+
+// subscribe looks up the Kafka topics matching the topic pattern until the
+// subscription is stopped, and reads the messages of the topics which are
+// not read yet. Topics found after the subscription is made are read from
+// their first message, since they're created after the subscription.
+func (ps *pubsub) subscribe(ctx context.Context, wg *sync.WaitGroup, subject, pattern string, read map[string]bool, done chan struct{}, h messaging.MessageHandler) {
+	defer close(done)
+	defer wg.Wait()
+
+	stop := func() {}
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		topics, err := listTopics(ctx, ps.brokers, pattern)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			ps.logger.Warn(fmt.Sprintf("Failed to look up topics of %s: %s", subject, err))
+		case err == nil && ps.group != "" && changed(read, topics):
+			// The consumer group reader has to be recreated to read the
+			// new topics, and the group resumes where it left off.
+			stop()
+			stop = ps.readGroup(ctx, wg, subject, topics, h)
+			read = names(topics)
+		case err == nil && ps.group == "":
+			ps.readTopics(ctx, wg, subject, firstOffsets(topics), read, h)
+		}
+
+		select {
+		case <-ctx.Done():
+			stop()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// readGroup reads the messages of the topics as a member of the consumer
+// group, until either the returned function is called or the subscription
+// is stopped.
+func (ps *pubsub) readGroup(ctx context.Context, wg *sync.WaitGroup, subject string, topics map[string][]int, h messaging.MessageHandler) func() {
+	if len(topics) == 0 {
+		return func() {}
+	}
+
+	r := broker.NewReader(broker.ReaderConfig{
+		Brokers:        ps.brokers,
+		GroupID:        fmt.Sprintf("%s_%s", ps.group, groupReplacer.Replace(subject)),
+		GroupTopics:    keys(topics),
+		StartOffset:    broker.FirstOffset,
+		CommitInterval: commitInterval,
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+		ps.consume(ctx, r, subject, h)
+		if err := r.Close(); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to close reader of %s: %s", subject, err))
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// readTopics reads the partitions of the topics which are not read yet,
+// starting from the given offsets, until the subscription is stopped.
+func (ps *pubsub) readTopics(ctx context.Context, wg *sync.WaitGroup, subject string, offsets map[string]map[int]int64, read map[string]bool, h messaging.MessageHandler) {
+	for topic, partitions := range offsets {
+		if read[topic] {
+			continue
+		}
+		for partition, offset := range partitions {
+			ps.readPartition(ctx, wg, subject, topic, partition, offset, h)
+		}
+		read[topic] = true
+	}
+}
+
+// readPartition reads the messages of the topic partition starting from the
+// offset, until the subscription is stopped.
+func (ps *pubsub) readPartition(ctx context.Context, wg *sync.WaitGroup, subject, topic string, partition int, offset int64, h messaging.MessageHandler) {
+	r := broker.NewReader(broker.ReaderConfig{
+		Brokers:   ps.brokers,
+		Topic:     topic,
+		Partition: partition,
+	})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer r.Close()
+
+		if err := r.SetOffset(offset); err != nil {
+			ps.logger.Error(fmt.Sprintf("Failed to read messages from %s: %s", topic, err))
+			return
+		}
+
+		for {
+			m, err := r.ReadMessage(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					ps.logger.Error(fmt.Sprintf("Failed to read messages from %s: %s", topic, err))
+				}
+				return
+			}
+
+			msg, ok := ps.decode(subject, m)
+			if !ok {
+				continue
+			}
+			if err := h(msg); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
+			}
+		}
+	}()
+}
+
+// consume reads the messages of the consumer group until the context is
+// done, and passes the ones matching the subject to the handler, handling
+// up to maxInFlight of them concurrently. The offset of the message is
+// committed once the message and all of the messages preceding it in its
+// partition are handled, so the messages which are not committed when the
+// subscriber stops are delivered again.
+func (ps *pubsub) consume(ctx context.Context, r *broker.Reader, subject string, h messaging.MessageHandler) {
+	n := ps.maxInFlight
+	if n < 1 {
+		n = 1
+	}
+	sem := make(chan struct{}, n)
+	offsets := newOffsets()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		m, err := r.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				ps.logger.Error(fmt.Sprintf("Failed to read messages from %s: %s", subject, err))
+			}
+			return
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		offsets.add(m)
+
+		wg.Add(1)
+		go func(m broker.Message) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if !ps.handle(ctx, subject, m, h) {
+				return
+			}
+			c, ok := offsets.done(m)
+			if !ok {
+				return
+			}
+			if err := r.CommitMessages(context.Background(), c); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to commit Mainflux message: %s", err))
+			}
+		}(m)
+	}
+}
+
+// handle passes the message to the handler if it matches the subject,
+// retrying it with exponential backoff until it succeeds. It returns false
+// if the subscription is stopped before the message is handled.
+func (ps *pubsub) handle(ctx context.Context, subject string, m broker.Message, h messaging.MessageHandler) bool {
+	msg, ok := ps.decode(subject, m)
+	if !ok {
+		return true
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = 0
+
+	op := func() error {
+		return h(msg)
+	}
+	notify := func(err error, d time.Duration) {
+		ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message, retrying in %s: %s", d, err))
+	}
+
+	return backoff.RetryNotify(op, backoff.WithContext(b, ctx), notify) == nil
+}
+
+// decode returns the Mainflux message if the Kafka message matches the
+// subject. Malformed messages are skipped, since handling them again fails
+// as well.
+func (ps *pubsub) decode(subject string, m broker.Message) (messaging.Message, bool) {
+	if !messaging.MatchSubject(subject, header(m, subjectKey)) {
+		return messaging.Message{}, false
+	}
+
+	var msg messaging.Message
+	if err := proto.Unmarshal(m.Value, &msg); err != nil {
+		ps.logger.Warn(fmt.Sprintf("Failed to unmarshal received message: %s", err))
+		return messaging.Message{}, false
+	}
+
+	return msg, true
+}
+
+func (sub subscription) stop() {
+	sub.cancel()
+	<-sub.done
+}
+
+func header(m broker.Message, key string) string {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func changed(read map[string]bool, topics map[string][]int) bool {
+	if len(read) != len(topics) {
+		return true
+	}
+	for topic := range topics {
+		if !read[topic] {
+			return true
+		}
+	}
+	return false
+}
+
+func names(topics map[string][]int) map[string]bool {
+	ret := make(map[string]bool)
+	for topic := range topics {
+		ret[topic] = true
+	}
+	return ret
+}
+
+func keys(topics map[string][]int) []string {
+	var ret []string
+	for topic := range topics {
+		ret = append(ret, topic)
+	}
+	return ret
+}
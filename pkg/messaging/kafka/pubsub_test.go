@@ -0,0 +1,232 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	topic       = "topic"
+	chansPrefix = "channels"
+	channel     = "9b7b1b3f-b1b0-46a8-a717-b8213f9eda3b"
+	subtopic    = "engine"
+	consumer    = "writer"
+	timeout     = 30 * time.Second
+)
+
+var (
+	msgChan = make(chan messaging.Message)
+	data    = []byte("payload")
+)
+
+func TestPubsub(t *testing.T) {
+	err := pubsub.Subscribe(fmt.Sprintf("%s.%s", chansPrefix, topic), handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = pubsub.Subscribe(fmt.Sprintf("%s.%s.%s", chansPrefix, topic, subtopic), handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		channel  string
+		subtopic string
+		payload  []byte
+	}{
+		{
+			desc:    "publish message with nil payload",
+			payload: nil,
+		},
+		{
+			desc:    "publish message with string payload",
+			payload: data,
+		},
+		{
+			desc:    "publish message with channel",
+			payload: data,
+			channel: channel,
+		},
+		{
+			desc:     "publish message with subtopic",
+			payload:  data,
+			subtopic: subtopic,
+		},
+		{
+			desc:     "publish message with channel and subtopic",
+			payload:  data,
+			channel:  channel,
+			subtopic: subtopic,
+		},
+	}
+
+	for _, tc := range cases {
+		expectedMsg := messaging.Message{
+			Channel:  tc.channel,
+			Subtopic: tc.subtopic,
+			Payload:  tc.payload,
+		}
+
+		err = pubsub.Publish(topic, expectedMsg)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		select {
+		case receivedMsg := <-msgChan:
+			assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, expectedMsg, receivedMsg))
+		case <-time.After(timeout):
+			assert.Fail(t, fmt.Sprintf("%s: message not delivered", tc.desc))
+		}
+	}
+
+	err = pubsub.Unsubscribe(fmt.Sprintf("%s.%s", chansPrefix, topic))
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = pubsub.Unsubscribe(fmt.Sprintf("%s.%s.%s", chansPrefix, topic, subtopic))
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+}
+
+func TestWildcardSubscription(t *testing.T) {
+	ps, err := kafka.NewPubSub(address, consumer, kafka.Config{Topic: "wildcards"}, logs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer ps.Close()
+
+	err = ps.Subscribe(fmt.Sprintf("%s.*.%s.>", chansPrefix, subtopic), handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// The message of the subtopic which doesn't match the subscription is
+	// published to another topic, which is not read.
+	ignoredMsg := messaging.Message{Channel: channel, Subtopic: subtopic, Payload: data}
+	err = ps.Publish(channel, ignoredMsg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	expectedMsg := messaging.Message{Channel: channel, Subtopic: fmt.Sprintf("%s.temperature", subtopic), Payload: data}
+	err = ps.Publish(channel, expectedMsg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	select {
+	case receivedMsg := <-msgChan:
+		assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("receive wildcard subscription message: expected %+v got %+v\n", expectedMsg, receivedMsg))
+	case <-time.After(timeout):
+		assert.Fail(t, "receive wildcard subscription message: message not delivered")
+	}
+}
+
+func TestConsumerGroup(t *testing.T) {
+	cfg := kafka.Config{Topic: "groups"}
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+
+	ps, err := kafka.NewPubSub(address, consumer, cfg, logs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = ps.Subscribe(subject, handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	handledMsg := messaging.Message{Channel: channel, Payload: []byte("handled")}
+	err = ps.Publish(topic, handledMsg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	select {
+	case <-msgChan:
+	case <-time.After(timeout):
+		require.Fail(t, "receive message: message not delivered")
+	}
+	ps.Close()
+
+	// Messages published while the subscriber is down are delivered once
+	// it subscribes again using the same group, and the messages which are
+	// already handled are not.
+	pub, err := kafka.NewPublisher(address, cfg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer pub.Close()
+	expectedMsg := messaging.Message{Channel: channel, Payload: data}
+	err = pub.Publish(topic, expectedMsg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	ps, err = kafka.NewPubSub(address, consumer, cfg, logs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer ps.Close()
+	err = ps.Subscribe(subject, handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	select {
+	case receivedMsg := <-msgChan:
+		assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("deliver message published while down: expected %+v got %+v\n", expectedMsg, receivedMsg))
+	case <-time.After(timeout):
+		assert.Fail(t, "deliver message published while down: message not delivered")
+	}
+}
+
+func TestHandlerFailure(t *testing.T) {
+	ps, err := kafka.NewPubSub(address, consumer, kafka.Config{Topic: "failures"}, logs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer ps.Close()
+
+	// The message the handler fails to handle is delivered again.
+	failed := false
+	err = ps.Subscribe(fmt.Sprintf("%s.%s", chansPrefix, topic), func(msg messaging.Message) error {
+		if !failed {
+			failed = true
+			return errors.New("failed to handle message")
+		}
+		return handler(msg)
+	})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	expectedMsg := messaging.Message{Channel: channel, Payload: data}
+	err = ps.Publish(topic, expectedMsg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	select {
+	case receivedMsg := <-msgChan:
+		assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("redeliver failed message: expected %+v got %+v\n", expectedMsg, receivedMsg))
+	case <-time.After(timeout):
+		assert.Fail(t, "redeliver failed message: message not delivered")
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	cfg := kafka.Config{Topic: "fanout"}
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+
+	// Subscribers without the consumer group receive all the messages
+	// published after they subscribe.
+	var chans []chan messaging.Message
+	for i := 0; i < 2; i++ {
+		ps, err := kafka.NewPubSub(address, "", cfg, logs)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		defer ps.Close()
+
+		ch := make(chan messaging.Message, 1)
+		err = ps.Subscribe(subject, func(msg messaging.Message) error {
+			ch <- msg
+			return nil
+		})
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		chans = append(chans, ch)
+	}
+
+	pub, err := kafka.NewPublisher(address, cfg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer pub.Close()
+	expectedMsg := messaging.Message{Channel: channel, Payload: data}
+	err = pub.Publish(topic, expectedMsg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i, ch := range chans {
+		select {
+		case receivedMsg := <-ch:
+			assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("subscriber %d: expected %+v got %+v\n", i, expectedMsg, receivedMsg))
+		case <-time.After(timeout):
+			assert.Fail(t, fmt.Sprintf("subscriber %d: message not delivered", i))
+		}
+	}
+}
+
+func handler(msg messaging.Message) error {
+	msgChan <- msg
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka_test
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging/kafka"
+	dockertest "github.com/ory/dockertest/v3"
+	dc "github.com/ory/dockertest/v3/docker"
+)
+
+// kafkaPort is bound to the same host port, because Kafka advertises
+// the address the clients connect to after the bootstrap.
+const kafkaPort = "29092"
+
+var (
+	address string
+	pubsub  kafka.PubSub
+	logs    logger.Logger
+)
+
+func TestMain(m *testing.M) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+
+	network, err := pool.CreateNetwork("mainflux-kafka-test")
+	if err != nil {
+		log.Fatalf("Could not create network: %s", err)
+	}
+
+	zookeeper, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Name:       "mainflux-zookeeper-test",
+		Repository: "bitnami/zookeeper",
+		Tag:        "3.7.0",
+		Networks:   []*dockertest.Network{network},
+		Env:        []string{"ALLOW_ANONYMOUS_LOGIN=yes"},
+	})
+	if err != nil {
+		log.Fatalf("Could not start container: %s", err)
+	}
+
+	container, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "bitnami/kafka",
+		Tag:        "2.8.0",
+		Networks:   []*dockertest.Network{network},
+		Env: []string{
+			"ALLOW_PLAINTEXT_LISTENER=yes",
+			"KAFKA_CFG_ZOOKEEPER_CONNECT=mainflux-zookeeper-test:2181",
+			fmt.Sprintf("KAFKA_CFG_LISTENERS=PLAINTEXT://:%s", kafkaPort),
+			fmt.Sprintf("KAFKA_CFG_ADVERTISED_LISTENERS=PLAINTEXT://localhost:%s", kafkaPort),
+		},
+		ExposedPorts: []string{fmt.Sprintf("%s/tcp", kafkaPort)},
+		PortBindings: map[dc.Port][]dc.PortBinding{
+			dc.Port(fmt.Sprintf("%s/tcp", kafkaPort)): {{HostIP: "localhost", HostPort: kafkaPort}},
+		},
+	})
+	if err != nil {
+		log.Fatalf("Could not start container: %s", err)
+	}
+	purge := func() {
+		for _, r := range []*dockertest.Resource{container, zookeeper} {
+			if err := pool.Purge(r); err != nil {
+				log.Fatalf("Could not purge container: %s", err)
+			}
+		}
+		if err := pool.RemoveNetwork(network); err != nil {
+			log.Fatalf("Could not remove network: %s", err)
+		}
+	}
+	handleInterrupt(purge)
+
+	logs, err = logger.New(os.Stdout, "error")
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	address = fmt.Sprintf("localhost:%s", kafkaPort)
+	if err := pool.Retry(func() error {
+		pubsub, err = kafka.NewPubSub(address, consumer, kafka.Config{}, logs)
+		return err
+	}); err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+
+	code := m.Run()
+	pubsub.Close()
+	purge()
+
+	os.Exit(code)
+}
+
+func handleInterrupt(purge func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-c
+		purge()
+		os.Exit(0)
+	}()
+}
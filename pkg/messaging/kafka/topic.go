@@ -0,0 +1,213 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	broker "github.com/segmentio/kafka-go"
+)
+
+const (
+	chansPrefix = "channels"
+	subjectKey  = "subject"
+
+	// DefTopic is the prefix of the Kafka topics used if no prefix is
+	// configured.
+	DefTopic = "mainflux"
+
+	requestTimeout = 10 * time.Second
+)
+
+// SubjectAllChannels represents subject to subscribe for all the channels.
+const SubjectAllChannels = "channels.>"
+
+// invalidTopicChars matches the characters Kafka topic names can't contain.
+var invalidTopicChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// Config represents the configuration of the Kafka topics the messages are
+// published to, and of the subscriptions reading them.
+type Config struct {
+	// Topic is the prefix of the Kafka topics. The messages of each
+	// channel subtopic are published to the topic named after the prefix,
+	// the channel and the subtopic, e.g. `mainflux.<channel>.<subtopic>`.
+	Topic string
+
+	// MaxInFlight is the number of messages of a subscription handled
+	// concurrently. Messages are handled one at a time, in the order they
+	// are delivered, if it's less than two.
+	MaxInFlight int
+}
+
+// createTopic creates the Kafka topic using the broker defaults for the
+// number of partitions and the replication factor, unless the topic
+// already exists.
+func createTopic(brokers []string, topic string) error {
+	client := &broker.Client{
+		Addr:    broker.TCP(brokers...),
+		Timeout: requestTimeout,
+	}
+
+	req := &broker.CreateTopicsRequest{
+		Topics: []broker.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     -1,
+				ReplicationFactor: -1,
+			},
+		},
+	}
+	res, err := client.CreateTopics(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	if err := res.Errors[topic]; err != nil && !errors.Is(err, broker.TopicAlreadyExists) {
+		return err
+	}
+	return nil
+}
+
+// ping checks whether the brokers are available by retrieving the cluster
+// metadata without the topics.
+func ping(brokers []string) error {
+	client := &broker.Client{
+		Addr:    broker.TCP(brokers...),
+		Timeout: requestTimeout,
+	}
+
+	_, err := client.Metadata(context.Background(), &broker.MetadataRequest{Topics: []string{}})
+	return err
+}
+
+// listTopics returns the partitions of the Kafka topics matching the topic
+// pattern.
+func listTopics(ctx context.Context, brokers []string, pattern string) (map[string][]int, error) {
+	client := &broker.Client{
+		Addr:    broker.TCP(brokers...),
+		Timeout: requestTimeout,
+	}
+
+	res, err := client.Metadata(ctx, &broker.MetadataRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	topics := make(map[string][]int)
+	for _, t := range res.Topics {
+		if t.Internal || t.Error != nil || !messaging.MatchSubject(pattern, t.Name) {
+			continue
+		}
+		for _, p := range t.Partitions {
+			topics[t.Name] = append(topics[t.Name], p.ID)
+		}
+	}
+
+	return topics, nil
+}
+
+// lastOffsets returns the end offsets of the topic partitions. Topics whose
+// offsets can't be looked up are left out, and are read from their first
+// offset once they're looked up again.
+func lastOffsets(ctx context.Context, brokers []string, topics map[string][]int) (map[string]map[int]int64, error) {
+	client := &broker.Client{
+		Addr:    broker.TCP(brokers...),
+		Timeout: requestTimeout,
+	}
+
+	req := &broker.ListOffsetsRequest{
+		Topics: make(map[string][]broker.OffsetRequest),
+	}
+	for topic, partitions := range topics {
+		for _, p := range partitions {
+			req.Topics[topic] = append(req.Topics[topic], broker.LastOffsetOf(p))
+		}
+	}
+	if len(req.Topics) == 0 {
+		return map[string]map[int]int64{}, nil
+	}
+
+	res, err := client.ListOffsets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := make(map[string]map[int]int64)
+	for topic, partitions := range res.Topics {
+		ret := make(map[int]int64)
+		for _, p := range partitions {
+			if p.Error != nil {
+				ret = nil
+				break
+			}
+			ret[p.Partition] = p.LastOffset
+		}
+		if len(ret) == len(topics[topic]) {
+			offsets[topic] = ret
+		}
+	}
+
+	return offsets, nil
+}
+
+// firstOffsets returns the first offsets of the topic partitions.
+func firstOffsets(topics map[string][]int) map[string]map[int]int64 {
+	offsets := make(map[string]map[int]int64)
+	for topic, partitions := range topics {
+		offsets[topic] = make(map[int]int64)
+		for _, p := range partitions {
+			offsets[topic][p] = broker.FirstOffset
+		}
+	}
+	return offsets
+}
+
+func brokers(url string) []string {
+	return strings.Split(url, ",")
+}
+
+func subject(topic string, subtopic string) string {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, subtopic)
+	}
+	return subject
+}
+
+// topicName returns the name of the Kafka topic the messages published to
+// the subject are stored in. The characters Kafka topic names can't contain
+// are replaced, so the subscribers filter the messages using the subject
+// header as well.
+func topicName(prefix, subject string) string {
+	name := strings.TrimPrefix(subject, chansPrefix)
+	return prefix + invalidTopicChars.ReplaceAllString(name, "_")
+}
+
+// topicPattern returns the pattern of the Kafka topics the messages
+// published to the subjects matching the subject pattern are stored in.
+func topicPattern(prefix, pattern string) string {
+	tokens := strings.Split(strings.TrimPrefix(pattern, chansPrefix), ".")
+	for i, token := range tokens {
+		if token != "*" && token != ">" {
+			tokens[i] = invalidTopicChars.ReplaceAllString(token, "_")
+		}
+	}
+
+	return prefix + strings.Join(tokens, ".")
+}
+
+func wildcard(pattern string) bool {
+	for _, token := range strings.Split(pattern, ".") {
+		if token == "*" || token == ">" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"fmt"
+	"sync"
+
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const chansPrefix = "channels"
+
+// SubjectAllChannels represents subject to subscribe for all the channels.
+const SubjectAllChannels = "channels.>"
+
+// bufferSize is the number of the messages buffered for each subscription.
+// Like NATS does for the slow consumers, the messages which don't fit in
+// the buffer are dropped.
+const bufferSize = 1024
+
+// Broker routes the published messages to the subscriptions of all the
+// PubSubs created from it.
+type Broker struct {
+	mu   sync.Mutex
+	subs []*subscription
+	// next holds the round-robin counters of the queue groups.
+	next map[string]uint64
+}
+
+// NewBroker returns the in-process message broker.
+func NewBroker() *Broker {
+	return &Broker{
+		next: make(map[string]uint64),
+	}
+}
+
+type subscription struct {
+	topic   string
+	queue   string
+	handler messaging.MessageHandler
+	logger  log.Logger
+	msgs    chan messaging.Message
+	done    chan struct{}
+}
+
+func (b *Broker) subscribe(sub *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subs = append(b.subs, sub)
+	go sub.run()
+}
+
+func (b *Broker) unsubscribe(sub *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, s := range b.subs {
+		if s == sub {
+			b.subs = append(b.subs[:i], b.subs[i+1:]...)
+			break
+		}
+	}
+	close(sub.done)
+}
+
+// publish delivers the message to all the matching subscriptions which are
+// not in a queue group, and to one of the matching subscriptions of each
+// queue group.
+func (b *Broker) publish(subject string, msg messaging.Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	groups := make(map[string][]*subscription)
+	for _, sub := range b.subs {
+		if !messaging.MatchSubject(sub.topic, subject) {
+			continue
+		}
+		if sub.queue == "" {
+			sub.deliver(msg)
+			continue
+		}
+		group := fmt.Sprintf("%s:%s", sub.queue, sub.topic)
+		groups[group] = append(groups[group], sub)
+	}
+
+	for group, subs := range groups {
+		n := b.next[group]
+		b.next[group] = n + 1
+		subs[n%uint64(len(subs))].deliver(msg)
+	}
+}
+
+func (sub *subscription) deliver(msg messaging.Message) {
+	select {
+	case sub.msgs <- msg:
+	default:
+		sub.logger.Warn(fmt.Sprintf("Dropped message published to %s: slow consumer", sub.topic))
+	}
+}
+
+// run passes the delivered messages to the handler until the subscription
+// is cancelled.
+func (sub *subscription) run() {
+	for {
+		select {
+		case msg := <-sub.msgs:
+			if err := sub.handler(msg); err != nil {
+				sub.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
+			}
+		case <-sub.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memory holds the in-process implementation of the Publisher and
+// PubSub interfaces. The messages are routed through the Broker shared by
+// the PubSubs created from it, using the NATS subject semantics, so the
+// services running in the same process can exchange messages without the
+// external message broker.
+package memory
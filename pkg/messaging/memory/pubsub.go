@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var (
+	errAlreadySubscribed = errors.New("already subscribed to topic")
+	errNotSubscribed     = errors.New("not subscribed")
+	errEmptyTopic        = errors.New("empty topic")
+)
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+// PubSub wraps messaging PubSub exposing Close() method which cancels
+// all the subscriptions.
+type PubSub interface {
+	messaging.PubSub
+	Close()
+}
+
+type pubsub struct {
+	broker        *Broker
+	queue         string
+	logger        log.Logger
+	mu            sync.Mutex
+	subscriptions map[string]*subscription
+}
+
+// NewPubSub returns in-memory message publisher/subscriber connected to
+// the broker. Parameter queue specifies the queue group for the Subscribe
+// method. Subscriptions to the same topic within the same queue group
+// share the messages, as the NATS queue subscriptions do. If the queue is
+// empty, each subscription receives all the messages.
+func NewPubSub(broker *Broker, queue string, logger log.Logger) PubSub {
+	return &pubsub{
+		broker:        broker,
+		queue:         queue,
+		logger:        logger,
+		subscriptions: make(map[string]*subscription),
+	}
+}
+
+func (ps *pubsub) Publish(topic string, msg messaging.Message) error {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	ps.broker.publish(subject, msg)
+	return nil
+}
+
+func (ps *pubsub) Subscribe(topic string, handler messaging.MessageHandler) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if _, ok := ps.subscriptions[topic]; ok {
+		return errAlreadySubscribed
+	}
+
+	sub := &subscription{
+		topic:   topic,
+		queue:   ps.queue,
+		handler: handler,
+		logger:  ps.logger,
+		msgs:    make(chan messaging.Message, bufferSize),
+		done:    make(chan struct{}),
+	}
+	ps.broker.subscribe(sub)
+	ps.subscriptions[topic] = sub
+	return nil
+}
+
+func (ps *pubsub) Unsubscribe(topic string) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	sub, ok := ps.subscriptions[topic]
+	if !ok {
+		return errNotSubscribed
+	}
+
+	ps.broker.unsubscribe(sub)
+	delete(ps.subscriptions, topic)
+	return nil
+}
+
+func (ps *pubsub) Close() {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	for topic, sub := range ps.subscriptions {
+		ps.broker.unsubscribe(sub)
+		delete(ps.subscriptions, topic)
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package memory_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	topic       = "topic"
+	chansPrefix = "channels"
+	channel     = "9b7b1b3f-b1b0-46a8-a717-b8213f9eda3b"
+	subtopic    = "engine"
+	queue       = "writers"
+	timeout     = time.Second
+)
+
+var data = []byte("payload")
+
+func newPubSub(t *testing.T, b *memory.Broker, queue string) memory.PubSub {
+	logs, err := logger.New(os.Stdout, "error")
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	return memory.NewPubSub(b, queue, logs)
+}
+
+func TestPubsub(t *testing.T) {
+	pubsub := newPubSub(t, memory.NewBroker(), "")
+	defer pubsub.Close()
+
+	msgs := make(chan messaging.Message)
+	handler := func(msg messaging.Message) error {
+		msgs <- msg
+		return nil
+	}
+	err := pubsub.Subscribe(fmt.Sprintf("%s.%s", chansPrefix, topic), handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = pubsub.Subscribe(fmt.Sprintf("%s.%s.%s", chansPrefix, topic, subtopic), handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		channel  string
+		subtopic string
+		payload  []byte
+	}{
+		{
+			desc:    "publish message with nil payload",
+			payload: nil,
+		},
+		{
+			desc:    "publish message with string payload",
+			payload: data,
+		},
+		{
+			desc:    "publish message with channel",
+			payload: data,
+			channel: channel,
+		},
+		{
+			desc:     "publish message with subtopic",
+			payload:  data,
+			subtopic: subtopic,
+		},
+		{
+			desc:     "publish message with channel and subtopic",
+			payload:  data,
+			channel:  channel,
+			subtopic: subtopic,
+		},
+	}
+
+	for _, tc := range cases {
+		expectedMsg := messaging.Message{
+			Channel:  tc.channel,
+			Subtopic: tc.subtopic,
+			Payload:  tc.payload,
+		}
+
+		err = pubsub.Publish(topic, expectedMsg)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		receivedMsg := receive(t, msgs)
+		assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, expectedMsg, receivedMsg))
+		assertEmpty(t, tc.desc, msgs)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	pubsub := newPubSub(t, memory.NewBroker(), "")
+	defer pubsub.Close()
+
+	handler := func(msg messaging.Message) error { return nil }
+	err := pubsub.Subscribe(memory.SubjectAllChannels, handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		topic string
+		err   error
+	}{
+		{
+			desc:  "subscribe to topic",
+			topic: fmt.Sprintf("%s.%s", chansPrefix, topic),
+			err:   nil,
+		},
+		{
+			desc:  "subscribe to the same topic",
+			topic: memory.SubjectAllChannels,
+			err:   fmt.Errorf("already subscribed to topic"),
+		},
+		{
+			desc:  "subscribe to empty topic",
+			topic: "",
+			err:   fmt.Errorf("empty topic"),
+		},
+	}
+
+	for _, tc := range cases {
+		err := pubsub.Subscribe(tc.topic, handler)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	pubsub := newPubSub(t, memory.NewBroker(), "")
+	defer pubsub.Close()
+
+	msgs := make(chan messaging.Message)
+	handler := func(msg messaging.Message) error {
+		msgs <- msg
+		return nil
+	}
+	err := pubsub.Subscribe(memory.SubjectAllChannels, handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		topic string
+		err   error
+	}{
+		{
+			desc:  "unsubscribe from topic",
+			topic: memory.SubjectAllChannels,
+			err:   nil,
+		},
+		{
+			desc:  "unsubscribe from topic twice",
+			topic: memory.SubjectAllChannels,
+			err:   fmt.Errorf("not subscribed"),
+		},
+		{
+			desc:  "unsubscribe from empty topic",
+			topic: "",
+			err:   fmt.Errorf("empty topic"),
+		},
+	}
+
+	for _, tc := range cases {
+		err := pubsub.Unsubscribe(tc.topic)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = pubsub.Publish(topic, messaging.Message{Channel: channel, Payload: data})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assertEmpty(t, "publish after unsubscribe", msgs)
+}
+
+func TestWildcardSubscription(t *testing.T) {
+	pubsub := newPubSub(t, memory.NewBroker(), "")
+	defer pubsub.Close()
+
+	msgs := make(chan messaging.Message)
+	handler := func(msg messaging.Message) error {
+		msgs <- msg
+		return nil
+	}
+	err := pubsub.Subscribe(fmt.Sprintf("%s.*.%s.>", chansPrefix, subtopic), handler)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		subtopic string
+		received bool
+	}{
+		{
+			desc:     "publish message without subtopic",
+			subtopic: "",
+			received: false,
+		},
+		{
+			desc:     "publish message to matching subtopic",
+			subtopic: fmt.Sprintf("%s.temperature", subtopic),
+			received: true,
+		},
+		{
+			desc:     "publish message to nested matching subtopic",
+			subtopic: fmt.Sprintf("%s.temperature.inlet", subtopic),
+			received: true,
+		},
+		{
+			desc:     "publish message to subtopic without remaining tokens",
+			subtopic: subtopic,
+			received: false,
+		},
+	}
+
+	for _, tc := range cases {
+		expectedMsg := messaging.Message{Channel: channel, Subtopic: tc.subtopic, Payload: data}
+		err := pubsub.Publish(channel, expectedMsg)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		if !tc.received {
+			assertEmpty(t, tc.desc, msgs)
+			continue
+		}
+		receivedMsg := receive(t, msgs)
+		assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, expectedMsg, receivedMsg))
+	}
+}
+
+func TestQueueSubscription(t *testing.T) {
+	broker := memory.NewBroker()
+	publisher := newPubSub(t, broker, "")
+	defer publisher.Close()
+
+	// Subscribers in the same queue group share the messages, while the
+	// subscriber without the queue receives all of them.
+	shared := make(chan messaging.Message, 2)
+	all := make(chan messaging.Message, 2)
+	for i := 0; i < 2; i++ {
+		ps := newPubSub(t, broker, queue)
+		defer ps.Close()
+		err := ps.Subscribe(memory.SubjectAllChannels, func(msg messaging.Message) error {
+			shared <- msg
+			return nil
+		})
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+	err := publisher.Subscribe(memory.SubjectAllChannels, func(msg messaging.Message) error {
+		all <- msg
+		return nil
+	})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i := 0; i < 2; i++ {
+		err := publisher.Publish(topic, messaging.Message{Channel: channel, Payload: data})
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	for i := 0; i < 2; i++ {
+		receive(t, shared)
+		receive(t, all)
+	}
+	assertEmpty(t, "receive queue group messages", shared)
+}
+
+func receive(t *testing.T, msgs chan messaging.Message) messaging.Message {
+	select {
+	case msg := <-msgs:
+		return msg
+	case <-time.After(timeout):
+		require.Fail(t, "message not delivered")
+	}
+	return messaging.Message{}
+}
+
+func assertEmpty(t *testing.T, desc string, msgs chan messaging.Message) {
+	select {
+	case msg := <-msgs:
+		assert.Fail(t, fmt.Sprintf("%s: unexpected message %+v", desc, msg))
+	case <-time.After(timeout / 10):
+	}
+}
@@ -9,7 +9,9 @@ type Publisher interface {
 	Publish(topic string, msg Message) error
 }
 
-// MessageHandler represents Message handler for Subscriber.
+// MessageHandler represents Message handler for Subscriber. Subscribers
+// which guarantee delivery acknowledge the message only if the handler
+// returns nil, so that the message is redelivered otherwise.
 type MessageHandler func(msg Message) error
 
 // Subscriber specifies message subscription API.
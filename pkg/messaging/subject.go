@@ -0,0 +1,28 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import "strings"
+
+// MatchSubject reports whether the subject matches the NATS-style subject
+// pattern, where the '*' token matches any single token and the '>' token
+// matches one or more remaining tokens.
+func MatchSubject(pattern, subject string) bool {
+	pts := strings.Split(pattern, ".")
+	sts := strings.Split(subject, ".")
+
+	for i, pt := range pts {
+		if pt == ">" {
+			return len(sts) > i
+		}
+		if i >= len(sts) {
+			return false
+		}
+		if pt != "*" && pt != sts[i] {
+			return false
+		}
+	}
+
+	return len(pts) == len(sts)
+}
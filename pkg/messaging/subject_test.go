@@ -0,0 +1,81 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchSubject(t *testing.T) {
+	cases := []struct {
+		desc    string
+		pattern string
+		subject string
+		match   bool
+	}{
+		{
+			desc:    "match equal subject",
+			pattern: "channels.1.temp",
+			subject: "channels.1.temp",
+			match:   true,
+		},
+		{
+			desc:    "match different subject",
+			pattern: "channels.1.temp",
+			subject: "channels.2.temp",
+			match:   false,
+		},
+		{
+			desc:    "match subject with more tokens",
+			pattern: "channels.1",
+			subject: "channels.1.temp",
+			match:   false,
+		},
+		{
+			desc:    "match subject with less tokens",
+			pattern: "channels.1.temp",
+			subject: "channels.1",
+			match:   false,
+		},
+		{
+			desc:    "match single token wildcard",
+			pattern: "channels.*.temp",
+			subject: "channels.1.temp",
+			match:   true,
+		},
+		{
+			desc:    "match single token wildcard with more tokens",
+			pattern: "channels.*",
+			subject: "channels.1.temp",
+			match:   false,
+		},
+		{
+			desc:    "match all channels",
+			pattern: "channels.>",
+			subject: "channels.1.temp.room",
+			match:   true,
+		},
+		{
+			desc:    "match full wildcard without remaining tokens",
+			pattern: "channels.1.>",
+			subject: "channels.1",
+			match:   false,
+		},
+		{
+			desc:    "match empty subject",
+			pattern: "channels.>",
+			subject: "",
+			match:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		match := messaging.MatchSubject(tc.pattern, tc.subject)
+		assert.Equal(t, tc.match, match, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.match, match))
+	}
+}
@@ -78,8 +78,17 @@ func (sdk mfSDK) CreateChannels(chs []Channel, token string) ([]Channel, error)
 	return ccr.Channels, nil
 }
 
-func (sdk mfSDK) Channels(token string, offset, limit uint64, name string) (ChannelsPage, error) {
-	endpoint := fmt.Sprintf("%s?offset=%d&limit=%d&name=%s", channelsEndpoint, offset, limit, name)
+func (sdk mfSDK) Channels(token string, offset, limit uint64, name, status string) (ChannelsPage, error) {
+	endpoint := fmt.Sprintf("%s?offset=%d&limit=%d&name=%s&status=%s", channelsEndpoint, offset, limit, name, status)
+	return sdk.channels(token, endpoint)
+}
+
+func (sdk mfSDK) ChannelsAfter(token, cursor string, limit uint64, name, status string) (ChannelsPage, error) {
+	endpoint := fmt.Sprintf("%s?cursor=%s&limit=%d&name=%s&status=%s", channelsEndpoint, cursor, limit, name, status)
+	return sdk.channels(token, endpoint)
+}
+
+func (sdk mfSDK) channels(token, endpoint string) (ChannelsPage, error) {
 	url := createURL(sdk.baseURL, sdk.thingsPrefix, endpoint)
 
 	req, err := http.NewRequest(http.MethodGet, url, nil)
@@ -220,3 +229,13 @@ func (sdk mfSDK) DeleteChannel(id, token string) error {
 
 	return nil
 }
+
+func (sdk mfSDK) EnableChannel(id, token string) error {
+	endpoint := fmt.Sprintf("%s/%s/enable", channelsEndpoint, id)
+	return sdk.updateStatus(endpoint, token)
+}
+
+func (sdk mfSDK) DisableChannel(id, token string) error {
+	endpoint := fmt.Sprintf("%s/%s/disable", channelsEndpoint, id)
+	return sdk.updateStatus(endpoint, token)
+}
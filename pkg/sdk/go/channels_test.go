@@ -165,6 +165,8 @@ func TestChannel(t *testing.T) {
 	mainfluxSDK := sdk.NewSDK(sdkConf)
 	id, err := mainfluxSDK.CreateChannel(channel, token)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ch := channel
+	ch.Status = "enabled"
 
 	cases := []struct {
 		desc     string
@@ -178,7 +180,7 @@ func TestChannel(t *testing.T) {
 			chanID:   id,
 			token:    token,
 			err:      nil,
-			response: channel,
+			response: ch,
 		},
 		{
 			desc:     "get non-existent channel",
@@ -222,6 +224,7 @@ func TestChannels(t *testing.T) {
 	for i := 1; i < 101; i++ {
 		ch := sdk.Channel{ID: fmt.Sprintf("%03d", i), Name: "test"}
 		mainfluxSDK.CreateChannel(ch, token)
+		ch.Status = "enabled"
 		channels = append(channels, ch)
 	}
 
@@ -231,6 +234,7 @@ func TestChannels(t *testing.T) {
 		offset   uint64
 		limit    uint64
 		name     string
+		status   string
 		err      error
 		response []sdk.Channel
 	}{
@@ -282,9 +286,36 @@ func TestChannels(t *testing.T) {
 			err:      nil,
 			response: []sdk.Channel{},
 		},
+		{
+			desc:     "get a list of enabled channels",
+			token:    token,
+			offset:   0,
+			limit:    5,
+			status:   "enabled",
+			err:      nil,
+			response: channels[0:5],
+		},
+		{
+			desc:     "get a list of disabled channels",
+			token:    token,
+			offset:   0,
+			limit:    5,
+			status:   "disabled",
+			err:      nil,
+			response: []sdk.Channel{},
+		},
+		{
+			desc:     "get a list of channels with invalid status",
+			token:    token,
+			offset:   0,
+			limit:    5,
+			status:   wrongValue,
+			err:      createError(sdk.ErrFailedFetch, http.StatusBadRequest),
+			response: nil,
+		},
 	}
 	for _, tc := range cases {
-		page, err := mainfluxSDK.Channels(tc.token, tc.offset, tc.limit, tc.name)
+		page, err := mainfluxSDK.Channels(tc.token, tc.offset, tc.limit, tc.name, tc.status)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 		assert.Equal(t, tc.response, page.Channels, fmt.Sprintf("%s: expected response channel %s, got %s", tc.desc, tc.response, page.Channels))
 	}
@@ -314,8 +345,9 @@ func TestChannelsByThing(t *testing.T) {
 	var channels []sdk.Channel
 	for i := 1; i < n+1; i++ {
 		ch := sdk.Channel{
-			ID:   fmt.Sprintf("%03d", i),
-			Name: "test",
+			ID:     fmt.Sprintf("%03d", i),
+			Name:   "test",
+			Status: "enabled",
 		}
 		cid, err := mainfluxSDK.CreateChannel(ch, token)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
@@ -556,3 +588,118 @@ func TestDeleteChannel(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 	}
 }
+
+func TestEnableChannel(t *testing.T) {
+	svc := newThingsService(map[string]string{token: email})
+	ts := newThingsServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		BaseURL:           ts.URL,
+		UsersPrefix:       "",
+		GroupsPrefix:      "",
+		ThingsPrefix:      "",
+		HTTPAdapterPrefix: "",
+		MsgContentType:    contentType,
+		TLSVerification:   false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	id, err := mainfluxSDK.CreateChannel(channel, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = mainfluxSDK.DisableChannel(id, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		chanID string
+		token  string
+		err    error
+	}{
+		{
+			desc:   "enable channel with invalid token",
+			chanID: id,
+			token:  wrongValue,
+			err:    createError(sdk.ErrFailedUpdate, http.StatusUnauthorized),
+		},
+		{
+			desc:   "enable non-existing channel",
+			chanID: badID,
+			token:  token,
+			err:    createError(sdk.ErrFailedUpdate, http.StatusNotFound),
+		},
+		{
+			desc:   "enable disabled channel",
+			chanID: id,
+			token:  token,
+			err:    nil,
+		},
+		{
+			desc:   "enable enabled channel",
+			chanID: id,
+			token:  token,
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := mainfluxSDK.EnableChannel(tc.chanID, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestDisableChannel(t *testing.T) {
+	svc := newThingsService(map[string]string{token: email})
+	ts := newThingsServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		BaseURL:           ts.URL,
+		UsersPrefix:       "",
+		GroupsPrefix:      "",
+		ThingsPrefix:      "",
+		HTTPAdapterPrefix: "",
+		MsgContentType:    contentType,
+		TLSVerification:   false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	id, err := mainfluxSDK.CreateChannel(channel, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		chanID string
+		token  string
+		err    error
+		status string
+	}{
+		{
+			desc:   "disable channel with invalid token",
+			chanID: id,
+			token:  wrongValue,
+			err:    createError(sdk.ErrFailedUpdate, http.StatusUnauthorized),
+			status: "enabled",
+		},
+		{
+			desc:   "disable non-existing channel",
+			chanID: badID,
+			token:  token,
+			err:    createError(sdk.ErrFailedUpdate, http.StatusNotFound),
+			status: "enabled",
+		},
+		{
+			desc:   "disable existing channel",
+			chanID: id,
+			token:  token,
+			err:    nil,
+			status: "disabled",
+		},
+	}
+
+	for _, tc := range cases {
+		err := mainfluxSDK.DisableChannel(tc.chanID, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		ch, err := mainfluxSDK.Channel(id, token)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, tc.status, ch.Status, fmt.Sprintf("%s: expected status %s, got %s", tc.desc, tc.status, ch.Status))
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -42,14 +44,30 @@ func (sdk mfSDK) SendMessage(chanName, msg, token string) error {
 }
 
 func (sdk mfSDK) ReadMessages(chanName, token string) (MessagesPage, error) {
+	return sdk.readMessages(chanName, token, url.Values{})
+}
+
+func (sdk mfSDK) ReadMessagesAfter(chanName, token, cursor string, limit uint64) (MessagesPage, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.FormatUint(limit, 10))
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	return sdk.readMessages(chanName, token, query)
+}
+
+func (sdk mfSDK) readMessages(chanName, token string, query url.Values) (MessagesPage, error) {
 	chanNameParts := strings.SplitN(chanName, ".", 2)
 	chanID := chanNameParts[0]
-	subtopicPart := ""
 	if len(chanNameParts) == 2 {
-		subtopicPart = fmt.Sprintf("?subtopic=%s", strings.Replace(chanNameParts[1], ".", "/", -1))
+		query.Set("subtopic", strings.Replace(chanNameParts[1], ".", "/", -1))
 	}
 
-	endpoint := fmt.Sprintf("channels/%s/messages%s", chanID, subtopicPart)
+	endpoint := fmt.Sprintf("channels/%s/messages", chanID)
+	if len(query) > 0 {
+		endpoint = fmt.Sprintf("%s?%s", endpoint, query.Encode())
+	}
 	url := createURL(sdk.readerURL, "", endpoint)
 
 	req, err := http.NewRequest(http.MethodGet, url, nil)
@@ -18,9 +18,10 @@ type createChannelsRes struct {
 }
 
 type pageRes struct {
-	Total  uint64 `json:"total"`
-	Offset uint64 `json:"offset"`
-	Limit  uint64 `json:"limit"`
+	Total      uint64 `json:"total"`
+	Offset     uint64 `json:"offset"`
+	Limit      uint64 `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // ThingsPage contains list of things in a page with proper metadata.
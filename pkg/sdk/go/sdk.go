@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/mainflux/mainflux/auth"
 )
@@ -105,6 +106,15 @@ type Thing struct {
 	Name     string                 `json:"name,omitempty"`
 	Key      string                 `json:"key,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Status   string                 `json:"status,omitempty"`
+}
+
+// Presence represents mainflux thing presence.
+type Presence struct {
+	Online         bool       `json:"online"`
+	ConnectedAt    *time.Time `json:"connected_at,omitempty"`
+	DisconnectedAt *time.Time `json:"disconnected_at,omitempty"`
+	LastSeen       *time.Time `json:"last_seen,omitempty"`
 }
 
 // Channel represents mainflux channel.
@@ -112,6 +122,7 @@ type Channel struct {
 	ID       string                 `json:"id,omitempty"`
 	Name     string                 `json:"name,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Status   string                 `json:"status,omitempty"`
 }
 
 // SDK contains Mainflux API.
@@ -137,8 +148,14 @@ type SDK interface {
 	// CreateThings registers new things and returns their ids.
 	CreateThings(things []Thing, token string) ([]Thing, error)
 
-	// Things returns page of things.
-	Things(token string, offset, limit uint64, name string) (ThingsPage, error)
+	// Things returns page of things. Things can be filtered by status, while
+	// the empty status selects things of all statuses.
+	Things(token string, offset, limit uint64, name, status string) (ThingsPage, error)
+
+	// ThingsAfter returns page of things that follow the given cursor, in the
+	// order of their IDs. The empty cursor selects the first page, and the
+	// next cursor of the returned page selects the following one.
+	ThingsAfter(token, cursor string, limit uint64, name, status string) (ThingsPage, error)
 
 	// ThingsByChannel returns page of things that are connected or not connected
 	// to specified channel.
@@ -153,6 +170,15 @@ type SDK interface {
 	// DeleteThing removes existing thing.
 	DeleteThing(id, token string) error
 
+	// EnableThing enables disabled thing.
+	EnableThing(id, token string) error
+
+	// DisableThing disables thing without removing it.
+	DisableThing(id, token string) error
+
+	// ThingPresence returns presence of the thing.
+	ThingPresence(id, token string) (Presence, error)
+
 	// CreateGroup creates new group and returns its id.
 	CreateGroup(group Group, token string) (string, error)
 
@@ -198,8 +224,14 @@ type SDK interface {
 	// CreateChannels registers new channels and returns their ids.
 	CreateChannels(channels []Channel, token string) ([]Channel, error)
 
-	// Channels returns page of channels.
-	Channels(token string, offset, limit uint64, name string) (ChannelsPage, error)
+	// Channels returns page of channels. Channels can be filtered by status,
+	// while the empty status selects channels of all statuses.
+	Channels(token string, offset, limit uint64, name, status string) (ChannelsPage, error)
+
+	// ChannelsAfter returns page of channels that follow the given cursor, in
+	// the order of their IDs. The empty cursor selects the first page, and the
+	// next cursor of the returned page selects the following one.
+	ChannelsAfter(token, cursor string, limit uint64, name, status string) (ChannelsPage, error)
 
 	// ChannelsByThing returns page of channels that are connected or not connected
 	// to specified thing.
@@ -214,12 +246,23 @@ type SDK interface {
 	// DeleteChannel removes existing channel.
 	DeleteChannel(id, token string) error
 
+	// EnableChannel enables disabled channel.
+	EnableChannel(id, token string) error
+
+	// DisableChannel disables channel without removing it.
+	DisableChannel(id, token string) error
+
 	// SendMessage send message to specified channel.
 	SendMessage(chanID, msg, token string) error
 
 	// ReadMessages read messages of specified channel.
 	ReadMessages(chanID, token string) (MessagesPage, error)
 
+	// ReadMessagesAfter reads page of messages of specified channel that
+	// follow the given cursor, from the latest one. The empty cursor selects
+	// the first page.
+	ReadMessagesAfter(chanID, token, cursor string, limit uint64) (MessagesPage, error)
+
 	// SetContentType sets message content type.
 	SetContentType(ct ContentType) error
 
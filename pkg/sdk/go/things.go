@@ -81,8 +81,17 @@ func (sdk mfSDK) CreateThings(things []Thing, token string) ([]Thing, error) {
 	return ctr.Things, nil
 }
 
-func (sdk mfSDK) Things(token string, offset, limit uint64, name string) (ThingsPage, error) {
-	endpoint := fmt.Sprintf("%s?offset=%d&limit=%d&name=%s", thingsEndpoint, offset, limit, name)
+func (sdk mfSDK) Things(token string, offset, limit uint64, name, status string) (ThingsPage, error) {
+	endpoint := fmt.Sprintf("%s?offset=%d&limit=%d&name=%s&status=%s", thingsEndpoint, offset, limit, name, status)
+	return sdk.things(token, endpoint)
+}
+
+func (sdk mfSDK) ThingsAfter(token, cursor string, limit uint64, name, status string) (ThingsPage, error) {
+	endpoint := fmt.Sprintf("%s?cursor=%s&limit=%d&name=%s&status=%s", thingsEndpoint, cursor, limit, name, status)
+	return sdk.things(token, endpoint)
+}
+
+func (sdk mfSDK) things(token, endpoint string) (ThingsPage, error) {
 	url := createURL(sdk.baseURL, sdk.thingsPrefix, endpoint)
 
 	req, err := http.NewRequest(http.MethodGet, url, nil)
@@ -224,6 +233,68 @@ func (sdk mfSDK) DeleteThing(id, token string) error {
 	return nil
 }
 
+func (sdk mfSDK) EnableThing(id, token string) error {
+	endpoint := fmt.Sprintf("%s/%s/enable", thingsEndpoint, id)
+	return sdk.updateStatus(endpoint, token)
+}
+
+func (sdk mfSDK) DisableThing(id, token string) error {
+	endpoint := fmt.Sprintf("%s/%s/disable", thingsEndpoint, id)
+	return sdk.updateStatus(endpoint, token)
+}
+
+func (sdk mfSDK) ThingPresence(id, token string) (Presence, error) {
+	endpoint := fmt.Sprintf("%s/%s/status", thingsEndpoint, id)
+	url := createURL(sdk.baseURL, sdk.thingsPrefix, endpoint)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return Presence{}, err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return Presence{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Presence{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Presence{}, errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+	}
+
+	var p Presence
+	if err := json.Unmarshal(body, &p); err != nil {
+		return Presence{}, err
+	}
+
+	return p, nil
+}
+
+func (sdk mfSDK) updateStatus(endpoint, token string) error {
+	url := createURL(sdk.baseURL, sdk.thingsPrefix, endpoint)
+
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrap(ErrFailedUpdate, errors.New(resp.Status))
+	}
+
+	return nil
+}
+
 func (sdk mfSDK) Connect(connIDs ConnectionIDs, token string) error {
 	data, err := json.Marshal(connIDs)
 	if err != nil {
@@ -4,10 +4,12 @@
 package sdk_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	sdk "github.com/mainflux/mainflux/pkg/sdk/go"
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -46,9 +48,10 @@ func newThingsService(tokens map[string]string) things.Service {
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
+	presenceRepo := mocks.NewPresenceRepository()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, presenceRepo, idProvider)
 }
 
 func newThingsServer(svc things.Service) *httptest.Server {
@@ -204,6 +207,8 @@ func TestThing(t *testing.T) {
 	id, err := mainfluxSDK.CreateThing(thing, token)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	thing.Key = fmt.Sprintf("%s%012d", keyPrefix, 2)
+	th := thing
+	th.Status = "enabled"
 
 	cases := []struct {
 		desc     string
@@ -217,7 +222,7 @@ func TestThing(t *testing.T) {
 			thID:     id,
 			token:    token,
 			err:      nil,
-			response: thing,
+			response: th,
 		},
 		{
 			desc:     "get non-existent thing",
@@ -264,6 +269,7 @@ func TestThings(t *testing.T) {
 		th := sdk.Thing{ID: fmt.Sprintf("%03d", i), Name: "test_device", Metadata: metadata}
 		mainfluxSDK.CreateThing(th, token)
 		th.Key = fmt.Sprintf("%s%012d", keyPrefix, 2*i)
+		th.Status = "enabled"
 		things = append(things, th)
 	}
 
@@ -275,6 +281,7 @@ func TestThings(t *testing.T) {
 		err      error
 		response []sdk.Thing
 		name     string
+		status   string
 	}{
 		{
 			desc:     "get a list of things",
@@ -324,14 +331,83 @@ func TestThings(t *testing.T) {
 			err:      nil,
 			response: []sdk.Thing{},
 		},
+		{
+			desc:     "get a list of enabled things",
+			token:    token,
+			offset:   0,
+			limit:    5,
+			status:   "enabled",
+			err:      nil,
+			response: things[0:5],
+		},
+		{
+			desc:     "get a list of disabled things",
+			token:    token,
+			offset:   0,
+			limit:    5,
+			status:   "disabled",
+			err:      nil,
+			response: []sdk.Thing{},
+		},
+		{
+			desc:     "get a list of things with invalid status",
+			token:    token,
+			offset:   0,
+			limit:    5,
+			status:   wrongValue,
+			err:      createError(sdk.ErrFailedFetch, http.StatusBadRequest),
+			response: nil,
+		},
 	}
 	for _, tc := range cases {
-		page, err := mainfluxSDK.Things(tc.token, tc.offset, tc.limit, tc.name)
+		page, err := mainfluxSDK.Things(tc.token, tc.offset, tc.limit, tc.name, tc.status)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 		assert.Equal(t, tc.response, page.Things, fmt.Sprintf("%s: expected response channel %s, got %s", tc.desc, tc.response, page.Things))
 	}
 }
 
+func TestThingsAfter(t *testing.T) {
+	svc := newThingsService(map[string]string{token: email})
+	ts := newThingsServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		BaseURL:           ts.URL,
+		UsersPrefix:       "",
+		GroupsPrefix:      "",
+		ThingsPrefix:      "",
+		HTTPAdapterPrefix: "",
+		MsgContentType:    contentType,
+		TLSVerification:   false,
+	}
+	var things []sdk.Thing
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	for i := 1; i < 26; i++ {
+		th := sdk.Thing{ID: fmt.Sprintf("%03d", i), Name: "test_device", Metadata: metadata}
+		mainfluxSDK.CreateThing(th, token)
+		th.Key = fmt.Sprintf("%s%012d", keyPrefix, 2*i)
+		th.Status = "enabled"
+		things = append(things, th)
+	}
+
+	// Follow the cursors through all the pages.
+	var cursor string
+	var retrieved []sdk.Thing
+	for i := 0; i < 3; i++ {
+		page, err := mainfluxSDK.ThingsAfter(token, cursor, 10, "", "")
+		require.Nil(t, err, fmt.Sprintf("page %d: unexpected error %s", i, err))
+		retrieved = append(retrieved, page.Things...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	assert.Equal(t, things, retrieved, fmt.Sprintf("expected things %v, got %v", things, retrieved))
+
+	_, err := mainfluxSDK.ThingsAfter(token, "!", 10, "", "")
+	assert.Equal(t, createError(sdk.ErrFailedFetch, http.StatusBadRequest), err, fmt.Sprintf("expected error %s, got %s", createError(sdk.ErrFailedFetch, http.StatusBadRequest), err))
+}
+
 func TestThingsByChannel(t *testing.T) {
 	svc := newThingsService(map[string]string{token: email})
 	ts := newThingsServer(svc)
@@ -361,6 +437,7 @@ func TestThingsByChannel(t *testing.T) {
 			Name:     "test_device",
 			Metadata: metadata,
 			Key:      fmt.Sprintf("%s%012d", keyPrefix, 2*i+1),
+			Status:   "enabled",
 		}
 		tid, err := mainfluxSDK.CreateThing(th, token)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
@@ -623,6 +700,180 @@ func TestDeleteThing(t *testing.T) {
 	}
 }
 
+func TestEnableThing(t *testing.T) {
+	svc := newThingsService(map[string]string{token: email})
+	ts := newThingsServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		BaseURL:           ts.URL,
+		UsersPrefix:       "",
+		GroupsPrefix:      "",
+		ThingsPrefix:      "",
+		HTTPAdapterPrefix: "",
+		MsgContentType:    contentType,
+		TLSVerification:   false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	id, err := mainfluxSDK.CreateThing(thing, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = mainfluxSDK.DisableThing(id, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		thingID string
+		token   string
+		err     error
+	}{
+		{
+			desc:    "enable thing with invalid token",
+			thingID: id,
+			token:   wrongValue,
+			err:     createError(sdk.ErrFailedUpdate, http.StatusUnauthorized),
+		},
+		{
+			desc:    "enable non-existing thing",
+			thingID: badID,
+			token:   token,
+			err:     createError(sdk.ErrFailedUpdate, http.StatusNotFound),
+		},
+		{
+			desc:    "enable disabled thing",
+			thingID: id,
+			token:   token,
+			err:     nil,
+		},
+		{
+			desc:    "enable enabled thing",
+			thingID: id,
+			token:   token,
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := mainfluxSDK.EnableThing(tc.thingID, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestDisableThing(t *testing.T) {
+	svc := newThingsService(map[string]string{token: email})
+	ts := newThingsServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		BaseURL:           ts.URL,
+		UsersPrefix:       "",
+		GroupsPrefix:      "",
+		ThingsPrefix:      "",
+		HTTPAdapterPrefix: "",
+		MsgContentType:    contentType,
+		TLSVerification:   false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	id, err := mainfluxSDK.CreateThing(thing, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		thingID string
+		token   string
+		err     error
+		status  string
+	}{
+		{
+			desc:    "disable thing with invalid token",
+			thingID: id,
+			token:   wrongValue,
+			err:     createError(sdk.ErrFailedUpdate, http.StatusUnauthorized),
+			status:  "enabled",
+		},
+		{
+			desc:    "disable non-existing thing",
+			thingID: badID,
+			token:   token,
+			err:     createError(sdk.ErrFailedUpdate, http.StatusNotFound),
+			status:  "enabled",
+		},
+		{
+			desc:    "disable existing thing",
+			thingID: id,
+			token:   token,
+			err:     nil,
+			status:  "disabled",
+		},
+	}
+
+	for _, tc := range cases {
+		err := mainfluxSDK.DisableThing(tc.thingID, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		th, err := mainfluxSDK.Thing(id, token)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, tc.status, th.Status, fmt.Sprintf("%s: expected status %s, got %s", tc.desc, tc.status, th.Status))
+	}
+}
+
+func TestThingPresence(t *testing.T) {
+	svc := newThingsService(map[string]string{token: email})
+	ts := newThingsServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		BaseURL:           ts.URL,
+		UsersPrefix:       "",
+		GroupsPrefix:      "",
+		ThingsPrefix:      "",
+		HTTPAdapterPrefix: "",
+		MsgContentType:    contentType,
+		TLSVerification:   false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	id, err := mainfluxSDK.CreateThing(thing, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	connected := time.Now().UTC().Round(time.Second)
+	err = svc.ThingConnectedHandler(context.Background(), id, connected)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		thingID  string
+		token    string
+		err      error
+		presence sdk.Presence
+	}{
+		{
+			desc:     "get presence of connected thing",
+			thingID:  id,
+			token:    token,
+			err:      nil,
+			presence: sdk.Presence{Online: true, ConnectedAt: &connected, LastSeen: &connected},
+		},
+		{
+			desc:     "get presence with invalid token",
+			thingID:  id,
+			token:    wrongValue,
+			err:      createError(sdk.ErrFailedFetch, http.StatusUnauthorized),
+			presence: sdk.Presence{},
+		},
+		{
+			desc:     "get presence of non-existing thing",
+			thingID:  badID,
+			token:    token,
+			err:      createError(sdk.ErrFailedFetch, http.StatusNotFound),
+			presence: sdk.Presence{},
+		},
+	}
+
+	for _, tc := range cases {
+		p, err := mainfluxSDK.ThingPresence(tc.thingID, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.presence, p, fmt.Sprintf("%s: expected response presence %v, got %v", tc.desc, tc.presence, p))
+	}
+}
+
 func TestConnectThing(t *testing.T) {
 	svc := newThingsService(map[string]string{
 		token:      email,
@@ -825,8 +1076,8 @@ func TestConnect(t *testing.T) {
 
 	for _, tc := range cases {
 		connIDs := sdk.ConnectionIDs{
-			[]string{tc.thingID},
-			[]string{tc.chanID},
+			ChannelIDs: []string{tc.thingID},
+			ThingIDs:   []string{tc.chanID},
 		}
 
 		err := mainfluxSDK.Connect(connIDs, tc.token)
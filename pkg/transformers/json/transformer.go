@@ -14,6 +14,9 @@ import (
 
 const sep = "/"
 
+// ContentType represents JSON message content type.
+const ContentType = "application/json"
+
 var keys = [...]string{"publisher", "protocol", "channel", "subtopic"}
 
 var (
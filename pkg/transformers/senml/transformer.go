@@ -32,6 +32,9 @@ type transformer struct {
 }
 
 // New returns transformer service implementation for SenML messages.
+// The messages are decoded using the format of their content type, and
+// the given content format is used if the message content type is not
+// SenML.
 func New(contentFormat string) transformers.Transformer {
 	format, ok := formats[contentFormat]
 	if !ok {
@@ -44,7 +47,12 @@ func New(contentFormat string) transformers.Transformer {
 }
 
 func (t transformer) Transform(msg messaging.Message) (interface{}, error) {
-	raw, err := senml.Decode(msg.Payload, t.format)
+	format, ok := formats[transformers.MediaType(msg.ContentType)]
+	if !ok {
+		format = t.format
+	}
+
+	raw, err := senml.Decode(msg.Payload, format)
 	if err != nil {
 		return nil, errors.Wrap(errDecode, err)
 	}
@@ -151,3 +151,70 @@ func TestTransformCBOR(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
 	}
 }
+
+func TestTransformContentType(t *testing.T) {
+	// Same content as in the CBOR test.
+	cborBytes, err := hex.DecodeString("81ac2169626173652d6e616d6522fb40590000000000002369626173652d756e6974200a24fb402400000000000025fb405900000000000000646e616d650164756e697406fb4072c0000000000007fb4062c0000000000002fb404500000000000005fb4024000000000000")
+	require.Nil(t, err, "Decoding CBOR expected to succeed")
+
+	tr := senml.New(senml.JSON)
+	msg := messaging.Message{
+		Channel:   "channel",
+		Subtopic:  "subtopic",
+		Publisher: "publisher",
+		Protocol:  "protocol",
+		Payload:   cborBytes,
+	}
+
+	val := 52.0
+	sum := 110.0
+	msgs := []senml.Message{
+		{
+			Channel:    "channel",
+			Subtopic:   "subtopic",
+			Publisher:  "publisher",
+			Protocol:   "protocol",
+			Name:       "base-namename",
+			Unit:       "unit",
+			Time:       400,
+			UpdateTime: 150,
+			Value:      &val,
+			Sum:        &sum,
+		},
+	}
+
+	cases := []struct {
+		desc        string
+		contentType string
+		msgs        interface{}
+		err         error
+	}{
+		{
+			desc:        "transform message with CBOR content type",
+			contentType: senml.CBOR,
+			msgs:        msgs,
+			err:         nil,
+		},
+		{
+			desc:        "transform message with CBOR content type and parameters",
+			contentType: fmt.Sprintf("%s; charset=utf-8", senml.CBOR),
+			msgs:        msgs,
+			err:         nil,
+		},
+	}
+
+	for _, tc := range cases {
+		m := msg
+		m.ContentType = tc.contentType
+		msgs, err := tr.Transform(m)
+		assert.Equal(t, tc.msgs, msgs, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.msgs, msgs))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+	}
+
+	// Messages with the content type which is not SenML are decoded using
+	// the configured JSON format.
+	m := msg
+	m.ContentType = "text/plain"
+	_, err = tr.Transform(m)
+	assert.NotNil(t, err, "transform CBOR message with unknown content type: expected error got nil")
+}
@@ -3,10 +3,49 @@
 
 package transformers
 
-import "github.com/mainflux/mainflux/pkg/messaging"
+import (
+	"mime"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
 
 // Transformer specifies API form Message transformer.
 type Transformer interface {
 	// Transform Mainflux message to any other format.
 	Transform(msg messaging.Message) (interface{}, error)
 }
+
+var _ Transformer = (*contentTypeTransformer)(nil)
+
+type contentTypeTransformer struct {
+	def   Transformer
+	types map[string]Transformer
+}
+
+// New returns the transformer which picks the transformer of the message
+// by its content type. The messages without the content type, or with the
+// content type no transformer is registered for, are transformed by the
+// default transformer.
+func New(def Transformer, types map[string]Transformer) Transformer {
+	return contentTypeTransformer{
+		def:   def,
+		types: types,
+	}
+}
+
+func (t contentTypeTransformer) Transform(msg messaging.Message) (interface{}, error) {
+	if tr, ok := t.types[MediaType(msg.ContentType)]; ok {
+		return tr.Transform(msg)
+	}
+	return t.def.Transform(msg)
+}
+
+// MediaType returns the media type of the content type, without the
+// parameters such as charset.
+func MediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mt
+}
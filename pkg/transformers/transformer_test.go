@@ -0,0 +1,69 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package transformers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	senmlContentType = "application/senml+json"
+	jsonContentType  = "application/json"
+)
+
+type namedTransformer string
+
+func (nt namedTransformer) Transform(msg messaging.Message) (interface{}, error) {
+	return string(nt), nil
+}
+
+func TestTransform(t *testing.T) {
+	tr := transformers.New(namedTransformer("default"), map[string]transformers.Transformer{
+		senmlContentType: namedTransformer("senml"),
+		jsonContentType:  namedTransformer("json"),
+	})
+
+	cases := []struct {
+		desc        string
+		contentType string
+		transformer string
+	}{
+		{
+			desc:        "transform SenML message",
+			contentType: senmlContentType,
+			transformer: "senml",
+		},
+		{
+			desc:        "transform JSON message",
+			contentType: jsonContentType,
+			transformer: "json",
+		},
+		{
+			desc:        "transform JSON message with content type parameters",
+			contentType: fmt.Sprintf("%s; charset=utf-8", jsonContentType),
+			transformer: "json",
+		},
+		{
+			desc:        "transform message with unknown content type",
+			contentType: "text/plain",
+			transformer: "default",
+		},
+		{
+			desc:        "transform message without content type",
+			contentType: "",
+			transformer: "default",
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := tr.Transform(messaging.Message{ContentType: tc.contentType})
+		assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s", tc.desc, err))
+		assert.Equal(t, tc.transformer, res, fmt.Sprintf("%s: expected %s got %v", tc.desc, tc.transformer, res))
+	}
+}
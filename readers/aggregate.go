@@ -0,0 +1,103 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+type bucket struct {
+	start float64
+	count int
+	sum   float64
+	min   float64
+	max   float64
+	first senml.Message
+	last  senml.Message
+}
+
+// Aggregate groups the SenML messages into time buckets of the given interval
+// and applies the aggregation to the values within each bucket. It's used by
+// the readers whose database can't aggregate the values natively. Messages
+// without a numeric value are skipped. Each bucket is represented by a message
+// that holds the bucket start time and the aggregated value, and the buckets
+// are sorted from the latest one.
+func Aggregate(msgs []senml.Message, aggregation string, interval time.Duration) []Message {
+	width := interval.Seconds()
+	buckets := map[float64]*bucket{}
+	for _, msg := range msgs {
+		if msg.Value == nil {
+			continue
+		}
+		v := *msg.Value
+
+		start := math.Floor(msg.Time/width) * width
+		b, ok := buckets[start]
+		if !ok {
+			b = &bucket{
+				start: start,
+				min:   v,
+				max:   v,
+				first: msg,
+				last:  msg,
+			}
+			buckets[start] = b
+		}
+
+		b.count++
+		b.sum += v
+		b.min = math.Min(b.min, v)
+		b.max = math.Max(b.max, v)
+		if msg.Time < b.first.Time {
+			b.first = msg
+		}
+		if msg.Time >= b.last.Time {
+			b.last = msg
+		}
+	}
+
+	sorted := make([]*bucket, 0, len(buckets))
+	for _, b := range buckets {
+		sorted = append(sorted, b)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].start > sorted[j].start
+	})
+
+	ret := make([]Message, 0, len(sorted))
+	for _, b := range sorted {
+		v := b.value(aggregation)
+		ret = append(ret, senml.Message{
+			Channel: b.first.Channel,
+			Name:    b.first.Name,
+			Time:    b.start,
+			Value:   &v,
+		})
+	}
+
+	return ret
+}
+
+func (b *bucket) value(aggregation string) float64 {
+	switch aggregation {
+	case AggregationMin:
+		return b.min
+	case AggregationMax:
+		return b.max
+	case AggregationSum:
+		return b.sum
+	case AggregationCount:
+		return float64(b.count)
+	case AggregationFirst:
+		return *b.first.Value
+	case AggregationLast:
+		return *b.last.Value
+	default:
+		return b.sum / float64(b.count)
+	}
+}
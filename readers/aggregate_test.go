@@ -0,0 +1,59 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregate(t *testing.T) {
+	values := []float64{4, 2, 6, 8, 1}
+	times := []float64{130, 150, 170, 185, 190}
+
+	var msgs []senml.Message
+	for i := range values {
+		msgs = append(msgs, senml.Message{
+			Channel: "channel",
+			Name:    "temperature",
+			Time:    times[i],
+			Value:   &values[i],
+		})
+	}
+	str := "value"
+	msgs = append(msgs, senml.Message{Channel: "channel", Name: "temperature", Time: 120, StringValue: &str})
+
+	cases := []struct {
+		aggregation string
+		values      []float64
+	}{
+		{aggregation: readers.AggregationAvg, values: []float64{4.5, 4}},
+		{aggregation: readers.AggregationMin, values: []float64{1, 2}},
+		{aggregation: readers.AggregationMax, values: []float64{8, 6}},
+		{aggregation: readers.AggregationSum, values: []float64{9, 12}},
+		{aggregation: readers.AggregationCount, values: []float64{2, 3}},
+		{aggregation: readers.AggregationFirst, values: []float64{8, 4}},
+		{aggregation: readers.AggregationLast, values: []float64{1, 6}},
+	}
+
+	for _, tc := range cases {
+		buckets := readers.Aggregate(msgs, tc.aggregation, time.Minute)
+		var expected []readers.Message
+		for i, start := range []float64{180, 120} {
+			v := tc.values[i]
+			expected = append(expected, senml.Message{
+				Channel: "channel",
+				Name:    "temperature",
+				Time:    start,
+				Value:   &v,
+			})
+		}
+		assert.Equal(t, expected, buckets, fmt.Sprintf("%s: expected %v got %v", tc.aggregation, expected, buckets))
+	}
+}
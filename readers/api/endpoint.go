@@ -26,7 +26,84 @@ func listMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
 		return pageRes{
 			PageMetadata: page.PageMetadata,
 			Total:        page.Total,
+			NextCursor:   page.NextCursor,
 			Messages:     page.Messages,
 		}, nil
 	}
 }
+
+func listChannelsMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(listChannelsMessagesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ReadChannels(req.chanIDs, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		return pageRes{
+			PageMetadata: page.PageMetadata,
+			Total:        page.Total,
+			NextCursor:   page.NextCursor,
+			Messages:     page.Messages,
+		}, nil
+	}
+}
+
+func latestMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(latestMessagesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		msgs, err := svc.Latest(req.chanID, req.pageMeta, req.perPublisher)
+		if err != nil {
+			return nil, err
+		}
+
+		return latestRes{Messages: msgs}, nil
+	}
+}
+
+func exportMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportMessagesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		iter, err := svc.Iterate(req.chanID, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		return exportRes{
+			contentType: req.contentType,
+			format:      req.pageMeta.Format,
+			iter:        iter,
+		}, nil
+	}
+}
+
+func deleteMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteMessagesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.Delete(req.chanID, req.meta); err != nil {
+			return nil, err
+		}
+
+		return deleteRes{}, nil
+	}
+}
@@ -7,8 +7,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,12 +29,15 @@ const (
 	svcName       = "test-service"
 	token         = "1"
 	invalid       = "invalid"
+	restricted    = "restricted"
 	numOfMessages = 100
 	valueFields   = 5
 	subtopic      = "topic"
 	mqttProt      = "mqtt"
 	httpProt      = "http"
 	msgName       = "temperature"
+	userToken     = "user-token"
+	email         = "user@example.com"
 )
 
 var (
@@ -45,7 +51,7 @@ var (
 )
 
 func newServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient) *httptest.Server {
-	mux := api.MakeHandler(repo, tc, svcName)
+	mux := api.MakeHandler(repo, tc, mocks.NewAuthService(nil, nil), mocks.NewThingChannels(nil), svcName)
 	return httptest.NewServer(mux)
 }
 
@@ -54,6 +60,7 @@ type testRequest struct {
 	method string
 	url    string
 	token  string
+	accept string
 	body   io.Reader
 }
 
@@ -65,6 +72,9 @@ func (tr testRequest) make() (*http.Response, error) {
 	if tr.token != "" {
 		req.Header.Set("Authorization", tr.token)
 	}
+	if tr.accept != "" {
+		req.Header.Set("Accept", tr.accept)
+	}
 
 	return tr.client.Do(req)
 }
@@ -122,7 +132,7 @@ func TestReadAll(t *testing.T) {
 		messages = append(messages, msg)
 	}
 
-	svc := mocks.NewThingsService()
+	svc := mocks.NewThingsService(nil)
 	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
 	ts := newServer(repo, svc)
 	defer ts.Close()
@@ -245,6 +255,22 @@ func TestReadAll(t *testing.T) {
 				Messages: queryMsgs[0:10],
 			},
 		},
+		{
+			desc:   "read page with subtopic by thing restricted to subtopics",
+			url:    fmt.Sprintf("%s/channels/%s/messages?subtopic=%s&protocol=%s", ts.URL, chanID, subtopic, httpProt),
+			token:  restricted,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(queryMsgs)),
+				Messages: queryMsgs[0:10],
+			},
+		},
+		{
+			desc:   "read page without subtopic by thing restricted to subtopics",
+			url:    fmt.Sprintf("%s/channels/%s/messages?offset=0&limit=10", ts.URL, chanID),
+			token:  restricted,
+			status: http.StatusForbidden,
+		},
 		{
 			desc:   "read page with subtopic and protocol",
 			url:    fmt.Sprintf("%s/channels/%s/messages?subtopic=%s&protocol=%s", ts.URL, chanID, subtopic, httpProt),
@@ -416,6 +442,68 @@ func TestReadAll(t *testing.T) {
 				Messages: messages[5:15],
 			},
 		},
+		{
+			desc:   "read page with count aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?name=name&aggregation=count&interval=876000h", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    1,
+				Messages: []senml.Message{aggregate(chanID, "name", float64(len(valueMsgs)))},
+			},
+		},
+		{
+			desc:   "read page with max aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?name=name&aggregation=max&interval=876000h", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    1,
+				Messages: []senml.Message{aggregate(chanID, "name", v)},
+			},
+		},
+		{
+			desc:   "read page with invalid aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?name=name&aggregation=median&interval=1h", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with aggregation without name",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=avg&interval=1h", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with aggregation without interval",
+			url:    fmt.Sprintf("%s/channels/%s/messages?name=name&aggregation=avg", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with invalid interval",
+			url:    fmt.Sprintf("%s/channels/%s/messages?name=name&aggregation=avg&interval=%s", ts.URL, chanID, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with negative interval",
+			url:    fmt.Sprintf("%s/channels/%s/messages?name=name&aggregation=avg&interval=-1h", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with interval without aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?name=name&interval=1h", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with aggregation of JSON messages",
+			url:    fmt.Sprintf("%s/channels/%s/messages?name=name&aggregation=avg&interval=1h&format=json", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range cases {
@@ -437,10 +525,690 @@ func TestReadAll(t *testing.T) {
 	}
 }
 
+func TestExport(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+
+	var messages []senml.Message
+	for i := 0; i < 3; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(now - int64(i)),
+			Value:     &v,
+		}
+		messages = append(messages, msg)
+	}
+	messages[1].Value = nil
+	messages[1].StringValue = &vs
+
+	svc := mocks.NewThingsService(nil)
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	ts := newServer(repo, svc)
+	defer ts.Close()
+
+	var ndjson string
+	for i := len(messages) - 1; i >= 0; i-- {
+		b, err := json.Marshal(messages[i])
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		ndjson += string(b) + "\n"
+	}
+
+	header := "channel,subtopic,publisher,protocol,name,unit,time,update_time,value,string_value,bool_value,data_value,sum\n"
+	rows := []string{
+		fmt.Sprintf("%s,,%s,mqtt,%s,,%d,0,5,,,,\n", chanID, pubID, msgName, now-2),
+		fmt.Sprintf("%s,,%s,mqtt,%s,,%d,0,,value,,,\n", chanID, pubID, msgName, now-1),
+		fmt.Sprintf("%s,,%s,mqtt,%s,,%d,0,5,,,,\n", chanID, pubID, msgName, now),
+	}
+
+	cases := []struct {
+		desc        string
+		url         string
+		token       string
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{
+			desc:        "export messages as NDJSON by default",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:       token,
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			body:        ndjson,
+		},
+		{
+			desc:        "export messages as NDJSON",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:       token,
+			accept:      "application/x-ndjson",
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			body:        ndjson,
+		},
+		{
+			desc:        "export messages as CSV",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:       token,
+			accept:      "text/csv",
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			body:        header + strings.Join(rows, ""),
+		},
+		{
+			desc:        "export messages filtered by time",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export?from=%d", ts.URL, chanID, now),
+			token:       token,
+			accept:      "text/csv",
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			body:        header + rows[2],
+		},
+		{
+			desc:   "export messages with unsupported format",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:  token,
+			accept: "application/xml",
+			status: http.StatusNotAcceptable,
+		},
+		{
+			desc:   "export aggregated messages",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?name=%s&aggregation=avg&interval=1h", ts.URL, chanID, msgName),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages with invalid comparator",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?v=5&comparator=invalid", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+			accept: tc.accept,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.contentType, res.Header.Get("Content-Type"), fmt.Sprintf("%s: expected content type %s got %s", tc.desc, tc.contentType, res.Header.Get("Content-Type")))
+		assert.Equal(t, tc.body, string(body), fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.body, string(body)))
+	}
+}
+
+func TestReadAllByCursor(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+
+	var messages []senml.Message
+	for i := 0; i < 25; i++ {
+		messages = append(messages, senml.Message{
+			Channel:  chanID,
+			Protocol: mqttProt,
+			Name:     msgName,
+			Time:     float64(now - int64(i)),
+			Value:    &v,
+		})
+	}
+
+	svc := mocks.NewThingsService(nil)
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	ts := newServer(repo, svc)
+	defer ts.Close()
+
+	// Follow the cursors through all the pages.
+	var cursor string
+	for i := 0; i < len(messages); i += 10 {
+		url := fmt.Sprintf("%s/channels/%s/messages?limit=10&cursor=%s", ts.URL, chanID, cursor)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    url,
+			token:  token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		require.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected %d got %d", http.StatusOK, res.StatusCode))
+
+		var page pageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+		end := i + 10
+		if end > len(messages) {
+			end = len(messages)
+		}
+		assert.Equal(t, uint64(len(messages)), page.Total, fmt.Sprintf("page %d: expected total %d got %d", i/10, len(messages), page.Total))
+		assert.Equal(t, messages[i:end], page.Messages, fmt.Sprintf("page %d: expected %v got %v", i/10, messages[i:end], page.Messages))
+		if end == len(messages) {
+			assert.Empty(t, page.NextCursor, fmt.Sprintf("page %d: expected no next cursor", i/10))
+			break
+		}
+		assert.NotEmpty(t, page.NextCursor, fmt.Sprintf("page %d: expected next cursor", i/10))
+		cursor = page.NextCursor
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		status int
+	}{
+		{
+			desc:   "read page with invalid cursor",
+			url:    fmt.Sprintf("%s/channels/%s/messages?cursor=%s", ts.URL, chanID, invalid),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with cursor and offset",
+			url:    fmt.Sprintf("%s/channels/%s/messages?offset=10&cursor=%s", ts.URL, chanID, cursor),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read page with cursor and aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?name=%s&aggregation=avg&interval=1h&cursor=%s", ts.URL, chanID, msgName, cursor),
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestLatest(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+
+	// Messages are stored from the latest one, alternating the names and
+	// the publishers.
+	names := []string{msgName, "humidity"}
+	pubs := []string{pubID, pubID2}
+	var messages []senml.Message
+	for i := 0; i < 20; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubs[(i/2)%2],
+			Protocol:  mqttProt,
+			Name:      names[i%2],
+			Time:      float64(now - int64(i)),
+			Value:     &v,
+		})
+	}
+
+	svc := mocks.NewThingsService(nil)
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	ts := newServer(repo, svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		res    []senml.Message
+	}{
+		{
+			desc:   "read latest messages",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+			res:    []senml.Message{messages[1], messages[0]},
+		},
+		{
+			desc:   "read latest messages per publisher",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?per_publisher=true", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+			res:    latestPerPublisher(messages[:4]),
+		},
+		{
+			desc:   "read latest messages with name",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?name=%s", ts.URL, chanID, msgName),
+			token:  token,
+			status: http.StatusOK,
+			res:    []senml.Message{messages[0]},
+		},
+		{
+			desc:   "read latest messages with publisher",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?publisher=%s", ts.URL, chanID, pubID2),
+			token:  token,
+			status: http.StatusOK,
+			res:    []senml.Message{messages[3], messages[2]},
+		},
+		{
+			desc:   "read latest messages before the given time",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?to=%d", ts.URL, chanID, now-10),
+			token:  token,
+			status: http.StatusOK,
+			res:    []senml.Message{messages[11], messages[12]},
+		},
+		{
+			desc:   "read latest messages with no matching messages",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?name=%s", ts.URL, chanID, invalid),
+			token:  token,
+			status: http.StatusOK,
+			res:    []senml.Message{},
+		},
+		{
+			desc:   "read latest messages with invalid per publisher flag",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?per_publisher=%s", ts.URL, chanID, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read latest messages of JSON format",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?format=%s", ts.URL, chanID, "json"),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read latest messages with aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?name=%s&aggregation=avg&interval=1h", ts.URL, chanID, msgName),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read latest messages with invalid comparator",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?v=%f&comparator=%s", ts.URL, chanID, v, invalid),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read latest messages with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "read latest messages with empty token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest", ts.URL, chanID),
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var latest struct {
+			Messages []senml.Message `json:"messages"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&latest)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, latest.Messages, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, latest.Messages))
+	}
+}
+
+// latestPerPublisher returns the given messages, which are expected to be the
+// latest ones per name and publisher, sorted by the name and the publisher.
+func latestPerPublisher(msgs []senml.Message) []senml.Message {
+	ret := append([]senml.Message{}, msgs...)
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Name != ret[j].Name {
+			return ret[i].Name < ret[j].Name
+		}
+		return ret[i].Publisher < ret[j].Publisher
+	})
+	return ret
+}
+
+func TestReadChannels(t *testing.T) {
+	var chanIDs []string
+	for i := 0; i < 3; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		chanIDs = append(chanIDs, id)
+	}
+	ch1, ch2, ch3 := chanIDs[0], chanIDs[1], chanIDs[2]
+
+	now := time.Now().Unix()
+
+	// Messages of the first two channels alternate in time, so that the
+	// merged messages are sorted by time.
+	msgs := map[string][]readers.Message{}
+	var merged []senml.Message
+	for i := 0; i < 20; i++ {
+		msg := senml.Message{
+			Channel:  chanIDs[i%2],
+			Protocol: mqttProt,
+			Name:     msgName,
+			Time:     float64(now - int64(i)),
+			Value:    &v,
+		}
+		msgs[msg.Channel] = append(msgs[msg.Channel], msg)
+		merged = append(merged, msg)
+	}
+	msgs[ch3] = []readers.Message{senml.Message{Channel: ch3, Time: float64(now)}}
+
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = ch1
+	}
+
+	users := map[string]string{userToken: email}
+	groups := map[string][]string{"group": {ch1, ch2}, "mixed": {ch1, ch3}}
+	owners := map[string]string{ch1: email, ch2: email, ch3: "other@example.com"}
+	thingChannels := map[string][]string{"thing": {ch2, ch1}}
+
+	repo := mocks.NewChannelsMessageRepository(msgs)
+	mux := api.MakeHandler(repo, mocks.NewThingsService(owners), mocks.NewAuthService(users, groups), mocks.NewThingChannels(thingChannels), svcName)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	res, err := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/messages?channels=%s,%s&limit=5", ts.URL, ch1, ch2),
+		token:  userToken,
+	}.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	var first pageRes
+	err = json.NewDecoder(res.Body).Decode(&first)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		res    pageRes
+	}{
+		{
+			desc:   "read messages of channels",
+			url:    fmt.Sprintf("%s/messages?channels=%s,%s&limit=5", ts.URL, ch1, ch2),
+			token:  userToken,
+			status: http.StatusOK,
+			res:    pageRes{Total: 20, Messages: merged[0:5]},
+		},
+		{
+			desc:   "read messages of channels with offset",
+			url:    fmt.Sprintf("%s/messages?channels=%s,%s&offset=5&limit=5", ts.URL, ch1, ch2),
+			token:  userToken,
+			status: http.StatusOK,
+			res:    pageRes{Total: 20, Messages: merged[5:10]},
+		},
+		{
+			desc:   "read messages of channels with cursor",
+			url:    fmt.Sprintf("%s/messages?channels=%s,%s&limit=5&cursor=%s", ts.URL, ch1, ch2, first.NextCursor),
+			token:  userToken,
+			status: http.StatusOK,
+			res:    pageRes{Total: 20, Messages: merged[5:10]},
+		},
+		{
+			desc:   "read messages of duplicated channels",
+			url:    fmt.Sprintf("%s/messages?channels=%s,%s&limit=5", ts.URL, ch1, ch1),
+			token:  userToken,
+			status: http.StatusOK,
+			res:    pageRes{Total: 10, Messages: []senml.Message{merged[0], merged[2], merged[4], merged[6], merged[8]}},
+		},
+		{
+			desc:   "read messages of group channels",
+			url:    fmt.Sprintf("%s/messages?group=%s&limit=5", ts.URL, "group"),
+			token:  userToken,
+			status: http.StatusOK,
+			res:    pageRes{Total: 20, Messages: merged[0:5]},
+		},
+		{
+			desc:   "read messages of thing channels",
+			url:    fmt.Sprintf("%s/messages?thing=%s&limit=5", ts.URL, "thing"),
+			token:  userToken,
+			status: http.StatusOK,
+			res:    pageRes{Total: 20, Messages: merged[0:5]},
+		},
+		{
+			desc:   "read messages of channels that aren't owned",
+			url:    fmt.Sprintf("%s/messages?channels=%s,%s", ts.URL, ch1, ch3),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "read messages of group with channels that aren't owned",
+			url:    fmt.Sprintf("%s/messages?group=%s", ts.URL, "mixed"),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "read messages of non-existing group",
+			url:    fmt.Sprintf("%s/messages?group=%s", ts.URL, invalid),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "read messages of non-existing thing",
+			url:    fmt.Sprintf("%s/messages?thing=%s", ts.URL, invalid),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "read messages of channels with invalid token",
+			url:    fmt.Sprintf("%s/messages?channels=%s", ts.URL, ch1),
+			token:  invalid,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "read messages of channels with empty token",
+			url:    fmt.Sprintf("%s/messages?channels=%s", ts.URL, ch1),
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "read messages without channels",
+			url:    fmt.Sprintf("%s/messages", ts.URL),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages of both channels and group",
+			url:    fmt.Sprintf("%s/messages?channels=%s&group=%s", ts.URL, ch1, "group"),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages of channels with empty channel ID",
+			url:    fmt.Sprintf("%s/messages?channels=%s,", ts.URL, ch1),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages of too many channels",
+			url:    fmt.Sprintf("%s/messages?channels=%s", ts.URL, strings.Join(tooMany, ",")),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages of channels with aggregation",
+			url:    fmt.Sprintf("%s/messages?channels=%s&name=%s&aggregation=avg&interval=1h", ts.URL, ch1, msgName),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page pageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res.Total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.res.Total, page.Total))
+		assert.Equal(t, tc.res.Messages, page.Messages, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res.Messages, page.Messages))
+	}
+}
+
+func TestDelete(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	otherID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	publishers := []string{"publisher-1", "publisher-2"}
+
+	var msgs []readers.Message
+	for i := 0; i < 20; i++ {
+		msgs = append(msgs, senml.Message{
+			Channel:   chanID,
+			Publisher: publishers[i%2],
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(now - int64(i)),
+			Value:     &v,
+		})
+	}
+
+	users := map[string]string{userToken: email}
+	owners := map[string]string{chanID: email, otherID: "other@example.com"}
+
+	repo := mocks.NewChannelsMessageRepository(map[string][]readers.Message{chanID: msgs})
+	mux := api.MakeHandler(repo, mocks.NewThingsService(owners), mocks.NewAuthService(users, nil), mocks.NewThingChannels(nil), svcName)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		total  uint64
+	}{
+		{
+			desc:   "delete messages of channel that isn't owned",
+			url:    fmt.Sprintf("%s/channels/%s/messages", ts.URL, otherID),
+			token:  userToken,
+			status: http.StatusForbidden,
+			total:  20,
+		},
+		{
+			desc:   "delete messages with thing key",
+			url:    fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+			token:  token,
+			status: http.StatusForbidden,
+			total:  20,
+		},
+		{
+			desc:   "delete messages with empty token",
+			url:    fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+			status: http.StatusForbidden,
+			total:  20,
+		},
+		{
+			desc:   "delete messages with invalid time range",
+			url:    fmt.Sprintf("%s/channels/%s/messages?from=%d&to=%d", ts.URL, chanID, now, now-10),
+			token:  userToken,
+			status: http.StatusBadRequest,
+			total:  20,
+		},
+		{
+			desc:   "delete messages with invalid from",
+			url:    fmt.Sprintf("%s/channels/%s/messages?from=%s", ts.URL, chanID, invalid),
+			token:  userToken,
+			status: http.StatusBadRequest,
+			total:  20,
+		},
+		{
+			desc:   "delete messages of publisher in time range",
+			url:    fmt.Sprintf("%s/channels/%s/messages?publisher=%s&from=%d&to=%d", ts.URL, chanID, publishers[0], now-9, now-4),
+			token:  userToken,
+			status: http.StatusNoContent,
+			total:  18,
+		},
+		{
+			desc:   "delete messages since time",
+			url:    fmt.Sprintf("%s/channels/%s/messages?from=%d", ts.URL, chanID, now-2),
+			token:  userToken,
+			status: http.StatusNoContent,
+			total:  15,
+		},
+		{
+			desc:   "delete all messages",
+			url:    fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusNoContent,
+			total:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		page, err := repo.ReadAll(chanID, readers.PageMetadata{Limit: numOfMessages})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+	}
+}
+
 type pageRes struct {
 	readers.PageMetadata
-	Total    uint64          `json:"total"`
-	Messages []senml.Message `json:"messages,omitempty"`
+	Total      uint64          `json:"total"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	Messages   []senml.Message `json:"messages,omitempty"`
 }
 
 func fromSenml(in []senml.Message) []readers.Message {
@@ -450,3 +1218,11 @@ func fromSenml(in []senml.Message) []readers.Message {
 	}
 	return ret
 }
+
+func aggregate(chanID, name string, value float64) senml.Message {
+	return senml.Message{
+		Channel: chanID,
+		Name:    name,
+		Value:   &value,
+	}
+}
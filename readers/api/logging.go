@@ -40,3 +40,55 @@ func (lm *loggingMiddleware) ReadAll(chanID string, rpm readers.PageMetadata) (p
 
 	return lm.svc.ReadAll(chanID, rpm)
 }
+
+func (lm *loggingMiddleware) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (page readers.MessagesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method read_channels for %d channels with query %v took %s to complete", len(chanIDs), rpm, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ReadChannels(chanIDs, rpm)
+}
+
+func (lm *loggingMiddleware) Iterate(chanID string, rpm readers.PageMetadata) (iter readers.MessageIterator, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method iterate for channel %s with query %v took %s to complete", chanID, rpm, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Iterate(chanID, rpm)
+}
+
+func (lm *loggingMiddleware) Latest(chanID string, rpm readers.PageMetadata, perPublisher bool) (msgs []readers.Message, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method latest for channel %s with query %v took %s to complete", chanID, rpm, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Latest(chanID, rpm, perPublisher)
+}
+
+func (lm *loggingMiddleware) Delete(chanID string, dm readers.DeleteMetadata) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method delete for channel %s with query %v took %s to complete", chanID, dm, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Delete(chanID, dm)
+}
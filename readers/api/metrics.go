@@ -37,3 +37,39 @@ func (mm *metricsMiddleware) ReadAll(chanID string, rpm readers.PageMetadata) (r
 
 	return mm.svc.ReadAll(chanID, rpm)
 }
+
+func (mm *metricsMiddleware) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "read_channels").Add(1)
+		mm.latency.With("method", "read_channels").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ReadChannels(chanIDs, rpm)
+}
+
+func (mm *metricsMiddleware) Iterate(chanID string, rpm readers.PageMetadata) (readers.MessageIterator, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "iterate").Add(1)
+		mm.latency.With("method", "iterate").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Iterate(chanID, rpm)
+}
+
+func (mm *metricsMiddleware) Latest(chanID string, rpm readers.PageMetadata, perPublisher bool) ([]readers.Message, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "latest").Add(1)
+		mm.latency.With("method", "latest").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Latest(chanID, rpm, perPublisher)
+}
+
+func (mm *metricsMiddleware) Delete(chanID string, dm readers.DeleteMetadata) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "delete").Add(1)
+		mm.latency.With("method", "delete").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Delete(chanID, dm)
+}
@@ -4,6 +4,8 @@
 package api
 
 import (
+	"time"
+
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/readers"
 )
@@ -21,14 +23,181 @@ func (req listMessagesReq) validate() error {
 	if req.pageMeta.Limit < 1 || req.pageMeta.Offset < 0 {
 		return errors.ErrInvalidQueryParams
 	}
-	if req.pageMeta.Comparator != "" &&
-		req.pageMeta.Comparator != readers.EqualKey &&
-		req.pageMeta.Comparator != readers.LowerThanKey &&
-		req.pageMeta.Comparator != readers.LowerThanEqualKey &&
-		req.pageMeta.Comparator != readers.GreaterThanKey &&
-		req.pageMeta.Comparator != readers.GreaterThanEqualKey {
+	if !validComparator(req.pageMeta.Comparator) {
+		return errors.ErrInvalidQueryParams
+	}
+	if err := req.validateCursor(); err != nil {
+		return err
+	}
+
+	return req.validateAggregation()
+}
+
+// validateCursor checks that the cursor is well formed. Cursor replaces the
+// offset, and buckets of aggregated values are paged by offset only.
+func (req listMessagesReq) validateCursor() error {
+	pm := req.pageMeta
+	if pm.Cursor == "" {
+		return nil
+	}
+	if pm.Offset > 0 || pm.Aggregation != "" {
+		return errors.ErrInvalidQueryParams
+	}
+	if _, err := readers.DecodeCursor(pm.Cursor); err != nil {
+		return errors.Wrap(errors.ErrInvalidQueryParams, err)
+	}
+
+	return nil
+}
+
+// validateAggregation checks that the aggregation is applied to the SenML
+// values with the given name, using a valid interval.
+func (req listMessagesReq) validateAggregation() error {
+	pm := req.pageMeta
+	if pm.Aggregation == "" {
+		if pm.Interval != "" {
+			return errors.ErrInvalidQueryParams
+		}
+		return nil
+	}
+
+	switch pm.Aggregation {
+	case readers.AggregationAvg,
+		readers.AggregationMin,
+		readers.AggregationMax,
+		readers.AggregationSum,
+		readers.AggregationCount,
+		readers.AggregationFirst,
+		readers.AggregationLast:
+	default:
+		return errors.ErrInvalidQueryParams
+	}
+
+	if pm.Name == "" || (pm.Format != "" && pm.Format != defFormat) {
+		return errors.ErrInvalidQueryParams
+	}
+
+	interval, err := time.ParseDuration(pm.Interval)
+	if err != nil || interval <= 0 {
+		return errors.ErrInvalidQueryParams
+	}
+
+	return nil
+}
+
+type listChannelsMessagesReq struct {
+	token    string
+	chanIDs  []string
+	group    string
+	thing    string
+	pageMeta readers.PageMetadata
+}
+
+// validate checks that the channels are selected in exactly one way, and that
+// the page is valid. Aggregation isn't supported across the channels.
+func (req listChannelsMessagesReq) validate() error {
+	sources := 0
+	for _, set := range []bool{len(req.chanIDs) > 0, req.group != "", req.thing != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 || len(req.chanIDs) > maxChannels {
+		return errors.ErrInvalidQueryParams
+	}
+	for _, id := range req.chanIDs {
+		if id == "" {
+			return errors.ErrInvalidQueryParams
+		}
+	}
+
+	pm := req.pageMeta
+	if pm.Aggregation != "" || pm.Interval != "" {
+		return errors.ErrInvalidQueryParams
+	}
+
+	return listMessagesReq{pageMeta: pm}.validate()
+}
+
+type exportMessagesReq struct {
+	chanID      string
+	contentType string
+	pageMeta    readers.PageMetadata
+}
+
+func (req exportMessagesReq) validate() error {
+	if req.chanID == "" {
+		return errors.ErrInvalidQueryParams
+	}
+	if req.contentType != csvContentType && req.contentType != ndjsonContentType {
+		return errNotAcceptable
+	}
+	if !validComparator(req.pageMeta.Comparator) {
+		return errors.ErrInvalidQueryParams
+	}
+	// Exports contain all the stored messages.
+	if req.pageMeta.Aggregation != "" || req.pageMeta.Interval != "" || req.pageMeta.Cursor != "" {
 		return errors.ErrInvalidQueryParams
 	}
 
 	return nil
 }
+
+type latestMessagesReq struct {
+	chanID       string
+	perPublisher bool
+	pageMeta     readers.PageMetadata
+}
+
+func (req latestMessagesReq) validate() error {
+	if req.chanID == "" {
+		return errors.ErrInvalidQueryParams
+	}
+	if !validComparator(req.pageMeta.Comparator) {
+		return errors.ErrInvalidQueryParams
+	}
+	// Only SenML messages have names.
+	pm := req.pageMeta
+	if pm.Format != "" && pm.Format != defFormat {
+		return errors.ErrInvalidQueryParams
+	}
+	if pm.Aggregation != "" || pm.Interval != "" || pm.Cursor != "" {
+		return errors.ErrInvalidQueryParams
+	}
+
+	return nil
+}
+
+type deleteMessagesReq struct {
+	chanID string
+	meta   readers.DeleteMetadata
+}
+
+func (req deleteMessagesReq) validate() error {
+	if req.chanID == "" {
+		return errors.ErrInvalidQueryParams
+	}
+	dm := req.meta
+	if dm.From < 0 || dm.To < 0 {
+		return errors.ErrInvalidQueryParams
+	}
+	if dm.From != 0 && dm.To != 0 && dm.From >= dm.To {
+		return errors.ErrInvalidQueryParams
+	}
+
+	return nil
+}
+
+func validComparator(comparator string) bool {
+	switch comparator {
+	case "",
+		readers.EqualKey,
+		readers.LowerThanKey,
+		readers.LowerThanEqualKey,
+		readers.GreaterThanKey,
+		readers.GreaterThanEqualKey:
+		return true
+	default:
+		return false
+	}
+}
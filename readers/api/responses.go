@@ -14,8 +14,9 @@ var _ mainflux.Response = (*pageRes)(nil)
 
 type pageRes struct {
 	readers.PageMetadata
-	Total    uint64            `json:"total"`
-	Messages []readers.Message `json:"messages,omitempty"`
+	Total      uint64            `json:"total"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	Messages   []readers.Message `json:"messages,omitempty"`
 }
 
 func (res pageRes) Headers() map[string]string {
@@ -30,6 +31,62 @@ func (res pageRes) Empty() bool {
 	return false
 }
 
+var _ mainflux.Response = (*latestRes)(nil)
+
+type latestRes struct {
+	Messages []readers.Message `json:"messages"`
+}
+
+func (res latestRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res latestRes) Code() int {
+	return http.StatusOK
+}
+
+func (res latestRes) Empty() bool {
+	return false
+}
+
+var _ mainflux.Response = (*exportRes)(nil)
+
+// exportRes streams the messages read by the iterator, so it's encoded
+// separately from the other responses.
+type exportRes struct {
+	contentType string
+	format      string
+	iter        readers.MessageIterator
+}
+
+func (res exportRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res exportRes) Code() int {
+	return http.StatusOK
+}
+
+func (res exportRes) Empty() bool {
+	return false
+}
+
+var _ mainflux.Response = (*deleteRes)(nil)
+
+type deleteRes struct{}
+
+func (res deleteRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deleteRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res deleteRes) Empty() bool {
+	return true
+}
+
 type errorRes struct {
 	Err string `json:"error"`
 }
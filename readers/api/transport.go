@@ -4,10 +4,13 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -15,7 +18,9 @@ import (
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/internal/httputil"
 	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
+	"github.com/mainflux/mainflux/things"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -36,19 +41,43 @@ const (
 	comparatorKey  = "comparator"
 	fromKey        = "from"
 	toKey          = "to"
+	aggregationKey = "aggregation"
+	intervalKey    = "interval"
+	cursorKey      = "cursor"
 	defLimit       = 10
 	defOffset      = 0
 	defFormat      = "messages"
+	anySubtopic    = ">"
+)
+
+const perPublisherKey = "per_publisher"
+
+const (
+	channelsKey  = "channels"
+	groupKey     = "group"
+	thingKey     = "thing"
+	channelsType = "channels"
+	maxChannels  = 100
+)
+
+const (
+	csvContentType    = "text/csv"
+	ndjsonContentType = "application/x-ndjson"
 )
 
 var (
 	errUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+	errNotAcceptable      = errors.New("requested export format is not supported")
 	auth                  mainflux.ThingsServiceClient
+	users                 mainflux.AuthServiceClient
+	thingChannels         readers.ThingChannels
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient, svcName string) http.Handler {
+func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, thc readers.ThingChannels, svcName string) http.Handler {
 	auth = tc
+	users = ac
+	thingChannels = thc
 
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
@@ -62,6 +91,34 @@ func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient,
 		opts...,
 	))
 
+	mux.Get("/messages", kithttp.NewServer(
+		listChannelsMessagesEndpoint(svc),
+		decodeListChannels,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/channels/:chanID/messages/latest", kithttp.NewServer(
+		latestMessagesEndpoint(svc),
+		decodeLatest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/channels/:chanID/messages/export", kithttp.NewServer(
+		exportMessagesEndpoint(svc),
+		decodeExport,
+		encodeExport,
+		opts...,
+	))
+
+	mux.Delete("/channels/:chanID/messages", kithttp.NewServer(
+		deleteMessagesEndpoint(svc),
+		decodeDelete,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.GetFunc("/version", mainflux.Version(svcName))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -74,103 +131,407 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, errors.ErrInvalidQueryParams
 	}
 
-	if err := authorize(r, chanID); err != nil {
+	subtopic, err := httputil.ReadStringQuery(r, subtopicKey, "")
+	if err != nil {
 		return nil, err
 	}
 
-	offset, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
+	if err := authorize(r, chanID, subtopic); err != nil {
+		return nil, err
+	}
+
+	pm, err := decodePageMeta(r)
 	if err != nil {
 		return nil, err
 	}
 
-	limit, err := httputil.ReadUintQuery(r, limitKey, defLimit)
+	return listMessagesReq{chanID: chanID, pageMeta: pm}, nil
+}
+
+func decodeListChannels(_ context.Context, r *http.Request) (interface{}, error) {
+	group, err := httputil.ReadStringQuery(r, groupKey, "")
 	if err != nil {
 		return nil, err
 	}
 
-	format, err := httputil.ReadStringQuery(r, formatKey, defFormat)
+	thing, err := httputil.ReadStringQuery(r, thingKey, "")
 	if err != nil {
 		return nil, err
 	}
 
-	subtopic, err := httputil.ReadStringQuery(r, subtopicKey, "")
+	pm, err := decodePageMeta(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listChannelsMessagesReq{
+		token:    r.Header.Get("Authorization"),
+		group:    group,
+		thing:    thing,
+		pageMeta: pm,
+	}
+	// Channels are given either as the comma-separated list, or as the
+	// repeated parameter.
+	req.chanIDs = bone.GetQuery(r, channelsKey)
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	chanIDs, err := authorizeChannels(r.Context(), req)
 	if err != nil {
 		return nil, err
 	}
+	// The group or the thing is resolved into the channels it selects.
+	req.chanIDs, req.group, req.thing = chanIDs, "", ""
+
+	return req, nil
+}
+
+// decodeDelete reads the messages to delete. Unlike reading, deletion is
+// allowed to the channel owner only.
+func decodeDelete(_ context.Context, r *http.Request) (interface{}, error) {
+	chanID := bone.GetValue(r, "chanID")
+	if chanID == "" {
+		return nil, errors.ErrInvalidQueryParams
+	}
 
 	publisher, err := httputil.ReadStringQuery(r, publisherKey, "")
 	if err != nil {
 		return nil, err
 	}
 
-	protocol, err := httputil.ReadStringQuery(r, protocolKey, "")
+	from, err := httputil.ReadFloatQuery(r, fromKey, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	name, err := httputil.ReadStringQuery(r, nameKey, "")
+	to, err := httputil.ReadFloatQuery(r, toKey, 0)
 	if err != nil {
 		return nil, err
 	}
 
+	owned := listChannelsMessagesReq{
+		token:   r.Header.Get("Authorization"),
+		chanIDs: []string{chanID},
+	}
+	if _, err := authorizeChannels(r.Context(), owned); err != nil {
+		return nil, err
+	}
+
+	req := deleteMessagesReq{
+		chanID: chanID,
+		meta: readers.DeleteMetadata{
+			Publisher: publisher,
+			From:      from,
+			To:        to,
+		},
+	}
+
+	return req, nil
+}
+
+// decodePageMeta reads the paging parameters and the query filters.
+func decodePageMeta(r *http.Request) (readers.PageMetadata, error) {
+	subtopic, err := httputil.ReadStringQuery(r, subtopicKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	offset, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	limit, err := httputil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	format, err := httputil.ReadStringQuery(r, formatKey, defFormat)
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	publisher, err := httputil.ReadStringQuery(r, publisherKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	protocol, err := httputil.ReadStringQuery(r, protocolKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	name, err := httputil.ReadStringQuery(r, nameKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
 	v, err := httputil.ReadFloatQuery(r, valueKey, 0)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	comparator, err := httputil.ReadStringQuery(r, comparatorKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	vs, err := httputil.ReadStringQuery(r, stringValueKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	vd, err := httputil.ReadStringQuery(r, dataValueKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	from, err := httputil.ReadFloatQuery(r, fromKey, 0)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	to, err := httputil.ReadFloatQuery(r, toKey, 0)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
-	req := listMessagesReq{
-		chanID: chanID,
-		pageMeta: readers.PageMetadata{
-			Offset:      offset,
-			Limit:       limit,
-			Format:      format,
-			Subtopic:    subtopic,
-			Publisher:   publisher,
-			Protocol:    protocol,
-			Name:        name,
-			Value:       v,
-			Comparator:  comparator,
-			StringValue: vs,
-			DataValue:   vd,
-			From:        from,
-			To:          to,
-		},
+	aggregation, err := httputil.ReadStringQuery(r, aggregationKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	interval, err := httputil.ReadStringQuery(r, intervalKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	cursor, err := httputil.ReadStringQuery(r, cursorKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	pm := readers.PageMetadata{
+		Offset:      offset,
+		Limit:       limit,
+		Format:      format,
+		Subtopic:    subtopic,
+		Publisher:   publisher,
+		Protocol:    protocol,
+		Name:        name,
+		Value:       v,
+		Comparator:  comparator,
+		StringValue: vs,
+		DataValue:   vd,
+		From:        from,
+		To:          to,
+		Aggregation: aggregation,
+		Interval:    interval,
+		Cursor:      cursor,
 	}
 
 	vb, err := readBoolValueQuery(r, "vb")
 	if err != nil && err != errors.ErrNotFoundParam {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 	if err == nil {
-		req.pageMeta.BoolValue = vb
+		pm.BoolValue = vb
 	}
 
-	return req, nil
+	return pm, nil
+}
+
+func decodeLatest(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeList(ctx, r)
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	perPublisher, err := httputil.ReadBoolQuery(r, perPublisherKey, false)
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	return latestMessagesReq{
+		chanID:       req.(listMessagesReq).chanID,
+		perPublisher: perPublisher,
+		pageMeta:     req.(listMessagesReq).pageMeta,
+	}, nil
+}
+
+var (
+	senmlHeader = []string{"channel", "subtopic", "publisher", "protocol", "name", "unit", "time", "update_time", "value", "string_value", "bool_value", "data_value", "sum"}
+	jsonHeader  = []string{"channel", "subtopic", "publisher", "protocol", "created", "payload"}
+)
+
+func decodeExport(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeList(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return exportMessagesReq{
+		chanID:      req.(listMessagesReq).chanID,
+		contentType: exportContentType(r.Header.Get("Accept")),
+		pageMeta:    req.(listMessagesReq).pageMeta,
+	}, nil
+}
+
+// exportContentType returns the export content type matching the Accept
+// header. NDJSON is used unless the client asks for something else.
+func exportContentType(accept string) string {
+	if accept == "" {
+		return ndjsonContentType
+	}
+
+	for _, t := range strings.Split(accept, ",") {
+		t = strings.TrimSpace(strings.Split(t, ";")[0])
+		switch t {
+		case csvContentType:
+			return csvContentType
+		case ndjsonContentType, contentType, "*/*":
+			return ndjsonContentType
+		}
+	}
+
+	return accept
+}
+
+func encodeExport(_ context.Context, w http.ResponseWriter, response interface{}) (err error) {
+	res := response.(exportRes)
+	defer func() {
+		if cerr := res.iter.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	w.Header().Set("Content-Type", res.contentType)
+	w.WriteHeader(res.Code())
+
+	switch res.contentType {
+	case csvContentType:
+		err = encodeCSV(w, res)
+	default:
+		err = encodeNDJSON(w, res)
+	}
+	if err != nil {
+		return err
+	}
+
+	return res.iter.Err()
+}
+
+func encodeNDJSON(w http.ResponseWriter, res exportRes) error {
+	enc := json.NewEncoder(w)
+	for res.iter.Next() {
+		if err := enc.Encode(res.iter.Message()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func encodeCSV(w http.ResponseWriter, res exportRes) error {
+	cw := csv.NewWriter(w)
+
+	header := senmlHeader
+	if res.format != "" && res.format != defFormat {
+		header = jsonHeader
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for res.iter.Next() {
+		var row []string
+		switch msg := res.iter.Message().(type) {
+		case senml.Message:
+			row = senmlRow(msg)
+		default:
+			r, err := jsonRow(msg)
+			if err != nil {
+				return err
+			}
+			row = r
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func senmlRow(msg senml.Message) []string {
+	row := []string{
+		msg.Channel,
+		msg.Subtopic,
+		msg.Publisher,
+		msg.Protocol,
+		msg.Name,
+		msg.Unit,
+		formatFloat(msg.Time),
+		formatFloat(msg.UpdateTime),
+		"", "", "", "", "",
+	}
+	if msg.Value != nil {
+		row[8] = formatFloat(*msg.Value)
+	}
+	if msg.StringValue != nil {
+		row[9] = *msg.StringValue
+	}
+	if msg.BoolValue != nil {
+		row[10] = strconv.FormatBool(*msg.BoolValue)
+	}
+	if msg.DataValue != nil {
+		row[11] = *msg.DataValue
+	}
+	if msg.Sum != nil {
+		row[12] = formatFloat(*msg.Sum)
+	}
+
+	return row
+}
+
+// jsonRow converts the JSON message into a CSV row. The message is
+// normalized through JSON since its type depends on the database.
+func jsonRow(msg readers.Message) ([]string, error) {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	row := make([]string, len(jsonHeader))
+	for i, key := range jsonHeader {
+		switch v := m[key].(type) {
+		case nil:
+		case string:
+			row[i] = v
+		case json.Number:
+			row[i] = v.String()
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			row[i] = string(b)
+		}
+	}
+
+	return row, nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
 }
 
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
@@ -198,6 +559,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errUnauthorizedAccess):
 		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, errNotAcceptable):
+		w.WriteHeader(http.StatusNotAcceptable)
 	default:
 		w.WriteHeader(http.StatusInternalServerError)
 	}
@@ -210,7 +573,10 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	}
 }
 
-func authorize(r *http.Request, chanID string) error {
+// authorize checks whether the thing is allowed to read the subtopic. Reading
+// without a subtopic returns the messages of every subtopic of the channel, so
+// it also requires access to all of the channel subtopics.
+func authorize(r *http.Request, chanID, subtopic string) error {
 	token := r.Header.Get("Authorization")
 	if token == "" {
 		return errUnauthorizedAccess
@@ -219,16 +585,110 @@ func authorize(r *http.Request, chanID string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	_, err := auth.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: token, ChanID: chanID})
+	subtopics := []string{subtopic}
+	if subtopic == "" {
+		subtopics = append(subtopics, anySubtopic)
+	}
+
+	for _, s := range subtopics {
+		_, err := auth.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{
+			Token:    token,
+			ChanID:   chanID,
+			Action:   things.SubscribeAction,
+			Subtopic: s,
+		})
+		if err != nil {
+			e, ok := status.FromError(err)
+			if ok && e.Code() == codes.PermissionDenied {
+				return errUnauthorizedAccess
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// authorizeChannels returns the IDs of the requested channels, all of which
+// have to be owned by the user identified by the token.
+func authorizeChannels(ctx context.Context, req listChannelsMessagesReq) ([]string, error) {
+	if req.token == "" {
+		return nil, errUnauthorizedAccess
+	}
+
+	user, err := users.Identify(ctx, &mainflux.Token{Value: req.token})
 	if err != nil {
-		e, ok := status.FromError(err)
-		if ok && e.Code() == codes.PermissionDenied {
-			return errUnauthorizedAccess
+		return nil, authError(err)
+	}
+
+	chanIDs := req.chanIDs
+	switch {
+	case req.group != "":
+		if chanIDs, err = groupChannels(ctx, req.token, req.group); err != nil {
+			return nil, authError(err)
+		}
+	case req.thing != "":
+		if chanIDs, err = thingChannels.ChannelsByThing(req.token, req.thing); err != nil {
+			return nil, errors.Wrap(errUnauthorizedAccess, err)
+		}
+	}
+	if len(chanIDs) > maxChannels {
+		return nil, errors.ErrInvalidQueryParams
+	}
+
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, id := range chanIDs {
+		if seen[id] {
+			continue
 		}
+		seen[id] = true
+
+		req := &mainflux.ChannelOwnerReq{Owner: user.GetEmail(), ChanID: id}
+		if _, err := auth.IsChannelOwner(ctx, req); err != nil {
+			return nil, authError(err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// groupChannels returns the IDs of the channels that belong to the group.
+func groupChannels(ctx context.Context, token, groupID string) ([]string, error) {
+	ids := []string{}
+	for {
+		res, err := users.Members(ctx, &mainflux.MembersReq{
+			Token:   token,
+			GroupID: groupID,
+			Offset:  uint64(len(ids)),
+			Limit:   maxChannels,
+			Type:    channelsType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, res.GetMembers()...)
+		if len(res.GetMembers()) == 0 || uint64(len(ids)) >= res.GetTotal() {
+			return ids, nil
+		}
+	}
+}
+
+// authError converts the errors of the access checks into the unauthorized
+// access error.
+func authError(err error) error {
+	e, ok := status.FromError(err)
+	if !ok {
 		return err
 	}
 
-	return nil
+	switch e.Code() {
+	case codes.Unauthenticated, codes.PermissionDenied, codes.NotFound:
+		return errUnauthorizedAccess
+	default:
+		return err
+	}
 }
 
 func readBoolValueQuery(r *http.Request, key string) (bool, error) {
@@ -0,0 +1,113 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cassandra
+
+import (
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/mainflux/mainflux/pkg/errors"
+	jsont "github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+)
+
+var _ readers.MessageIterator = (*messageIterator)(nil)
+
+type messageIterator struct {
+	iter    *gocql.Iter
+	scanner gocql.Scanner
+	format  string
+	msg     readers.Message
+	err     error
+}
+
+func (cr cassandraRepository) Iterate(chanID string, rpm readers.PageMetadata) (readers.MessageIterator, error) {
+	format := defTable
+	if rpm.Format != "" {
+		format = rpm.Format
+	}
+
+	q, vals := buildQuery(chanID, rpm)
+	// Drop the limit, since all the messages are iterated over.
+	vals = vals[:len(vals)-1]
+
+	cql := fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, name, unit,
+		value, string_value, bool_value, data_value, sum, time,
+		update_time FROM messages WHERE channel = ? %s ORDER BY time ASC
+		ALLOW FILTERING`, q)
+	if format != defTable {
+		cql = fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, created, payload FROM %s
+			WHERE channel = ? %s ORDER BY created ASC ALLOW FILTERING`, format, q)
+	}
+
+	iter := cr.session.Query(cql, vals...).Iter()
+	return &messageIterator{
+		iter:    iter,
+		scanner: iter.Scanner(),
+		format:  format,
+	}, nil
+}
+
+func (it *messageIterator) Next() bool {
+	if it.err != nil || !it.scanner.Next() {
+		return false
+	}
+
+	switch it.format {
+	case defTable:
+		var msg senml.Message
+		err := it.scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol,
+			&msg.Name, &msg.Unit, &msg.Value, &msg.StringValue, &msg.BoolValue,
+			&msg.DataValue, &msg.Sum, &msg.Time, &msg.UpdateTime)
+		if err != nil {
+			it.err = errors.Wrap(errReadMessages, err)
+			return false
+		}
+		it.msg = msg
+	default:
+		var msg jsonMessage
+		err := it.scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol, &msg.Created, &msg.Payload)
+		if err != nil {
+			it.err = errors.Wrap(errReadMessages, err)
+			return false
+		}
+		m, err := msg.toMap()
+		if err != nil {
+			it.err = errors.Wrap(errReadMessages, err)
+			return false
+		}
+		m["payload"] = jsont.ParseFlat(m["payload"])
+		it.msg = m
+	}
+
+	return true
+}
+
+func (it *messageIterator) Message() readers.Message {
+	return it.msg
+}
+
+func (it *messageIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	if err := it.scanner.Err(); err != nil {
+		if e, ok := err.(gocql.RequestError); ok && e.Code() == undefinedTableCode {
+			return nil
+		}
+		return errors.Wrap(errReadMessages, err)
+	}
+	return nil
+}
+
+func (it *messageIterator) Close() error {
+	if err := it.iter.Close(); err != nil {
+		if e, ok := err.(gocql.RequestError); ok && e.Code() == undefinedTableCode {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -6,6 +6,9 @@ package cassandra
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"time"
 
 	"github.com/gocql/gocql"
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -14,7 +17,12 @@ import (
 	"github.com/mainflux/mainflux/readers"
 )
 
-var errReadMessages = errors.New("failed to read messages from cassandra database")
+var (
+	errReadMessages   = errors.New("failed to read messages from cassandra database")
+	errDeleteMessages = errors.New("failed to delete messages from cassandra database")
+	errMissingRange   = errors.New("aggregation requires from and to times")
+	errTooManyRows    = errors.New("too many messages to aggregate")
+)
 
 const (
 	format = "format"
@@ -23,6 +31,10 @@ const (
 
 	// Error code for Undefined table error.
 	undefinedTableCode = 8704
+
+	// maxAggregateRows is the maximum number of rows scanned to aggregate
+	// the values.
+	maxAggregateRows = 100000
 )
 
 var _ readers.MessageRepository = (*cassandraRepository)(nil)
@@ -39,26 +51,53 @@ func New(session *gocql.Session) readers.MessageRepository {
 }
 
 func (cr cassandraRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return cr.aggregate(chanID, rpm)
+	}
+
 	format := defTable
 	if rpm.Format != "" {
 		format = rpm.Format
 	}
 
 	q, vals := buildQuery(chanID, rpm)
+	countVals := vals[:len(vals)-1]
 
-	selectCQL := fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, name, unit,
+	order := "time"
+	if format != defTable {
+		order = "created"
+	}
+	var c readers.Cursor
+	var cq string
+	selectVals := vals
+	if rpm.Cursor != "" {
+		var err error
+		if c, err = readers.DecodeCursor(rpm.Cursor); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errors.ErrInvalidQueryParams, err)
+		}
+		// Messages with the cursor time that precede the cursor are
+		// skipped while scanning, so the number of rows to read is unknown.
+		var t interface{} = c.Time
+		if order == "created" {
+			t = c.Created
+		}
+		cq = fmt.Sprintf(` AND %s <= ?`, order)
+		selectVals = append(append([]interface{}{}, countVals...), t, math.MaxInt32)
+	}
+
+	selectCQL := fmt.Sprintf(`SELECT id, channel, subtopic, publisher, protocol, name, unit,
 		value, string_value, bool_value, data_value, sum, time,
-		update_time FROM messages WHERE channel = ? %s LIMIT ?
-		ALLOW FILTERING`, q)
+		update_time FROM messages WHERE channel = ? %s%s LIMIT ?
+		ALLOW FILTERING`, q, cq)
 	countCQL := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE channel = ? %s ALLOW FILTERING`, format, q)
 
 	if format != defTable {
-		selectCQL = fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, created, payload FROM %s WHERE channel = ? %s LIMIT ?
-			ALLOW FILTERING`, format, q)
+		selectCQL = fmt.Sprintf(`SELECT id, channel, subtopic, publisher, protocol, created, payload FROM %s WHERE channel = ? %s%s LIMIT ?
+			ALLOW FILTERING`, format, q, cq)
 		countCQL = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE channel = ? %s ALLOW FILTERING`, format, q)
 	}
 
-	iter := cr.session.Query(selectCQL, vals...).Iter()
+	iter := cr.session.Query(selectCQL, selectVals...).Iter()
 	defer iter.Close()
 	scanner := iter.Scanner()
 
@@ -74,11 +113,16 @@ func (cr cassandraRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 		Messages:     []readers.Message{},
 	}
 
+	// Rows with the same time are ordered by ID, so the rows are skipped
+	// until the one the cursor points to.
+	skip := rpm.Cursor != ""
+	var last readers.Cursor
 	switch format {
 	case defTable:
-		for scanner.Next() {
+		for uint64(len(page.Messages)) < rpm.Limit && scanner.Next() {
+			var id gocql.UUID
 			var msg senml.Message
-			err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol,
+			err := scanner.Scan(&id, &msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol,
 				&msg.Name, &msg.Unit, &msg.Value, &msg.StringValue, &msg.BoolValue,
 				&msg.DataValue, &msg.Sum, &msg.Time, &msg.UpdateTime)
 			if err != nil {
@@ -89,12 +133,18 @@ func (cr cassandraRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 				}
 				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 			}
+			if skip && msg.Time == c.Time {
+				skip = id.String() != c.ID
+				continue
+			}
+			skip = false
 			page.Messages = append(page.Messages, msg)
+			last = readers.Cursor{Time: msg.Time, ID: id.String()}
 		}
 	default:
-		for scanner.Next() {
+		for uint64(len(page.Messages)) < rpm.Limit && scanner.Next() {
 			var msg jsonMessage
-			err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol, &msg.Created, &msg.Payload)
+			err := scanner.Scan(&msg.ID, &msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol, &msg.Created, &msg.Payload)
 			if err != nil {
 				if e, ok := err.(gocql.RequestError); ok {
 					if e.Code() == undefinedTableCode {
@@ -103,16 +153,25 @@ func (cr cassandraRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 				}
 				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 			}
+			if skip && msg.Created == c.Created {
+				skip = msg.ID != c.ID
+				continue
+			}
+			skip = false
 			m, err := msg.toMap()
 			if err != nil {
 				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 			}
 			m["payload"] = jsont.ParseFlat(m["payload"])
 			page.Messages = append(page.Messages, m)
+			last = readers.Cursor{Created: msg.Created, ID: msg.ID}
 		}
 	}
+	if uint64(len(page.Messages)) == rpm.Limit {
+		page.NextCursor = last.Encode()
+	}
 
-	if err := cr.session.Query(countCQL, vals[:len(vals)-1]...).Scan(&page.Total); err != nil {
+	if err := cr.session.Query(countCQL, countVals...).Scan(&page.Total); err != nil {
 		if e, ok := err.(gocql.RequestError); ok {
 			if e.Code() == undefinedTableCode {
 				return readers.MessagesPage{}, nil
@@ -124,6 +183,408 @@ func (cr cassandraRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 	return page, nil
 }
 
+// ReadChannels reads the first messages of each channel and merges them in
+// memory, since Cassandra can't sort the rows of different partitions.
+func (cr cassandraRepository) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	var c *readers.Cursor
+	if rpm.Cursor != "" {
+		cur, err := readers.DecodeCursor(rpm.Cursor)
+		if err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errors.ErrInvalidQueryParams, err)
+		}
+		c = &cur
+	}
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	var msgs []channelMessage
+	for _, chanID := range chanIDs {
+		cm, total, err := cr.readChannel(chanID, rpm, c)
+		if err != nil {
+			return readers.MessagesPage{}, err
+		}
+		msgs = append(msgs, cm...)
+		page.Total += total
+	}
+
+	sort.Slice(msgs, func(i, j int) bool {
+		return precedes(msgs[i].cursor, msgs[j].cursor)
+	})
+
+	if rpm.Offset >= uint64(len(msgs)) {
+		return page, nil
+	}
+	end := rpm.Offset + rpm.Limit
+	if end > uint64(len(msgs)) {
+		end = uint64(len(msgs))
+	}
+	for _, m := range msgs[rpm.Offset:end] {
+		page.Messages = append(page.Messages, m.msg)
+	}
+	if uint64(len(page.Messages)) == rpm.Limit {
+		page.NextCursor = msgs[end-1].cursor.Encode()
+	}
+
+	return page, nil
+}
+
+// readChannel reads the first offset+limit messages of the channel that
+// follow the cursor, along with the rest of the messages that share the time
+// with the last one, and returns them with the total number of messages.
+func (cr cassandraRepository) readChannel(chanID string, rpm readers.PageMetadata, c *readers.Cursor) ([]channelMessage, uint64, error) {
+	format := defTable
+	order := "time"
+	if rpm.Format != "" && rpm.Format != defTable {
+		format = rpm.Format
+		order = "created"
+	}
+
+	q, vals := buildQuery(chanID, rpm)
+	vals = vals[:len(vals)-1]
+
+	var total uint64
+	countCQL := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE channel = ? %s ALLOW FILTERING`, format, q)
+	if err := cr.session.Query(countCQL, vals...).Scan(&total); err != nil {
+		if e, ok := err.(gocql.RequestError); ok {
+			if e.Code() == undefinedTableCode {
+				return nil, 0, nil
+			}
+		}
+		return nil, 0, errors.Wrap(errReadMessages, err)
+	}
+
+	if c != nil {
+		q = fmt.Sprintf(`%s AND %s <= ?`, q, order)
+		if order == "created" {
+			vals = append(vals, c.Created)
+		} else {
+			vals = append(vals, c.Time)
+		}
+	}
+
+	selectCQL := fmt.Sprintf(`SELECT id, channel, subtopic, publisher, protocol, name, unit,
+		value, string_value, bool_value, data_value, sum, time,
+		update_time FROM messages WHERE channel = ? %s ALLOW FILTERING`, q)
+	if format != defTable {
+		selectCQL = fmt.Sprintf(`SELECT id, channel, subtopic, publisher, protocol, created, payload FROM %s WHERE channel = ? %s
+			ALLOW FILTERING`, format, q)
+	}
+
+	iter := cr.session.Query(selectCQL, vals...).Iter()
+	defer iter.Close()
+	scanner := iter.Scanner()
+
+	n := rpm.Offset + rpm.Limit
+	var msgs []channelMessage
+	for scanner.Next() {
+		var cm channelMessage
+		switch format {
+		case defTable:
+			var id gocql.UUID
+			var msg senml.Message
+			err := scanner.Scan(&id, &msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol,
+				&msg.Name, &msg.Unit, &msg.Value, &msg.StringValue, &msg.BoolValue,
+				&msg.DataValue, &msg.Sum, &msg.Time, &msg.UpdateTime)
+			if err != nil {
+				return nil, 0, errors.Wrap(errReadMessages, err)
+			}
+			cm = channelMessage{cursor: readers.Cursor{Time: msg.Time, ID: id.String()}, msg: msg}
+		default:
+			var msg jsonMessage
+			if err := scanner.Scan(&msg.ID, &msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol, &msg.Created, &msg.Payload); err != nil {
+				return nil, 0, errors.Wrap(errReadMessages, err)
+			}
+			m, err := msg.toMap()
+			if err != nil {
+				return nil, 0, errors.Wrap(errReadMessages, err)
+			}
+			m["payload"] = jsont.ParseFlat(m["payload"])
+			cm = channelMessage{cursor: readers.Cursor{Created: msg.Created, ID: msg.ID}, msg: m}
+		}
+
+		if c != nil && !precedes(*c, cm.cursor) {
+			continue
+		}
+		// Rows with the same time aren't sorted by ID, so all of them are
+		// read to be merged with the other channels.
+		if uint64(len(msgs)) >= n {
+			last := msgs[len(msgs)-1].cursor
+			if last.Time != cm.cursor.Time || last.Created != cm.cursor.Created {
+				break
+			}
+		}
+		msgs = append(msgs, cm)
+	}
+
+	return msgs, total, nil
+}
+
+// channelMessage holds the message along with the cursor that points to it.
+type channelMessage struct {
+	cursor readers.Cursor
+	msg    readers.Message
+}
+
+// precedes reports whether the message the cursor a points to is read
+// before the one the cursor b points to, that is if it's later, or if it has
+// the greater ID when the time is the same.
+func precedes(a, b readers.Cursor) bool {
+	if a.Time != b.Time {
+		return a.Time > b.Time
+	}
+	if a.Created != b.Created {
+		return a.Created > b.Created
+	}
+	return a.ID > b.ID
+}
+
+// aggregate groups the SenML values into time buckets of the requested
+// interval. Since Cassandra can't group rows by time, values are aggregated
+// in memory. The time range is required, and only the rows of the channel
+// partition within the range are scanned, up to maxAggregateRows of them.
+// The rest of the filters are applied in memory, so that the rows which
+// don't match them are scanned only once.
+func (cr cassandraRepository) aggregate(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	interval, err := time.ParseDuration(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errors.ErrInvalidQueryParams, err)
+	}
+	if rpm.From == 0 || rpm.To == 0 || rpm.From >= rpm.To {
+		return readers.MessagesPage{}, errors.Wrap(errors.ErrInvalidQueryParams, errMissingRange)
+	}
+
+	selectCQL := `SELECT channel, subtopic, publisher, protocol, name, value,
+		string_value, bool_value, data_value, time FROM messages
+		WHERE channel = ? AND time >= ? AND time < ? LIMIT ?`
+
+	iter := cr.session.Query(selectCQL, chanID, rpm.From, rpm.To, maxAggregateRows+1).Iter()
+	scanner := iter.Scanner()
+
+	var msgs []senml.Message
+	scanned := 0
+	for scanner.Next() {
+		if scanned++; scanned > maxAggregateRows {
+			iter.Close()
+			return readers.MessagesPage{}, errors.Wrap(errors.ErrInvalidQueryParams, errTooManyRows)
+		}
+		var msg senml.Message
+		err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol, &msg.Name,
+			&msg.Value, &msg.StringValue, &msg.BoolValue, &msg.DataValue, &msg.Time)
+		if err != nil {
+			iter.Close()
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		if matches(msg, rpm) {
+			msgs = append(msgs, msg)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+
+	buckets := readers.Aggregate(msgs, rpm.Aggregation, interval)
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Total:        uint64(len(buckets)),
+		Messages:     []readers.Message{},
+	}
+	if rpm.Offset < page.Total {
+		end := rpm.Offset + rpm.Limit
+		if end > page.Total {
+			end = page.Total
+		}
+		page.Messages = buckets[rpm.Offset:end]
+	}
+
+	return page, nil
+}
+
+// matches checks whether the SenML message matches the query filters other
+// than the channel and the time range.
+func matches(msg senml.Message, rpm readers.PageMetadata) bool {
+	switch {
+	case rpm.Subtopic != "" && msg.Subtopic != rpm.Subtopic,
+		rpm.Publisher != "" && msg.Publisher != rpm.Publisher,
+		rpm.Protocol != "" && msg.Protocol != rpm.Protocol,
+		rpm.Name != "" && msg.Name != rpm.Name,
+		rpm.BoolValue && (msg.BoolValue == nil || !*msg.BoolValue),
+		rpm.StringValue != "" && (msg.StringValue == nil || *msg.StringValue != rpm.StringValue),
+		rpm.DataValue != "" && (msg.DataValue == nil || *msg.DataValue != rpm.DataValue):
+		return false
+	}
+	if rpm.Value == 0 {
+		return true
+	}
+	if msg.Value == nil {
+		return false
+	}
+
+	v := *msg.Value
+	switch rpm.Comparator {
+	case readers.LowerThanKey:
+		return v < rpm.Value
+	case readers.LowerThanEqualKey:
+		return v <= rpm.Value
+	case readers.GreaterThanKey:
+		return v > rpm.Value
+	case readers.GreaterThanEqualKey:
+		return v >= rpm.Value
+	default:
+		return v == rpm.Value
+	}
+}
+
+// Latest scans the messages starting from the latest one and keeps the first
+// message of each group, since Cassandra can't group rows by a regular column.
+func (cr cassandraRepository) Latest(chanID string, rpm readers.PageMetadata, perPublisher bool) ([]readers.Message, error) {
+	q, vals := buildQuery(chanID, rpm)
+	selectCQL := fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, name, unit,
+		value, string_value, bool_value, data_value, sum, time,
+		update_time FROM messages WHERE channel = ? %s ALLOW FILTERING`, q)
+
+	iter := cr.session.Query(selectCQL, vals[:len(vals)-1]...).Iter()
+	scanner := iter.Scanner()
+
+	type key struct {
+		name      string
+		publisher string
+	}
+	seen := map[key]bool{}
+	var latest []senml.Message
+	for scanner.Next() {
+		var msg senml.Message
+		err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol,
+			&msg.Name, &msg.Unit, &msg.Value, &msg.StringValue, &msg.BoolValue,
+			&msg.DataValue, &msg.Sum, &msg.Time, &msg.UpdateTime)
+		if err != nil {
+			iter.Close()
+			if e, ok := err.(gocql.RequestError); ok {
+				if e.Code() == undefinedTableCode {
+					return []readers.Message{}, nil
+				}
+			}
+			return nil, errors.Wrap(errReadMessages, err)
+		}
+
+		k := key{name: msg.Name}
+		if perPublisher {
+			k.publisher = msg.Publisher
+		}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		latest = append(latest, msg)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+
+	sort.SliceStable(latest, func(i, j int) bool {
+		if latest[i].Name != latest[j].Name {
+			return latest[i].Name < latest[j].Name
+		}
+		return perPublisher && latest[i].Publisher < latest[j].Publisher
+	})
+
+	msgs := []readers.Message{}
+	for _, msg := range latest {
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+func (cr cassandraRepository) Delete(chanID string, dm readers.DeleteMetadata) error {
+	tables, err := cr.jsonTables()
+	if err != nil {
+		return errors.Wrap(errDeleteMessages, err)
+	}
+
+	// SenML time is in seconds, and JSON creation time in nanoseconds.
+	cond, vals := deleteCondition(chanID, "time", dm, dm.From, dm.To)
+	if err := cr.delete(defTable, "time", cond, vals, dm.Publisher); err != nil {
+		return errors.Wrap(errDeleteMessages, err)
+	}
+	for _, table := range tables {
+		cond, vals := deleteCondition(chanID, "created", dm, int64(dm.From*1e9), int64(dm.To*1e9))
+		if err := cr.delete(table, "created", cond, vals, dm.Publisher); err != nil {
+			return errors.Wrap(errDeleteMessages, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteCondition returns the condition on the channel partition and the
+// time column, and its values, of the messages which match the delete
+// metadata. The bounds are the time column values of the metadata bounds.
+func deleteCondition(chanID, column string, dm readers.DeleteMetadata, from, to interface{}) (string, []interface{}) {
+	cond := `channel = ?`
+	vals := []interface{}{chanID}
+	if dm.From != 0 {
+		cond = fmt.Sprintf(`%s AND %s >= ?`, cond, column)
+		vals = append(vals, from)
+	}
+	if dm.To != 0 {
+		cond = fmt.Sprintf(`%s AND %s < ?`, cond, column)
+		vals = append(vals, to)
+	}
+	return cond, vals
+}
+
+// delete removes the messages matching the condition from the table, which
+// is clustered by the given time column and the message ID within the
+// channel partition.
+func (cr cassandraRepository) delete(table, column, cond string, vals []interface{}, publisher string) error {
+	if publisher == "" {
+		cql := fmt.Sprintf(`DELETE FROM %s WHERE %s`, table, cond)
+		return cr.session.Query(cql, vals...).Exec()
+	}
+
+	// Publisher isn't part of the primary key, so the matching messages are
+	// looked up first and removed one by one.
+	cql := fmt.Sprintf(`SELECT %s, id FROM %s WHERE %s AND publisher = ? ALLOW FILTERING`, column, table, cond)
+	iter := cr.session.Query(cql, append(vals, publisher)...).Iter()
+
+	cql = fmt.Sprintf(`DELETE FROM %s WHERE channel = ? AND %s = ? AND id = ?`, table, column)
+	chanID := vals[0]
+	for {
+		row := map[string]interface{}{}
+		if !iter.MapScan(row) {
+			break
+		}
+		if err := cr.session.Query(cql, chanID, row[column], row["id"]).Exec(); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+
+	return iter.Close()
+}
+
+// jsonTables returns the names of the tables of the JSON messages, which
+// are created per message format in the keyspace of the session.
+func (cr cassandraRepository) jsonTables() ([]string, error) {
+	q := cr.session.Query(`SELECT table_name, column_name FROM system_schema.columns WHERE keyspace_name = ?`)
+	iter := q.Bind(q.Keyspace()).Iter()
+
+	tables := []string{}
+	var table, column string
+	for iter.Scan(&table, &column) {
+		if column == "payload" {
+			tables = append(tables, table)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
 func buildQuery(chanID string, rpm readers.PageMetadata) (string, []interface{}) {
 	var condCQL string
 	vals := []interface{}{chanID}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	cwriter "github.com/mainflux/mainflux/consumers/writers/cassandra"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/transformers/json"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -28,9 +29,10 @@ const (
 	httpProt    = "http"
 	msgName     = "temperature"
 
-	format1 = "format_1"
-	format2 = "format_2"
-	wrongID = "0"
+	format1      = "format_1"
+	format2      = "format_2"
+	deleteFormat = "delete_format"
+	wrongID      = "0"
 )
 
 var (
@@ -533,6 +535,507 @@ func TestReadJSON(t *testing.T) {
 	}
 }
 
+func TestReadAggregation(t *testing.T) {
+	session, err := creader.Connect(creader.DBConfig{
+		Hosts:    []string{addr},
+		Keyspace: keyspace,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
+	defer session.Close()
+	writer := cwriter.New(session)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Messages are split into two one-minute buckets, with the values
+	// 0 to 5 in the first one and 6 to 11 in the second one.
+	start := float64(time.Now().Add(-time.Hour).Unix() / 60 * 60)
+	var messages []senml.Message
+	for i := 0; i < 12; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      start + float64(i*10),
+			Value:     &v,
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := creader.New(session)
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		values   []float64
+		total    uint64
+	}{
+		"read average values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationAvg, Interval: "1m", From: start, To: start + 120},
+			values:   []float64{8.5, 2.5},
+			total:    2,
+		},
+		"read min values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationMin, Interval: "1m", From: start, To: start + 120},
+			values:   []float64{6, 0},
+			total:    2,
+		},
+		"read max values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationMax, Interval: "1m", From: start, To: start + 120},
+			values:   []float64{11, 5},
+			total:    2,
+		},
+		"read sum of values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationSum, Interval: "1m", From: start, To: start + 120},
+			values:   []float64{51, 15},
+			total:    2,
+		},
+		"read count of values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationCount, Interval: "1m", From: start, To: start + 120},
+			values:   []float64{6, 6},
+			total:    2,
+		},
+		"read first values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationFirst, Interval: "1m", From: start, To: start + 120},
+			values:   []float64{6, 0},
+			total:    2,
+		},
+		"read last values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationLast, Interval: "1m", From: start, To: start + 120},
+			values:   []float64{11, 5},
+			total:    2,
+		},
+		"read average values with offset": {
+			pageMeta: readers.PageMetadata{Offset: 1, Limit: limit, Name: msgName, Aggregation: readers.AggregationAvg, Interval: "1m", From: start, To: start + 120},
+			values:   []float64{2.5},
+			total:    2,
+		},
+		"read average values over single bucket": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationAvg, Interval: "1h", From: start, To: start + 60},
+			values:   []float64{2.5},
+			total:    1,
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ReadAll(chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.Equal(t, tc.total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.total, result.Total))
+
+		var values []float64
+		for _, m := range result.Messages {
+			msg := m.(senml.Message)
+			values = append(values, *msg.Value)
+		}
+		assert.Equal(t, tc.values, values, fmt.Sprintf("%s: expected %v got %v", desc, tc.values, values))
+	}
+
+	invalid := map[string]readers.PageMetadata{
+		"read aggregation without time range": {Limit: limit, Name: msgName, Aggregation: readers.AggregationAvg, Interval: "1m"},
+		"read aggregation without end time":   {Limit: limit, Name: msgName, Aggregation: readers.AggregationAvg, Interval: "1m", From: start},
+		"read aggregation with empty range":   {Limit: limit, Name: msgName, Aggregation: readers.AggregationAvg, Interval: "1m", From: start, To: start},
+	}
+	for desc, pm := range invalid {
+		_, err := reader.ReadAll(chanID, pm)
+		assert.True(t, errors.Contains(err, errors.ErrInvalidQueryParams), fmt.Sprintf("%s: expected %s got %s", desc, errors.ErrInvalidQueryParams, err))
+	}
+}
+
+func TestIterate(t *testing.T) {
+	session, err := creader.Connect(creader.DBConfig{
+		Hosts:    []string{addr},
+		Keyspace: keyspace,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
+	defer session.Close()
+	writer := cwriter.New(session)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	start := float64(time.Now().Add(-time.Hour).Unix())
+	var messages []senml.Message
+	for i := 0; i < 12; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      start + float64(i*10),
+			Value:     &v,
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := creader.New(session)
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		values   []float64
+	}{
+		"iterate all messages": {
+			pageMeta: readers.PageMetadata{},
+			values:   []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		},
+		"iterate messages from time": {
+			pageMeta: readers.PageMetadata{From: start + 60},
+			values:   []float64{6, 7, 8, 9, 10, 11},
+		},
+		"iterate messages by value": {
+			pageMeta: readers.PageMetadata{Value: 3, Comparator: readers.LowerThanEqualKey},
+			values:   []float64{0, 1, 2, 3},
+		},
+	}
+
+	for desc, tc := range cases {
+		iter, err := reader.Iterate(chanID, tc.pageMeta)
+		require.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+
+		var values []float64
+		for iter.Next() {
+			msg := iter.Message().(senml.Message)
+			values = append(values, *msg.Value)
+		}
+		assert.Nil(t, iter.Err(), fmt.Sprintf("%s: expected no error got %s", desc, iter.Err()))
+		assert.Nil(t, iter.Close(), fmt.Sprintf("%s: expected no error on close", desc))
+		assert.Equal(t, tc.values, values, fmt.Sprintf("%s: expected %v got %v", desc, tc.values, values))
+	}
+}
+
+func TestReadByCursor(t *testing.T) {
+	session, err := creader.Connect(creader.DBConfig{
+		Hosts:    []string{addr},
+		Keyspace: keyspace,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
+	defer session.Close()
+	writer := cwriter.New(session)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Pairs of messages share the time, so the cursor has to tell them
+	// apart.
+	start := float64(time.Now().Add(-time.Hour).Unix())
+	var messages []senml.Message
+	for i := 0; i < 12; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      start + float64(i/2*10),
+			Value:     &v,
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := creader.New(session)
+
+	// Follow the cursors through all the pages.
+	pm := readers.PageMetadata{Limit: 5}
+	var values []float64
+	for i := 0; i < 3; i++ {
+		page, err := reader.ReadAll(chanID, pm)
+		require.Nil(t, err, fmt.Sprintf("page %d: expected no error got %s", i, err))
+		assert.Equal(t, uint64(len(messages)), page.Total, fmt.Sprintf("page %d: expected total %d got %d", i, len(messages), page.Total))
+		for _, m := range page.Messages {
+			values = append(values, *m.(senml.Message).Value)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		pm.Cursor = page.NextCursor
+	}
+	assert.ElementsMatch(t, []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, values, fmt.Sprintf("expected all the messages once got %v", values))
+
+	_, err = reader.ReadAll(chanID, readers.PageMetadata{Limit: 5, Cursor: "invalid"})
+	assert.True(t, errors.Contains(err, errors.ErrInvalidQueryParams), fmt.Sprintf("expected %s got %s", errors.ErrInvalidQueryParams, err))
+}
+
+func TestReadLatest(t *testing.T) {
+	session, err := creader.Connect(creader.DBConfig{
+		Hosts:    []string{addr},
+		Keyspace: keyspace,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
+	defer session.Close()
+	writer := cwriter.New(session)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Names alternate with every message and publishers with every pair,
+	// and the value of the message is its index.
+	start := float64(time.Now().Add(-time.Hour).Unix())
+	names := []string{msgName, "humidity"}
+	pubs := []string{pubID, pubID2}
+	var messages []senml.Message
+	for i := 0; i < 12; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubs[(i/2)%2],
+			Protocol:  mqttProt,
+			Name:      names[i%2],
+			Time:      start + float64(i*10),
+			Value:     &v,
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := creader.New(session)
+
+	cases := map[string]struct {
+		pm           readers.PageMetadata
+		perPublisher bool
+		values       []float64
+	}{
+		"read latest messages": {
+			values: []float64{11, 10},
+		},
+		"read latest messages per publisher": {
+			perPublisher: true,
+			values:       []float64{9, 11, 8, 10},
+		},
+		"read latest messages with name": {
+			pm:     readers.PageMetadata{Name: msgName},
+			values: []float64{10},
+		},
+		"read latest messages before the given time": {
+			pm:     readers.PageMetadata{To: start + 95},
+			values: []float64{9, 8},
+		},
+		"read latest messages with no matching messages": {
+			pm:     readers.PageMetadata{Name: "unknown"},
+			values: []float64{},
+		},
+	}
+
+	for desc, tc := range cases {
+		msgs, err := reader.Latest(chanID, tc.pm, tc.perPublisher)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		values := []float64{}
+		for _, m := range msgs {
+			values = append(values, *m.(senml.Message).Value)
+		}
+		if tc.perPublisher {
+			// Publishers are ordered by their random IDs.
+			assert.ElementsMatch(t, tc.values, values, fmt.Sprintf("%s: expected %v got %v", desc, tc.values, values))
+			continue
+		}
+		assert.Equal(t, tc.values, values, fmt.Sprintf("%s: expected %v got %v", desc, tc.values, values))
+	}
+}
+
+func TestReadChannels(t *testing.T) {
+	session, err := creader.Connect(creader.DBConfig{
+		Hosts:    []string{addr},
+		Keyspace: keyspace,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
+	defer session.Close()
+	writer := cwriter.New(session)
+
+	var chanIDs []string
+	for i := 0; i < 4; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		chanIDs = append(chanIDs, id)
+	}
+
+	// Messages are sent to the first three channels in turn, and the value
+	// of the message is its index.
+	start := float64(time.Now().Add(-time.Hour).Unix())
+	var messages []senml.Message
+	for i := 0; i < 12; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:  chanIDs[i%3],
+			Protocol: mqttProt,
+			Name:     msgName,
+			Time:     start + float64(i*10),
+			Value:    &v,
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := creader.New(session)
+
+	cases := map[string]struct {
+		chanIDs []string
+		pm      readers.PageMetadata
+		total   uint64
+		values  []float64
+	}{
+		"read messages of channels": {
+			chanIDs: chanIDs[0:2],
+			pm:      readers.PageMetadata{Limit: 10},
+			total:   8,
+			values:  []float64{10, 9, 7, 6, 4, 3, 1, 0},
+		},
+		"read messages of channels with offset": {
+			chanIDs: chanIDs[0:2],
+			pm:      readers.PageMetadata{Offset: 2, Limit: 3},
+			total:   8,
+			values:  []float64{7, 6, 4},
+		},
+		"read messages of channels with filter": {
+			chanIDs: chanIDs[0:2],
+			pm:      readers.PageMetadata{Limit: 10, From: start + 30},
+			total:   6,
+			values:  []float64{10, 9, 7, 6, 4, 3},
+		},
+		"read messages of single channel": {
+			chanIDs: chanIDs[1:2],
+			pm:      readers.PageMetadata{Limit: 10},
+			total:   4,
+			values:  []float64{10, 7, 4, 1},
+		},
+		"read messages of channel without messages": {
+			chanIDs: chanIDs[3:],
+			pm:      readers.PageMetadata{Limit: 10},
+			total:   0,
+			values:  []float64{},
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := reader.ReadChannels(tc.chanIDs, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", desc, tc.total, page.Total))
+		values := []float64{}
+		for _, m := range page.Messages {
+			values = append(values, *m.(senml.Message).Value)
+		}
+		assert.Equal(t, tc.values, values, fmt.Sprintf("%s: expected %v got %v", desc, tc.values, values))
+	}
+
+	// Follow the cursors through all the pages.
+	pm := readers.PageMetadata{Limit: 3}
+	var values []float64
+	for i := 0; i < 3; i++ {
+		page, err := reader.ReadChannels(chanIDs[0:2], pm)
+		require.Nil(t, err, fmt.Sprintf("page %d: expected no error got %s", i, err))
+		for _, m := range page.Messages {
+			values = append(values, *m.(senml.Message).Value)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		pm.Cursor = page.NextCursor
+	}
+	assert.Equal(t, []float64{10, 9, 7, 6, 4, 3, 1, 0}, values, fmt.Sprintf("expected all the messages once got %v", values))
+}
+
+func TestDelete(t *testing.T) {
+	session, err := creader.Connect(creader.DBConfig{
+		Hosts:    []string{addr},
+		Keyspace: keyspace,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to connect to Cassandra: %s", err))
+	defer session.Close()
+	writer := cwriter.New(session)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubs := []string{"publisher-1", "publisher-2"}
+
+	// Publishers send the messages in turn, one per second.
+	start := time.Now().Add(-time.Hour).Unix()
+	var messages []senml.Message
+	for i := 0; i < 10; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubs[i%2],
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(start + int64(i)),
+			Value:     &v,
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	jsonMsgs := json.Messages{Format: deleteFormat}
+	for i := 0; i < 4; i++ {
+		jsonMsgs.Data = append(jsonMsgs.Data, json.Message{
+			Channel:   chanID,
+			Publisher: pubs[i%2],
+			Protocol:  mqttProt,
+			Created:   (start + int64(i)) * int64(time.Second),
+			Payload:   map[string]interface{}{"field_1": float64(i)},
+		})
+	}
+	err = writer.Consume(jsonMsgs)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := creader.New(session)
+
+	cases := []struct {
+		desc  string
+		dm    readers.DeleteMetadata
+		senml uint64
+		json  uint64
+	}{
+		{
+			desc:  "delete messages of publisher in time range",
+			dm:    readers.DeleteMetadata{Publisher: pubs[0], From: float64(start + 2), To: float64(start + 6)},
+			senml: 8,
+			json:  3,
+		},
+		{
+			desc:  "delete messages since time",
+			dm:    readers.DeleteMetadata{From: float64(start + 8)},
+			senml: 6,
+			json:  3,
+		},
+		{
+			desc:  "delete messages until time",
+			dm:    readers.DeleteMetadata{To: float64(start + 1)},
+			senml: 5,
+			json:  2,
+		},
+		{
+			desc:  "delete all messages",
+			dm:    readers.DeleteMetadata{},
+			senml: 0,
+			json:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		err := reader.Delete(chanID, tc.dm)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+
+		page, err := reader.ReadAll(chanID, readers.PageMetadata{Limit: msgsNum})
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Equal(t, tc.senml, page.Total, fmt.Sprintf("%s: expected %d SenML messages got %d", tc.desc, tc.senml, page.Total))
+
+		page, err = reader.ReadAll(chanID, readers.PageMetadata{Limit: msgsNum, Format: deleteFormat})
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Equal(t, tc.json, page.Total, fmt.Sprintf("%s: expected %d JSON messages got %d", tc.desc, tc.json, page.Total))
+	}
+}
+
 func fromSenml(in []senml.Message) []readers.Message {
 	var ret []readers.Message
 	for _, m := range in {
@@ -0,0 +1,53 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor indicates that the page cursor is malformed.
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// Cursor identifies the last message of the page, so that the next page
+// starts right after it. Messages are ordered from the latest one, and the
+// message ID breaks the ties between the messages with the same time.
+type Cursor struct {
+	// Time is the SenML message time in seconds.
+	Time float64 `json:"t,omitempty"`
+
+	// Created is the JSON message creation time in nanoseconds. The readers
+	// that store the SenML time in nanoseconds use it for SenML messages too.
+	Created int64 `json:"c,omitempty"`
+
+	// ID is the message ID, if the database stores one.
+	ID string `json:"id,omitempty"`
+}
+
+// Encode returns the opaque token that represents the cursor.
+func (c Cursor) Encode() string {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor decodes the cursor from the opaque token.
+func DecodeCursor(token string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
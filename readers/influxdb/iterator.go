@@ -0,0 +1,113 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb
+
+import (
+	"fmt"
+	"io"
+
+	influxdata "github.com/influxdata/influxdb/client/v2"
+	"github.com/influxdata/influxdb/models"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/readers"
+)
+
+// chunkSize is the number of points returned by a single response chunk.
+const chunkSize = 1000
+
+var _ readers.MessageIterator = (*messageIterator)(nil)
+
+type messageIterator struct {
+	resp   *influxdata.ChunkedResponse
+	format string
+	series []models.Row
+	row    int
+	msg    readers.Message
+	err    error
+}
+
+func (repo *influxRepository) Iterate(chanID string, rpm readers.PageMetadata) (readers.MessageIterator, error) {
+	format := defMeasurement
+	if rpm.Format != "" {
+		format = rpm.Format
+	}
+
+	cmd := fmt.Sprintf(`SELECT * FROM %s WHERE %s ORDER BY time ASC`, format, fmtCondition(chanID, rpm))
+	resp, err := repo.client.QueryAsChunk(influxdata.Query{
+		Command:   cmd,
+		Database:  repo.database,
+		Chunked:   true,
+		ChunkSize: chunkSize,
+	})
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+
+	return &messageIterator{
+		resp:   resp,
+		format: format,
+	}, nil
+}
+
+func (it *messageIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	// Skip the exhausted series, and read the next chunk once all the
+	// series of the current one are exhausted.
+	for len(it.series) == 0 || it.row >= len(it.series[0].Values) {
+		if len(it.series) > 0 {
+			it.series = it.series[1:]
+			it.row = 0
+			continue
+		}
+		if !it.nextChunk() {
+			return false
+		}
+	}
+
+	s := it.series[0]
+	msg, err := parseMessage(it.format, s.Columns, s.Values[it.row])
+	if err != nil {
+		it.err = errors.Wrap(errReadMessages, err)
+		return false
+	}
+	it.msg = msg
+	it.row++
+
+	return true
+}
+
+func (it *messageIterator) nextChunk() bool {
+	resp, err := it.resp.NextResponse()
+	if err != nil {
+		if err != io.EOF {
+			it.err = errors.Wrap(errReadMessages, err)
+		}
+		return false
+	}
+	if resp.Error() != nil {
+		it.err = errors.Wrap(errReadMessages, resp.Error())
+		return false
+	}
+
+	for _, r := range resp.Results {
+		it.series = append(it.series, r.Series...)
+	}
+	it.row = 0
+	return true
+}
+
+func (it *messageIterator) Message() readers.Message {
+	return it.msg
+}
+
+func (it *messageIterator) Err() error {
+	return it.err
+}
+
+func (it *messageIterator) Close() error {
+	return it.resp.Close()
+}
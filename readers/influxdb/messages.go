@@ -22,7 +22,22 @@ const (
 	defMeasurement = "messages"
 )
 
-var errReadMessages = errors.New("failed to read messages from influxdb database")
+var (
+	errReadMessages   = errors.New("failed to read messages from influxdb database")
+	errDeleteMessages = errors.New("failed to delete messages from influxdb database")
+)
+
+// aggregations maps the aggregations to the InfluxQL functions that compute
+// them.
+var aggregations = map[string]string{
+	readers.AggregationAvg:   "MEAN",
+	readers.AggregationMin:   "MIN",
+	readers.AggregationMax:   "MAX",
+	readers.AggregationSum:   "SUM",
+	readers.AggregationCount: "COUNT",
+	readers.AggregationFirst: "FIRST",
+	readers.AggregationLast:  "LAST",
+}
 
 var _ readers.MessageRepository = (*influxRepository)(nil)
 
@@ -40,14 +55,45 @@ func New(client influxdata.Client, database string) readers.MessageRepository {
 }
 
 func (repo *influxRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return repo.aggregate(chanID, rpm)
+	}
+
+	return repo.readAll(fmtCondition(chanID, rpm), rpm)
+}
+
+func (repo *influxRepository) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if len(chanIDs) == 0 {
+		return readers.MessagesPage{PageMetadata: rpm, Messages: []readers.Message{}}, nil
+	}
+
+	var channels []string
+	for _, id := range chanIDs {
+		channels = append(channels, fmt.Sprintf(`channel='%s'`, id))
+	}
+	condition := fmt.Sprintf(`(%s)`, strings.Join(channels, " OR "))
+
+	return repo.readAll(fmtFilters(condition, rpm), rpm)
+}
+
+// readAll reads the page of points that match the condition.
+func (repo *influxRepository) readAll(condition string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	format := defMeasurement
 	if rpm.Format != "" {
 		format = rpm.Format
 	}
 
-	condition := fmtCondition(chanID, rpm)
+	pageCondition := condition
+	if rpm.Cursor != "" {
+		c, err := readers.DecodeCursor(rpm.Cursor)
+		if err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errors.ErrInvalidQueryParams, err)
+		}
+		// Points don't have an ID, so the cursor holds the exact point time.
+		pageCondition = fmt.Sprintf(`%s AND time < %d`, condition, c.Created)
+	}
 
-	cmd := fmt.Sprintf(`SELECT * FROM %s WHERE %s ORDER BY time DESC LIMIT %d OFFSET %d`, format, condition, rpm.Limit, rpm.Offset)
+	cmd := fmt.Sprintf(`SELECT * FROM %s WHERE %s ORDER BY time DESC LIMIT %d OFFSET %d`, format, pageCondition, rpm.Limit, rpm.Offset)
 	q := influxdata.Query{
 		Command:  cmd,
 		Database: repo.database,
@@ -69,12 +115,14 @@ func (repo *influxRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 	}
 
 	result := resp.Results[0].Series[0]
+	var last readers.Cursor
 	for _, v := range result.Values {
 		msg, err := parseMessage(format, result.Columns, v)
 		if err != nil {
 			return readers.MessagesPage{}, err
 		}
 		ret = append(ret, msg)
+		last = readers.Cursor{Created: pointTime(result.Columns, v)}
 	}
 
 	total, err := repo.count(format, condition)
@@ -87,10 +135,153 @@ func (repo *influxRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 		Total:        total,
 		Messages:     ret,
 	}
+	if uint64(len(ret)) == rpm.Limit {
+		page.NextCursor = last.Encode()
+	}
+
+	return page, nil
+}
+
+// aggregate groups the SenML values into time buckets of the requested
+// interval. Empty buckets are omitted.
+func (repo *influxRepository) aggregate(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	fn, ok := aggregations[rpm.Aggregation]
+	if !ok {
+		return readers.MessagesPage{}, errors.ErrInvalidQueryParams
+	}
+	interval, err := time.ParseDuration(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errors.ErrInvalidQueryParams, err)
+	}
+
+	buckets := fmt.Sprintf(`SELECT %s(value) AS value FROM %s WHERE %s GROUP BY time(%du) fill(none)`, fn, defMeasurement, fmtCondition(chanID, rpm), interval.Microseconds())
+	cmd := fmt.Sprintf(`%s ORDER BY time DESC LIMIT %d OFFSET %d`, buckets, rpm.Limit, rpm.Offset)
+	resp, err := repo.client.Query(influxdata.Query{
+		Command:  cmd,
+		Database: repo.database,
+	})
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	if resp.Error() != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, resp.Error())
+	}
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	if len(resp.Results) < 1 || len(resp.Results[0].Series) < 1 {
+		return page, nil
+	}
+
+	for _, v := range resp.Results[0].Series[0].Values {
+		msg := parseSenml([]string{"time", "value"}, v).(senml.Message)
+		msg.Channel = chanID
+		msg.Name = rpm.Name
+		page.Messages = append(page.Messages, msg)
+	}
+
+	cmd = fmt.Sprintf(`SELECT COUNT(value) AS count_value FROM (%s)`, buckets)
+	resp, err = repo.client.Query(influxdata.Query{
+		Command:  cmd,
+		Database: repo.database,
+	})
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	if resp.Error() != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, resp.Error())
+	}
+	if len(resp.Results) < 1 ||
+		len(resp.Results[0].Series) < 1 ||
+		len(resp.Results[0].Series[0].Values) < 1 {
+		return page, nil
+	}
+
+	// The count is the second column, following the time.
+	result := resp.Results[0].Series[0].Values[0]
+	if len(result) < 2 {
+		return page, nil
+	}
+	if count, ok := result[1].(json.Number); ok {
+		total, err := strconv.ParseUint(count.String(), 10, 64)
+		if err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		page.Total = total
+	}
 
 	return page, nil
 }
 
+func (repo *influxRepository) Latest(chanID string, rpm readers.PageMetadata, perPublisher bool) ([]readers.Message, error) {
+	group := `"name"`
+	if perPublisher {
+		group = `"name", "publisher"`
+	}
+
+	// Each group is returned as a separate series holding its latest point.
+	cmd := fmt.Sprintf(`SELECT * FROM %s WHERE %s GROUP BY %s ORDER BY time DESC LIMIT 1`, defMeasurement, fmtCondition(chanID, rpm), group)
+	resp, err := repo.client.Query(influxdata.Query{
+		Command:  cmd,
+		Database: repo.database,
+	})
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	if resp.Error() != nil {
+		return nil, errors.Wrap(errReadMessages, resp.Error())
+	}
+
+	msgs := []readers.Message{}
+	if len(resp.Results) < 1 {
+		return msgs, nil
+	}
+
+	for _, s := range resp.Results[0].Series {
+		if len(s.Values) < 1 {
+			continue
+		}
+		// Grouping tags are returned with the series instead of the columns.
+		names, fields := s.Columns, s.Values[0]
+		for tag, val := range s.Tags {
+			names = append(names, tag)
+			fields = append(fields, val)
+		}
+		msgs = append(msgs, parseSenml(names, fields))
+	}
+
+	return msgs, nil
+}
+
+func (repo *influxRepository) Delete(chanID string, dm readers.DeleteMetadata) error {
+	// Delete without the measurement removes the points of the channel from
+	// the SenML and all the JSON measurements.
+	cmd := `DELETE WHERE "channel" = $channel`
+	params := map[string]interface{}{"channel": chanID}
+	if dm.Publisher != "" {
+		cmd = fmt.Sprintf(`%s AND "publisher" = $publisher`, cmd)
+		params["publisher"] = dm.Publisher
+	}
+	if dm.From != 0 {
+		cmd = fmt.Sprintf(`%s AND time >= %d`, cmd, int64(dm.From*1e9))
+	}
+	if dm.To != 0 {
+		cmd = fmt.Sprintf(`%s AND time < %d`, cmd, int64(dm.To*1e9))
+	}
+
+	resp, err := repo.client.Query(influxdata.NewQueryWithParameters(cmd, repo.database, "", params))
+	if err != nil {
+		return errors.Wrap(errDeleteMessages, err)
+	}
+	if resp.Error() != nil {
+		return errors.Wrap(errDeleteMessages, resp.Error())
+	}
+
+	return nil
+}
+
 func (repo *influxRepository) count(measurement, condition string) (uint64, error) {
 	cmd := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s`, measurement, condition)
 	q := influxdata.Query{
@@ -132,9 +323,29 @@ func (repo *influxRepository) count(measurement, condition string) (uint64, erro
 	return strconv.ParseUint(count.String(), 10, 64)
 }
 
+// pointTime returns the time of the point in nanoseconds.
+func pointTime(names []string, fields []interface{}) int64 {
+	for i, name := range names {
+		if name != "time" {
+			continue
+		}
+		if s, ok := fields[i].(string); ok {
+			if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+				return t.UnixNano()
+			}
+		}
+	}
+
+	return 0
+}
+
 func fmtCondition(chanID string, rpm readers.PageMetadata) string {
-	condition := fmt.Sprintf(`channel='%s'`, chanID)
+	return fmtFilters(fmt.Sprintf(`channel='%s'`, chanID), rpm)
+}
 
+// fmtFilters appends the conditions of the query filters to the channel
+// condition.
+func fmtFilters(condition string, rpm readers.PageMetadata) string {
 	var query map[string]interface{}
 	meta, err := json.Marshal(rpm)
 	if err != nil {
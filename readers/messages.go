@@ -18,14 +18,74 @@ const (
 	GreaterThanEqualKey = "ge"
 )
 
+// Aggregations applied to the SenML values within a time bucket.
+const (
+	// AggregationAvg represents the average of the values.
+	AggregationAvg = "avg"
+	// AggregationMin represents the lowest value.
+	AggregationMin = "min"
+	// AggregationMax represents the highest value.
+	AggregationMax = "max"
+	// AggregationSum represents the sum of the values.
+	AggregationSum = "sum"
+	// AggregationCount represents the number of values.
+	AggregationCount = "count"
+	// AggregationFirst represents the earliest value.
+	AggregationFirst = "first"
+	// AggregationLast represents the latest value.
+	AggregationLast = "last"
+)
+
 // ErrNotFound indicates that requested entity doesn't exist.
 var ErrNotFound = errors.New("entity not found")
 
 // MessageRepository specifies message reader API.
 type MessageRepository interface {
 	// ReadAll skips given number of messages for given channel and returns next
-	// limited number of messages.
+	// limited number of messages. If the cursor is set, messages following the
+	// cursor are returned instead, and the page holds the cursor of the next
+	// page if there are more messages. If the aggregation is set, SenML values
+	// with the given name are aggregated into time buckets of the given
+	// interval, and the buckets are paged instead of the messages.
 	ReadAll(chanID string, pm PageMetadata) (MessagesPage, error)
+
+	// ReadChannels returns the page of messages of the given channels, which
+	// are merged and sorted by time starting from the latest one. Paging and
+	// filters are applied the same way as by ReadAll, while aggregation is
+	// ignored.
+	ReadChannels(chanIDs []string, pm PageMetadata) (MessagesPage, error)
+
+	// Iterate returns the iterator over all messages of the given channel
+	// that match the page metadata, starting from the oldest one. Offset,
+	// limit and aggregation are ignored. Messages are read from the database
+	// as the iterator advances, and the iterator has to be closed once done.
+	Iterate(chanID string, pm PageMetadata) (MessageIterator, error)
+
+	// Latest returns the latest SenML message of each name within the given
+	// channel that matches the page metadata. If perPublisher is set, the
+	// latest message of each name is returned for every publisher. Offset,
+	// limit, format, cursor and aggregation are ignored.
+	Latest(chanID string, pm PageMetadata, perPublisher bool) ([]Message, error)
+
+	// Delete removes the messages of the given channel that match the delete
+	// metadata, regardless of their format.
+	Delete(chanID string, dm DeleteMetadata) error
+}
+
+// MessageIterator iterates over the messages read from the database.
+type MessageIterator interface {
+	// Next advances the iterator to the next message. It returns false once
+	// there are no more messages, or if reading the message failed.
+	Next() bool
+
+	// Message returns the message the iterator points to.
+	Message() Message
+
+	// Err returns the error that stopped the iteration, if any.
+	Err() error
+
+	// Close releases the resources held by the iterator.
+	Close() error
 }
 
 // Message represents any message format.
@@ -35,8 +95,9 @@ type Message interface{}
 // belong to this page.
 type MessagesPage struct {
 	PageMetadata
-	Total    uint64
-	Messages []Message
+	Total      uint64
+	NextCursor string
+	Messages   []Message
 }
 
 // PageMetadata represents the parameters used to create database queries
@@ -55,6 +116,18 @@ type PageMetadata struct {
 	From        float64 `json:"from,omitempty"`
 	To          float64 `json:"to,omitempty"`
 	Format      string  `json:"format,omitempty"`
+	Aggregation string  `json:"aggregation,omitempty"`
+	Interval    string  `json:"interval,omitempty"`
+	Cursor      string  `json:"cursor,omitempty"`
+}
+
+// DeleteMetadata represents the filters of the messages to be removed. Empty
+// publisher and zero time bounds match all the messages. Time bounds are in
+// seconds, and the messages created at the upper bound are kept.
+type DeleteMetadata struct {
+	Publisher string  `json:"publisher,omitempty"`
+	From      float64 `json:"from,omitempty"`
+	To        float64 `json:"to,omitempty"`
 }
 
 // ParseValueComparator convert comparison operator keys into mathematic anotation
@@ -0,0 +1,90 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mainflux/mainflux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errUnauthenticated = status.Error(codes.Unauthenticated, "missing or invalid credentials provided")
+
+var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
+
+type authServiceMock struct {
+	users  map[string]string
+	groups map[string][]string
+}
+
+// NewAuthService returns mock implementation of auth service, which maps the
+// tokens to the user emails and the group IDs to the IDs of their members.
+func NewAuthService(users map[string]string, groups map[string][]string) mainflux.AuthServiceClient {
+	return authServiceMock{users: users, groups: groups}
+}
+
+func (svc authServiceMock) Identify(_ context.Context, in *mainflux.Token, _ ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	email, ok := svc.users[in.GetValue()]
+	if !ok {
+		return nil, errUnauthenticated
+	}
+
+	return &mainflux.UserIdentity{Id: email, Email: email}, nil
+}
+
+func (svc authServiceMock) Members(_ context.Context, in *mainflux.MembersReq, _ ...grpc.CallOption) (*mainflux.MembersRes, error) {
+	if _, ok := svc.users[in.GetToken()]; !ok {
+		return nil, errUnauthenticated
+	}
+	members, ok := svc.groups[in.GetGroupID()]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	total := uint64(len(members))
+	res := &mainflux.MembersRes{
+		Total:  total,
+		Offset: in.GetOffset(),
+		Limit:  in.GetLimit(),
+		Type:   in.GetType(),
+	}
+	if in.GetOffset() >= total {
+		return res, nil
+	}
+	end := in.GetOffset() + in.GetLimit()
+	if end > total {
+		end = total
+	}
+	res.Members = members[in.GetOffset():end]
+
+	return res, nil
+}
+
+func (svc authServiceMock) Issue(context.Context, *mainflux.IssueReq, ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Authorize(context.Context, *mainflux.AuthorizeReq, ...grpc.CallOption) (*mainflux.AuthorizeRes, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Assign(context.Context, *mainflux.Assignment, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) AddPolicy(context.Context, *mainflux.PolicyReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) DeletePolicy(context.Context, *mainflux.PolicyReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) ListObjects(context.Context, *mainflux.ListObjectsReq, ...grpc.CallOption) (*mainflux.ListObjectsRes, error) {
+	panic("not implemented")
+}
@@ -0,0 +1,29 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"github.com/mainflux/mainflux/readers"
+)
+
+var _ readers.ThingChannels = (*thingChannelsMock)(nil)
+
+type thingChannelsMock struct {
+	channels map[string][]string
+}
+
+// NewThingChannels returns mock implementation of the thing channels lister,
+// which maps the thing IDs to the IDs of the connected channels.
+func NewThingChannels(channels map[string][]string) readers.ThingChannels {
+	return thingChannelsMock{channels: channels}
+}
+
+func (tc thingChannelsMock) ChannelsByThing(token, thingID string) ([]string, error) {
+	chs, ok := tc.channels[thingID]
+	if !ok {
+		return nil, readers.ErrNotFound
+	}
+
+	return chs, nil
+}
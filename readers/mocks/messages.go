@@ -5,7 +5,9 @@ package mocks
 
 import (
 	"encoding/json"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
@@ -30,6 +32,15 @@ func NewMessageRepository(chanID string, messages []readers.Message) readers.Mes
 	}
 }
 
+// NewChannelsMessageRepository returns mock implementation of message
+// repository, which maps the channel IDs to their messages.
+func NewChannelsMessageRepository(messages map[string][]readers.Message) readers.MessageRepository {
+	return &messageRepositoryMock{
+		mutex:    sync.Mutex{},
+		messages: messages,
+	}
+}
+
 func (repo *messageRepositoryMock) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	repo.mutex.Lock()
 	defer repo.mutex.Unlock()
@@ -38,6 +49,159 @@ func (repo *messageRepositoryMock) ReadAll(chanID string, rpm readers.PageMetada
 		return readers.MessagesPage{}, nil
 	}
 
+	return repo.readAll(repo.filter(chanID, rpm), rpm)
+}
+
+func (repo *messageRepositoryMock) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if rpm.Format != "" && rpm.Format != "messages" {
+		return readers.MessagesPage{}, nil
+	}
+
+	var msgs []readers.Message
+	for _, chanID := range chanIDs {
+		msgs = append(msgs, repo.filter(chanID, rpm)...)
+	}
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return msgs[i].(senml.Message).Time > msgs[j].(senml.Message).Time
+	})
+	rpm.Aggregation = ""
+
+	return repo.readAll(msgs, rpm)
+}
+
+func (repo *messageRepositoryMock) readAll(msgs []readers.Message, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		interval, err := time.ParseDuration(rpm.Interval)
+		if err != nil {
+			return readers.MessagesPage{}, err
+		}
+		var values []senml.Message
+		for _, m := range msgs {
+			values = append(values, m.(senml.Message))
+		}
+		msgs = readers.Aggregate(values, rpm.Aggregation, interval)
+	}
+
+	total := uint64(len(msgs))
+
+	// Mock messages are identified by their time, and are stored from the
+	// latest one.
+	if rpm.Cursor != "" {
+		c, err := readers.DecodeCursor(rpm.Cursor)
+		if err != nil {
+			return readers.MessagesPage{}, err
+		}
+		var next []readers.Message
+		for _, m := range msgs {
+			if m.(senml.Message).Time < c.Time {
+				next = append(next, m)
+			}
+		}
+		msgs = next
+	}
+
+	numOfMessages := uint64(len(msgs))
+
+	if rpm.Offset >= numOfMessages {
+		return readers.MessagesPage{}, nil
+	}
+
+	if rpm.Limit < 1 {
+		return readers.MessagesPage{}, nil
+	}
+
+	end := rpm.Offset + rpm.Limit
+	if rpm.Offset+rpm.Limit > numOfMessages {
+		end = numOfMessages
+	}
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Total:        total,
+		Messages:     msgs[rpm.Offset:end],
+	}
+	if end-rpm.Offset == rpm.Limit && rpm.Aggregation == "" {
+		last := msgs[end-1].(senml.Message)
+		page.NextCursor = readers.Cursor{Time: last.Time}.Encode()
+	}
+
+	return page, nil
+}
+
+func (repo *messageRepositoryMock) Iterate(chanID string, rpm readers.PageMetadata) (readers.MessageIterator, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if rpm.Format != "" && rpm.Format != "messages" {
+		return &messageIterator{}, nil
+	}
+
+	// Messages are iterated over from the oldest one.
+	msgs := repo.filter(chanID, rpm)
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return msgs[i].(senml.Message).Time < msgs[j].(senml.Message).Time
+	})
+
+	return &messageIterator{msgs: msgs, pos: -1}, nil
+}
+
+func (repo *messageRepositoryMock) Latest(chanID string, rpm readers.PageMetadata, perPublisher bool) ([]readers.Message, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	msgs := repo.filter(chanID, rpm)
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return msgs[i].(senml.Message).Time > msgs[j].(senml.Message).Time
+	})
+
+	seen := map[string]bool{}
+	latest := []readers.Message{}
+	for _, m := range msgs {
+		msg := m.(senml.Message)
+		key := msg.Name
+		if perPublisher {
+			key = msg.Name + "/" + msg.Publisher
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		latest = append(latest, msg)
+	}
+	sort.SliceStable(latest, func(i, j int) bool {
+		mi, mj := latest[i].(senml.Message), latest[j].(senml.Message)
+		if mi.Name != mj.Name {
+			return mi.Name < mj.Name
+		}
+		return mi.Publisher < mj.Publisher
+	})
+
+	return latest, nil
+}
+
+func (repo *messageRepositoryMock) Delete(chanID string, dm readers.DeleteMetadata) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	var kept []readers.Message
+	for _, m := range repo.messages[chanID] {
+		msg := m.(senml.Message)
+		switch {
+		case dm.Publisher != "" && msg.Publisher != dm.Publisher,
+			dm.From != 0 && msg.Time < dm.From,
+			dm.To != 0 && msg.Time >= dm.To:
+			kept = append(kept, m)
+		}
+	}
+	repo.messages[chanID] = kept
+
+	return nil
+}
+
+func (repo *messageRepositoryMock) filter(chanID string, rpm readers.PageMetadata) []readers.Message {
 	var query map[string]interface{}
 	meta, _ := json.Marshal(rpm)
 	json.Unmarshal(meta, &query)
@@ -140,24 +304,30 @@ func (repo *messageRepositoryMock) ReadAll(chanID string, rpm readers.PageMetada
 		}
 	}
 
-	numOfMessages := uint64(len(msgs))
+	return msgs
+}
 
-	if rpm.Offset >= numOfMessages {
-		return readers.MessagesPage{}, nil
-	}
+type messageIterator struct {
+	msgs []readers.Message
+	pos  int
+}
 
-	if rpm.Limit < 1 {
-		return readers.MessagesPage{}, nil
+func (it *messageIterator) Next() bool {
+	if it.pos+1 >= len(it.msgs) {
+		return false
 	}
+	it.pos++
+	return true
+}
 
-	end := rpm.Offset + rpm.Limit
-	if rpm.Offset+rpm.Limit > numOfMessages {
-		end = numOfMessages
-	}
+func (it *messageIterator) Message() readers.Message {
+	return it.msgs[it.pos]
+}
 
-	return readers.MessagesPage{
-		PageMetadata: rpm,
-		Total:        uint64(len(msgs)),
-		Messages:     msgs[rpm.Offset:end],
-	}, nil
+func (it *messageIterator) Err() error {
+	return nil
+}
+
+func (it *messageIterator) Close() error {
+	return nil
 }
@@ -18,11 +18,16 @@ var errUnauthorized = status.Error(codes.PermissionDenied, "missing or invalid c
 
 var _ mainflux.ThingsServiceClient = (*thingsServiceMock)(nil)
 
-type thingsServiceMock struct{}
+var errNotFound = status.Error(codes.NotFound, "entity does not exist")
 
-// NewThingsService returns mock implementation of things service
-func NewThingsService() mainflux.ThingsServiceClient {
-	return thingsServiceMock{}
+type thingsServiceMock struct {
+	owners map[string]string
+}
+
+// NewThingsService returns mock implementation of things service, which maps
+// the channel IDs to the emails of their owners.
+func NewThingsService(owners map[string]string) mainflux.ThingsServiceClient {
+	return thingsServiceMock{owners: owners}
 }
 
 func (svc thingsServiceMock) CanAccessByKey(ctx context.Context, in *mainflux.AccessByKeyReq, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
@@ -35,6 +40,12 @@ func (svc thingsServiceMock) CanAccessByKey(ctx context.Context, in *mainflux.Ac
 		return nil, errUnauthorized
 	}
 
+	// The restricted thing is denied access to the subtopics of the channel
+	// other than the ones it explicitly reads.
+	if token == "restricted" && in.GetSubtopic() == ">" {
+		return nil, errUnauthorized
+	}
+
 	return &mainflux.ThingID{Value: token}, nil
 }
 
@@ -42,8 +53,12 @@ func (svc thingsServiceMock) CanAccessByID(context.Context, *mainflux.AccessByID
 	panic("not implemented")
 }
 
-func (svc thingsServiceMock) IsChannelOwner(context.Context, *mainflux.ChannelOwnerReq, ...grpc.CallOption) (*empty.Empty, error) {
-	panic("not implemented")
+func (svc thingsServiceMock) IsChannelOwner(_ context.Context, in *mainflux.ChannelOwnerReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	if owner, ok := svc.owners[in.GetChanID()]; !ok || owner != in.GetOwner() {
+		return nil, errNotFound
+	}
+
+	return &empty.Empty{}, nil
 }
 
 func (svc thingsServiceMock) Identify(context.Context, *mainflux.Token, ...grpc.CallOption) (*mainflux.ThingID, error) {
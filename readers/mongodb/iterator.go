@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	jsont "github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ readers.MessageIterator = (*messageIterator)(nil)
+
+type messageIterator struct {
+	cursor *mongo.Cursor
+	format string
+	msg    readers.Message
+	err    error
+}
+
+func (repo mongoRepository) Iterate(chanID string, rpm readers.PageMetadata) (readers.MessageIterator, error) {
+	format := defCollection
+	order := "time"
+	if rpm.Format != "" && rpm.Format != defCollection {
+		order = "created"
+		format = rpm.Format
+	}
+
+	sortMap := map[string]interface{}{
+		order: 1,
+	}
+	filter := fmtCondition(chanID, rpm)
+	cursor, err := repo.db.Collection(format).Find(context.Background(), filter, options.Find().SetSort(sortMap))
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+
+	return &messageIterator{
+		cursor: cursor,
+		format: format,
+	}, nil
+}
+
+func (it *messageIterator) Next() bool {
+	if it.err != nil || !it.cursor.Next(context.Background()) {
+		return false
+	}
+
+	switch it.format {
+	case defCollection:
+		var m senml.Message
+		if err := it.cursor.Decode(&m); err != nil {
+			it.err = errors.Wrap(errReadMessages, err)
+			return false
+		}
+		it.msg = m
+	default:
+		var m map[string]interface{}
+		if err := it.cursor.Decode(&m); err != nil {
+			it.err = errors.Wrap(errReadMessages, err)
+			return false
+		}
+		m["payload"] = jsont.ParseFlat(m["payload"])
+		it.msg = m
+	}
+
+	return true
+}
+
+func (it *messageIterator) Message() readers.Message {
+	return it.msg
+}
+
+func (it *messageIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	if err := it.cursor.Err(); err != nil {
+		return errors.Wrap(errReadMessages, err)
+	}
+	return nil
+}
+
+func (it *messageIterator) Close() error {
+	return it.cursor.Close(context.Background())
+}
@@ -6,12 +6,14 @@ package mongodb
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/errors"
 	jsont "github.com/mainflux/mainflux/pkg/transformers/json"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -20,9 +22,26 @@ const (
 	format = "format"
 	// Collection for SenML messages
 	defCollection = "messages"
+	// Collection of the retention policies managed by the writer
+	retentionCollection = "retention_policies"
 )
 
-var errReadMessages = errors.New("failed to read messages from mongodb database")
+var (
+	errReadMessages   = errors.New("failed to read messages from mongodb database")
+	errDeleteMessages = errors.New("failed to delete messages from mongodb database")
+)
+
+// aggregations maps the aggregations to the accumulators that compute them
+// over the values in the bucket.
+var aggregations = map[string]bson.M{
+	readers.AggregationAvg:   {"$avg": "$value"},
+	readers.AggregationMin:   {"$min": "$value"},
+	readers.AggregationMax:   {"$max": "$value"},
+	readers.AggregationSum:   {"$sum": "$value"},
+	readers.AggregationCount: {"$sum": 1},
+	readers.AggregationFirst: {"$first": "$value"},
+	readers.AggregationLast:  {"$last": "$value"},
+}
 
 var _ readers.MessageRepository = (*mongoRepository)(nil)
 
@@ -38,6 +57,20 @@ func New(db *mongo.Database) readers.MessageRepository {
 }
 
 func (repo mongoRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return repo.aggregate(chanID, rpm)
+	}
+
+	return repo.readAll(fmtCondition(chanID, rpm), rpm)
+}
+
+func (repo mongoRepository) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	channels := bson.D{{Key: "channel", Value: bson.M{"$in": chanIDs}}}
+	return repo.readAll(fmtFilters(channels, rpm), rpm)
+}
+
+// readAll reads the page of messages that match the filter.
+func (repo mongoRepository) readAll(filter bson.D, rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	format := defCollection
 	order := "time"
 	if rpm.Format != "" && rpm.Format != defCollection {
@@ -47,27 +80,35 @@ func (repo mongoRepository) ReadAll(chanID string, rpm readers.PageMetadata) (re
 
 	col := repo.db.Collection(format)
 
-	sortMap := map[string]interface{}{
-		order: -1,
+	// Messages with the same time are ordered by ID, so that the cursor
+	// identifies the position within the page unambiguously.
+	sort := bson.D{{Key: order, Value: -1}, {Key: "_id", Value: -1}}
+	pageFilter := filter
+	if rpm.Cursor != "" {
+		cf, err := cursorFilter(order, rpm.Cursor)
+		if err != nil {
+			return readers.MessagesPage{}, err
+		}
+		pageFilter = append(bson.D{cf}, filter...)
 	}
-	// Remove format filter and format the rest properly.
-	filter := fmtCondition(chanID, rpm)
-	cursor, err := col.Find(context.Background(), filter, options.Find().SetSort(sortMap).SetLimit(int64(rpm.Limit)).SetSkip(int64(rpm.Offset)))
+	cursor, err := col.Find(context.Background(), pageFilter, options.Find().SetSort(sort).SetLimit(int64(rpm.Limit)).SetSkip(int64(rpm.Offset)))
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 	}
 	defer cursor.Close(context.Background())
 
 	var messages []readers.Message
+	var last readers.Cursor
 	switch format {
 	case defCollection:
 		for cursor.Next(context.Background()) {
-			var m senml.Message
+			var m senmlMessage
 			if err := cursor.Decode(&m); err != nil {
 				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 			}
 
-			messages = append(messages, m)
+			messages = append(messages, m.Message)
+			last = readers.Cursor{Time: m.Time, ID: m.ID.Hex()}
 		}
 	default:
 		for cursor.Next(context.Background()) {
@@ -78,6 +119,13 @@ func (repo mongoRepository) ReadAll(chanID string, rpm readers.PageMetadata) (re
 			m["payload"] = jsont.ParseFlat(m["payload"])
 
 			messages = append(messages, m)
+			last = readers.Cursor{}
+			if id, ok := m["_id"].(primitive.ObjectID); ok {
+				last.ID = id.Hex()
+			}
+			if created, ok := m["created"].(int64); ok {
+				last.Created = created
+			}
 		}
 	}
 
@@ -91,10 +139,195 @@ func (repo mongoRepository) ReadAll(chanID string, rpm readers.PageMetadata) (re
 		Total:        uint64(total),
 		Messages:     messages,
 	}
+	if uint64(len(messages)) == rpm.Limit {
+		mp.NextCursor = last.Encode()
+	}
 
 	return mp, nil
 }
 
+// aggregate groups the SenML values into buckets of the requested interval,
+// where the bucket is identified by its start time in seconds.
+func (repo mongoRepository) aggregate(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	acc, ok := aggregations[rpm.Aggregation]
+	if !ok {
+		return readers.MessagesPage{}, errors.ErrInvalidQueryParams
+	}
+	interval, err := time.ParseDuration(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errors.ErrInvalidQueryParams, err)
+	}
+	width := interval.Seconds()
+
+	filter := fmtCondition(chanID, rpm)
+	if rpm.Value == 0 {
+		filter = append(filter, bson.E{Key: "value", Value: bson.M{"$type": "number"}})
+	}
+	bucket := bson.M{"$multiply": bson.A{bson.M{"$floor": bson.M{"$divide": bson.A{"$time", width}}}, width}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.M{"time": 1}}},
+		{{Key: "$group", Value: bson.M{"_id": bucket, "value": acc}}},
+		{{Key: "$sort", Value: bson.M{"_id": -1}}},
+		{{Key: "$facet", Value: bson.M{
+			"total":   bson.A{bson.M{"$count": "count"}},
+			"buckets": bson.A{bson.M{"$skip": int64(rpm.Offset)}, bson.M{"$limit": int64(rpm.Limit)}},
+		}}},
+	}
+
+	cursor, err := repo.db.Collection(defCollection).Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer cursor.Close(context.Background())
+
+	var res []struct {
+		Total []struct {
+			Count uint64 `bson:"count"`
+		} `bson:"total"`
+		Buckets []struct {
+			Time  float64 `bson:"_id"`
+			Value float64 `bson:"value"`
+		} `bson:"buckets"`
+	}
+	if err := cursor.All(context.Background(), &res); err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	if len(res) < 1 {
+		return page, nil
+	}
+	if len(res[0].Total) > 0 {
+		page.Total = res[0].Total[0].Count
+	}
+	for _, b := range res[0].Buckets {
+		v := b.Value
+		page.Messages = append(page.Messages, senml.Message{
+			Channel: chanID,
+			Name:    rpm.Name,
+			Time:    b.Time,
+			Value:   &v,
+		})
+	}
+
+	return page, nil
+}
+
+func (repo mongoRepository) Latest(chanID string, rpm readers.PageMetadata, perPublisher bool) ([]readers.Message, error) {
+	group := bson.M{"name": "$name"}
+	order := bson.D{{Key: "name", Value: 1}}
+	if perPublisher {
+		group["publisher"] = "$publisher"
+		order = append(order, bson.E{Key: "publisher", Value: 1})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: fmtCondition(chanID, rpm)}},
+		{{Key: "$sort", Value: bson.D{{Key: "time", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": group, "msg": bson.M{"$first": "$$ROOT"}}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$msg"}}},
+		{{Key: "$sort", Value: order}},
+	}
+
+	cursor, err := repo.db.Collection(defCollection).Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	defer cursor.Close(context.Background())
+
+	msgs := []readers.Message{}
+	for cursor.Next(context.Background()) {
+		var m senmlMessage
+		if err := cursor.Decode(&m); err != nil {
+			return nil, errors.Wrap(errReadMessages, err)
+		}
+		msgs = append(msgs, m.Message)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+
+	return msgs, nil
+}
+
+func (repo mongoRepository) Delete(chanID string, dm readers.DeleteMetadata) error {
+	names, err := repo.db.ListCollectionNames(context.Background(), bson.D{})
+	if err != nil {
+		return errors.Wrap(errDeleteMessages, err)
+	}
+
+	for _, name := range names {
+		if name == retentionCollection {
+			continue
+		}
+		if _, err := repo.db.Collection(name).DeleteMany(context.Background(), deleteFilter(chanID, name, dm)); err != nil {
+			return errors.Wrap(errDeleteMessages, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteFilter returns the filter of the collection messages which match
+// the delete metadata. SenML time is in seconds, and JSON creation time in
+// nanoseconds.
+func deleteFilter(chanID, collection string, dm readers.DeleteMetadata) bson.D {
+	filter := bson.D{{Key: "channel", Value: chanID}}
+	if dm.Publisher != "" {
+		filter = append(filter, bson.E{Key: "publisher", Value: dm.Publisher})
+	}
+
+	key, from, to := "time", interface{}(dm.From), interface{}(dm.To)
+	if collection != defCollection {
+		key, from, to = "created", int64(dm.From*1e9), int64(dm.To*1e9)
+	}
+	bounds := bson.M{}
+	if dm.From != 0 {
+		bounds["$gte"] = from
+	}
+	if dm.To != 0 {
+		bounds["$lt"] = to
+	}
+	if len(bounds) > 0 {
+		filter = append(filter, bson.E{Key: key, Value: bounds})
+	}
+
+	return filter
+}
+
+// cursorFilter returns the filter that selects the messages following the
+// cursor.
+func cursorFilter(order, token string) (bson.E, error) {
+	c, err := readers.DecodeCursor(token)
+	if err != nil {
+		return bson.E{}, errors.Wrap(errors.ErrInvalidQueryParams, err)
+	}
+	id, err := primitive.ObjectIDFromHex(c.ID)
+	if err != nil {
+		return bson.E{}, errors.Wrap(errors.ErrInvalidQueryParams, readers.ErrInvalidCursor)
+	}
+
+	var t interface{} = c.Time
+	if order == "created" {
+		t = c.Created
+	}
+
+	return bson.E{Key: "$or", Value: bson.A{
+		bson.M{order: bson.M{"$lt": t}},
+		bson.M{order: t, "_id": bson.M{"$lt": id}},
+	}}, nil
+}
+
+type senmlMessage struct {
+	ID            primitive.ObjectID `bson:"_id"`
+	senml.Message `bson:",inline"`
+}
+
 func fmtCondition(chanID string, rpm readers.PageMetadata) bson.D {
 	filter := bson.D{
 		bson.E{
@@ -103,6 +336,11 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) bson.D {
 		},
 	}
 
+	return fmtFilters(filter, rpm)
+}
+
+// fmtFilters appends the query filters to the channel filter.
+func fmtFilters(filter bson.D, rpm readers.PageMetadata) bson.D {
 	var query map[string]interface{}
 	meta, err := json.Marshal(rpm)
 	if err != nil {
@@ -70,6 +70,19 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE messages",
 				},
 			},
+			{
+				Id: "messages_2",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS retention_policies (
+						channel       UUID,
+						days          BIGINT NOT NULL,
+						PRIMARY KEY (channel)
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE retention_policies",
+				},
+			},
 		},
 	}
 
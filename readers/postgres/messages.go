@@ -6,7 +6,9 @@ package postgres
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx" // required for DB access
 	"github.com/lib/pq"
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -24,9 +26,14 @@ const (
 
 	// Error code for Undefined table error.
 	undefinedTableCode = "42P01"
+
+	chanCondition = `channel = :channel`
 )
 
-var errReadMessages = errors.New("failed to read messages from postgres database")
+var (
+	errReadMessages   = errors.New("failed to read messages from postgres database")
+	errDeleteMessages = errors.New("failed to delete messages from postgres database")
+)
 
 var _ readers.MessageRepository = (*postgresRepository)(nil)
 
@@ -42,6 +49,23 @@ func New(db *sqlx.DB) readers.MessageRepository {
 }
 
 func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return tr.aggregate(chanID, rpm)
+	}
+
+	return tr.readAll(chanCondition, queryParams(chanID, rpm), rpm)
+}
+
+func (tr postgresRepository) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	params := queryParams("", rpm)
+	params["channels"] = pq.Array(chanIDs)
+
+	return tr.readAll(`channel = ANY(:channels)`, params, rpm)
+}
+
+// readAll reads the page of messages of the channels selected by the
+// channel condition.
+func (tr postgresRepository) readAll(chanCond string, params map[string]interface{}, rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	order := "time"
 	format := defTable
 
@@ -50,26 +74,15 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 		format = rpm.Format
 	}
 
-	q := fmt.Sprintf(`SELECT * FROM %s
-    WHERE %s ORDER BY %s DESC
-	LIMIT :limit OFFSET :offset;`, format, fmtCondition(chanID, rpm), order)
-
-	params := map[string]interface{}{
-		"channel":      chanID,
-		"limit":        rpm.Limit,
-		"offset":       rpm.Offset,
-		"subtopic":     rpm.Subtopic,
-		"publisher":    rpm.Publisher,
-		"name":         rpm.Name,
-		"protocol":     rpm.Protocol,
-		"value":        rpm.Value,
-		"bool_value":   rpm.BoolValue,
-		"string_value": rpm.StringValue,
-		"data_value":   rpm.DataValue,
-		"from":         rpm.From,
-		"to":           rpm.To,
+	condition := fmtFilters(chanCond, rpm)
+	cq, err := cursorCondition(order, rpm.Cursor, params)
+	if err != nil {
+		return readers.MessagesPage{}, err
 	}
 
+	q := fmt.Sprintf(`SELECT * FROM %s
+    WHERE %s%s ORDER BY %s DESC, id DESC
+	LIMIT :limit OFFSET :offset;`, format, condition, cq, order)
 	rows, err := tr.db.NamedQuery(q, params)
 	if err != nil {
 		if e, ok := err.(*pq.Error); ok {
@@ -85,6 +98,7 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 		PageMetadata: rpm,
 		Messages:     []readers.Message{},
 	}
+	var last readers.Cursor
 	switch format {
 	case defTable:
 		for rows.Next() {
@@ -94,6 +108,7 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 			}
 
 			page.Messages = append(page.Messages, msg.Message)
+			last = readers.Cursor{Time: msg.Time, ID: msg.ID}
 		}
 	default:
 		for rows.Next() {
@@ -107,11 +122,15 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 			}
 			m["payload"] = jsont.ParseFlat(m["payload"])
 			page.Messages = append(page.Messages, m)
+			last = readers.Cursor{Created: msg.Created, ID: msg.ID}
 		}
 
 	}
+	if uint64(len(page.Messages)) == rpm.Limit {
+		page.NextCursor = last.Encode()
+	}
 
-	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, fmtCondition(chanID, rpm))
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, condition)
 	rows, err = tr.db.NamedQuery(q, params)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
@@ -129,9 +148,225 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 	return page, nil
 }
 
+// aggregate groups the SenML values into buckets of the requested interval,
+// where the bucket is identified by its start time in seconds.
+func (tr postgresRepository) aggregate(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	agg, ok := aggregations[rpm.Aggregation]
+	if !ok {
+		return readers.MessagesPage{}, errors.ErrInvalidQueryParams
+	}
+	interval, err := time.ParseDuration(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errors.ErrInvalidQueryParams, err)
+	}
+
+	params := queryParams(chanID, rpm)
+	params["interval"] = interval.Seconds()
+
+	condition := fmt.Sprintf("%s AND value IS NOT NULL", fmtCondition(chanID, rpm))
+	bucket := "FLOOR(time / :interval) * :interval"
+
+	q := fmt.Sprintf(`SELECT %s AS time, %s AS value FROM %s
+    WHERE %s GROUP BY 1 ORDER BY 1 DESC
+	LIMIT :limit OFFSET :offset;`, bucket, agg, defTable, condition)
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	for rows.Next() {
+		var b aggregateBucket
+		if err := rows.StructScan(&b); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		page.Messages = append(page.Messages, senml.Message{
+			Channel: chanID,
+			Name:    rpm.Name,
+			Time:    b.Time,
+			Value:   &b.Value,
+		})
+	}
+
+	q = fmt.Sprintf(`SELECT COUNT(DISTINCT %s) FROM %s WHERE %s;`, bucket, defTable, condition)
+	rows, err = tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&page.Total); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+	}
+
+	return page, nil
+}
+
+func (tr postgresRepository) Latest(chanID string, rpm readers.PageMetadata, perPublisher bool) ([]readers.Message, error) {
+	group := "name"
+	if perPublisher {
+		group = "name, publisher"
+	}
+
+	q := fmt.Sprintf(`SELECT DISTINCT ON (%s) * FROM %s
+    WHERE %s ORDER BY %s, time DESC, id DESC;`, group, defTable, fmtCondition(chanID, rpm), group)
+	rows, err := tr.db.NamedQuery(q, queryParams(chanID, rpm))
+	if err != nil {
+		if e, ok := err.(*pq.Error); ok {
+			if e.Code == undefinedTableCode {
+				return []readers.Message{}, nil
+			}
+		}
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	msgs := []readers.Message{}
+	for rows.Next() {
+		msg := senmlMessage{Message: senml.Message{}}
+		if err := rows.StructScan(&msg); err != nil {
+			return nil, errors.Wrap(errReadMessages, err)
+		}
+		msgs = append(msgs, msg.Message)
+	}
+
+	return msgs, nil
+}
+
+func (tr postgresRepository) Delete(chanID string, dm readers.DeleteMetadata) (err error) {
+	tables, err := tr.jsonTables()
+	if err != nil {
+		return errors.Wrap(errDeleteMessages, err)
+	}
+
+	tx, err := tr.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errDeleteMessages, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errDeleteMessages, err)
+		}
+	}()
+
+	// SenML time is in seconds, and JSON creation time in nanoseconds.
+	params := map[string]interface{}{
+		"channel":      chanID,
+		"publisher":    dm.Publisher,
+		"from":         dm.From,
+		"to":           dm.To,
+		"from_created": int64(dm.From * 1e9),
+		"to_created":   int64(dm.To * 1e9),
+	}
+	q := fmt.Sprintf(`DELETE FROM %s WHERE %s`, defTable, deleteCondition("time", "", dm))
+	if _, err := tx.NamedExec(q, params); err != nil {
+		return deleteError(err)
+	}
+	for _, table := range tables {
+		q := fmt.Sprintf(`DELETE FROM %s WHERE %s`, pq.QuoteIdentifier(table), deleteCondition("created", "_created", dm))
+		if _, err := tx.NamedExec(q, params); err != nil {
+			return deleteError(err)
+		}
+	}
+
+	return nil
+}
+
+// jsonTables returns the names of the tables of the JSON messages, which
+// are created per message format.
+func (tr postgresRepository) jsonTables() ([]string, error) {
+	q := `SELECT table_name FROM information_schema.columns
+          WHERE table_schema = current_schema() AND column_name = 'payload'`
+
+	tables := []string{}
+	if err := tr.db.Select(&tables, q); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// deleteCondition returns the condition that selects the messages of the
+// channel which match the delete metadata. Time bounds are compared to the
+// given column, using the parameters with the given suffix.
+func deleteCondition(column, suffix string, dm readers.DeleteMetadata) string {
+	condition := chanCondition
+	if dm.Publisher != "" {
+		condition = fmt.Sprintf(`%s AND publisher = :publisher`, condition)
+	}
+	if dm.From != 0 {
+		condition = fmt.Sprintf(`%s AND %s >= :from%s`, condition, column, suffix)
+	}
+	if dm.To != 0 {
+		condition = fmt.Sprintf(`%s AND %s < :to%s`, condition, column, suffix)
+	}
+	return condition
+}
+
+func deleteError(err error) error {
+	if e, ok := err.(*pq.Error); ok && e.Code.Name() == errInvalid {
+		return errors.Wrap(errors.ErrInvalidQueryParams, err)
+	}
+	return errors.Wrap(errDeleteMessages, err)
+}
+
+func queryParams(chanID string, rpm readers.PageMetadata) map[string]interface{} {
+	return map[string]interface{}{
+		"channel":      chanID,
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+	}
+}
+
+// cursorCondition returns the condition that selects the messages following
+// the cursor, and adds the cursor to the query parameters.
+func cursorCondition(order, token string, params map[string]interface{}) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	c, err := readers.DecodeCursor(token)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrInvalidQueryParams, err)
+	}
+	if _, err := uuid.FromString(c.ID); err != nil {
+		return "", errors.Wrap(errors.ErrInvalidQueryParams, readers.ErrInvalidCursor)
+	}
+
+	params["cursor_id"] = c.ID
+	if order == "created" {
+		params["cursor_time"] = c.Created
+		return ` AND (created, id) < (:cursor_time, :cursor_id)`, nil
+	}
+	params["cursor_time"] = c.Time
+	return ` AND (time, id) < (:cursor_time, :cursor_id)`, nil
+}
+
 func fmtCondition(chanID string, rpm readers.PageMetadata) string {
-	condition := `channel = :channel`
+	return fmtFilters(chanCondition, rpm)
+}
 
+// fmtFilters appends the conditions of the query filters to the channel
+// condition.
+func fmtFilters(condition string, rpm readers.PageMetadata) string {
 	var query map[string]interface{}
 	meta, err := json.Marshal(rpm)
 	if err != nil {
@@ -165,6 +400,23 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 	return condition
 }
 
+// aggregations maps the aggregations to the SQL expressions that compute
+// them over the values in the bucket.
+var aggregations = map[string]string{
+	readers.AggregationAvg:   "AVG(value)",
+	readers.AggregationMin:   "MIN(value)",
+	readers.AggregationMax:   "MAX(value)",
+	readers.AggregationSum:   "SUM(value)",
+	readers.AggregationCount: "COUNT(value)",
+	readers.AggregationFirst: "(ARRAY_AGG(value ORDER BY time))[1]",
+	readers.AggregationLast:  "(ARRAY_AGG(value ORDER BY time DESC))[1]",
+}
+
+type aggregateBucket struct {
+	Time  float64 `db:"time"`
+	Value float64 `db:"value"`
+}
+
 type senmlMessage struct {
 	ID string `db:"id"`
 	senml.Message
@@ -0,0 +1,43 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import mfsdk "github.com/mainflux/mainflux/pkg/sdk/go"
+
+const thingChannelsLimit = 100
+
+// ThingChannels lists the channels that the things are connected to.
+type ThingChannels interface {
+	// ChannelsByThing returns the IDs of the channels connected to the thing
+	// with the given ID, as seen by the user identified by the token.
+	ChannelsByThing(token, thingID string) ([]string, error)
+}
+
+var _ ThingChannels = (*sdkThingChannels)(nil)
+
+type sdkThingChannels struct {
+	sdk mfsdk.SDK
+}
+
+// NewThingChannels returns the thing channels lister that retrieves the
+// channels from the things service using the SDK.
+func NewThingChannels(sdk mfsdk.SDK) ThingChannels {
+	return sdkThingChannels{sdk: sdk}
+}
+
+func (tc sdkThingChannels) ChannelsByThing(token, thingID string) ([]string, error) {
+	ids := []string{}
+	for {
+		page, err := tc.sdk.ChannelsByThing(token, thingID, uint64(len(ids)), thingChannelsLimit, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, ch := range page.Channels {
+			ids = append(ids, ch.ID)
+		}
+		if len(page.Channels) == 0 || uint64(len(ids)) >= page.Total {
+			return ids, nil
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	jsont "github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/readers"
+)
+
+var _ readers.MessageIterator = (*messageIterator)(nil)
+
+type messageIterator struct {
+	rows   *sqlx.Rows
+	format string
+	msg    readers.Message
+	err    error
+}
+
+func (tr timescaleRepository) Iterate(chanID string, rpm readers.PageMetadata) (readers.MessageIterator, error) {
+	order := "time"
+	format := defTable
+	columns := senmlColumns
+
+	if rpm.Format != "" && rpm.Format != defTable {
+		order = "created"
+		format = rpm.Format
+		columns = "*"
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM %s WHERE %s ORDER BY %s;`, columns, format, fmtCondition(chanID, format, rpm), order)
+	rows, err := tr.db.NamedQuery(q, queryParams(chanID, rpm))
+	if err != nil {
+		if e, ok := err.(*pq.Error); ok {
+			if e.Code == undefinedTableCode {
+				return &messageIterator{format: format}, nil
+			}
+		}
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+
+	return &messageIterator{
+		rows:   rows,
+		format: format,
+	}, nil
+}
+
+func (it *messageIterator) Next() bool {
+	if it.rows == nil || it.err != nil || !it.rows.Next() {
+		return false
+	}
+
+	switch it.format {
+	case defTable:
+		msg := senmlMessage{}
+		if err := it.rows.StructScan(&msg); err != nil {
+			it.err = errors.Wrap(errReadMessages, err)
+			return false
+		}
+		it.msg = msg.Message
+	default:
+		msg := jsonMessage{}
+		if err := it.rows.StructScan(&msg); err != nil {
+			it.err = errors.Wrap(errReadMessages, err)
+			return false
+		}
+		m, err := msg.toMap()
+		if err != nil {
+			it.err = errors.Wrap(errReadMessages, err)
+			return false
+		}
+		m["payload"] = jsont.ParseFlat(m["payload"])
+		it.msg = m
+	}
+
+	return true
+}
+
+func (it *messageIterator) Message() readers.Message {
+	return it.msg
+}
+
+func (it *messageIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	if it.rows == nil {
+		return nil
+	}
+	if err := it.rows.Err(); err != nil {
+		return errors.Wrap(errReadMessages, err)
+	}
+	return nil
+}
+
+func (it *messageIterator) Close() error {
+	if it.rows == nil {
+		return nil
+	}
+	return it.rows.Close()
+}
@@ -0,0 +1,572 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx" // required for DB access
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	jsont "github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+)
+
+const errInvalid = "invalid_text_representation"
+
+const (
+	format = "format"
+	// Table for SenML messages
+	defTable = "messages"
+
+	// Error code for Undefined table error.
+	undefinedTableCode = "42P01"
+
+	chanCondition = `channel = :channel`
+
+	// bucketOrigin is the Unix time of the origin the time buckets are
+	// aligned to, 2000-01-03 00:00:00 UTC.
+	bucketOrigin = 946857600
+
+	// SenML columns with the time converted back to seconds.
+	senmlColumns = `id, channel, subtopic, publisher, protocol, name, unit,
+    value, string_value, bool_value, data_value, sum,
+    EXTRACT(EPOCH FROM time)::FLOAT AS time, update_time`
+)
+
+// viewName matches the names of the continuous aggregates of the SenML
+// messages, which end with their bucket width.
+var viewName = regexp.MustCompile(`^messages_([0-9]+)([smhd])$`)
+
+var (
+	errReadMessages   = errors.New("failed to read messages from timescale database")
+	errDeleteMessages = errors.New("failed to delete messages from timescale database")
+)
+
+var _ readers.MessageRepository = (*timescaleRepository)(nil)
+
+type timescaleRepository struct {
+	db *sqlx.DB
+}
+
+// New returns new TimescaleDB reader.
+func New(db *sqlx.DB) readers.MessageRepository {
+	return &timescaleRepository{
+		db: db,
+	}
+}
+
+func (tr timescaleRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return tr.aggregate(chanID, rpm)
+	}
+
+	return tr.readAll(chanCondition, queryParams(chanID, rpm), rpm)
+}
+
+func (tr timescaleRepository) ReadChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	params := queryParams("", rpm)
+	params["channels"] = pq.Array(chanIDs)
+
+	return tr.readAll(`channel = ANY(:channels)`, params, rpm)
+}
+
+// readAll reads the page of messages of the channels selected by the
+// channel condition.
+func (tr timescaleRepository) readAll(chanCond string, params map[string]interface{}, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	order := "time"
+	format := defTable
+	columns := senmlColumns
+
+	if rpm.Format != "" && rpm.Format != defTable {
+		order = "created"
+		format = rpm.Format
+		columns = "*"
+	}
+
+	condition := fmtFilters(chanCond, format, rpm)
+	cq, err := cursorCondition(order, rpm.Cursor, params)
+	if err != nil {
+		return readers.MessagesPage{}, err
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM %s
+    WHERE %s%s ORDER BY %s DESC, id DESC
+	LIMIT :limit OFFSET :offset;`, columns, format, condition, cq, order)
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		if e, ok := err.(*pq.Error); ok {
+			if e.Code == undefinedTableCode {
+				return readers.MessagesPage{}, nil
+			}
+		}
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	var last readers.Cursor
+	switch format {
+	case defTable:
+		for rows.Next() {
+			msg := senmlMessage{Message: senml.Message{}}
+			if err := rows.StructScan(&msg); err != nil {
+				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+			}
+
+			page.Messages = append(page.Messages, msg.Message)
+			last = readers.Cursor{Time: msg.Time, ID: msg.ID}
+		}
+	default:
+		for rows.Next() {
+			msg := jsonMessage{}
+			if err := rows.StructScan(&msg); err != nil {
+				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+			}
+			m, err := msg.toMap()
+			if err != nil {
+				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+			}
+			m["payload"] = jsont.ParseFlat(m["payload"])
+			page.Messages = append(page.Messages, m)
+			last = readers.Cursor{Created: msg.Created, ID: msg.ID}
+		}
+
+	}
+	if uint64(len(page.Messages)) == rpm.Limit {
+		page.NextCursor = last.Encode()
+	}
+
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, condition)
+	rows, err = tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	total := uint64(0)
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return page, err
+		}
+	}
+	page.Total = total
+
+	return page, nil
+}
+
+// aggregate groups the SenML values into time buckets of the requested
+// interval. The buckets are computed from the widest continuous aggregate
+// which can answer the query, and from the messages table if there's none.
+func (tr timescaleRepository) aggregate(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	agg, ok := aggregations[rpm.Aggregation]
+	if !ok {
+		return readers.MessagesPage{}, errors.ErrInvalidQueryParams
+	}
+	interval, err := time.ParseDuration(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errors.ErrInvalidQueryParams, err)
+	}
+
+	params := queryParams(chanID, rpm)
+	params["interval"] = interval.Seconds()
+
+	table := defTable
+	column := "time"
+	condition := fmt.Sprintf("%s AND value IS NOT NULL", fmtCondition(chanID, defTable, rpm))
+
+	view, err := tr.view(interval, rpm)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	if view != "" {
+		table = view
+		column = "bucket"
+		agg = viewAggregations[rpm.Aggregation]
+		condition = fmt.Sprintf("%s AND count > 0", viewCondition(rpm))
+	}
+	bucket := fmt.Sprintf("EXTRACT(EPOCH FROM time_bucket(make_interval(secs => :interval), %s))::FLOAT", column)
+
+	q := fmt.Sprintf(`SELECT %s AS time, %s AS value FROM %s
+    WHERE %s GROUP BY 1 ORDER BY 1 DESC
+	LIMIT :limit OFFSET :offset;`, bucket, agg, table, condition)
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	for rows.Next() {
+		var b aggregateBucket
+		if err := rows.StructScan(&b); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		page.Messages = append(page.Messages, senml.Message{
+			Channel: chanID,
+			Name:    rpm.Name,
+			Time:    b.Time,
+			Value:   &b.Value,
+		})
+	}
+
+	q = fmt.Sprintf(`SELECT COUNT(DISTINCT %s) FROM %s WHERE %s;`, bucket, table, condition)
+	rows, err = tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&page.Total); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+	}
+
+	return page, nil
+}
+
+// view returns the name of the widest continuous aggregate of the SenML
+// messages the aggregation can be computed from, or an empty string if
+// there's none. The aggregate can be used if its bucket width divides the
+// interval and the time range, and if the query filters only by the columns
+// the aggregate is grouped by.
+func (tr timescaleRepository) view(interval time.Duration, rpm readers.PageMetadata) (string, error) {
+	if _, ok := viewAggregations[rpm.Aggregation]; !ok {
+		return "", nil
+	}
+	if rpm.Protocol != "" || rpm.Value != 0 || rpm.BoolValue || rpm.StringValue != "" || rpm.DataValue != "" {
+		return "", nil
+	}
+
+	q := `SELECT view_name FROM timescaledb_information.continuous_aggregates
+          WHERE hypertable_name = $1 AND view_schema = current_schema()`
+	views := []string{}
+	if err := tr.db.Select(&views, q, defTable); err != nil {
+		return "", err
+	}
+
+	ret := ""
+	var max time.Duration
+	for _, view := range views {
+		width, ok := viewWidth(view)
+		if !ok || width <= max || interval%width != 0 {
+			continue
+		}
+		if !aligned(rpm.From, width) || !aligned(rpm.To, width) {
+			continue
+		}
+		ret, max = view, width
+	}
+
+	return ret, nil
+}
+
+// viewWidth returns the bucket width of the continuous aggregate, parsed
+// from its name, e.g. one hour for messages_1h.
+func viewWidth(view string) (time.Duration, bool) {
+	m := viewName.FindStringSubmatch(view)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+
+	units := map[string]time.Duration{
+		"s": time.Second,
+		"m": time.Minute,
+		"h": time.Hour,
+		"d": 24 * time.Hour,
+	}
+	return time.Duration(n) * units[m[2]], true
+}
+
+// aligned checks whether the time in seconds is at the start of a bucket of
+// the given width. Buckets are aligned to the time_bucket default origin.
+func aligned(t float64, width time.Duration) bool {
+	if t == 0 {
+		return true
+	}
+	return math.Mod(t-bucketOrigin, width.Seconds()) == 0
+}
+
+// viewCondition returns the condition of the query filters over the
+// continuous aggregate.
+func viewCondition(rpm readers.PageMetadata) string {
+	condition := chanCondition
+	if rpm.Subtopic != "" {
+		condition = fmt.Sprintf(`%s AND subtopic = :subtopic`, condition)
+	}
+	if rpm.Publisher != "" {
+		condition = fmt.Sprintf(`%s AND publisher = :publisher`, condition)
+	}
+	if rpm.Name != "" {
+		condition = fmt.Sprintf(`%s AND name = :name`, condition)
+	}
+	if rpm.From != 0 {
+		condition = fmt.Sprintf(`%s AND bucket >= to_timestamp(:from)`, condition)
+	}
+	if rpm.To != 0 {
+		condition = fmt.Sprintf(`%s AND bucket < to_timestamp(:to)`, condition)
+	}
+	return condition
+}
+
+func (tr timescaleRepository) Latest(chanID string, rpm readers.PageMetadata, perPublisher bool) ([]readers.Message, error) {
+	group := "name"
+	if perPublisher {
+		group = "name, publisher"
+	}
+
+	q := fmt.Sprintf(`SELECT DISTINCT ON (%s) %s FROM %s
+    WHERE %s ORDER BY %s, time DESC, id DESC;`, group, senmlColumns, defTable, fmtCondition(chanID, defTable, rpm), group)
+	rows, err := tr.db.NamedQuery(q, queryParams(chanID, rpm))
+	if err != nil {
+		if e, ok := err.(*pq.Error); ok {
+			if e.Code == undefinedTableCode {
+				return []readers.Message{}, nil
+			}
+		}
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	msgs := []readers.Message{}
+	for rows.Next() {
+		msg := senmlMessage{Message: senml.Message{}}
+		if err := rows.StructScan(&msg); err != nil {
+			return nil, errors.Wrap(errReadMessages, err)
+		}
+		msgs = append(msgs, msg.Message)
+	}
+
+	return msgs, nil
+}
+
+func (tr timescaleRepository) Delete(chanID string, dm readers.DeleteMetadata) (err error) {
+	tables, err := tr.jsonTables()
+	if err != nil {
+		return errors.Wrap(errDeleteMessages, err)
+	}
+
+	tx, err := tr.db.Beginx()
+	if err != nil {
+		return errors.Wrap(errDeleteMessages, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errDeleteMessages, err)
+		}
+	}()
+
+	rpm := readers.PageMetadata{
+		Publisher: dm.Publisher,
+		From:      dm.From,
+		To:        dm.To,
+	}
+	params := queryParams(chanID, rpm)
+	q := fmt.Sprintf(`DELETE FROM %s WHERE %s`, defTable, fmtFilters(chanCondition, defTable, rpm))
+	if _, err := tx.NamedExec(q, params); err != nil {
+		return deleteError(err)
+	}
+	for _, table := range tables {
+		q := fmt.Sprintf(`DELETE FROM %s WHERE %s`, pq.QuoteIdentifier(table), fmtFilters(chanCondition, table, rpm))
+		if _, err := tx.NamedExec(q, params); err != nil {
+			return deleteError(err)
+		}
+	}
+
+	return nil
+}
+
+// jsonTables returns the names of the tables of the JSON messages, which
+// are created per message format.
+func (tr timescaleRepository) jsonTables() ([]string, error) {
+	q := `SELECT table_name FROM information_schema.columns
+          WHERE table_schema = current_schema() AND column_name = 'payload'`
+
+	tables := []string{}
+	if err := tr.db.Select(&tables, q); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+func deleteError(err error) error {
+	if e, ok := err.(*pq.Error); ok && e.Code.Name() == errInvalid {
+		return errors.Wrap(errors.ErrInvalidQueryParams, err)
+	}
+	return errors.Wrap(errDeleteMessages, err)
+}
+
+func queryParams(chanID string, rpm readers.PageMetadata) map[string]interface{} {
+	return map[string]interface{}{
+		"channel":      chanID,
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+		"from_created": int64(rpm.From * 1e9),
+		"to_created":   int64(rpm.To * 1e9),
+	}
+}
+
+// cursorCondition returns the condition that selects the messages following
+// the cursor, and adds the cursor to the query parameters.
+func cursorCondition(order, token string, params map[string]interface{}) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	c, err := readers.DecodeCursor(token)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrInvalidQueryParams, err)
+	}
+	if _, err := uuid.FromString(c.ID); err != nil {
+		return "", errors.Wrap(errors.ErrInvalidQueryParams, readers.ErrInvalidCursor)
+	}
+
+	params["cursor_id"] = c.ID
+	if order == "created" {
+		params["cursor_time"] = c.Created
+		return ` AND (created, id) < (:cursor_time, :cursor_id)`, nil
+	}
+	params["cursor_time"] = c.Time
+	return ` AND (time, id) < (to_timestamp(:cursor_time), :cursor_id)`, nil
+}
+
+func fmtCondition(chanID, format string, rpm readers.PageMetadata) string {
+	return fmtFilters(chanCondition, format, rpm)
+}
+
+// fmtFilters appends the conditions of the query filters to the channel
+// condition.
+func fmtFilters(condition, format string, rpm readers.PageMetadata) string {
+	var query map[string]interface{}
+	meta, err := json.Marshal(rpm)
+	if err != nil {
+		return condition
+	}
+	json.Unmarshal(meta, &query)
+
+	for name := range query {
+		switch name {
+		case
+			"subtopic",
+			"publisher",
+			"name",
+			"protocol":
+			condition = fmt.Sprintf(`%s AND %s = :%s`, condition, name, name)
+		case "v":
+			comparator := readers.ParseValueComparator(query)
+			condition = fmt.Sprintf(`%s AND value %s :value`, condition, comparator)
+		case "vb":
+			condition = fmt.Sprintf(`%s AND bool_value = :bool_value`, condition)
+		case "vs":
+			condition = fmt.Sprintf(`%s AND string_value = :string_value`, condition)
+		case "vd":
+			condition = fmt.Sprintf(`%s AND data_value = :data_value`, condition)
+		case "from":
+			if format != defTable {
+				condition = fmt.Sprintf(`%s AND created >= :from_created`, condition)
+				continue
+			}
+			condition = fmt.Sprintf(`%s AND time >= to_timestamp(:from)`, condition)
+		case "to":
+			if format != defTable {
+				condition = fmt.Sprintf(`%s AND created < :to_created`, condition)
+				continue
+			}
+			condition = fmt.Sprintf(`%s AND time < to_timestamp(:to)`, condition)
+		}
+	}
+	return condition
+}
+
+// aggregations maps the aggregations to the SQL expressions that compute
+// them over the values in the bucket.
+var aggregations = map[string]string{
+	readers.AggregationAvg:   "AVG(value)",
+	readers.AggregationMin:   "MIN(value)",
+	readers.AggregationMax:   "MAX(value)",
+	readers.AggregationSum:   "SUM(value)",
+	readers.AggregationCount: "COUNT(value)",
+	readers.AggregationFirst: "FIRST(value, time)",
+	readers.AggregationLast:  "LAST(value, time)",
+}
+
+// viewAggregations maps the aggregations to the SQL expressions that compute
+// them over the continuous aggregate buckets. First and last values aren't
+// kept in the continuous aggregates.
+var viewAggregations = map[string]string{
+	readers.AggregationAvg:   "SUM(sum) / SUM(count)::FLOAT",
+	readers.AggregationMin:   "MIN(min)",
+	readers.AggregationMax:   "MAX(max)",
+	readers.AggregationSum:   "SUM(sum)",
+	readers.AggregationCount: "SUM(count)::FLOAT",
+}
+
+type aggregateBucket struct {
+	Time  float64 `db:"time"`
+	Value float64 `db:"value"`
+}
+
+type senmlMessage struct {
+	ID string `db:"id"`
+	senml.Message
+}
+
+type jsonMessage struct {
+	ID        string `db:"id"`
+	Channel   string `db:"channel"`
+	Created   int64  `db:"created"`
+	Subtopic  string `db:"subtopic"`
+	Publisher string `db:"publisher"`
+	Protocol  string `db:"protocol"`
+	Payload   []byte `db:"payload"`
+}
+
+func (msg jsonMessage) toMap() (map[string]interface{}, error) {
+	ret := map[string]interface{}{
+		"id":        msg.ID,
+		"channel":   msg.Channel,
+		"created":   msg.Created,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"protocol":  msg.Protocol,
+		"payload":   map[string]interface{}{},
+	}
+	pld := make(map[string]interface{})
+	if err := json.Unmarshal(msg.Payload, &pld); err != nil {
+		return nil, err
+	}
+	ret["payload"] = pld
+	return ret, nil
+}
@@ -0,0 +1,1031 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	twriter "github.com/mainflux/mainflux/consumers/writers/timescale"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/readers"
+	treader "github.com/mainflux/mainflux/readers/timescale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	subtopic     = "subtopic"
+	msgsNum      = 100
+	limit        = 10
+	valueFields  = 5
+	mqttProt     = "mqtt"
+	httpProt     = "http"
+	msgName      = "temperature"
+	format1      = "format1"
+	format2      = "format2"
+	deleteFormat = "delete_format"
+	wrongID      = "0"
+	wrongValue   = "wrong-value"
+)
+
+var (
+	v   float64 = 5
+	vs          = "value"
+	vb          = true
+	vd          = "dataValue"
+	sum float64 = 42
+
+	idProvider = uuid.New()
+)
+
+func TestReadSenml(t *testing.T) {
+	writer := twriter.New(db, twriter.Policy{})
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	wrongID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	m := senml.Message{
+		Channel:   chanID,
+		Publisher: pubID,
+		Protocol:  mqttProt,
+	}
+
+	messages := []senml.Message{}
+	valueMsgs := []senml.Message{}
+	boolMsgs := []senml.Message{}
+	stringMsgs := []senml.Message{}
+	dataMsgs := []senml.Message{}
+	queryMsgs := []senml.Message{}
+
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		// Mix possible values as well as value sum.
+		msg := m
+		msg.Time = now - float64(i)
+
+		count := i % valueFields
+		switch count {
+		case 0:
+			msg.Value = &v
+			valueMsgs = append(valueMsgs, msg)
+		case 1:
+			msg.BoolValue = &vb
+			boolMsgs = append(boolMsgs, msg)
+		case 2:
+			msg.StringValue = &vs
+			stringMsgs = append(stringMsgs, msg)
+		case 3:
+			msg.DataValue = &vd
+			dataMsgs = append(dataMsgs, msg)
+		case 4:
+			msg.Sum = &sum
+			msg.Subtopic = subtopic
+			msg.Protocol = httpProt
+			msg.Publisher = pubID2
+			msg.Name = msgName
+			queryMsgs = append(queryMsgs, msg)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	err = writer.Consume(messages)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+
+	// Since messages are not saved in natural order,
+	// cases that return subset of messages are only
+	// checking data result set size, but not content.
+	cases := map[string]struct {
+		chanID   string
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		"read message page for existing channel": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromSenml(messages),
+			},
+		},
+		"read message page for non-existent channel": {
+			chanID: wrongID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Messages: []readers.Message{},
+			},
+		},
+		"read message last page": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: msgsNum - 20,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromSenml(messages[msgsNum-20 : msgsNum]),
+			},
+		},
+		"read message with non-existent subtopic": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:   0,
+				Limit:    msgsNum,
+				Subtopic: "not-present",
+			},
+			page: readers.MessagesPage{
+				Messages: []readers.Message{},
+			},
+		},
+		"read message with subtopic": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:   0,
+				Limit:    uint64(len(queryMsgs)),
+				Subtopic: subtopic,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read message with publisher": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    0,
+				Limit:     uint64(len(queryMsgs)),
+				Publisher: pubID2,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read message with wrong format": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Format:    "messagess",
+				Offset:    0,
+				Limit:     uint64(len(queryMsgs)),
+				Publisher: pubID2,
+			},
+			page: readers.MessagesPage{
+				Total:    0,
+				Messages: []readers.Message{},
+			},
+		},
+		"read message with protocol": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:   0,
+				Limit:    uint64(len(queryMsgs)),
+				Protocol: httpProt,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read message with name": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  limit,
+				Name:   msgName,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs[0:limit]),
+			},
+		},
+		"read message with value": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  limit,
+				Value:  v,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read message with value and equal comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     0,
+				Limit:      limit,
+				Value:      v,
+				Comparator: readers.EqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read message with value and lower-than comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     0,
+				Limit:      limit,
+				Value:      v + 1,
+				Comparator: readers.LowerThanKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read message with value and lower-than-or-equal comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     0,
+				Limit:      limit,
+				Value:      v + 1,
+				Comparator: readers.LowerThanEqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read message with value and greater-than comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     0,
+				Limit:      limit,
+				Value:      v - 1,
+				Comparator: readers.GreaterThanKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read message with value and greater-than-or-equal comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     0,
+				Limit:      limit,
+				Value:      v - 1,
+				Comparator: readers.GreaterThanEqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read message with boolean value": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    0,
+				Limit:     limit,
+				BoolValue: vb,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(boolMsgs)),
+				Messages: fromSenml(boolMsgs[0:limit]),
+			},
+		},
+		"read message with string value": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:      0,
+				Limit:       limit,
+				StringValue: vs,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(stringMsgs)),
+				Messages: fromSenml(stringMsgs[0:limit]),
+			},
+		},
+		"read message with data value": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    0,
+				Limit:     limit,
+				DataValue: vd,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(dataMsgs)),
+				Messages: fromSenml(dataMsgs[0:limit]),
+			},
+		},
+		"read message with from": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  uint64(len(messages[0:21])),
+				From:   messages[20].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(messages[0:21])),
+				Messages: fromSenml(messages[0:21]),
+			},
+		},
+		"read message with to": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  uint64(len(messages[21:])),
+				To:     messages[20].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(messages[21:])),
+				Messages: fromSenml(messages[21:]),
+			},
+		},
+		"read message with from/to": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: 0,
+				Limit:  limit,
+				From:   messages[5].Time,
+				To:     messages[0].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    5,
+				Messages: fromSenml(messages[1:6]),
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ReadAll(tc.chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	writer := twriter.New(db, twriter.Policy{})
+
+	id1, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	m := json.Message{
+		Channel:   id1,
+		Publisher: id1,
+		Created:   time.Now().Unix(),
+		Subtopic:  "subtopic/format/some_json",
+		Protocol:  "coap",
+		Payload: map[string]interface{}{
+			"field_1": 123.0,
+			"field_2": "value",
+			"field_3": false,
+			"field_4": 12.344,
+			"field_5": map[string]interface{}{
+				"field_1": "value",
+				"field_2": 42.0,
+			},
+		},
+	}
+	messages1 := json.Messages{
+		Format: format1,
+	}
+	msgs1 := []map[string]interface{}{}
+	for i := 0; i < msgsNum; i++ {
+		msg := m
+		messages1.Data = append(messages1.Data, msg)
+		m := toMap(msg)
+		msgs1 = append(msgs1, m)
+	}
+	err = writer.Consume(messages1)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	id2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	m = json.Message{
+		Channel:   id2,
+		Publisher: id2,
+		Created:   time.Now().Unix(),
+		Subtopic:  "subtopic/other_format/some_other_json",
+		Protocol:  "udp",
+		Payload: map[string]interface{}{
+			"field_1":     "other_value",
+			"false_value": false,
+			"field_pi":    3.14159265,
+		},
+	}
+	messages2 := json.Messages{
+		Format: format2,
+	}
+	msgs2 := []map[string]interface{}{}
+	for i := 0; i < msgsNum; i++ {
+		msg := m
+		if i%2 == 0 {
+			msg.Protocol = httpProt
+		}
+		messages2.Data = append(messages2.Data, msg)
+		m := toMap(msg)
+		msgs2 = append(msgs2, m)
+	}
+	err = writer.Consume(messages2)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	httpMsgs := []map[string]interface{}{}
+	for i := 0; i < msgsNum; i += 2 {
+		httpMsgs = append(httpMsgs, msgs2[i])
+	}
+
+	reader := treader.New(db)
+
+	cases := map[string]struct {
+		chanID   string
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		"read message page for existing channel": {
+			chanID: id1,
+			pageMeta: readers.PageMetadata{
+				Format: messages1.Format,
+				Offset: 0,
+				Limit:  10,
+			},
+			page: readers.MessagesPage{
+				Total:    100,
+				Messages: fromJSON(msgs1[:10]),
+			},
+		},
+		"read message page for non-existent channel": {
+			chanID: wrongID,
+			pageMeta: readers.PageMetadata{
+				Format: messages1.Format,
+				Offset: 0,
+				Limit:  10,
+			},
+			page: readers.MessagesPage{
+				Messages: []readers.Message{},
+			},
+		},
+		"read message last page": {
+			chanID: id2,
+			pageMeta: readers.PageMetadata{
+				Format: messages2.Format,
+				Offset: msgsNum - 20,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromJSON(msgs2[msgsNum-20 : msgsNum]),
+			},
+		},
+		"read message with protocol": {
+			chanID: id2,
+			pageMeta: readers.PageMetadata{
+				Format:   messages2.Format,
+				Offset:   0,
+				Limit:    uint64(msgsNum / 2),
+				Protocol: httpProt,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(msgsNum / 2),
+				Messages: fromJSON(httpMsgs),
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ReadAll(tc.chanID, tc.pageMeta)
+		for i := 0; i < len(result.Messages); i++ {
+			m := result.Messages[i]
+			// Remove id as it is not sent by the client.
+			delete(m.(map[string]interface{}), "id")
+			result.Messages[i] = m
+		}
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestReadAggregation(t *testing.T) {
+	writer := twriter.New(db, twriter.Policy{})
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Messages are split into two one-minute buckets, with the values
+	// 0 to 5 in the first one and 6 to 11 in the second one.
+	start := float64(time.Now().Add(-time.Hour).Unix() / 60 * 60)
+	var messages []senml.Message
+	for i := 0; i < 12; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      start + float64(i*10),
+			Value:     &v,
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		values   []float64
+		total    uint64
+	}{
+		"read average values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationAvg, Interval: "1m"},
+			values:   []float64{8.5, 2.5},
+			total:    2,
+		},
+		"read min values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationMin, Interval: "1m"},
+			values:   []float64{6, 0},
+			total:    2,
+		},
+		"read max values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationMax, Interval: "1m"},
+			values:   []float64{11, 5},
+			total:    2,
+		},
+		"read sum of values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationSum, Interval: "1m"},
+			values:   []float64{51, 15},
+			total:    2,
+		},
+		"read count of values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationCount, Interval: "1m"},
+			values:   []float64{6, 6},
+			total:    2,
+		},
+		"read first values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationFirst, Interval: "1m"},
+			values:   []float64{6, 0},
+			total:    2,
+		},
+		"read last values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationLast, Interval: "1m"},
+			values:   []float64{11, 5},
+			total:    2,
+		},
+		"read average values with offset": {
+			pageMeta: readers.PageMetadata{Offset: 1, Limit: limit, Name: msgName, Aggregation: readers.AggregationAvg, Interval: "1m"},
+			values:   []float64{2.5},
+			total:    2,
+		},
+		"read average values over single bucket": {
+			pageMeta: readers.PageMetadata{Limit: limit, Name: msgName, Aggregation: readers.AggregationAvg, Interval: "1h", From: start, To: start + 60},
+			values:   []float64{2.5},
+			total:    1,
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ReadAll(chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.Equal(t, tc.total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.total, result.Total))
+
+		var values []float64
+		for _, m := range result.Messages {
+			msg := m.(senml.Message)
+			values = append(values, *msg.Value)
+		}
+		assert.Equal(t, tc.values, values, fmt.Sprintf("%s: expected %v got %v", desc, tc.values, values))
+	}
+
+	// The same buckets are read from the continuous aggregate maintained
+	// by the writer.
+	_, err = db.Exec(`CREATE MATERIALIZED VIEW messages_1m
+          WITH (timescaledb.continuous) AS
+          SELECT time_bucket(INTERVAL '60 seconds', time) AS bucket,
+          channel, publisher, subtopic, name, unit,
+          COUNT(value) AS count, AVG(value) AS avg, MIN(value) AS min,
+          MAX(value) AS max, SUM(value) AS sum
+          FROM messages
+          GROUP BY bucket, channel, publisher, subtopic, name, unit
+          WITH NO DATA`)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer db.Exec(`DROP MATERIALIZED VIEW messages_1m`)
+	_, err = db.Exec(`CALL refresh_continuous_aggregate('messages_1m', NULL, NULL)`)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for desc, tc := range cases {
+		result, err := reader.ReadAll(chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s from aggregate: expected no error got %s", desc, err))
+		assert.Equal(t, tc.total, result.Total, fmt.Sprintf("%s from aggregate: expected %v got %v", desc, tc.total, result.Total))
+
+		var values []float64
+		for _, m := range result.Messages {
+			msg := m.(senml.Message)
+			values = append(values, *msg.Value)
+		}
+		assert.Equal(t, tc.values, values, fmt.Sprintf("%s from aggregate: expected %v got %v", desc, tc.values, values))
+	}
+}
+
+func TestIterate(t *testing.T) {
+	writer := twriter.New(db, twriter.Policy{})
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	start := float64(time.Now().Add(-time.Hour).Unix())
+	var messages []senml.Message
+	for i := 0; i < 12; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      start + float64(i*10),
+			Value:     &v,
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		values   []float64
+	}{
+		"iterate all messages": {
+			pageMeta: readers.PageMetadata{},
+			values:   []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		},
+		"iterate messages from time": {
+			pageMeta: readers.PageMetadata{From: start + 60},
+			values:   []float64{6, 7, 8, 9, 10, 11},
+		},
+		"iterate messages by value": {
+			pageMeta: readers.PageMetadata{Value: 3, Comparator: readers.LowerThanEqualKey},
+			values:   []float64{0, 1, 2, 3},
+		},
+	}
+
+	for desc, tc := range cases {
+		iter, err := reader.Iterate(chanID, tc.pageMeta)
+		require.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+
+		var values []float64
+		for iter.Next() {
+			msg := iter.Message().(senml.Message)
+			values = append(values, *msg.Value)
+		}
+		assert.Nil(t, iter.Err(), fmt.Sprintf("%s: expected no error got %s", desc, iter.Err()))
+		assert.Nil(t, iter.Close(), fmt.Sprintf("%s: expected no error on close", desc))
+		assert.Equal(t, tc.values, values, fmt.Sprintf("%s: expected %v got %v", desc, tc.values, values))
+	}
+}
+
+func TestReadByCursor(t *testing.T) {
+	writer := twriter.New(db, twriter.Policy{})
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Pairs of messages share the time, so the cursor has to tell them
+	// apart.
+	start := float64(time.Now().Add(-time.Hour).Unix())
+	var messages []senml.Message
+	for i := 0; i < 12; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      start + float64(i/2*10),
+			Value:     &v,
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+
+	// Follow the cursors through all the pages.
+	pm := readers.PageMetadata{Limit: 5}
+	var values []float64
+	for i := 0; i < 3; i++ {
+		page, err := reader.ReadAll(chanID, pm)
+		require.Nil(t, err, fmt.Sprintf("page %d: expected no error got %s", i, err))
+		assert.Equal(t, uint64(len(messages)), page.Total, fmt.Sprintf("page %d: expected total %d got %d", i, len(messages), page.Total))
+		for _, m := range page.Messages {
+			values = append(values, *m.(senml.Message).Value)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		pm.Cursor = page.NextCursor
+	}
+	assert.ElementsMatch(t, []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, values, fmt.Sprintf("expected all the messages once got %v", values))
+
+	_, err = reader.ReadAll(chanID, readers.PageMetadata{Limit: 5, Cursor: "invalid"})
+	assert.True(t, errors.Contains(err, errors.ErrInvalidQueryParams), fmt.Sprintf("expected %s got %s", errors.ErrInvalidQueryParams, err))
+}
+
+func TestReadLatest(t *testing.T) {
+	writer := twriter.New(db, twriter.Policy{})
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Names alternate with every message and publishers with every pair,
+	// and the value of the message is its index.
+	start := float64(time.Now().Add(-time.Hour).Unix())
+	names := []string{msgName, "humidity"}
+	pubs := []string{pubID, pubID2}
+	var messages []senml.Message
+	for i := 0; i < 12; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubs[(i/2)%2],
+			Protocol:  mqttProt,
+			Name:      names[i%2],
+			Time:      start + float64(i*10),
+			Value:     &v,
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+
+	cases := map[string]struct {
+		pm           readers.PageMetadata
+		perPublisher bool
+		values       []float64
+	}{
+		"read latest messages": {
+			values: []float64{11, 10},
+		},
+		"read latest messages per publisher": {
+			perPublisher: true,
+			values:       []float64{9, 11, 8, 10},
+		},
+		"read latest messages with name": {
+			pm:     readers.PageMetadata{Name: msgName},
+			values: []float64{10},
+		},
+		"read latest messages before the given time": {
+			pm:     readers.PageMetadata{To: start + 95},
+			values: []float64{9, 8},
+		},
+		"read latest messages with no matching messages": {
+			pm:     readers.PageMetadata{Name: "unknown"},
+			values: []float64{},
+		},
+	}
+
+	for desc, tc := range cases {
+		msgs, err := reader.Latest(chanID, tc.pm, tc.perPublisher)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		values := []float64{}
+		for _, m := range msgs {
+			values = append(values, *m.(senml.Message).Value)
+		}
+		if tc.perPublisher {
+			// Publishers are ordered by their random IDs.
+			assert.ElementsMatch(t, tc.values, values, fmt.Sprintf("%s: expected %v got %v", desc, tc.values, values))
+			continue
+		}
+		assert.Equal(t, tc.values, values, fmt.Sprintf("%s: expected %v got %v", desc, tc.values, values))
+	}
+}
+
+func TestReadChannels(t *testing.T) {
+	writer := twriter.New(db, twriter.Policy{})
+
+	var chanIDs []string
+	for i := 0; i < 4; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		chanIDs = append(chanIDs, id)
+	}
+
+	// Messages are sent to the first three channels in turn, and the value
+	// of the message is its index.
+	start := float64(time.Now().Add(-time.Hour).Unix())
+	var messages []senml.Message
+	for i := 0; i < 12; i++ {
+		v := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:  chanIDs[i%3],
+			Protocol: mqttProt,
+			Name:     msgName,
+			Time:     start + float64(i*10),
+			Value:    &v,
+		})
+	}
+	err := writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+
+	cases := map[string]struct {
+		chanIDs []string
+		pm      readers.PageMetadata
+		total   uint64
+		values  []float64
+	}{
+		"read messages of channels": {
+			chanIDs: chanIDs[0:2],
+			pm:      readers.PageMetadata{Limit: 10},
+			total:   8,
+			values:  []float64{10, 9, 7, 6, 4, 3, 1, 0},
+		},
+		"read messages of channels with offset": {
+			chanIDs: chanIDs[0:2],
+			pm:      readers.PageMetadata{Offset: 2, Limit: 3},
+			total:   8,
+			values:  []float64{7, 6, 4},
+		},
+		"read messages of channels with filter": {
+			chanIDs: chanIDs[0:2],
+			pm:      readers.PageMetadata{Limit: 10, From: start + 30},
+			total:   6,
+			values:  []float64{10, 9, 7, 6, 4, 3},
+		},
+		"read messages of single channel": {
+			chanIDs: chanIDs[1:2],
+			pm:      readers.PageMetadata{Limit: 10},
+			total:   4,
+			values:  []float64{10, 7, 4, 1},
+		},
+		"read messages of channel without messages": {
+			chanIDs: chanIDs[3:],
+			pm:      readers.PageMetadata{Limit: 10},
+			total:   0,
+			values:  []float64{},
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := reader.ReadChannels(tc.chanIDs, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", desc, tc.total, page.Total))
+		values := []float64{}
+		for _, m := range page.Messages {
+			values = append(values, *m.(senml.Message).Value)
+		}
+		assert.Equal(t, tc.values, values, fmt.Sprintf("%s: expected %v got %v", desc, tc.values, values))
+	}
+
+	// Follow the cursors through all the pages.
+	pm := readers.PageMetadata{Limit: 3}
+	var values []float64
+	for i := 0; i < 3; i++ {
+		page, err := reader.ReadChannels(chanIDs[0:2], pm)
+		require.Nil(t, err, fmt.Sprintf("page %d: expected no error got %s", i, err))
+		for _, m := range page.Messages {
+			values = append(values, *m.(senml.Message).Value)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		pm.Cursor = page.NextCursor
+	}
+	assert.Equal(t, []float64{10, 9, 7, 6, 4, 3, 1, 0}, values, fmt.Sprintf("expected all the messages once got %v", values))
+}
+
+func TestDelete(t *testing.T) {
+	writer := twriter.New(db, twriter.Policy{})
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubs := []string{"publisher-1", "publisher-2"}
+
+	// Publishers send the messages in turn, one per second.
+	start := time.Now().Add(-time.Hour).Unix()
+	var messages []senml.Message
+	for i := 0; i < 10; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubs[i%2],
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      float64(start + int64(i)),
+			Value:     &v,
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	jsonMsgs := json.Messages{Format: deleteFormat}
+	for i := 0; i < 4; i++ {
+		jsonMsgs.Data = append(jsonMsgs.Data, json.Message{
+			Channel:   chanID,
+			Publisher: pubs[i%2],
+			Protocol:  mqttProt,
+			Created:   (start + int64(i)) * int64(time.Second),
+			Payload:   map[string]interface{}{"field_1": float64(i)},
+		})
+	}
+	err = writer.Consume(jsonMsgs)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+
+	cases := []struct {
+		desc  string
+		dm    readers.DeleteMetadata
+		senml uint64
+		json  uint64
+	}{
+		{
+			desc:  "delete messages of publisher in time range",
+			dm:    readers.DeleteMetadata{Publisher: pubs[0], From: float64(start + 2), To: float64(start + 6)},
+			senml: 8,
+			json:  3,
+		},
+		{
+			desc:  "delete messages since time",
+			dm:    readers.DeleteMetadata{From: float64(start + 8)},
+			senml: 6,
+			json:  3,
+		},
+		{
+			desc:  "delete messages until time",
+			dm:    readers.DeleteMetadata{To: float64(start + 1)},
+			senml: 5,
+			json:  2,
+		},
+		{
+			desc:  "delete all messages",
+			dm:    readers.DeleteMetadata{},
+			senml: 0,
+			json:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		err := reader.Delete(chanID, tc.dm)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+
+		page, err := reader.ReadAll(chanID, readers.PageMetadata{Limit: msgsNum})
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Equal(t, tc.senml, page.Total, fmt.Sprintf("%s: expected %d SenML messages got %d", tc.desc, tc.senml, page.Total))
+
+		page, err = reader.ReadAll(chanID, readers.PageMetadata{Limit: msgsNum, Format: deleteFormat})
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Equal(t, tc.json, page.Total, fmt.Sprintf("%s: expected %d JSON messages got %d", tc.desc, tc.json, page.Total))
+	}
+}
+
+func fromSenml(msg []senml.Message) []readers.Message {
+	var ret []readers.Message
+	for _, m := range msg {
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+func fromJSON(msg []map[string]interface{}) []readers.Message {
+	var ret []readers.Message
+	for _, m := range msg {
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+func toMap(msg json.Message) map[string]interface{} {
+	return map[string]interface{}{
+		"channel":   msg.Channel,
+		"created":   msg.Created,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"protocol":  msg.Protocol,
+		"payload":   map[string]interface{}(msg.Payload),
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import "strings"
+
+const (
+	subtopicSep = "."
+	anyToken    = "*"
+	restTokens  = ">"
+)
+
+// ConnectionACL represents the access rules of the thing-channel connection.
+// Actions lists the actions the thing can perform on the channel, while Allow
+// and Deny list the subtopic patterns the thing can and can not use. Patterns
+// consist of dot-separated tokens, where the `*` token matches any single
+// subtopic token and the trailing `>` token matches one or more tokens.
+type ConnectionACL struct {
+	Actions []string
+	Allow   []string
+	Deny    []string
+}
+
+// Restricted returns true if the access to the channel is restricted to a
+// subset of the channel's subtopics.
+func (acl ConnectionACL) Restricted() bool {
+	return len(acl.Allow) > 0 || len(acl.Deny) > 0
+}
+
+// AllowsSubtopic determines whether the subtopic can be accessed. The subtopic
+// is denied if it overlaps any of the deny patterns, or if allow patterns are
+// present and none of them covers the subtopic. Subtopics of subscriptions may
+// contain wildcards themselves.
+func (acl ConnectionACL) AllowsSubtopic(subtopic string) bool {
+	tokens := splitSubtopic(subtopic)
+	for _, pattern := range acl.Deny {
+		if overlaps(splitSubtopic(pattern), tokens) {
+			return false
+		}
+	}
+
+	if len(acl.Allow) == 0 {
+		return true
+	}
+	for _, pattern := range acl.Allow {
+		if covers(splitSubtopic(pattern), tokens) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (acl ConnectionACL) validate() error {
+	for _, action := range acl.Actions {
+		if action != PublishAction && action != SubscribeAction {
+			return ErrMalformedEntity
+		}
+	}
+
+	for _, pattern := range append(acl.Allow, acl.Deny...) {
+		if !validPattern(pattern) {
+			return ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
+func validPattern(pattern string) bool {
+	tokens := splitSubtopic(pattern)
+	if len(tokens) == 0 {
+		return false
+	}
+
+	for i, token := range tokens {
+		switch {
+		case token == "":
+			return false
+		case token == restTokens && i != len(tokens)-1:
+			return false
+		case len(token) > 1 && strings.ContainsAny(token, anyToken+restTokens):
+			return false
+		}
+	}
+
+	return true
+}
+
+// covers determines whether every subtopic matched by the subject is matched
+// by the pattern as well.
+func covers(pattern, subject []string) bool {
+	for i, token := range subject {
+		if i >= len(pattern) {
+			return false
+		}
+
+		switch pattern[i] {
+		case restTokens:
+			return true
+		case anyToken:
+			if token == restTokens {
+				return false
+			}
+		default:
+			if pattern[i] != token {
+				return false
+			}
+		}
+	}
+
+	return len(pattern) == len(subject)
+}
+
+// overlaps determines whether there is a subtopic matched by both the
+// pattern and the subject.
+func overlaps(pattern, subject []string) bool {
+	for i, token := range subject {
+		if i >= len(pattern) {
+			return false
+		}
+
+		if pattern[i] == restTokens || token == restTokens {
+			return true
+		}
+		if pattern[i] != anyToken && token != anyToken && pattern[i] != token {
+			return false
+		}
+	}
+
+	return len(pattern) == len(subject)
+}
+
+func splitSubtopic(subtopic string) []string {
+	if subtopic == "" {
+		return []string{}
+	}
+
+	return strings.Split(subtopic, subtopicSep)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/things"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowsSubtopic(t *testing.T) {
+	cases := []struct {
+		desc     string
+		acl      things.ConnectionACL
+		subtopic string
+		allowed  bool
+	}{
+		{
+			desc:     "access any subtopic without patterns",
+			acl:      things.ConnectionACL{},
+			subtopic: "a.b",
+			allowed:  true,
+		},
+		{
+			desc:     "access exactly allowed subtopic",
+			acl:      things.ConnectionACL{Allow: []string{"a.b"}},
+			subtopic: "a.b",
+			allowed:  true,
+		},
+		{
+			desc:     "access subtopic allowed by single token wildcard",
+			acl:      things.ConnectionACL{Allow: []string{"a.*.c"}},
+			subtopic: "a.b.c",
+			allowed:  true,
+		},
+		{
+			desc:     "access subtopic with more tokens than single token wildcard pattern",
+			acl:      things.ConnectionACL{Allow: []string{"a.*"}},
+			subtopic: "a.b.c",
+			allowed:  false,
+		},
+		{
+			desc:     "access subtopic allowed by trailing wildcard",
+			acl:      things.ConnectionACL{Allow: []string{"a.>"}},
+			subtopic: "a.b.c",
+			allowed:  true,
+		},
+		{
+			desc:     "access subtopic prefix of trailing wildcard pattern",
+			acl:      things.ConnectionACL{Allow: []string{"a.>"}},
+			subtopic: "a",
+			allowed:  false,
+		},
+		{
+			desc:     "access empty subtopic with allow patterns",
+			acl:      things.ConnectionACL{Allow: []string{">"}},
+			subtopic: "",
+			allowed:  false,
+		},
+		{
+			desc:     "subscribe to wildcard subtopic covered by pattern",
+			acl:      things.ConnectionACL{Allow: []string{"a.>"}},
+			subtopic: "a.*.c",
+			allowed:  true,
+		},
+		{
+			desc:     "subscribe to wildcard subtopic wider than pattern",
+			acl:      things.ConnectionACL{Allow: []string{"a.b.>"}},
+			subtopic: "a.>",
+			allowed:  false,
+		},
+		{
+			desc:     "access denied subtopic",
+			acl:      things.ConnectionACL{Deny: []string{"a.b"}},
+			subtopic: "a.b",
+			allowed:  false,
+		},
+		{
+			desc:     "access subtopic that is not denied",
+			acl:      things.ConnectionACL{Deny: []string{"a.b"}},
+			subtopic: "a.c",
+			allowed:  true,
+		},
+		{
+			desc:     "access allowed subtopic that is denied",
+			acl:      things.ConnectionACL{Allow: []string{"a.>"}, Deny: []string{"a.*.secret"}},
+			subtopic: "a.b.secret",
+			allowed:  false,
+		},
+		{
+			desc:     "subscribe to wildcard subtopic overlapping denied subtopic",
+			acl:      things.ConnectionACL{Allow: []string{"a.>"}, Deny: []string{"a.b.secret"}},
+			subtopic: "a.*.>",
+			allowed:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		allowed := tc.acl.AllowsSubtopic(tc.subtopic)
+		assert.Equal(t, tc.allowed, allowed, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.allowed, allowed))
+	}
+}
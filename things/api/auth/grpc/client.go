@@ -74,6 +74,8 @@ func (client grpcClient) CanAccessByKey(ctx context.Context, req *mainflux.Acces
 	ar := AccessByKeyReq{
 		thingKey: req.GetToken(),
 		chanID:   req.GetChanID(),
+		action:   req.GetAction(),
+		subtopic: req.GetSubtopic(),
 	}
 	res, err := client.canAccessByKey(ctx, ar)
 	if err != nil {
@@ -85,7 +87,12 @@ func (client grpcClient) CanAccessByKey(ctx context.Context, req *mainflux.Acces
 }
 
 func (client grpcClient) CanAccessByID(ctx context.Context, req *mainflux.AccessByIDReq, _ ...grpc.CallOption) (*empty.Empty, error) {
-	ar := accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID()}
+	ar := accessByIDReq{
+		thingID:  req.GetThingID(),
+		chanID:   req.GetChanID(),
+		action:   req.GetAction(),
+		subtopic: req.GetSubtopic(),
+	}
 	res, err := client.canAccessByID(ctx, ar)
 	if err != nil {
 		return nil, err
@@ -121,12 +128,22 @@ func (client grpcClient) Identify(ctx context.Context, req *mainflux.Token, _ ..
 
 func encodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(AccessByKeyReq)
-	return &mainflux.AccessByKeyReq{Token: req.thingKey, ChanID: req.chanID}, nil
+	return &mainflux.AccessByKeyReq{
+		Token:    req.thingKey,
+		ChanID:   req.chanID,
+		Action:   req.action,
+		Subtopic: req.subtopic,
+	}, nil
 }
 
 func encodeCanAccessByIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(accessByIDReq)
-	return &mainflux.AccessByIDReq{ThingID: req.thingID, ChanID: req.chanID}, nil
+	return &mainflux.AccessByIDReq{
+		ThingID:  req.thingID,
+		ChanID:   req.chanID,
+		Action:   req.action,
+		Subtopic: req.subtopic,
+	}, nil
 }
 
 func encodeIsChannelOwner(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -17,7 +17,7 @@ func canAccessEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		id, err := svc.CanAccessByKey(ctx, req.chanID, req.thingKey)
+		id, err := svc.CanAccessByKey(ctx, req.chanID, req.thingKey, req.action, req.subtopic)
 		if err != nil {
 			return identityRes{}, err
 		}
@@ -32,7 +32,7 @@ func canAccessByIDEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		err := svc.CanAccessByID(ctx, req.chanID, req.thingID)
+		err := svc.CanAccessByID(ctx, req.chanID, req.thingID, req.action, req.subtopic)
 		return emptyRes{err: err}, err
 	}
 }
@@ -34,10 +34,15 @@ func TestCanAccessByKey(t *testing.T) {
 	th1 := ths[0]
 	th2 := ths[1]
 
-	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th1.ID})
+	sch := chs[1]
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th1.ID}, things.ConnectionACL{Actions: []string{things.PublishAction}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	acl := things.ConnectionACL{Allow: []string{"site.>"}}
+	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{th1.ID}, acl)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	usersAddr := fmt.Sprintf("localhost:%d", port)
@@ -48,10 +53,12 @@ func TestCanAccessByKey(t *testing.T) {
 	defer cancel()
 
 	cases := map[string]struct {
-		key     string
-		chanID  string
-		thingID string
-		code    codes.Code
+		key      string
+		chanID   string
+		action   string
+		subtopic string
+		thingID  string
+		code     codes.Code
 	}{
 		"check if connected thing can access existing channel": {
 			key:     th1.Key,
@@ -59,6 +66,41 @@ func TestCanAccessByKey(t *testing.T) {
 			thingID: th1.ID,
 			code:    codes.OK,
 		},
+		"check if connected thing can publish to existing channel": {
+			key:     th1.Key,
+			chanID:  ch.ID,
+			action:  things.PublishAction,
+			thingID: th1.ID,
+			code:    codes.OK,
+		},
+		"check if publish-only thing can subscribe to existing channel": {
+			key:     th1.Key,
+			chanID:  ch.ID,
+			action:  things.SubscribeAction,
+			thingID: wrongID,
+			code:    codes.PermissionDenied,
+		},
+		"check if connected thing can access existing channel with invalid action": {
+			key:     th1.Key,
+			chanID:  ch.ID,
+			action:  wrong,
+			thingID: wrongID,
+			code:    codes.InvalidArgument,
+		},
+		"check if connected thing can access allowed subtopic": {
+			key:      th1.Key,
+			chanID:   sch.ID,
+			subtopic: "site.temperature",
+			thingID:  th1.ID,
+			code:     codes.OK,
+		},
+		"check if connected thing can access subtopic that is not allowed": {
+			key:      th1.Key,
+			chanID:   sch.ID,
+			subtopic: "other.temperature",
+			thingID:  wrongID,
+			code:     codes.PermissionDenied,
+		},
 		"check if unconnected thing can access existing channel": {
 			key:     th2.Key,
 			chanID:  ch.ID,
@@ -80,7 +122,7 @@ func TestCanAccessByKey(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		id, err := cli.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: tc.key, ChanID: tc.chanID})
+		id, err := cli.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: tc.key, ChanID: tc.chanID, Action: tc.action, Subtopic: tc.subtopic})
 		e, ok := status.FromError(err)
 		assert.True(t, ok, "OK expected to be true")
 		assert.Equal(t, tc.thingID, id.GetValue(), fmt.Sprintf("%s: expected %s got %s", desc, tc.thingID, id.GetValue()))
@@ -99,7 +141,7 @@ func TestCanAccessByID(t *testing.T) {
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	svc.Connect(context.Background(), token, []string{ch.ID}, []string{th2.ID})
+	svc.Connect(context.Background(), token, []string{ch.ID}, []string{th2.ID}, things.ConnectionACL{})
 
 	usersAddr := fmt.Sprintf("localhost:%d", port)
 	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
@@ -111,6 +153,7 @@ func TestCanAccessByID(t *testing.T) {
 	cases := map[string]struct {
 		chanID  string
 		thingID string
+		action  string
 		code    codes.Code
 	}{
 		"check if connected thing can access existing channel": {
@@ -118,6 +161,18 @@ func TestCanAccessByID(t *testing.T) {
 			thingID: th2.ID,
 			code:    codes.OK,
 		},
+		"check if connected thing can subscribe to existing channel": {
+			chanID:  ch.ID,
+			thingID: th2.ID,
+			action:  things.SubscribeAction,
+			code:    codes.OK,
+		},
+		"check if connected thing can access existing channel with invalid action": {
+			chanID:  ch.ID,
+			thingID: th2.ID,
+			action:  wrong,
+			code:    codes.InvalidArgument,
+		},
 		"check if unconnected thing can access existing channel": {
 			chanID:  ch.ID,
 			thingID: th1.ID,
@@ -141,7 +196,7 @@ func TestCanAccessByID(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		_, err := cli.CanAccessByID(ctx, &mainflux.AccessByIDReq{ThingID: tc.thingID, ChanID: tc.chanID})
+		_, err := cli.CanAccessByID(ctx, &mainflux.AccessByIDReq{ThingID: tc.thingID, ChanID: tc.chanID, Action: tc.action})
 		e, ok := status.FromError(err)
 		assert.True(t, ok, "OK expected to be true")
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
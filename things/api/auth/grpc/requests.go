@@ -8,6 +8,8 @@ import "github.com/mainflux/mainflux/things"
 type AccessByKeyReq struct {
 	thingKey string
 	chanID   string
+	action   string
+	subtopic string
 }
 
 func (req AccessByKeyReq) validate() error {
@@ -15,12 +17,14 @@ func (req AccessByKeyReq) validate() error {
 		return things.ErrMalformedEntity
 	}
 
-	return nil
+	return validateAction(req.action)
 }
 
 type accessByIDReq struct {
-	thingID string
-	chanID  string
+	thingID  string
+	chanID   string
+	action   string
+	subtopic string
 }
 
 func (req accessByIDReq) validate() error {
@@ -28,7 +32,7 @@ func (req accessByIDReq) validate() error {
 		return things.ErrMalformedEntity
 	}
 
-	return nil
+	return validateAction(req.action)
 }
 
 type channelOwnerReq struct {
@@ -55,3 +59,12 @@ func (req identifyReq) validate() error {
 
 	return nil
 }
+
+func validateAction(action string) error {
+	switch action {
+	case "", things.PublishAction, things.SubscribeAction:
+		return nil
+	default:
+		return things.ErrMalformedEntity
+	}
+}
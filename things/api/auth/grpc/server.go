@@ -89,12 +89,22 @@ func (gs *grpcServer) Identify(ctx context.Context, req *mainflux.Token) (*mainf
 
 func decodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AccessByKeyReq)
-	return AccessByKeyReq{thingKey: req.GetToken(), chanID: req.GetChanID()}, nil
+	return AccessByKeyReq{
+		thingKey: req.GetToken(),
+		chanID:   req.GetChanID(),
+		action:   req.GetAction(),
+		subtopic: req.GetSubtopic(),
+	}, nil
 }
 
 func decodeCanAccessByIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AccessByIDReq)
-	return accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID()}, nil
+	return accessByIDReq{
+		thingID:  req.GetThingID(),
+		chanID:   req.GetChanID(),
+		action:   req.GetAction(),
+		subtopic: req.GetSubtopic(),
+	}, nil
 }
 
 func decodeIsChannelOwnerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -127,6 +137,8 @@ func encodeError(err error) error {
 		return status.Error(codes.PermissionDenied, "missing or invalid credentials provided")
 	case things.ErrEntityConnected:
 		return status.Error(codes.PermissionDenied, "entities are not connected")
+	case things.ErrSubtopicDenied:
+		return status.Error(codes.PermissionDenied, "subtopic access denied")
 	case things.ErrNotFound:
 		return status.Error(codes.NotFound, "entity does not exist")
 	default:
@@ -72,9 +72,10 @@ func newService(tokens map[string]string) things.Service {
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
+	presenceRepo := mocks.NewPresenceRepository()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, presenceRepo, idProvider)
 }
 
 func newServer(svc things.Service) *httptest.Server {
@@ -155,12 +156,24 @@ func TestCanAccessByKey(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("failed to create channel: %s", err))
 	ch := chs[0]
 
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, things.ConnectionACL{Actions: []string{things.PublishAction}})
 	require.Nil(t, err, fmt.Sprintf("failed to connect thing and channel: %s", err))
 
 	data := toJSON(canAccessByKeyReq{
 		Token: th.Key,
 	})
+	pubData := toJSON(canAccessByKeyReq{
+		Token:  th.Key,
+		Action: things.PublishAction,
+	})
+	subData := toJSON(canAccessByKeyReq{
+		Token:  th.Key,
+		Action: things.SubscribeAction,
+	})
+	invalidData := toJSON(canAccessByKeyReq{
+		Token:  th.Key,
+		Action: wrong,
+	})
 
 	cases := map[string]struct {
 		contentType string
@@ -174,6 +187,24 @@ func TestCanAccessByKey(t *testing.T) {
 			req:         data,
 			status:      http.StatusOK,
 		},
+		"check publish access for connected thing and channel": {
+			contentType: contentType,
+			chanID:      ch.ID,
+			req:         pubData,
+			status:      http.StatusOK,
+		},
+		"check subscribe access for publish-only connected thing and channel": {
+			contentType: contentType,
+			chanID:      ch.ID,
+			req:         subData,
+			status:      http.StatusForbidden,
+		},
+		"check access with invalid action": {
+			contentType: contentType,
+			chanID:      ch.ID,
+			req:         invalidData,
+			status:      http.StatusBadRequest,
+		},
 		"check access for not connected thing and channel": {
 			contentType: contentType,
 			chanID:      wrong,
@@ -233,7 +264,7 @@ func TestCanAccessByID(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("failed to create channel: %s", err))
 	ch := chs[0]
 
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, things.ConnectionACL{})
 	require.Nil(t, err, fmt.Sprintf("failed to connect thing and channel: %s", err))
 
 	data := toJSON(canAccessByIDReq{
@@ -303,9 +334,11 @@ type identifyReq struct {
 }
 
 type canAccessByKeyReq struct {
-	Token string `json:"token"`
+	Token  string `json:"token"`
+	Action string `json:"action,omitempty"`
 }
 
 type canAccessByIDReq struct {
 	ThingID string `json:"thing_id"`
+	Action  string `json:"action,omitempty"`
 }
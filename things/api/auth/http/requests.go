@@ -18,8 +18,10 @@ func (req identifyReq) validate() error {
 }
 
 type canAccessByKeyReq struct {
-	chanID string
-	Token  string `json:"token"`
+	chanID   string
+	Token    string `json:"token"`
+	Action   string `json:"action,omitempty"`
+	Subtopic string `json:"subtopic,omitempty"`
 }
 
 func (req canAccessByKeyReq) validate() error {
@@ -27,12 +29,14 @@ func (req canAccessByKeyReq) validate() error {
 		return things.ErrUnauthorizedAccess
 	}
 
-	return nil
+	return validateAction(req.Action)
 }
 
 type canAccessByIDReq struct {
-	chanID  string
-	ThingID string `json:"thing_id"`
+	chanID   string
+	ThingID  string `json:"thing_id"`
+	Action   string `json:"action,omitempty"`
+	Subtopic string `json:"subtopic,omitempty"`
 }
 
 func (req canAccessByIDReq) validate() error {
@@ -40,5 +44,14 @@ func (req canAccessByIDReq) validate() error {
 		return things.ErrUnauthorizedAccess
 	}
 
-	return nil
+	return validateAction(req.Action)
+}
+
+func validateAction(action string) error {
+	switch action {
+	case "", things.PublishAction, things.SubscribeAction:
+		return nil
+	default:
+		return things.ErrMalformedEntity
+	}
 }
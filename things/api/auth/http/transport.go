@@ -118,9 +118,11 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch err {
 	case things.ErrUnauthorizedAccess:
 		w.WriteHeader(http.StatusUnauthorized)
+	case things.ErrMalformedEntity:
+		w.WriteHeader(http.StatusBadRequest)
 	case things.ErrNotFound:
 		w.WriteHeader(http.StatusNotFound)
-	case things.ErrEntityConnected:
+	case things.ErrEntityConnected, things.ErrSubtopicDenied:
 		w.WriteHeader(http.StatusForbidden)
 
 	case errors.ErrUnsupportedContentType:
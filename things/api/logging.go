@@ -52,9 +52,9 @@ func (lm *loggingMiddleware) UpdateThing(ctx context.Context, token string, thin
 	return lm.svc.UpdateThing(ctx, token, thing)
 }
 
-func (lm *loggingMiddleware) UpdateKey(ctx context.Context, token, id, key string) (err error) {
+func (lm *loggingMiddleware) UpdateThingStatus(ctx context.Context, token, id, status string) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method update_key for thing %s and key %s took %s to complete", id, key, time.Since(begin))
+		message := fmt.Sprintf("Method update_thing_status for token %s, thing %s and status %s took %s to complete", token, id, status, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -62,7 +62,59 @@ func (lm *loggingMiddleware) UpdateKey(ctx context.Context, token, id, key strin
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.UpdateKey(ctx, token, id, key)
+	return lm.svc.UpdateThingStatus(ctx, token, id, status)
+}
+
+func (lm *loggingMiddleware) UpdateKey(ctx context.Context, token, id, key string, grace time.Duration) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_key for thing %s and key %s with grace period %s took %s to complete", id, key, grace, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateKey(ctx, token, id, key, grace)
+}
+
+func (lm *loggingMiddleware) AddKey(ctx context.Context, token, id string, key things.Key) (saved things.Key, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_key for thing %s and key %s took %s to complete", id, key.Name, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AddKey(ctx, token, id, key)
+}
+
+func (lm *loggingMiddleware) ListKeys(ctx context.Context, token, id string) (keys []things.Key, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_keys for token %s and thing %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListKeys(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) RevokeKey(ctx context.Context, token, id, name string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method revoke_key for thing %s and key %s took %s to complete", id, name, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RevokeKey(ctx, token, id, name)
 }
 
 func (lm *loggingMiddleware) ViewThing(ctx context.Context, token, id string) (thing things.Thing, err error) {
@@ -78,6 +130,19 @@ func (lm *loggingMiddleware) ViewThing(ctx context.Context, token, id string) (t
 	return lm.svc.ViewThing(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) ViewPresence(ctx context.Context, token, id string) (_ things.Presence, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_presence for token %s and thing %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewPresence(ctx, token, id)
+}
+
 func (lm *loggingMiddleware) ListThings(ctx context.Context, token string, pm things.PageMetadata) (_ things.Page, err error) {
 	defer func(begin time.Time) {
 		nlog := ""
@@ -146,6 +211,19 @@ func (lm *loggingMiddleware) UpdateChannel(ctx context.Context, token string, ch
 	return lm.svc.UpdateChannel(ctx, token, channel)
 }
 
+func (lm *loggingMiddleware) UpdateChannelStatus(ctx context.Context, token, id, status string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_channel_status for token %s, channel %s and status %s took %s to complete", token, id, status, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateChannelStatus(ctx, token, id, status)
+}
+
 func (lm *loggingMiddleware) ViewChannel(ctx context.Context, token, id string) (channel things.Channel, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method view_channel for token %s and channel %s took %s to complete", token, id, time.Since(begin))
@@ -201,9 +279,9 @@ func (lm *loggingMiddleware) RemoveChannel(ctx context.Context, token, id string
 	return lm.svc.RemoveChannel(ctx, token, id)
 }
 
-func (lm *loggingMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs []string) (err error) {
+func (lm *loggingMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs []string, acl things.ConnectionACL) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method connect for token %s, channels %s and things %s took %s to complete", token, chIDs, thIDs, time.Since(begin))
+		message := fmt.Sprintf("Method connect for token %s, channels %s, things %s and actions %s took %s to complete", token, chIDs, thIDs, acl.Actions, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -211,7 +289,7 @@ func (lm *loggingMiddleware) Connect(ctx context.Context, token string, chIDs, t
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Connect(ctx, token, chIDs, thIDs)
+	return lm.svc.Connect(ctx, token, chIDs, thIDs, acl)
 }
 
 func (lm *loggingMiddleware) Disconnect(ctx context.Context, token string, chIDs, thIDs []string) (err error) {
@@ -227,9 +305,9 @@ func (lm *loggingMiddleware) Disconnect(ctx context.Context, token string, chIDs
 	return lm.svc.Disconnect(ctx, token, chIDs, thIDs)
 }
 
-func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key string) (thing string, err error) {
+func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key, action, subtopic string) (thing string, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method can_access for channel %s and thing %s took %s to complete", id, thing, time.Since(begin))
+		message := fmt.Sprintf("Method can_access for channel %s, subtopic %s, thing %s and action %s took %s to complete", id, subtopic, thing, action, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -237,12 +315,12 @@ func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key string)
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.CanAccessByKey(ctx, id, key)
+	return lm.svc.CanAccessByKey(ctx, id, key, action, subtopic)
 }
 
-func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID string) (err error) {
+func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID, action, subtopic string) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method can_access_by_id for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
+		message := fmt.Sprintf("Method can_access_by_id for channel %s, subtopic %s, thing %s and action %s took %s to complete", chanID, subtopic, thingID, action, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -250,7 +328,7 @@ func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.CanAccessByID(ctx, chanID, thingID)
+	return lm.svc.CanAccessByID(ctx, chanID, thingID, action, subtopic)
 }
 
 func (lm *loggingMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) (err error) {
@@ -291,3 +369,94 @@ func (lm *loggingMiddleware) ListMembers(ctx context.Context, token, groupID str
 
 	return lm.svc.ListMembers(ctx, token, groupID, pm)
 }
+
+func (lm *loggingMiddleware) ShareThing(ctx context.Context, token, thingID string, actions, subjectIDs []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method share_thing for token %s and thing %s took %s to complete", token, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ShareThing(ctx, token, thingID, actions, subjectIDs)
+}
+
+func (lm *loggingMiddleware) UnshareThing(ctx context.Context, token, thingID string, actions, subjectIDs []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method unshare_thing for token %s and thing %s took %s to complete", token, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UnshareThing(ctx, token, thingID, actions, subjectIDs)
+}
+
+func (lm *loggingMiddleware) ShareChannel(ctx context.Context, token, chanID string, actions, subjectIDs []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method share_channel for token %s and channel %s took %s to complete", token, chanID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ShareChannel(ctx, token, chanID, actions, subjectIDs)
+}
+
+func (lm *loggingMiddleware) UnshareChannel(ctx context.Context, token, chanID string, actions, subjectIDs []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method unshare_channel for token %s and channel %s took %s to complete", token, chanID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UnshareChannel(ctx, token, chanID, actions, subjectIDs)
+}
+
+func (lm *loggingMiddleware) ThingConnectedHandler(ctx context.Context, thingID string, at time.Time) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method thing_connected_handler for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ThingConnectedHandler(ctx, thingID, at)
+}
+
+func (lm *loggingMiddleware) ThingDisconnectedHandler(ctx context.Context, thingID string, at time.Time) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method thing_disconnected_handler for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ThingDisconnectedHandler(ctx, thingID, at)
+}
+
+func (lm *loggingMiddleware) ThingPublishedHandler(ctx context.Context, thingID string, at time.Time) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method thing_published_handler for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ThingPublishedHandler(ctx, thingID, at)
+}
@@ -48,13 +48,49 @@ func (ms *metricsMiddleware) UpdateThing(ctx context.Context, token string, thin
 	return ms.svc.UpdateThing(ctx, token, thing)
 }
 
-func (ms *metricsMiddleware) UpdateKey(ctx context.Context, token, id, key string) error {
+func (ms *metricsMiddleware) UpdateThingStatus(ctx context.Context, token, id, status string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_thing_status").Add(1)
+		ms.latency.With("method", "update_thing_status").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateThingStatus(ctx, token, id, status)
+}
+
+func (ms *metricsMiddleware) UpdateKey(ctx context.Context, token, id, key string, grace time.Duration) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "update_key").Add(1)
 		ms.latency.With("method", "update_key").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.UpdateKey(ctx, token, id, key)
+	return ms.svc.UpdateKey(ctx, token, id, key, grace)
+}
+
+func (ms *metricsMiddleware) AddKey(ctx context.Context, token, id string, key things.Key) (things.Key, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_key").Add(1)
+		ms.latency.With("method", "add_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AddKey(ctx, token, id, key)
+}
+
+func (ms *metricsMiddleware) ListKeys(ctx context.Context, token, id string) ([]things.Key, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_keys").Add(1)
+		ms.latency.With("method", "list_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListKeys(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) RevokeKey(ctx context.Context, token, id, name string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_key").Add(1)
+		ms.latency.With("method", "revoke_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeKey(ctx, token, id, name)
 }
 
 func (ms *metricsMiddleware) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
@@ -66,6 +102,15 @@ func (ms *metricsMiddleware) ViewThing(ctx context.Context, token, id string) (t
 	return ms.svc.ViewThing(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) ViewPresence(ctx context.Context, token, id string) (things.Presence, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_presence").Add(1)
+		ms.latency.With("method", "view_presence").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewPresence(ctx, token, id)
+}
+
 func (ms *metricsMiddleware) ListThings(ctx context.Context, token string, pm things.PageMetadata) (things.Page, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_things").Add(1)
@@ -111,6 +156,15 @@ func (ms *metricsMiddleware) UpdateChannel(ctx context.Context, token string, ch
 	return ms.svc.UpdateChannel(ctx, token, channel)
 }
 
+func (ms *metricsMiddleware) UpdateChannelStatus(ctx context.Context, token, id, status string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_channel_status").Add(1)
+		ms.latency.With("method", "update_channel_status").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateChannelStatus(ctx, token, id, status)
+}
+
 func (ms *metricsMiddleware) ViewChannel(ctx context.Context, token, id string) (things.Channel, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_channel").Add(1)
@@ -147,13 +201,13 @@ func (ms *metricsMiddleware) RemoveChannel(ctx context.Context, token, id string
 	return ms.svc.RemoveChannel(ctx, token, id)
 }
 
-func (ms *metricsMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
+func (ms *metricsMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs []string, acl things.ConnectionACL) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "connect").Add(1)
 		ms.latency.With("method", "connect").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Connect(ctx, token, chIDs, thIDs)
+	return ms.svc.Connect(ctx, token, chIDs, thIDs, acl)
 }
 
 func (ms *metricsMiddleware) Disconnect(ctx context.Context, token string, chIDs, thIDs []string) error {
@@ -165,22 +219,22 @@ func (ms *metricsMiddleware) Disconnect(ctx context.Context, token string, chIDs
 	return ms.svc.Disconnect(ctx, token, chIDs, thIDs)
 }
 
-func (ms *metricsMiddleware) CanAccessByKey(ctx context.Context, id, key string) (string, error) {
+func (ms *metricsMiddleware) CanAccessByKey(ctx context.Context, id, key, action, subtopic string) (string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "can_access_by_key").Add(1)
 		ms.latency.With("method", "can_access_by_key").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.CanAccessByKey(ctx, id, key)
+	return ms.svc.CanAccessByKey(ctx, id, key, action, subtopic)
 }
 
-func (ms *metricsMiddleware) CanAccessByID(ctx context.Context, chanID, thingID string) error {
+func (ms *metricsMiddleware) CanAccessByID(ctx context.Context, chanID, thingID, action, subtopic string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "can_access_by_id").Add(1)
 		ms.latency.With("method", "can_access_by_id").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.CanAccessByID(ctx, chanID, thingID)
+	return ms.svc.CanAccessByID(ctx, chanID, thingID, action, subtopic)
 }
 
 func (ms *metricsMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) error {
@@ -209,3 +263,66 @@ func (ms *metricsMiddleware) ListMembers(ctx context.Context, token, groupID str
 
 	return ms.svc.ListMembers(ctx, token, groupID, pm)
 }
+
+func (ms *metricsMiddleware) ShareThing(ctx context.Context, token, thingID string, actions, subjectIDs []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "share_thing").Add(1)
+		ms.latency.With("method", "share_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ShareThing(ctx, token, thingID, actions, subjectIDs)
+}
+
+func (ms *metricsMiddleware) UnshareThing(ctx context.Context, token, thingID string, actions, subjectIDs []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unshare_thing").Add(1)
+		ms.latency.With("method", "unshare_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UnshareThing(ctx, token, thingID, actions, subjectIDs)
+}
+
+func (ms *metricsMiddleware) ShareChannel(ctx context.Context, token, chanID string, actions, subjectIDs []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "share_channel").Add(1)
+		ms.latency.With("method", "share_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ShareChannel(ctx, token, chanID, actions, subjectIDs)
+}
+
+func (ms *metricsMiddleware) UnshareChannel(ctx context.Context, token, chanID string, actions, subjectIDs []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unshare_channel").Add(1)
+		ms.latency.With("method", "unshare_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UnshareChannel(ctx, token, chanID, actions, subjectIDs)
+}
+
+func (ms *metricsMiddleware) ThingConnectedHandler(ctx context.Context, thingID string, at time.Time) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "thing_connected_handler").Add(1)
+		ms.latency.With("method", "thing_connected_handler").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ThingConnectedHandler(ctx, thingID, at)
+}
+
+func (ms *metricsMiddleware) ThingDisconnectedHandler(ctx context.Context, thingID string, at time.Time) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "thing_disconnected_handler").Add(1)
+		ms.latency.With("method", "thing_disconnected_handler").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ThingDisconnectedHandler(ctx, thingID, at)
+}
+
+func (ms *metricsMiddleware) ThingPublishedHandler(ctx context.Context, thingID string, at time.Time) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "thing_published_handler").Add(1)
+		ms.latency.With("method", "thing_published_handler").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ThingPublishedHandler(ctx, thingID, at)
+}
@@ -5,6 +5,7 @@ package http
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/mainflux/mainflux/auth"
@@ -112,7 +113,98 @@ func updateKeyEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.UpdateKey(ctx, req.token, req.id, req.Key); err != nil {
+		if err := svc.UpdateKey(ctx, req.token, req.id, req.Key, time.Duration(req.Grace)); err != nil {
+			return nil, err
+		}
+
+		res := thingRes{ID: req.id, created: false}
+		return res, nil
+	}
+}
+
+func addKeyEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(addKeyReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		key := things.Key{
+			Name:      req.Name,
+			Value:     req.Key,
+			ExpiresAt: req.ExpiresAt,
+		}
+		saved, err := svc.AddKey(ctx, req.token, req.id, key)
+		if err != nil {
+			return nil, err
+		}
+
+		res := toKeyRes(saved)
+		res.created = true
+		return res, nil
+	}
+}
+
+func listKeysEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		keys, err := svc.ListKeys(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := keysRes{Keys: []keyRes{}}
+		for _, k := range keys {
+			res.Keys = append(res.Keys, toKeyRes(k))
+		}
+		return res, nil
+	}
+}
+
+func revokeKeyEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(keyReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeKey(ctx, req.token, req.id, req.name); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func toKeyRes(k things.Key) keyRes {
+	res := keyRes{
+		thingID: k.ThingID,
+		Name:    k.Name,
+		Key:     k.Value,
+	}
+	if !k.ExpiresAt.IsZero() {
+		res.ExpiresAt = &k.ExpiresAt
+	}
+
+	return res
+}
+
+func updateThingStatusEndpoint(svc things.Service, status string) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UpdateThingStatus(ctx, req.token, req.id, status); err != nil {
 			return nil, err
 		}
 
@@ -140,6 +232,36 @@ func viewThingEndpoint(svc things.Service) endpoint.Endpoint {
 			Name:     thing.Name,
 			Key:      thing.Key,
 			Metadata: thing.Metadata,
+			Status:   thing.Status,
+		}
+		return res, nil
+	}
+}
+
+func viewPresenceEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		p, err := svc.ViewPresence(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := presenceRes{
+			Online: p.Online,
+		}
+		if !p.ConnectedAt.IsZero() {
+			res.ConnectedAt = &p.ConnectedAt
+		}
+		if !p.DisconnectedAt.IsZero() {
+			res.DisconnectedAt = &p.DisconnectedAt
+		}
+		if !p.LastSeen.IsZero() {
+			res.LastSeen = &p.LastSeen
 		}
 		return res, nil
 	}
@@ -160,11 +282,12 @@ func listThingsEndpoint(svc things.Service) endpoint.Endpoint {
 
 		res := thingsPageRes{
 			pageRes: pageRes{
-				Total:  page.Total,
-				Offset: page.Offset,
-				Limit:  page.Limit,
-				Order:  page.Order,
-				Dir:    page.Dir,
+				Total:      page.Total,
+				Offset:     page.Offset,
+				Limit:      page.Limit,
+				Order:      page.Order,
+				Dir:        page.Dir,
+				NextCursor: page.NextCursor,
 			},
 			Things: []viewThingRes{},
 		}
@@ -175,6 +298,7 @@ func listThingsEndpoint(svc things.Service) endpoint.Endpoint {
 				Name:     thing.Name,
 				Key:      thing.Key,
 				Metadata: thing.Metadata,
+				Status:   thing.Status,
 			}
 			res.Things = append(res.Things, view)
 		}
@@ -211,6 +335,7 @@ func listThingsByChannelEndpoint(svc things.Service) endpoint.Endpoint {
 				Key:      thing.Key,
 				Name:     thing.Name,
 				Metadata: thing.Metadata,
+				Status:   thing.Status,
 			}
 			res.Things = append(res.Things, view)
 		}
@@ -327,6 +452,23 @@ func updateChannelEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func updateChannelStatusEndpoint(svc things.Service, status string) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UpdateChannelStatus(ctx, req.token, req.id, status); err != nil {
+			return nil, err
+		}
+
+		res := channelRes{ID: req.id, created: false}
+		return res, nil
+	}
+}
+
 func viewChannelEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(viewResourceReq)
@@ -345,6 +487,7 @@ func viewChannelEndpoint(svc things.Service) endpoint.Endpoint {
 			Owner:    channel.Owner,
 			Name:     channel.Name,
 			Metadata: channel.Metadata,
+			Status:   channel.Status,
 		}
 
 		return res, nil
@@ -366,11 +509,12 @@ func listChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 
 		res := channelsPageRes{
 			pageRes: pageRes{
-				Total:  page.Total,
-				Offset: page.Offset,
-				Limit:  page.Limit,
-				Order:  page.Order,
-				Dir:    page.Dir,
+				Total:      page.Total,
+				Offset:     page.Offset,
+				Limit:      page.Limit,
+				Order:      page.Order,
+				Dir:        page.Dir,
+				NextCursor: page.NextCursor,
 			},
 			Channels: []viewChannelRes{},
 		}
@@ -381,6 +525,7 @@ func listChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 				Owner:    channel.Owner,
 				Name:     channel.Name,
 				Metadata: channel.Metadata,
+				Status:   channel.Status,
 			}
 
 			res.Channels = append(res.Channels, view)
@@ -417,6 +562,7 @@ func listChannelsByThingEndpoint(svc things.Service) endpoint.Endpoint {
 				Owner:    channel.Owner,
 				Name:     channel.Name,
 				Metadata: channel.Metadata,
+				Status:   channel.Status,
 			}
 			res.Channels = append(res.Channels, view)
 		}
@@ -452,7 +598,7 @@ func connectThingEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.Connect(ctx, cr.token, []string{cr.chanID}, []string{cr.thingID}); err != nil {
+		if err := svc.Connect(ctx, cr.token, []string{cr.chanID}, []string{cr.thingID}, things.ConnectionACL{}); err != nil {
 			return nil, err
 		}
 
@@ -468,7 +614,12 @@ func connectEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.Connect(ctx, cr.token, cr.ChannelIDs, cr.ThingIDs); err != nil {
+		acl := things.ConnectionACL{
+			Actions: cr.Actions,
+			Allow:   cr.Allow,
+			Deny:    cr.Deny,
+		}
+		if err := svc.Connect(ctx, cr.token, cr.ChannelIDs, cr.ThingIDs, acl); err != nil {
 			return nil, err
 		}
 
@@ -491,6 +642,66 @@ func disconnectEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func shareThingEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ShareThing(ctx, req.token, req.id, req.Actions, req.Subjects); err != nil {
+			return nil, err
+		}
+
+		return shareRes{}, nil
+	}
+}
+
+func unshareThingEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UnshareThing(ctx, req.token, req.id, req.Actions, req.Subjects); err != nil {
+			return nil, err
+		}
+
+		return unshareRes{}, nil
+	}
+}
+
+func shareChannelEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ShareChannel(ctx, req.token, req.id, req.Actions, req.Subjects); err != nil {
+			return nil, err
+		}
+
+		return shareRes{}, nil
+	}
+}
+
+func unshareChannelEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UnshareChannel(ctx, req.token, req.id, req.Actions, req.Subjects); err != nil {
+			return nil, err
+		}
+
+		return unshareRes{}, nil
+	}
+}
+
 func disconnectThingEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(connectThingReq)
@@ -538,6 +749,7 @@ func buildThingsResponse(up things.Page) thingsPageRes {
 			Key:      th.Key,
 			Owner:    th.Owner,
 			Metadata: th.Metadata,
+			Status:   th.Status,
 		}
 		res.Things = append(res.Things, view)
 	}
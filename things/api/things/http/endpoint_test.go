@@ -85,9 +85,10 @@ func newService(tokens map[string]string) things.Service {
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
+	presenceRepo := mocks.NewPresenceRepository()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, presenceRepo, idProvider)
 }
 
 func newServer(svc things.Service) *httptest.Server {
@@ -438,6 +439,72 @@ func TestUpdateThing(t *testing.T) {
 	}
 }
 
+func TestUpdateThingStatus(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	cases := []struct {
+		desc   string
+		id     string
+		action string
+		auth   string
+		status int
+	}{
+		{
+			desc:   "disable existing thing",
+			id:     th.ID,
+			action: "disable",
+			auth:   token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "enable disabled thing",
+			id:     th.ID,
+			action: "enable",
+			auth:   token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "disable non-existent thing",
+			id:     strconv.FormatUint(wrongID, 10),
+			action: "disable",
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "enable thing with invalid token",
+			id:     th.ID,
+			action: "enable",
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "disable thing with empty token",
+			id:     th.ID,
+			action: "disable",
+			auth:   "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/things/%s/%s", ts.URL, tc.id, tc.action),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestUpdateKey(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ts := newServer(svc)
@@ -455,6 +522,11 @@ func TestUpdateKey(t *testing.T) {
 	th.Key = "key"
 	dummyData := toJSON(th)
 
+	graceData := toJSON(map[string]interface{}{"key": "newer-key", "grace": 3600})
+	graceStrData := toJSON(map[string]interface{}{"key": "newest-key", "grace": "1h30m"})
+	negativeGraceData := toJSON(map[string]interface{}{"key": "negative-key", "grace": -3600})
+	invalidGraceData := toJSON(map[string]interface{}{"key": "invalid-key", "grace": "an hour"})
+
 	cases := []struct {
 		desc        string
 		req         string
@@ -479,6 +551,38 @@ func TestUpdateKey(t *testing.T) {
 			auth:        token,
 			status:      http.StatusConflict,
 		},
+		{
+			desc:        "update key with grace period",
+			req:         graceData,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update key with grace period duration string",
+			req:         graceStrData,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update key with invalid grace period",
+			req:         invalidGraceData,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update key with negative grace period",
+			req:         negativeGraceData,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
 		{
 			desc:        "update key with empty JSON request",
 			req:         "{}",
@@ -560,6 +664,268 @@ func TestUpdateKey(t *testing.T) {
 	}
 }
 
+func TestAddKey(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Round(time.Second)
+	data := toJSON(keyReq{Name: "named", Key: "named-key", ExpiresAt: &expiresAt})
+	generatedData := toJSON(keyReq{Name: "generated"})
+	expired := time.Now().Add(-time.Hour)
+	expiredData := toJSON(keyReq{Name: "expired", ExpiresAt: &expired})
+
+	cases := []struct {
+		desc        string
+		req         string
+		id          string
+		contentType string
+		auth        string
+		status      int
+		location    string
+		res         keyRes
+	}{
+		{
+			desc:        "add key with provided value",
+			req:         data,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/things/%s/keys/named", th.ID),
+			res:         keyRes{Name: "named", Key: "named-key", ExpiresAt: &expiresAt},
+		},
+		{
+			desc:        "add key with generated value",
+			req:         generatedData,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/things/%s/keys/generated", th.ID),
+			res:         keyRes{Name: "generated", Key: fmt.Sprintf("%s%012d", uuid.Prefix, 3)},
+		},
+		{
+			desc:        "add key with existing name",
+			req:         data,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusConflict,
+		},
+		{
+			desc:        "add expired key",
+			req:         expiredData,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add key without name",
+			req:         "{}",
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add key to non-existent thing",
+			req:         generatedData,
+			id:          strconv.FormatUint(wrongID, 10),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "add key with invalid user token",
+			req:         generatedData,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "add key with invalid data format",
+			req:         "{",
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add key without content type",
+			req:         generatedData,
+			id:          th.ID,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/%s/keys", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		location := res.Header.Get("Location")
+		assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, location))
+
+		if tc.status != http.StatusCreated {
+			continue
+		}
+		var body keyRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body))
+	}
+}
+
+func TestListKeys(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	keys := []keyRes{}
+	for _, name := range []string{"a", "b"} {
+		k, err := svc.AddKey(context.Background(), token, th.ID, things.Key{Name: name})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		keys = append(keys, keyRes{Name: k.Name, Key: k.Value})
+	}
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		status int
+		res    []keyRes
+	}{
+		{
+			desc:   "list keys of existing thing",
+			id:     th.ID,
+			auth:   token,
+			status: http.StatusOK,
+			res:    keys,
+		},
+		{
+			desc:   "list keys of non-existent thing",
+			id:     strconv.FormatUint(wrongID, 10),
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "list keys with invalid user token",
+			id:     th.ID,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list keys with empty user token",
+			id:     th.ID,
+			auth:   "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/%s/keys", ts.URL, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body keysRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.ElementsMatch(t, tc.res, body.Keys, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body.Keys))
+	}
+}
+
+func TestRevokeKey(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	k, err := svc.AddKey(context.Background(), token, th.ID, things.Key{Name: "named"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		name   string
+		auth   string
+		status int
+	}{
+		{
+			desc:   "revoke key with invalid user token",
+			id:     th.ID,
+			name:   k.Name,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "revoke key of non-existent thing",
+			id:     strconv.FormatUint(wrongID, 10),
+			name:   k.Name,
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "revoke existing key",
+			id:     th.ID,
+			name:   k.Name,
+			auth:   token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "revoke non-existent key",
+			id:     th.ID,
+			name:   k.Name,
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/things/%s/keys/%s", ts.URL, tc.id, tc.name),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestViewThing(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ts := newServer(svc)
@@ -574,6 +940,7 @@ func TestViewThing(t *testing.T) {
 		Name:     th.Name,
 		Key:      th.Key,
 		Metadata: th.Metadata,
+		Status:   th.Status,
 	})
 
 	cases := []struct {
@@ -637,6 +1004,80 @@ func TestViewThing(t *testing.T) {
 	}
 }
 
+func TestViewPresence(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	connected := time.Now().UTC().Round(time.Second)
+	err = svc.ThingConnectedHandler(context.Background(), ths[0].ID, connected)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		status int
+		res    presenceRes
+	}{
+		{
+			desc:   "view presence of connected thing",
+			id:     ths[0].ID,
+			auth:   token,
+			status: http.StatusOK,
+			res:    presenceRes{Online: true, ConnectedAt: &connected, LastSeen: &connected},
+		},
+		{
+			desc:   "view presence of thing that has never been seen",
+			id:     ths[1].ID,
+			auth:   token,
+			status: http.StatusOK,
+			res:    presenceRes{Online: false},
+		},
+		{
+			desc:   "view presence of non-existent thing",
+			id:     strconv.FormatUint(wrongID, 10),
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view presence with invalid user token",
+			id:     ths[0].ID,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view presence with empty user token",
+			id:     ths[0].ID,
+			auth:   "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/%s/status", ts.URL, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body presenceRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body))
+	}
+}
+
 func TestListThings(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ts := newServer(svc)
@@ -652,8 +1093,11 @@ func TestListThings(t *testing.T) {
 			Name:     th.Name,
 			Key:      th.Key,
 			Metadata: th.Metadata,
+			Status:   th.Status,
 		})
 	}
+	err := svc.ThingConnectedHandler(context.Background(), data[0].ID, time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	thingURL := fmt.Sprintf("%s/things", ts.URL)
 	cases := []struct {
@@ -662,6 +1106,7 @@ func TestListThings(t *testing.T) {
 		status int
 		url    string
 		res    []thingRes
+		next   string
 	}{
 		{
 			desc:   "get a list of things",
@@ -793,35 +1238,99 @@ func TestListThings(t *testing.T) {
 			desc:   "get a list of things filtering with invalid name",
 			auth:   token,
 			status: http.StatusBadRequest,
-			url:    fmt.Sprintf("%s?offset=%d&limit=%d&name=%s", thingURL, 0, 5, invalidName),
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&name=%s", thingURL, 0, 5, invalidName),
+			res:    nil,
+		},
+		{
+			desc:   "get a list of things sorted by name ascendent",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=%s&dir=%s", thingURL, 0, 5, nameKey, ascKey),
+			res:    data[0:5],
+		},
+		{
+			desc:   "get a list of things sorted by name descendent",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=%s&dir=%s", thingURL, 0, 5, nameKey, descKey),
+			res:    data[0:5],
+		},
+		{
+			desc:   "get a list of things sorted with invalid order",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=%s&dir=%s", thingURL, 0, 5, "wrong", descKey),
+			res:    nil,
+		},
+		{
+			desc:   "get a list of things sorted by name with invalid direction",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=%s&dir=%s", thingURL, 0, 5, nameKey, "wrong"),
+			res:    nil,
+		},
+		{
+			desc:   "get a list of enabled things",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&status=%s", thingURL, 0, 5, things.EnabledStatus),
+			res:    data[0:5],
+		},
+		{
+			desc:   "get a list of disabled things",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&status=%s", thingURL, 0, 5, things.DisabledStatus),
+			res:    []thingRes{},
+		},
+		{
+			desc:   "get a list of things with invalid status",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&status=%s", thingURL, 0, 5, "wrong"),
+			res:    nil,
+		},
+		{
+			desc:   "get a list of online things",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&presence=%s", thingURL, 0, 5, things.OnlinePresence),
+			res:    data[0:1],
+		},
+		{
+			desc:   "get a list of things with invalid presence",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&presence=%s", thingURL, 0, 5, "wrong"),
 			res:    nil,
 		},
 		{
-			desc:   "get a list of things sorted by name ascendent",
+			desc:   "get a list of things with cursor",
 			auth:   token,
 			status: http.StatusOK,
-			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=%s&dir=%s", thingURL, 0, 5, nameKey, ascKey),
-			res:    data[0:5],
+			url:    fmt.Sprintf("%s?limit=%d&cursor=%s", thingURL, 5, things.EncodeCursor(data[4].ID)),
+			res:    data[5:10],
+			next:   things.EncodeCursor(data[9].ID),
 		},
 		{
-			desc:   "get a list of things sorted by name descendent",
+			desc:   "get a list of things with invalid cursor",
 			auth:   token,
-			status: http.StatusOK,
-			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=%s&dir=%s", thingURL, 0, 5, nameKey, descKey),
-			res:    data[0:5],
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?limit=%d&cursor=%s", thingURL, 5, "!"),
+			res:    nil,
 		},
 		{
-			desc:   "get a list of things sorted with invalid order",
+			desc:   "get a list of things with cursor and offset",
 			auth:   token,
 			status: http.StatusBadRequest,
-			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=%s&dir=%s", thingURL, 0, 5, "wrong", descKey),
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&cursor=%s", thingURL, 5, 5, things.EncodeCursor(data[4].ID)),
 			res:    nil,
 		},
 		{
-			desc:   "get a list of things sorted by name with invalid direction",
+			desc:   "get a list of things with cursor ordered by name",
 			auth:   token,
 			status: http.StatusBadRequest,
-			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=%s&dir=%s", thingURL, 0, 5, nameKey, "wrong"),
+			url:    fmt.Sprintf("%s?limit=%d&order=name&cursor=%s", thingURL, 5, things.EncodeCursor(data[4].ID)),
 			res:    nil,
 		},
 	}
@@ -839,6 +1348,9 @@ func TestListThings(t *testing.T) {
 		json.NewDecoder(res.Body).Decode(&data)
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 		assert.ElementsMatch(t, tc.res, data.Things, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, data.Things))
+		if tc.next != "" {
+			assert.Equal(t, tc.next, data.NextCursor, fmt.Sprintf("%s: expected next cursor %s got %s", tc.desc, tc.next, data.NextCursor))
+		}
 	}
 }
 
@@ -889,6 +1401,7 @@ func TestSearchThings(t *testing.T) {
 			Name:     th.Name,
 			Key:      th.Key,
 			Metadata: th.Metadata,
+			Status:   th.Status,
 		})
 	}
 
@@ -1051,7 +1564,7 @@ func TestListThingsByChannel(t *testing.T) {
 		ths, err := svc.CreateThings(context.Background(), token, thing)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		th := ths[0]
-		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, things.ConnectionACL{})
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 		data = append(data, thingRes{
@@ -1059,6 +1572,7 @@ func TestListThingsByChannel(t *testing.T) {
 			Name:     th.Name,
 			Key:      th.Key,
 			Metadata: th.Metadata,
+			Status:   th.Status,
 		})
 	}
 	thingURL := fmt.Sprintf("%s/channels", ts.URL)
@@ -1593,6 +2107,72 @@ func TestUpdateChannel(t *testing.T) {
 	}
 }
 
+func TestUpdateChannelStatus(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	cases := []struct {
+		desc   string
+		id     string
+		action string
+		auth   string
+		status int
+	}{
+		{
+			desc:   "disable existing channel",
+			id:     ch.ID,
+			action: "disable",
+			auth:   token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "enable disabled channel",
+			id:     ch.ID,
+			action: "enable",
+			auth:   token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "disable non-existent channel",
+			id:     strconv.FormatUint(wrongID, 10),
+			action: "disable",
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "enable channel with invalid token",
+			id:     ch.ID,
+			action: "enable",
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "disable channel with empty token",
+			id:     ch.ID,
+			action: "disable",
+			auth:   "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/channels/%s/%s", ts.URL, tc.id, tc.action),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestViewChannel(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ts := newServer(svc)
@@ -1605,12 +2185,13 @@ func TestViewChannel(t *testing.T) {
 	ths, err := svc.CreateThings(context.Background(), token, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th := ths[0]
-	svc.Connect(context.Background(), token, []string{sch.ID}, []string{th.ID})
+	svc.Connect(context.Background(), token, []string{sch.ID}, []string{th.ID}, things.ConnectionACL{})
 
 	data := toJSON(channelRes{
 		ID:       sch.ID,
 		Name:     sch.Name,
 		Metadata: sch.Metadata,
+		Status:   sch.Status,
 	})
 
 	cases := []struct {
@@ -1692,12 +2273,13 @@ func TestListChannels(t *testing.T) {
 		ths, err := svc.CreateThings(context.Background(), token, thing)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		th := ths[0]
-		svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+		svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, things.ConnectionACL{})
 
 		channels = append(channels, channelRes{
 			ID:       ch.ID,
 			Name:     ch.Name,
 			Metadata: ch.Metadata,
+			Status:   ch.Status,
 		})
 	}
 	channelURL := fmt.Sprintf("%s/channels", ts.URL)
@@ -1708,6 +2290,7 @@ func TestListChannels(t *testing.T) {
 		status int
 		url    string
 		res    []channelRes
+		next   string
 	}{
 		{
 			desc:   "get a list of channels",
@@ -1870,6 +2453,21 @@ func TestListChannels(t *testing.T) {
 			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=%s&dir=%s", channelURL, 0, 6, nameKey, "wrong"),
 			res:    nil,
 		},
+		{
+			desc:   "get a list of channels with cursor",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s?limit=%d&cursor=%s", channelURL, 6, things.EncodeCursor(channels[5].ID)),
+			res:    channels[6:12],
+			next:   things.EncodeCursor(channels[11].ID),
+		},
+		{
+			desc:   "get a list of channels with cursor and offset",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&cursor=%s", channelURL, 6, 6, things.EncodeCursor(channels[5].ID)),
+			res:    nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1885,6 +2483,9 @@ func TestListChannels(t *testing.T) {
 		json.NewDecoder(res.Body).Decode(&body)
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 		assert.ElementsMatch(t, tc.res, body.Channels, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body.Channels))
+		if tc.next != "" {
+			assert.Equal(t, tc.next, body.NextCursor, fmt.Sprintf("%s: expected next cursor %s got %s", tc.desc, tc.next, body.NextCursor))
+		}
 	}
 }
 
@@ -1902,13 +2503,14 @@ func TestListChannelsByThing(t *testing.T) {
 		chs, err := svc.CreateChannels(context.Background(), token, channel)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		ch := chs[0]
-		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, things.ConnectionACL{})
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 		channels = append(channels, channelRes{
 			ID:       ch.ID,
 			Name:     ch.Name,
 			Metadata: ch.Metadata,
+			Status:   ch.Status,
 		})
 	}
 	channelURL := fmt.Sprintf("%s/things", ts.URL)
@@ -2252,6 +2854,9 @@ func TestCreateConnections(t *testing.T) {
 		desc        string
 		channelIDs  []string
 		thingIDs    []string
+		actions     []string
+		allow       []string
+		deny        []string
 		auth        string
 		contentType string
 		body        string
@@ -2265,6 +2870,43 @@ func TestCreateConnections(t *testing.T) {
 			contentType: contentType,
 			status:      http.StatusOK,
 		},
+		{
+			desc:        "connect existing things to existing channels with publish action",
+			channelIDs:  chIDs1,
+			thingIDs:    thIDs,
+			actions:     []string{things.PublishAction},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "connect existing things to existing channels with invalid action",
+			channelIDs:  chIDs1,
+			thingIDs:    thIDs,
+			actions:     []string{"invalid"},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "connect existing things to existing channels with subtopic patterns",
+			channelIDs:  chIDs1,
+			thingIDs:    thIDs,
+			allow:       []string{"site.*.>"},
+			deny:        []string{"site.admin.>"},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "connect existing things to existing channels with invalid subtopic pattern",
+			channelIDs:  chIDs1,
+			thingIDs:    thIDs,
+			allow:       []string{"site.>.temp"},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
 		{
 			desc:        "connect existing things to non-existent channels",
 			channelIDs:  []string{strconv.FormatUint(wrongID, 10)},
@@ -2382,9 +3024,15 @@ func TestCreateConnections(t *testing.T) {
 		data := struct {
 			ChannelIDs []string `json:"channel_ids"`
 			ThingIDs   []string `json:"thing_ids"`
+			Actions    []string `json:"actions,omitempty"`
+			Allow      []string `json:"allow,omitempty"`
+			Deny       []string `json:"deny,omitempty"`
 		}{
 			tc.channelIDs,
 			tc.thingIDs,
+			tc.actions,
+			tc.allow,
+			tc.deny,
 		}
 		body := toJSON(data)
 
@@ -2438,7 +3086,7 @@ func TestDisconnectList(t *testing.T) {
 		chIDs2 = append(chIDs2, ch.ID)
 	}
 
-	err = svc.Connect(context.Background(), token, chIDs1, thIDs)
+	err = svc.Connect(context.Background(), token, chIDs1, thIDs, things.ConnectionACL{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := []struct {
@@ -2613,7 +3261,7 @@ func TestDisconnnect(t *testing.T) {
 	th1 := ths[0]
 	chs, _ := svc.CreateChannels(context.Background(), token, channel)
 	ch1 := chs[0]
-	svc.Connect(context.Background(), token, []string{ch1.ID}, []string{th1.ID})
+	svc.Connect(context.Background(), token, []string{ch1.ID}, []string{th1.ID}, things.ConnectionACL{})
 	chs, _ = svc.CreateChannels(context.Background(), otherToken, channel)
 	ch2 := chs[0]
 
@@ -2707,28 +3355,265 @@ type thingRes struct {
 	Name     string                 `json:"name,omitempty"`
 	Key      string                 `json:"key"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Status   string                 `json:"status,omitempty"`
 }
 
 type channelRes struct {
 	ID       string                 `json:"id"`
 	Name     string                 `json:"name,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Status   string                 `json:"status,omitempty"`
 }
 
 type thingsPageRes struct {
-	Things []thingRes `json:"things"`
-	Total  uint64     `json:"total"`
-	Offset uint64     `json:"offset"`
-	Limit  uint64     `json:"limit"`
+	Things     []thingRes `json:"things"`
+	Total      uint64     `json:"total"`
+	Offset     uint64     `json:"offset"`
+	Limit      uint64     `json:"limit"`
+	NextCursor string     `json:"next_cursor"`
 }
 
 type channelsPageRes struct {
-	Channels []channelRes `json:"channels"`
-	Total    uint64       `json:"total"`
-	Offset   uint64       `json:"offset"`
-	Limit    uint64       `json:"limit"`
+	Channels   []channelRes `json:"channels"`
+	Total      uint64       `json:"total"`
+	Offset     uint64       `json:"offset"`
+	Limit      uint64       `json:"limit"`
+	NextCursor string       `json:"next_cursor"`
 }
 
 type errorRes struct {
 	Err string `json:"error"`
 }
+
+type keyReq struct {
+	Name      string     `json:"name"`
+	Key       string     `json:"key,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type keyRes struct {
+	Name      string     `json:"name"`
+	Key       string     `json:"key"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type keysRes struct {
+	Keys []keyRes `json:"keys"`
+}
+
+type presenceRes struct {
+	Online         bool       `json:"online"`
+	ConnectedAt    *time.Time `json:"connected_at,omitempty"`
+	DisconnectedAt *time.Time `json:"disconnected_at,omitempty"`
+	LastSeen       *time.Time `json:"last_seen,omitempty"`
+}
+
+func TestShareThing(t *testing.T) {
+	otherEmail := "other_user@example.com"
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	cases := []struct {
+		desc        string
+		id          string
+		actions     []string
+		subjects    []string
+		auth        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{
+			desc:        "share existing thing",
+			id:          th.ID,
+			actions:     []string{things.ReadAction, things.WriteAction},
+			subjects:    []string{otherEmail},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "share non-existent thing",
+			id:          strconv.FormatUint(wrongID, 10),
+			actions:     []string{things.ReadAction},
+			subjects:    []string{otherEmail},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "share thing with invalid action",
+			id:          th.ID,
+			actions:     []string{wrongValue},
+			subjects:    []string{otherEmail},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "share thing with empty actions",
+			id:          th.ID,
+			actions:     []string{},
+			subjects:    []string{otherEmail},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "share thing with empty subjects",
+			id:          th.ID,
+			actions:     []string{things.ReadAction},
+			subjects:    []string{},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "share thing with invalid token",
+			id:          th.ID,
+			actions:     []string{things.ReadAction},
+			subjects:    []string{otherEmail},
+			auth:        wrongValue,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "share thing with empty token",
+			id:          th.ID,
+			actions:     []string{things.ReadAction},
+			subjects:    []string{otherEmail},
+			auth:        "",
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "share thing with invalid content type",
+			id:          th.ID,
+			actions:     []string{things.ReadAction},
+			subjects:    []string{otherEmail},
+			auth:        token,
+			contentType: "invalid",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "share thing with invalid JSON",
+			id:          th.ID,
+			auth:        token,
+			contentType: contentType,
+			body:        "{",
+			status:      http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		data := struct {
+			Actions  []string `json:"actions"`
+			Subjects []string `json:"subjects"`
+		}{
+			tc.actions,
+			tc.subjects,
+		}
+		body := toJSON(data)
+
+		if tc.body != "" {
+			body = tc.body
+		}
+
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/%s/share", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(body),
+		}
+
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestUnshareChannel(t *testing.T) {
+	otherEmail := "other_user@example.com"
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	err = svc.ShareChannel(context.Background(), token, ch.ID, []string{things.ReadAction}, []string{otherEmail})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc     string
+		id       string
+		actions  []string
+		subjects []string
+		auth     string
+		status   int
+	}{
+		{
+			desc:     "unshare existing channel",
+			id:       ch.ID,
+			actions:  []string{things.ReadAction},
+			subjects: []string{otherEmail},
+			auth:     token,
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "unshare non-existent channel",
+			id:       strconv.FormatUint(wrongID, 10),
+			actions:  []string{things.ReadAction},
+			subjects: []string{otherEmail},
+			auth:     token,
+			status:   http.StatusNotFound,
+		},
+		{
+			desc:     "unshare channel with invalid action",
+			id:       ch.ID,
+			actions:  []string{wrongValue},
+			subjects: []string{otherEmail},
+			auth:     token,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "unshare channel with invalid token",
+			id:       ch.ID,
+			actions:  []string{things.ReadAction},
+			subjects: []string{otherEmail},
+			auth:     wrongValue,
+			status:   http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		data := struct {
+			Actions  []string `json:"actions"`
+			Subjects []string `json:"subjects"`
+		}{
+			tc.actions,
+			tc.subjects,
+		}
+
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/channels/%s/unshare", ts.URL, tc.id),
+			contentType: contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(toJSON(data)),
+		}
+
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
@@ -4,7 +4,11 @@
 package http
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/things"
 )
 
@@ -17,6 +21,34 @@ const (
 	descDir      = "desc"
 )
 
+var errInvalidDuration = errors.New("duration must be a number of seconds or a duration string")
+
+// duration is decoded from JSON either as a number of seconds or as a
+// duration string, such as "1h30m".
+type duration time.Duration
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	switch val := v.(type) {
+	case float64:
+		*d = duration(val * float64(time.Second))
+	case string:
+		dur, err := time.ParseDuration(val)
+		if err != nil {
+			return errors.Wrap(errInvalidDuration, err)
+		}
+		*d = duration(dur)
+	default:
+		return errInvalidDuration
+	}
+
+	return nil
+}
+
 type createThingReq struct {
 	token    string
 	Name     string                 `json:"name,omitempty"`
@@ -85,7 +117,8 @@ func (req updateThingReq) validate() error {
 type updateKeyReq struct {
 	token string
 	id    string
-	Key   string `json:"key"`
+	Key   string   `json:"key"`
+	Grace duration `json:"grace,omitempty"`
 }
 
 func (req updateKeyReq) validate() error {
@@ -93,7 +126,49 @@ func (req updateKeyReq) validate() error {
 		return things.ErrUnauthorizedAccess
 	}
 
-	if req.id == "" || req.Key == "" {
+	if req.id == "" || req.Key == "" || req.Grace < 0 {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type addKeyReq struct {
+	token     string
+	id        string
+	Name      string    `json:"name"`
+	Key       string    `json:"key,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+func (req addKeyReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || req.Name == "" {
+		return things.ErrMalformedEntity
+	}
+
+	if len(req.Name) > maxNameSize {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type keyReq struct {
+	token string
+	id    string
+	name  string
+}
+
+func (req keyReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || req.name == "" {
 		return things.ErrMalformedEntity
 	}
 
@@ -213,6 +288,29 @@ func (req *listResourcesReq) validate() error {
 		return things.ErrMalformedEntity
 	}
 
+	switch req.pageMetadata.Status {
+	case "", things.AllStatus, things.EnabledStatus, things.DisabledStatus:
+	default:
+		return things.ErrMalformedEntity
+	}
+
+	switch req.pageMetadata.Presence {
+	case "", things.OnlinePresence, things.OfflinePresence:
+	default:
+		return things.ErrMalformedEntity
+	}
+
+	// Cursor points to the last retrieved ID, so it can't be combined with
+	// the offset or ordering by name.
+	if req.pageMetadata.Cursor != "" {
+		if req.pageMetadata.Offset > 0 || req.pageMetadata.Order == nameOrder {
+			return things.ErrMalformedEntity
+		}
+		if _, err := things.DecodeCursor(req.pageMetadata.Cursor); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -270,6 +368,9 @@ type connectReq struct {
 	token      string
 	ChannelIDs []string `json:"channel_ids,omitempty"`
 	ThingIDs   []string `json:"thing_ids,omitempty"`
+	Actions    []string `json:"actions,omitempty"`
+	Allow      []string `json:"allow,omitempty"`
+	Deny       []string `json:"deny,omitempty"`
 }
 
 func (req connectReq) validate() error {
@@ -291,6 +392,43 @@ func (req connectReq) validate() error {
 			return things.ErrMalformedEntity
 		}
 	}
+	for _, action := range req.Actions {
+		if action != things.PublishAction && action != things.SubscribeAction {
+			return things.ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
+type shareReq struct {
+	token    string
+	id       string
+	Actions  []string `json:"actions,omitempty"`
+	Subjects []string `json:"subjects,omitempty"`
+}
+
+func (req shareReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || len(req.Actions) == 0 || len(req.Subjects) == 0 {
+		return things.ErrMalformedEntity
+	}
+
+	for _, act := range req.Actions {
+		switch act {
+		case things.ReadAction, things.WriteAction, things.DeleteAction, things.ConnectAction:
+		default:
+			return things.ErrMalformedEntity
+		}
+	}
+	for _, sub := range req.Subjects {
+		if sub == "" {
+			return things.ErrMalformedEntity
+		}
+	}
 
 	return nil
 }
@@ -6,6 +6,7 @@ package http
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/mainflux/mainflux"
 )
@@ -14,6 +15,8 @@ var (
 	_ mainflux.Response = (*removeRes)(nil)
 	_ mainflux.Response = (*thingRes)(nil)
 	_ mainflux.Response = (*viewThingRes)(nil)
+	_ mainflux.Response = (*keyRes)(nil)
+	_ mainflux.Response = (*keysRes)(nil)
 	_ mainflux.Response = (*thingsPageRes)(nil)
 	_ mainflux.Response = (*channelRes)(nil)
 	_ mainflux.Response = (*viewChannelRes)(nil)
@@ -96,6 +99,7 @@ type viewThingRes struct {
 	Name     string                 `json:"name,omitempty"`
 	Key      string                 `json:"key"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Status   string                 `json:"status,omitempty"`
 }
 
 func (res viewThingRes) Code() int {
@@ -110,6 +114,71 @@ func (res viewThingRes) Empty() bool {
 	return false
 }
 
+type presenceRes struct {
+	Online         bool       `json:"online"`
+	ConnectedAt    *time.Time `json:"connected_at,omitempty"`
+	DisconnectedAt *time.Time `json:"disconnected_at,omitempty"`
+	LastSeen       *time.Time `json:"last_seen,omitempty"`
+}
+
+func (res presenceRes) Code() int {
+	return http.StatusOK
+}
+
+func (res presenceRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res presenceRes) Empty() bool {
+	return false
+}
+
+type keyRes struct {
+	thingID   string
+	Name      string     `json:"name"`
+	Key       string     `json:"key"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	created   bool
+}
+
+func (res keyRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res keyRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/things/%s/keys/%s", res.thingID, res.Name),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res keyRes) Empty() bool {
+	return false
+}
+
+type keysRes struct {
+	Keys []keyRes `json:"keys"`
+}
+
+func (res keysRes) Code() int {
+	return http.StatusOK
+}
+
+func (res keysRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res keysRes) Empty() bool {
+	return false
+}
+
 type thingsPageRes struct {
 	pageRes
 	Things []viewThingRes `json:"things"`
@@ -184,6 +253,7 @@ type viewChannelRes struct {
 	Name     string                 `json:"name,omitempty"`
 	Things   []viewThingRes         `json:"connected,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Status   string                 `json:"status,omitempty"`
 }
 
 func (res viewChannelRes) Code() int {
@@ -259,6 +329,34 @@ func (res disconnectRes) Empty() bool {
 	return true
 }
 
+type shareRes struct{}
+
+func (res shareRes) Code() int {
+	return http.StatusOK
+}
+
+func (res shareRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res shareRes) Empty() bool {
+	return true
+}
+
+type unshareRes struct{}
+
+func (res unshareRes) Code() int {
+	return http.StatusOK
+}
+
+func (res unshareRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unshareRes) Empty() bool {
+	return true
+}
+
 type disconnectThingRes struct{}
 
 func (res disconnectThingRes) Code() int {
@@ -274,11 +372,12 @@ func (res disconnectThingRes) Empty() bool {
 }
 
 type pageRes struct {
-	Total  uint64 `json:"total"`
-	Offset uint64 `json:"offset"`
-	Limit  uint64 `json:"limit"`
-	Order  string `json:"order"`
-	Dir    string `json:"direction"`
+	Total      uint64 `json:"total"`
+	Offset     uint64 `json:"offset"`
+	Limit      uint64 `json:"limit"`
+	Order      string `json:"order"`
+	Dir        string `json:"direction"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type errorRes struct {
@@ -31,6 +31,9 @@ const (
 	dirKey      = "dir"
 	metadataKey = "metadata"
 	disconnKey  = "disconnected"
+	statusKey   = "status"
+	presenceKey = "presence"
+	cursorKey   = "cursor"
 	defOffset   = 0
 	defLimit    = 10
 )
@@ -64,6 +67,27 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Post("/things/:id/keys", kithttp.NewServer(
+		kitot.TraceServer(tracer, "add_key")(addKeyEndpoint(svc)),
+		decodeKeyCreation,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/things/:id/keys", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_keys")(listKeysEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/things/:id/keys/:name", kithttp.NewServer(
+		kitot.TraceServer(tracer, "revoke_key")(revokeKeyEndpoint(svc)),
+		decodeKeyRevocation,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Put("/things/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "update_thing")(updateThingEndpoint(svc)),
 		decodeThingUpdate,
@@ -78,6 +102,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Post("/things/:id/enable", kithttp.NewServer(
+		kitot.TraceServer(tracer, "enable_thing")(updateThingStatusEndpoint(svc, things.EnabledStatus)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/things/:id/disable", kithttp.NewServer(
+		kitot.TraceServer(tracer, "disable_thing")(updateThingStatusEndpoint(svc, things.DisabledStatus)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/things/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_thing")(viewThingEndpoint(svc)),
 		decodeView,
@@ -85,6 +123,27 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Get("/things/:id/status", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_presence")(viewPresenceEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/things/:id/share", kithttp.NewServer(
+		kitot.TraceServer(tracer, "share_thing")(shareThingEndpoint(svc)),
+		decodeShare,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/things/:id/unshare", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unshare_thing")(unshareThingEndpoint(svc)),
+		decodeShare,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/things/:id/channels", kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_channels_by_thing")(listChannelsByThingEndpoint(svc)),
 		decodeListByConnection,
@@ -134,6 +193,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Post("/channels/:id/enable", kithttp.NewServer(
+		kitot.TraceServer(tracer, "enable_channel")(updateChannelStatusEndpoint(svc, things.EnabledStatus)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/channels/:id/disable", kithttp.NewServer(
+		kitot.TraceServer(tracer, "disable_channel")(updateChannelStatusEndpoint(svc, things.DisabledStatus)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/channels/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_channel")(viewChannelEndpoint(svc)),
 		decodeView,
@@ -141,6 +214,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Post("/channels/:id/share", kithttp.NewServer(
+		kitot.TraceServer(tracer, "share_channel")(shareChannelEndpoint(svc)),
+		decodeShare,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/channels/:id/unshare", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unshare_channel")(unshareChannelEndpoint(svc)),
+		decodeShare,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/channels/:id/things", kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_things_by_channel")(listThingsByChannelEndpoint(svc)),
 		decodeListByConnection,
@@ -254,6 +341,32 @@ func decodeKeyUpdate(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeKeyCreation(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	req := addKeyReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(things.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeKeyRevocation(_ context.Context, r *http.Request) (interface{}, error) {
+	req := keyReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+		name:  bone.GetValue(r, "name"),
+	}
+
+	return req, nil
+}
+
 func decodeChannelCreation(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, errors.ErrUnsupportedContentType
@@ -337,6 +450,21 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
+	st, err := httputil.ReadStringQuery(r, statusKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := httputil.ReadStringQuery(r, presenceKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := httputil.ReadStringQuery(r, cursorKey, "")
+	if err != nil {
+		return nil, err
+	}
+
 	req := listResourcesReq{
 		token: r.Header.Get("Authorization"),
 		pageMetadata: things.PageMetadata{
@@ -346,6 +474,9 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 			Order:    or,
 			Dir:      d,
 			Metadata: m,
+			Status:   st,
+			Presence: p,
+			Cursor:   c,
 		},
 	}
 
@@ -425,6 +556,22 @@ func decodeConnectList(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodeShare(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	req := shareReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(things.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeListMembersRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	o, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
 	if err != nil {
@@ -13,6 +13,7 @@ type Channel struct {
 	ID       string
 	Owner    string
 	Name     string
+	Status   string
 	Metadata map[string]interface{}
 }
 
@@ -34,12 +35,22 @@ type ChannelRepository interface {
 	// returned to indicate operation failure.
 	Update(ctx context.Context, c Channel) error
 
+	// UpdateStatus updates status of the existing channel. A non-nil error is
+	// returned to indicate operation failure.
+	UpdateStatus(ctx context.Context, owner, id, status string) error
+
 	// RetrieveByID retrieves the channel having the provided identifier, that is owned
 	// by the specified user.
 	RetrieveByID(ctx context.Context, owner, id string) (Channel, error)
 
-	// RetrieveAll retrieves the subset of channels owned by the specified user.
-	RetrieveAll(ctx context.Context, owner string, pm PageMetadata) (ChannelsPage, error)
+	// RetrieveAll retrieves the subset of channels owned by the specified user
+	// or identified by one of the shared channel IDs. If the cursor is set,
+	// the channels following the cursor are retrieved in the order of their IDs
+	// instead of skipping the offset.
+	RetrieveAll(ctx context.Context, owner string, shared []string, pm PageMetadata) (ChannelsPage, error)
+
+	// RetrieveByIDs retrieves the subset of channels specified by given channel ids.
+	RetrieveByIDs(ctx context.Context, chIDs []string, pm PageMetadata) (ChannelsPage, error)
 
 	// RetrieveByThing retrieves the subset of channels owned by the specified
 	// user and have specified thing connected or not connected to them.
@@ -49,31 +60,39 @@ type ChannelRepository interface {
 	// by the specified user.
 	Remove(ctx context.Context, owner, id string) error
 
-	// Connect adds things to the channels list of connected things.
-	Connect(ctx context.Context, owner string, chIDs, thIDs []string) error
+	// Connect adds things to the channels list of connected things. The
+	// connections carry the provided access rules.
+	Connect(ctx context.Context, owner string, chIDs, thIDs []string, acl ConnectionACL) error
 
 	// Disconnect removes things from the channels list of connected
 	// things.
 	Disconnect(ctx context.Context, owner string, chIDs, thIDs []string) error
 
 	// HasThing determines whether the thing with the provided access key, is
-	// "connected" to the specified channel. If that's the case, it returns
-	// thing's ID.
-	HasThing(ctx context.Context, chanID, key string) (string, error)
+	// "connected" to the specified channel with the given action. If that's
+	// the case, it returns thing's ID. An empty action matches any connection,
+	// while connections of disabled things and channels match none.
+	HasThing(ctx context.Context, chanID, key, action string) (string, error)
 
 	// HasThingByID determines whether the thing with the provided ID, is
-	// "connected" to the specified channel. If that's the case, then
-	// returned error will be nil.
-	HasThingByID(ctx context.Context, chanID, thingID string) error
+	// "connected" to the specified channel with the given action. If that's
+	// the case, then returned error will be nil. An empty action matches any
+	// connection, while connections of disabled things and channels match
+	// none.
+	HasThingByID(ctx context.Context, chanID, thingID, action string) error
+
+	// RetrieveACL retrieves the access rules of the connection between the
+	// specified channel and thing.
+	RetrieveACL(ctx context.Context, chanID, thingID string) (ConnectionACL, error)
 }
 
 // ChannelCache contains channel-thing connection caching interface.
 type ChannelCache interface {
-	// Connect channel thing connection.
-	Connect(context.Context, string, string) error
+	// Connect channel thing connection for the given action.
+	Connect(context.Context, string, string, string) error
 
-	// HasThing checks if thing is connected to channel.
-	HasThing(context.Context, string, string) bool
+	// HasThing checks if thing is connected to channel for the given action.
+	HasThing(context.Context, string, string, string) bool
 
 	// Disconnects thing from channel.
 	Disconnect(context.Context, string, string) error
@@ -0,0 +1,23 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import "encoding/base64"
+
+// EncodeCursor returns the opaque page cursor that points to the thing or the
+// channel with the given ID. The next page starts right after it.
+func EncodeCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+// DecodeCursor returns the ID of the thing or the channel the page cursor
+// points to.
+func DecodeCursor(cursor string) (string, error) {
+	id, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(id) == 0 {
+		return "", ErrMalformedEntity
+	}
+
+	return string(id), nil
+}
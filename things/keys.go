@@ -0,0 +1,30 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import "time"
+
+// PreviousKey is the name of the key replaced by the most recent thing key
+// update. It remains valid until the grace period of the update expires.
+const PreviousKey = "previous"
+
+// Key represents a thing key. Besides the key it is created with, a thing
+// can be identified by any of its named keys, each of which can have an
+// expiration time.
+type Key struct {
+	ThingID   string
+	Name      string
+	Value     string
+	ExpiresAt time.Time
+}
+
+// Expired verifies if the key is expired. Keys without expiration time never
+// expire.
+func (k Key) Expired() bool {
+	if k.ExpiresAt.IsZero() {
+		return false
+	}
+
+	return k.ExpiresAt.UTC().Before(time.Now().UTC())
+}
@@ -5,6 +5,8 @@ package mocks
 
 import (
 	"context"
+	"sort"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
@@ -12,25 +14,38 @@ import (
 	"google.golang.org/grpc"
 )
 
+const ownerAction = "owner"
+
 var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
 
+type policy struct {
+	sub string
+	obj string
+	act string
+}
+
 type authServiceMock struct {
-	users map[string]string
+	mu       sync.Mutex
+	users    map[string]string
+	policies map[policy]bool
 }
 
 // NewAuthService creates mock of users service.
 func NewAuthService(users map[string]string) mainflux.AuthServiceClient {
-	return &authServiceMock{users}
+	return &authServiceMock{
+		users:    users,
+		policies: make(map[policy]bool),
+	}
 }
 
-func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+func (svc *authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
 	if id, ok := svc.users[in.Value]; ok {
 		return &mainflux.UserIdentity{Id: id, Email: id}, nil
 	}
 	return nil, users.ErrUnauthorizedAccess
 }
 
-func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+func (svc *authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
 	if id, ok := svc.users[in.GetEmail()]; ok {
 		switch in.Type {
 		default:
@@ -40,14 +55,80 @@ func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opt
 	return nil, users.ErrUnauthorizedAccess
 }
 
-func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
-	panic("not implemented")
+func (svc *authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	p := policy{sub: req.GetSub(), obj: req.GetObj(), act: req.GetAct()}
+	return &mainflux.AuthorizeRes{Authorized: svc.policies[p]}, nil
 }
 
-func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
+func (svc *authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
 	panic("not implemented")
 }
 
-func (svc authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+func (svc *authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc *authServiceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	id, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	p := policy{sub: req.GetSub(), obj: req.GetObj(), act: req.GetAct()}
+	switch owner := svc.owner(p.obj); {
+	case p.act == ownerAction && p.sub == id && (owner == "" || owner == id):
+	case p.act != ownerAction && (owner == id || svc.policies[policy{sub: id, obj: p.obj, act: p.act}]):
+	default:
+		return nil, users.ErrUnauthorizedAccess
+	}
+
+	svc.policies[p] = true
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	id, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	if req.GetAct() == ownerAction || svc.owner(req.GetObj()) != id {
+		return nil, users.ErrUnauthorizedAccess
+	}
+
+	delete(svc.policies, policy{sub: req.GetSub(), obj: req.GetObj(), act: req.GetAct()})
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceMock) ListObjects(ctx context.Context, req *mainflux.ListObjectsReq, _ ...grpc.CallOption) (r *mainflux.ListObjectsRes, err error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	var objects []string
+	for p := range svc.policies {
+		if p.sub == req.GetSub() && p.act == req.GetAct() {
+			objects = append(objects, p.obj)
+		}
+	}
+	sort.Strings(objects)
+
+	return &mainflux.ListObjectsRes{Objects: objects}, nil
+}
+
+func (svc *authServiceMock) owner(obj string) string {
+	for p := range svc.policies {
+		if p.obj == obj && p.act == ownerAction {
+			return p.sub
+		}
+	}
+	return ""
+}
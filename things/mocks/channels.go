@@ -29,6 +29,7 @@ type channelRepositoryMock struct {
 	channels map[string]things.Channel
 	tconns   chan Connection                      // used for synchronization with thing repo
 	cconns   map[string]map[string]things.Channel // used to track connections
+	acls     map[string]things.ConnectionACL      // used to track connection access rules
 	things   things.ThingRepository
 }
 
@@ -38,6 +39,7 @@ func NewChannelRepository(repo things.ThingRepository, tconns chan Connection) t
 		channels: make(map[string]things.Channel),
 		tconns:   tconns,
 		cconns:   make(map[string]map[string]things.Channel),
+		acls:     make(map[string]things.ConnectionACL),
 		things:   repo,
 	}
 }
@@ -61,14 +63,48 @@ func (crm *channelRepositoryMock) Update(_ context.Context, channel things.Chann
 
 	dbKey := key(channel.Owner, channel.ID)
 
-	if _, ok := crm.channels[dbKey]; !ok {
+	ch, ok := crm.channels[dbKey]
+	if !ok {
 		return things.ErrNotFound
 	}
 
+	channel.Status = ch.Status
 	crm.channels[dbKey] = channel
 	return nil
 }
 
+func (crm *channelRepositoryMock) UpdateStatus(_ context.Context, owner, id, status string) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	dbKey := key(owner, id)
+
+	ch, ok := crm.channels[dbKey]
+	if !ok {
+		return things.ErrNotFound
+	}
+
+	ch.Status = status
+	crm.channels[dbKey] = ch
+	return nil
+}
+
+// enabled verifies if both the channel and the thing exist and are not
+// disabled.
+func (crm *channelRepositoryMock) enabled(chanID, thingID string) bool {
+	if trm, ok := crm.things.(*thingRepositoryMock); ok && !trm.enabled(thingID) {
+		return false
+	}
+
+	for _, ch := range crm.channels {
+		if ch.ID == chanID {
+			return ch.Status != things.DisabledStatus
+		}
+	}
+
+	return false
+}
+
 func (crm *channelRepositoryMock) RetrieveByID(_ context.Context, owner, id string) (things.Channel, error) {
 	if c, ok := crm.channels[key(owner, id)]; ok {
 		return c, nil
@@ -77,7 +113,7 @@ func (crm *channelRepositoryMock) RetrieveByID(_ context.Context, owner, id stri
 	return things.Channel{}, things.ErrNotFound
 }
 
-func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, pm things.PageMetadata) (things.ChannelsPage, error) {
+func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, shared []string, pm things.PageMetadata) (things.ChannelsPage, error) {
 	if pm.Limit < 0 {
 		return things.ChannelsPage{}, nil
 	}
@@ -94,7 +130,7 @@ func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, p
 	// itself (see mocks/commons.go).
 	prefix := fmt.Sprintf("%s-", owner)
 	for k, v := range crm.channels {
-		if strings.HasPrefix(k, prefix) {
+		if (strings.HasPrefix(k, prefix) || contains(shared, v.ID)) && matchStatus(pm.Status, v.Status) {
 			chs = append(chs, v)
 		}
 	}
@@ -102,6 +138,26 @@ func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, p
 	// Sort Channels list
 	chs = sortChannels(pm, chs)
 
+	// Cursor replaces the offset, so the page starts after the channel the
+	// cursor points to.
+	if pm.Cursor != "" {
+		if pm.Order == "name" {
+			return things.ChannelsPage{}, things.ErrMalformedEntity
+		}
+		id, err := things.DecodeCursor(pm.Cursor)
+		if err != nil {
+			return things.ChannelsPage{}, err
+		}
+		first = len(chs)
+		for i, ch := range chs {
+			if ch.ID == id {
+				first = i + 1
+				break
+			}
+		}
+		last = first + int(pm.Limit)
+	}
+
 	if last > len(chs) {
 		last = len(chs)
 	}
@@ -118,6 +174,41 @@ func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, p
 			Limit:  pm.Limit,
 		},
 	}
+	if last-first == int(pm.Limit) {
+		page.NextCursor = things.EncodeCursor(chs[last-1].ID)
+	}
+
+	return page, nil
+}
+
+func (crm *channelRepositoryMock) RetrieveByIDs(_ context.Context, chIDs []string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	if pm.Limit == 0 {
+		return things.ChannelsPage{}, nil
+	}
+
+	var all []things.Channel
+	for _, ch := range crm.channels {
+		if contains(chIDs, ch.ID) && matchStatus(pm.Status, ch.Status) {
+			all = append(all, ch)
+		}
+	}
+	all = sortChannels(pm, all)
+
+	items := make([]things.Channel, 0)
+	for i, ch := range all {
+		if uint64(i) >= pm.Offset && uint64(i) < pm.Offset+pm.Limit {
+			items = append(items, ch)
+		}
+	}
+
+	page := things.ChannelsPage{
+		Channels: items,
+		PageMetadata: things.PageMetadata{
+			Total:  uint64(len(all)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
 
 	return page, nil
 }
@@ -188,7 +279,7 @@ func (crm *channelRepositoryMock) Remove(_ context.Context, owner, id string) er
 	return nil
 }
 
-func (crm *channelRepositoryMock) Connect(_ context.Context, owner string, chIDs, thIDs []string) error {
+func (crm *channelRepositoryMock) Connect(_ context.Context, owner string, chIDs, thIDs []string, acl things.ConnectionACL) error {
 	for _, chID := range chIDs {
 		ch, err := crm.RetrieveByID(context.Background(), owner, chID)
 		if err != nil {
@@ -210,6 +301,7 @@ func (crm *channelRepositoryMock) Connect(_ context.Context, owner string, chIDs
 				crm.cconns[thID] = make(map[string]things.Channel)
 			}
 			crm.cconns[thID][chID] = ch
+			crm.acls[key(chID, thID)] = acl
 		}
 	}
 
@@ -233,17 +325,19 @@ func (crm *channelRepositoryMock) Disconnect(_ context.Context, owner string, ch
 				connected: false,
 			}
 			delete(crm.cconns[thID], chID)
+			delete(crm.acls, key(chID, thID))
 		}
 	}
 
 	return nil
 }
 
-func (crm *channelRepositoryMock) HasThing(_ context.Context, chanID, token string) (string, error) {
-	tid, err := crm.things.RetrieveByKey(context.Background(), token)
+func (crm *channelRepositoryMock) HasThing(_ context.Context, chanID, token, action string) (string, error) {
+	k, err := crm.things.RetrieveByKey(context.Background(), token)
 	if err != nil {
 		return "", err
 	}
+	tid := k.ThingID
 
 	chans, ok := crm.cconns[tid]
 	if !ok {
@@ -254,10 +348,18 @@ func (crm *channelRepositoryMock) HasThing(_ context.Context, chanID, token stri
 		return "", things.ErrEntityConnected
 	}
 
+	if action != "" && !contains(crm.acls[key(chanID, tid)].Actions, action) {
+		return "", things.ErrEntityConnected
+	}
+
+	if !crm.enabled(chanID, tid) {
+		return "", things.ErrEntityConnected
+	}
+
 	return tid, nil
 }
 
-func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingID string) error {
+func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingID, action string) error {
 	chans, ok := crm.cconns[thingID]
 	if !ok {
 		return things.ErrEntityConnected
@@ -267,9 +369,26 @@ func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingI
 		return things.ErrEntityConnected
 	}
 
+	if action != "" && !contains(crm.acls[key(chanID, thingID)].Actions, action) {
+		return things.ErrEntityConnected
+	}
+
+	if !crm.enabled(chanID, thingID) {
+		return things.ErrEntityConnected
+	}
+
 	return nil
 }
 
+func (crm *channelRepositoryMock) RetrieveACL(_ context.Context, chanID, thingID string) (things.ConnectionACL, error) {
+	acl, ok := crm.acls[key(chanID, thingID)]
+	if !ok {
+		return things.ConnectionACL{}, things.ErrNotFound
+	}
+
+	return acl, nil
+}
+
 type channelCacheMock struct {
 	mu       sync.Mutex
 	channels map[string]string
@@ -282,26 +401,28 @@ func NewChannelCache() things.ChannelCache {
 	}
 }
 
-func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID string) error {
+func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID, action string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	ccm.channels[chanID] = thingID
+	ccm.channels[key(chanID, action)] = thingID
 	return nil
 }
 
-func (ccm *channelCacheMock) HasThing(_ context.Context, chanID, thingID string) bool {
+func (ccm *channelCacheMock) HasThing(_ context.Context, chanID, thingID, action string) bool {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	return ccm.channels[chanID] == thingID
+	return ccm.channels[key(chanID, action)] == thingID
 }
 
 func (ccm *channelCacheMock) Disconnect(_ context.Context, chanID, thingID string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	delete(ccm.channels, chanID)
+	for _, action := range []string{"", things.PublishAction, things.SubscribeAction} {
+		delete(ccm.channels, key(chanID, action))
+	}
 	return nil
 }
 
@@ -309,6 +430,8 @@ func (ccm *channelCacheMock) Remove(_ context.Context, chanID string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	delete(ccm.channels, chanID)
+	for _, action := range []string{"", things.PublishAction, things.SubscribeAction} {
+		delete(ccm.channels, key(chanID, action))
+	}
 	return nil
 }
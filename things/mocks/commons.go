@@ -18,6 +18,21 @@ func key(owner string, id string) string {
 	return fmt.Sprintf("%s-%s", owner, id)
 }
 
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// matchStatus determines whether the entity status matches the status the
+// entities are filtered by.
+func matchStatus(filter, status string) bool {
+	return filter == "" || filter == things.AllStatus || filter == status
+}
+
 func sortThings(pm things.PageMetadata, ths []things.Thing) []things.Thing {
 	switch pm.Order {
 	case "name":
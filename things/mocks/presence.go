@@ -0,0 +1,102 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/things"
+)
+
+var _ things.PresenceRepository = (*presenceRepositoryMock)(nil)
+
+type presenceRepositoryMock struct {
+	mu        sync.Mutex
+	presences map[string]things.Presence
+}
+
+// NewPresenceRepository creates in-memory thing presence repository.
+func NewPresenceRepository() things.PresenceRepository {
+	return &presenceRepositoryMock{
+		presences: make(map[string]things.Presence),
+	}
+}
+
+func (prm *presenceRepositoryMock) Connect(_ context.Context, thingID string, at time.Time) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	p := prm.presences[thingID]
+	p.ThingID = thingID
+	p.Online = true
+	p.ConnectedAt = at
+	p.LastSeen = at
+	prm.presences[thingID] = p
+
+	return nil
+}
+
+func (prm *presenceRepositoryMock) Disconnect(_ context.Context, thingID string, at time.Time) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	p := prm.presences[thingID]
+	if p.ConnectedAt.After(at) {
+		return nil
+	}
+	p.ThingID = thingID
+	p.Online = false
+	p.DisconnectedAt = at
+	prm.presences[thingID] = p
+
+	return nil
+}
+
+func (prm *presenceRepositoryMock) Seen(_ context.Context, thingID string, at time.Time) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	p := prm.presences[thingID]
+	p.ThingID = thingID
+	p.LastSeen = at
+	prm.presences[thingID] = p
+
+	return nil
+}
+
+func (prm *presenceRepositoryMock) Retrieve(_ context.Context, thingID string) (things.Presence, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	p, ok := prm.presences[thingID]
+	if !ok {
+		return things.Presence{ThingID: thingID}, nil
+	}
+
+	return p, nil
+}
+
+func (prm *presenceRepositoryMock) RetrieveOnline(_ context.Context, thingIDs []string) ([]string, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	ids := []string{}
+	for _, id := range thingIDs {
+		if prm.presences[id].Online {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+func (prm *presenceRepositoryMock) Remove(_ context.Context, thingID string) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	delete(prm.presences, thingID)
+	return nil
+}
@@ -6,6 +6,7 @@ package mocks
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,6 +22,7 @@ type thingRepositoryMock struct {
 	conns   chan Connection
 	tconns  map[string]map[string]things.Thing
 	things  map[string]things.Thing
+	keys    map[string]things.Key
 }
 
 // NewThingRepository creates in-memory thing repository.
@@ -29,6 +31,7 @@ func NewThingRepository(conns chan Connection) things.ThingRepository {
 		conns:  conns,
 		things: make(map[string]things.Thing),
 		tconns: make(map[string]map[string]things.Thing),
+		keys:   make(map[string]things.Key),
 	}
 	go func(conns chan Connection, repo *thingRepositoryMock) {
 		for conn := range conns {
@@ -48,10 +51,8 @@ func (trm *thingRepositoryMock) Save(_ context.Context, ths ...things.Thing) ([]
 	defer trm.mu.Unlock()
 
 	for i := range ths {
-		for _, th := range trm.things {
-			if th.Key == ths[i].Key {
-				return []things.Thing{}, things.ErrConflict
-			}
+		if trm.keyExists(ths[i].Key) {
+			return []things.Thing{}, things.ErrConflict
 		}
 
 		trm.counter++
@@ -68,25 +69,56 @@ func (trm *thingRepositoryMock) Update(_ context.Context, thing things.Thing) er
 
 	dbKey := key(thing.Owner, thing.ID)
 
-	if _, ok := trm.things[dbKey]; !ok {
+	th, ok := trm.things[dbKey]
+	if !ok {
 		return things.ErrNotFound
 	}
 
+	thing.Status = th.Status
 	trm.things[dbKey] = thing
 
 	return nil
 }
 
-func (trm *thingRepositoryMock) UpdateKey(_ context.Context, owner, id, val string) error {
+func (trm *thingRepositoryMock) UpdateStatus(_ context.Context, owner, id, status string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	dbKey := key(owner, id)
+
+	th, ok := trm.things[dbKey]
+	if !ok {
+		return things.ErrNotFound
+	}
+
+	th.Status = status
+	trm.things[dbKey] = th
+
+	return nil
+}
+
+// enabled verifies if the thing exists and is not disabled.
+func (trm *thingRepositoryMock) enabled(id string) bool {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 
 	for _, th := range trm.things {
-		if th.Key == val {
-			return things.ErrConflict
+		if th.ID == id {
+			return th.Status != things.DisabledStatus
 		}
 	}
 
+	return false
+}
+
+func (trm *thingRepositoryMock) UpdateKey(_ context.Context, owner, id, val string, prev things.Key) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if trm.keyExists(val) {
+		return things.ErrConflict
+	}
+
 	dbKey := key(owner, id)
 
 	th, ok := trm.things[dbKey]
@@ -97,9 +129,81 @@ func (trm *thingRepositoryMock) UpdateKey(_ context.Context, owner, id, val stri
 	th.Key = val
 	trm.things[dbKey] = th
 
+	if prev.Name != "" {
+		delete(trm.keys, key(id, prev.Name))
+	}
+	if prev.Value != "" {
+		trm.keys[key(id, prev.Name)] = prev
+	}
+
+	return nil
+}
+
+func (trm *thingRepositoryMock) SaveKey(_ context.Context, owner string, k things.Key) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if _, ok := trm.things[key(owner, k.ThingID)]; !ok {
+		return things.ErrNotFound
+	}
+
+	if _, ok := trm.keys[key(k.ThingID, k.Name)]; ok || trm.keyExists(k.Value) {
+		return things.ErrConflict
+	}
+
+	trm.keys[key(k.ThingID, k.Name)] = k
 	return nil
 }
 
+func (trm *thingRepositoryMock) RetrieveKeys(_ context.Context, owner, id string) ([]things.Key, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	keys := []things.Key{}
+	if _, ok := trm.things[key(owner, id)]; !ok {
+		return keys, nil
+	}
+
+	for _, k := range trm.keys {
+		if k.ThingID == id {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].Name < keys[j].Name
+	})
+
+	return keys, nil
+}
+
+func (trm *thingRepositoryMock) RemoveKey(_ context.Context, owner, id, name string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if _, ok := trm.things[key(owner, id)]; ok {
+		delete(trm.keys, key(id, name))
+	}
+
+	return nil
+}
+
+func (trm *thingRepositoryMock) keyExists(val string) bool {
+	for _, th := range trm.things {
+		if th.Key == val {
+			return true
+		}
+	}
+
+	for _, k := range trm.keys {
+		if k.Value == val {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (trm *thingRepositoryMock) RetrieveByID(_ context.Context, owner, id string) (things.Thing, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
@@ -111,7 +215,7 @@ func (trm *thingRepositoryMock) RetrieveByID(_ context.Context, owner, id string
 	return things.Thing{}, things.ErrNotFound
 }
 
-func (trm *thingRepositoryMock) RetrieveAll(_ context.Context, owner string, pm things.PageMetadata) (things.Page, error) {
+func (trm *thingRepositoryMock) RetrieveAll(_ context.Context, owner string, shared []string, pm things.PageMetadata) (things.Page, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 
@@ -120,6 +224,21 @@ func (trm *thingRepositoryMock) RetrieveAll(_ context.Context, owner string, pm
 	}
 
 	first := uint64(pm.Offset) + 1
+	// Mock IDs are sequential, so the cursor replaces the offset.
+	if pm.Cursor != "" {
+		if pm.Order == "name" {
+			return things.Page{}, things.ErrMalformedEntity
+		}
+		c, err := things.DecodeCursor(pm.Cursor)
+		if err != nil {
+			return things.Page{}, err
+		}
+		id, err := strconv.ParseUint(c, 10, 64)
+		if err != nil {
+			return things.Page{}, things.ErrMalformedEntity
+		}
+		first = id + 1
+	}
 	last := first + uint64(pm.Limit)
 
 	var ths []things.Thing
@@ -129,7 +248,7 @@ func (trm *thingRepositoryMock) RetrieveAll(_ context.Context, owner string, pm
 	prefix := fmt.Sprintf("%s-", owner)
 	for k, v := range trm.things {
 		id, _ := strconv.ParseUint(v.ID, 10, 64)
-		if strings.HasPrefix(k, prefix) && id >= first && id < last {
+		if (strings.HasPrefix(k, prefix) || contains(shared, v.ID)) && matchStatus(pm.Status, v.Status) && id >= first && id < last {
 			ths = append(ths, v)
 		}
 	}
@@ -145,6 +264,15 @@ func (trm *thingRepositoryMock) RetrieveAll(_ context.Context, owner string, pm
 			Limit:  pm.Limit,
 		},
 	}
+	if pm.Limit > 0 && uint64(len(ths)) == pm.Limit {
+		next := ths[0].ID
+		for _, th := range ths {
+			if th.ID > next {
+				next = th.ID
+			}
+		}
+		page.NextCursor = things.EncodeCursor(next)
+	}
 
 	return page, nil
 }
@@ -153,33 +281,29 @@ func (trm *thingRepositoryMock) RetrieveByIDs(_ context.Context, thingIDs []stri
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 
-	items := make([]things.Thing, 0)
-
 	if pm.Limit == 0 {
 		return things.Page{}, nil
 	}
 
-	first := uint64(pm.Offset) + 1
-	last := first + uint64(pm.Limit)
-
-	// This obscure way to examine map keys is enforced by the key structure
-	// itself (see mocks/commons.go).
-	for _, id := range thingIDs {
-		suffix := fmt.Sprintf("-%s", id)
-		for k, v := range trm.things {
-			id, _ := strconv.ParseUint(v.ID, 10, 64)
-			if strings.HasSuffix(k, suffix) && id >= first && id < last {
-				items = append(items, v)
-			}
+	var all []things.Thing
+	for _, th := range trm.things {
+		if contains(thingIDs, th.ID) && matchStatus(pm.Status, th.Status) {
+			all = append(all, th)
 		}
 	}
+	all = sortThings(pm, all)
 
-	items = sortThings(pm, items)
+	items := make([]things.Thing, 0)
+	for i, th := range all {
+		if uint64(i) >= pm.Offset && uint64(i) < pm.Offset+pm.Limit {
+			items = append(items, th)
+		}
+	}
 
 	page := things.Page{
 		Things: items,
 		PageMetadata: things.PageMetadata{
-			Total:  trm.counter,
+			Total:  uint64(len(all)),
 			Offset: pm.Offset,
 			Limit:  pm.Limit,
 		},
@@ -248,20 +372,36 @@ func (trm *thingRepositoryMock) Remove(_ context.Context, owner, id string) erro
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 	delete(trm.things, key(owner, id))
+	for k, tk := range trm.keys {
+		if tk.ThingID == id {
+			delete(trm.keys, k)
+		}
+	}
 	return nil
 }
 
-func (trm *thingRepositoryMock) RetrieveByKey(_ context.Context, key string) (string, error) {
+func (trm *thingRepositoryMock) RetrieveByKey(_ context.Context, val string) (things.Key, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 
+	disabled := make(map[string]bool)
 	for _, thing := range trm.things {
-		if thing.Key == key {
-			return thing.ID, nil
+		if thing.Status == things.DisabledStatus {
+			disabled[thing.ID] = true
+			continue
+		}
+		if thing.Key == val {
+			return things.Key{ThingID: thing.ID, Value: val}, nil
+		}
+	}
+
+	for _, k := range trm.keys {
+		if k.Value == val && !k.Expired() && !disabled[k.ThingID] {
+			return k, nil
 		}
 	}
 
-	return "", things.ErrNotFound
+	return things.Key{}, things.ErrNotFound
 }
 
 func (trm *thingRepositoryMock) connect(conn Connection) {
@@ -287,21 +427,21 @@ func (trm *thingRepositoryMock) disconnect(conn Connection) {
 
 type thingCacheMock struct {
 	mu     sync.Mutex
-	things map[string]string
+	things map[string]things.Key
 }
 
 // NewThingCache returns mock cache instance.
 func NewThingCache() things.ThingCache {
 	return &thingCacheMock{
-		things: make(map[string]string),
+		things: make(map[string]things.Key),
 	}
 }
 
-func (tcm *thingCacheMock) Save(_ context.Context, key, id string) error {
+func (tcm *thingCacheMock) Save(_ context.Context, key things.Key) error {
 	tcm.mu.Lock()
 	defer tcm.mu.Unlock()
 
-	tcm.things[key] = id
+	tcm.things[key.Value] = key
 	return nil
 }
 
@@ -309,12 +449,12 @@ func (tcm *thingCacheMock) ID(_ context.Context, key string) (string, error) {
 	tcm.mu.Lock()
 	defer tcm.mu.Unlock()
 
-	id, ok := tcm.things[key]
-	if !ok {
+	k, ok := tcm.things[key]
+	if !ok || k.Expired() {
 		return "", things.ErrNotFound
 	}
 
-	return id, nil
+	return k.ThingID, nil
 }
 
 func (tcm *thingCacheMock) Remove(_ context.Context, id string) error {
@@ -322,11 +462,18 @@ func (tcm *thingCacheMock) Remove(_ context.Context, id string) error {
 	defer tcm.mu.Unlock()
 
 	for key, val := range tcm.things {
-		if val == id {
+		if val.ThingID == id {
 			delete(tcm.things, key)
-			return nil
 		}
 	}
 
 	return nil
 }
+
+func (tcm *thingCacheMock) RemoveKey(_ context.Context, key string) error {
+	tcm.mu.Lock()
+	defer tcm.mu.Unlock()
+
+	delete(tcm.things, key)
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/lib/pq"
@@ -24,9 +25,12 @@ type channelRepository struct {
 }
 
 type dbConnection struct {
-	Channel string `db:"channel"`
-	Thing   string `db:"thing"`
-	Owner   string `db:"owner"`
+	Channel string         `db:"channel"`
+	Thing   string         `db:"thing"`
+	Owner   string         `db:"owner"`
+	Actions pq.StringArray `db:"actions"`
+	Allow   pq.StringArray `db:"allow"`
+	Deny    pq.StringArray `db:"deny"`
 }
 
 // NewChannelRepository instantiates a PostgreSQL implementation of channel
@@ -43,8 +47,8 @@ func (cr channelRepository) Save(ctx context.Context, channels ...things.Channel
 		return nil, errors.Wrap(things.ErrCreateEntity, err)
 	}
 
-	q := `INSERT INTO channels (id, owner, name, metadata)
-		  VALUES (:id, :owner, :name, :metadata);`
+	q := `INSERT INTO channels (id, owner, name, metadata, status)
+		  VALUES (:id, :owner, :name, :metadata, :status);`
 
 	for _, channel := range channels {
 		dbch := toDBChannel(channel)
@@ -102,8 +106,39 @@ func (cr channelRepository) Update(ctx context.Context, channel things.Channel)
 	return nil
 }
 
+func (cr channelRepository) UpdateStatus(ctx context.Context, owner, id, status string) error {
+	q := `UPDATE channels SET status = :status WHERE owner = :owner AND id = :id;`
+
+	dbch := dbChannel{
+		ID:     id,
+		Owner:  owner,
+		Status: status,
+	}
+
+	res, err := cr.db.NamedExecContext(ctx, q, dbch)
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && errInvalid == pqErr.Code.Name() {
+			return things.ErrNotFound
+		}
+
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return things.ErrNotFound
+	}
+
+	return nil
+}
+
 func (cr channelRepository) RetrieveByID(ctx context.Context, owner, id string) (things.Channel, error) {
-	q := `SELECT name, metadata FROM channels WHERE id = $1 AND owner = $2;`
+	q := `SELECT name, metadata, status FROM channels WHERE id = $1 AND owner = $2;`
 
 	dbch := dbChannel{
 		ID:    id,
@@ -120,24 +155,33 @@ func (cr channelRepository) RetrieveByID(ctx context.Context, owner, id string)
 	return toChannel(dbch), nil
 }
 
-func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, pm things.PageMetadata) (things.ChannelsPage, error) {
+func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, shared []string, pm things.PageMetadata) (things.ChannelsPage, error) {
 	nq, name := getNameQuery(pm.Name)
+	sq := getStatusQuery(pm.Status)
 	oq := getOrderQuery(pm.Order)
 	dq := getDirQuery(pm.Dir)
+	ownq, ids := getOwnerQuery(shared)
+	curq, cursor, err := getCursorQuery(pm.Cursor, pm.Order, pm.Dir)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(things.ErrMalformedEntity, err)
+	}
 	meta, mq, err := getMetadataQuery(pm.Metadata)
 	if err != nil {
 		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, metadata FROM channels
-	      WHERE owner = :owner %s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, mq, nq, oq, dq)
+	q := fmt.Sprintf(`SELECT id, owner, name, metadata, status FROM channels
+	      WHERE %s%s%s%s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, ownq, mq, nq, sq, curq, oq, dq)
 
 	params := map[string]interface{}{
 		"owner":    owner,
+		"shared":   ids,
 		"limit":    pm.Limit,
 		"offset":   pm.Offset,
 		"name":     name,
 		"metadata": meta,
+		"status":   pm.Status,
+		"cursor":   cursor,
 	}
 	rows, err := cr.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
@@ -147,7 +191,7 @@ func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, pm th
 
 	items := []things.Channel{}
 	for rows.Next() {
-		dbch := dbChannel{Owner: owner}
+		dbch := dbChannel{}
 		if err := rows.StructScan(&dbch); err != nil {
 			return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
 		}
@@ -156,7 +200,72 @@ func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, pm th
 		items = append(items, ch)
 	}
 
-	cq := fmt.Sprintf(`SELECT COUNT(*) FROM channels WHERE owner = :owner %s%s;`, nq, mq)
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM channels WHERE %s%s%s%s;`, ownq, nq, mq, sq)
+
+	total, err := total(ctx, cr.db, cq, params)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+
+	page := things.ChannelsPage{
+		Channels: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+			Order:  pm.Order,
+			Dir:    pm.Dir,
+		},
+	}
+	if n := len(items); oq == "id" && n > 0 && uint64(n) == pm.Limit {
+		page.NextCursor = things.EncodeCursor(items[n-1].ID)
+	}
+
+	return page, nil
+}
+
+func (cr channelRepository) RetrieveByIDs(ctx context.Context, chIDs []string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	if len(chIDs) == 0 {
+		return things.ChannelsPage{}, nil
+	}
+
+	nq, name := getNameQuery(pm.Name)
+	sq := getStatusQuery(pm.Status)
+	oq := getOrderQuery(pm.Order)
+	dq := getDirQuery(pm.Dir)
+	meta, mq, err := getMetadataQuery(pm.Metadata)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner, name, metadata, status FROM channels
+	      WHERE id = ANY(:ids) %s%s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, mq, nq, sq, oq, dq)
+
+	params := map[string]interface{}{
+		"ids":      pq.Array(chIDs),
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+		"name":     name,
+		"metadata": meta,
+		"status":   pm.Status,
+	}
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	items := []things.Channel{}
+	for rows.Next() {
+		dbch := dbChannel{}
+		if err := rows.StructScan(&dbch); err != nil {
+			return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
+		}
+
+		items = append(items, toChannel(dbch))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM channels WHERE id = ANY(:ids) %s%s%s;`, nq, mq, sq)
 
 	total, err := total(ctx, cr.db, cq, params)
 	if err != nil {
@@ -189,7 +298,7 @@ func (cr channelRepository) RetrieveByThing(ctx context.Context, owner, thID str
 	var q, qc string
 	switch pm.Disconnected {
 	case true:
-		q = fmt.Sprintf(`SELECT id, name, metadata
+		q = fmt.Sprintf(`SELECT id, name, metadata, status
 		        FROM channels ch
 		        WHERE ch.owner = :owner AND ch.id NOT IN
 		        (SELECT id FROM channels ch
@@ -208,7 +317,7 @@ func (cr channelRepository) RetrieveByThing(ctx context.Context, owner, thID str
 		          ON ch.id = conn.channel_id
 		          WHERE ch.owner = $1 AND conn.thing_id = $2);`
 	default:
-		q = fmt.Sprintf(`SELECT id, name, metadata FROM channels ch
+		q = fmt.Sprintf(`SELECT id, name, metadata, status FROM channels ch
 		        INNER JOIN connections conn
 		        ON ch.id = conn.channel_id
 		        WHERE ch.owner = :owner AND conn.thing_id = :thing
@@ -272,14 +381,14 @@ func (cr channelRepository) Remove(ctx context.Context, owner, id string) error
 	return nil
 }
 
-func (cr channelRepository) Connect(ctx context.Context, owner string, chIDs, thIDs []string) error {
+func (cr channelRepository) Connect(ctx context.Context, owner string, chIDs, thIDs []string, acl things.ConnectionACL) error {
 	tx, err := cr.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(things.ErrConnect, err)
 	}
 
-	q := `INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner)
-	      VALUES (:channel, :owner, :thing, :owner);`
+	q := `INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner, actions, allow, deny)
+	      VALUES (:channel, :owner, :thing, :owner, :actions, :allow, :deny);`
 
 	for _, chID := range chIDs {
 		for _, thID := range thIDs {
@@ -287,6 +396,9 @@ func (cr channelRepository) Connect(ctx context.Context, owner string, chIDs, th
 				Channel: chID,
 				Thing:   thID,
 				Owner:   owner,
+				Actions: acl.Actions,
+				Allow:   acl.Allow,
+				Deny:    acl.Deny,
 			}
 
 			_, err := tx.NamedExecContext(ctx, q, dbco)
@@ -365,28 +477,56 @@ func (cr channelRepository) Disconnect(ctx context.Context, owner string, chIDs,
 	return nil
 }
 
-func (cr channelRepository) HasThing(ctx context.Context, chanID, thingKey string) (string, error) {
+func (cr channelRepository) HasThing(ctx context.Context, chanID, thingKey, action string) (string, error) {
 	var thingID string
-	q := `SELECT id FROM things WHERE key = $1`
-	if err := cr.db.QueryRowxContext(ctx, q, thingKey).Scan(&thingID); err != nil {
+	q := `SELECT id FROM things WHERE key = $1 AND status = $3
+	      UNION ALL
+	      SELECT tk.thing_id FROM thing_keys tk
+	      INNER JOIN things th ON th.id = tk.thing_id
+	      WHERE tk.key = $1 AND (tk.expires_at IS NULL OR tk.expires_at > $2) AND th.status = $3
+	      LIMIT 1;`
+	if err := cr.db.QueryRowxContext(ctx, q, thingKey, time.Now().UTC(), things.EnabledStatus).Scan(&thingID); err != nil {
 		return "", errors.Wrap(things.ErrEntityConnected, err)
 	}
 
-	if err := cr.hasThing(ctx, chanID, thingID); err != nil {
+	if err := cr.hasThing(ctx, chanID, thingID, action); err != nil {
 		return "", err
 	}
 
 	return thingID, nil
 }
 
-func (cr channelRepository) HasThingByID(ctx context.Context, chanID, thingID string) error {
-	return cr.hasThing(ctx, chanID, thingID)
+func (cr channelRepository) HasThingByID(ctx context.Context, chanID, thingID, action string) error {
+	return cr.hasThing(ctx, chanID, thingID, action)
+}
+
+func (cr channelRepository) RetrieveACL(ctx context.Context, chanID, thingID string) (things.ConnectionACL, error) {
+	q := `SELECT actions, allow, deny FROM connections WHERE channel_id = $1 AND thing_id = $2;`
+
+	var dbco dbConnection
+	if err := cr.db.QueryRowxContext(ctx, q, chanID, thingID).StructScan(&dbco); err != nil {
+		if err == sql.ErrNoRows {
+			return things.ConnectionACL{}, things.ErrNotFound
+		}
+		return things.ConnectionACL{}, errors.Wrap(things.ErrEntityConnected, err)
+	}
+
+	return things.ConnectionACL{
+		Actions: dbco.Actions,
+		Allow:   dbco.Allow,
+		Deny:    dbco.Deny,
+	}, nil
 }
 
-func (cr channelRepository) hasThing(ctx context.Context, chanID, thingID string) error {
-	q := `SELECT EXISTS (SELECT 1 FROM connections WHERE channel_id = $1 AND thing_id = $2);`
+func (cr channelRepository) hasThing(ctx context.Context, chanID, thingID, action string) error {
+	q := `SELECT EXISTS (SELECT 1 FROM connections conn
+	      INNER JOIN things th ON th.id = conn.thing_id
+	      INNER JOIN channels ch ON ch.id = conn.channel_id
+	      WHERE conn.channel_id = $1 AND conn.thing_id = $2
+	      AND ($3 = '' OR $3 = ANY(conn.actions))
+	      AND th.status = $4 AND ch.status = $4);`
 	exists := false
-	if err := cr.db.QueryRowxContext(ctx, q, chanID, thingID).Scan(&exists); err != nil {
+	if err := cr.db.QueryRowxContext(ctx, q, chanID, thingID, action, things.EnabledStatus).Scan(&exists); err != nil {
 		return errors.Wrap(things.ErrEntityConnected, err)
 	}
 
@@ -440,14 +580,21 @@ type dbChannel struct {
 	Owner    string     `db:"owner"`
 	Name     string     `db:"name"`
 	Metadata dbMetadata `db:"metadata"`
+	Status   string     `db:"status"`
 }
 
 func toDBChannel(ch things.Channel) dbChannel {
+	status := ch.Status
+	if status == "" {
+		status = things.EnabledStatus
+	}
+
 	return dbChannel{
 		ID:       ch.ID,
 		Owner:    ch.Owner,
 		Name:     ch.Name,
 		Metadata: ch.Metadata,
+		Status:   status,
 	}
 }
 
@@ -457,6 +604,7 @@ func toChannel(ch dbChannel) things.Channel {
 		Owner:    ch.Owner,
 		Name:     ch.Name,
 		Metadata: ch.Metadata,
+		Status:   ch.Status,
 	}
 }
 
@@ -469,6 +617,15 @@ func getNameQuery(name string) (string, string) {
 	return nq, name
 }
 
+// getStatusQuery returns the query that filters the entities by status.
+// Entities of all statuses are selected when the status is not set.
+func getStatusQuery(status string) string {
+	if status == "" || status == things.AllStatus {
+		return ""
+	}
+	return ` AND status = :status`
+}
+
 func getOrderQuery(order string) string {
 	switch order {
 	case "name":
@@ -496,6 +653,49 @@ func getDirQuery(dir string) string {
 	}
 }
 
+// getOwnerQuery returns the query that selects the entities owned by the
+// owner or identified by one of the shared IDs. Shared IDs that are not
+// valid UUIDs can't identify a thing or a channel, so they are skipped.
+func getOwnerQuery(shared []string) (string, interface{}) {
+	var ids []string
+	for _, id := range shared {
+		if _, err := uuid.FromString(id); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return "owner = :owner", pq.Array(ids)
+	}
+
+	return "(owner = :owner OR id = ANY(:shared))", pq.Array(ids)
+}
+
+// getCursorQuery returns the query that selects the entities following the
+// one the cursor points to, in the order of their IDs. The cursor can't be
+// used with any other order.
+func getCursorQuery(cursor, order, dir string) (string, string, error) {
+	if cursor == "" {
+		return "", "", nil
+	}
+	if getOrderQuery(order) != "id" {
+		return "", "", things.ErrMalformedEntity
+	}
+
+	id, err := things.DecodeCursor(cursor)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := uuid.FromString(id); err != nil {
+		return "", "", things.ErrMalformedEntity
+	}
+
+	if getDirQuery(dir) == "ASC" {
+		return " AND id > :cursor", id, nil
+	}
+	return " AND id < :cursor", id, nil
+}
+
 func getMetadataQuery(m things.Metadata) ([]byte, string, error) {
 	mq := ""
 	mb := []byte("{}")
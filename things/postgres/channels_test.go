@@ -16,6 +16,8 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var connACL = things.ConnectionACL{Actions: []string{things.PublishAction, things.SubscribeAction}}
+
 func TestChannelsSave(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	channelRepo := postgres.NewChannelRepository(dbMiddleware)
@@ -140,6 +142,73 @@ func TestChannelUpdate(t *testing.T) {
 	}
 }
 
+func TestChannelUpdateStatus(t *testing.T) {
+	email := "channel-update-status@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ch := things.Channel{
+		ID:    id,
+		Owner: email,
+	}
+
+	chs, err := chanRepo.Save(context.Background(), ch)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch.ID = chs[0].ID
+
+	nonexistentChanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		owner  string
+		id     string
+		status string
+		err    error
+	}{
+		{
+			desc:   "disable existing channel",
+			owner:  email,
+			id:     ch.ID,
+			status: things.DisabledStatus,
+			err:    nil,
+		},
+		{
+			desc:   "enable existing channel",
+			owner:  email,
+			id:     ch.ID,
+			status: things.EnabledStatus,
+			err:    nil,
+		},
+		{
+			desc:   "update status of existing channel with non-existing user",
+			owner:  wrongValue,
+			id:     ch.ID,
+			status: things.DisabledStatus,
+			err:    things.ErrNotFound,
+		},
+		{
+			desc:   "update status of non-existing channel",
+			owner:  email,
+			id:     nonexistentChanID,
+			status: things.DisabledStatus,
+			err:    things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := chanRepo.UpdateStatus(context.Background(), tc.owner, tc.id, tc.status)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			ch, err := chanRepo.RetrieveByID(context.Background(), tc.owner, tc.id)
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+			assert.Equal(t, tc.status, ch.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, ch.Status))
+		}
+	}
+}
+
 func TestSingleChannelRetrieval(t *testing.T) {
 	email := "channel-single-retrieval@example.com"
 	dbMiddleware := postgres.NewDatabase(db)
@@ -166,7 +235,7 @@ func TestSingleChannelRetrieval(t *testing.T) {
 	}
 	chs, _ := chanRepo.Save(context.Background(), ch)
 	ch.ID = chs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{ch.ID}, []string{th.ID})
+	chanRepo.Connect(context.Background(), email, []string{ch.ID}, []string{th.ID}, connACL)
 
 	nonexistentChanID, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -221,11 +290,16 @@ func TestMultiChannelRetrieval(t *testing.T) {
 	nameNum := uint64(3)
 	metaNum := uint64(3)
 	nameMetaNum := uint64(2)
+	sharedNum := uint64(4)
 
 	n := uint64(10)
+	var shared []string
 	for i := uint64(0); i < n; i++ {
 		chID, err := idProvider.ID()
 		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		if i < sharedNum {
+			shared = append(shared, chID)
+		}
 
 		ch := things.Channel{
 			ID:    chID,
@@ -251,6 +325,7 @@ func TestMultiChannelRetrieval(t *testing.T) {
 
 	cases := map[string]struct {
 		owner        string
+		shared       []string
 		size         uint64
 		pageMetadata things.PageMetadata
 	}{
@@ -281,6 +356,26 @@ func TestMultiChannelRetrieval(t *testing.T) {
 			},
 			size: 0,
 		},
+		"retrieve channels shared with non-owner": {
+			owner:  wrongValue,
+			shared: shared,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  sharedNum,
+			},
+			size: sharedNum,
+		},
+		"retrieve all channels with existing owner and shared channels": {
+			owner:  email,
+			shared: shared,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  n,
+			},
+			size: n,
+		},
 		"retrieve channels with existing name": {
 			owner: email,
 			pageMetadata: things.PageMetadata{
@@ -356,7 +451,7 @@ func TestMultiChannelRetrieval(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		page, err := chanRepo.RetrieveAll(context.Background(), tc.owner, tc.pageMetadata)
+		page, err := chanRepo.RetrieveAll(context.Background(), tc.owner, tc.shared, tc.pageMetadata)
 		size := uint64(len(page.Channels))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
 		assert.Equal(t, tc.pageMetadata.Total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.pageMetadata.Total, page.Total))
@@ -367,6 +462,45 @@ func TestMultiChannelRetrieval(t *testing.T) {
 	}
 }
 
+func TestMultiChannelRetrievalByCursor(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+
+	email := "channel-multi-retrieval-by-cursor@example.com"
+
+	n := 10
+	var ids []string
+	for i := 0; i < n; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		_, err = chanRepo.Save(context.Background(), things.Channel{Owner: email, ID: id})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		ids = append(ids, id)
+	}
+
+	for _, dir := range []string{"asc", "desc"} {
+		// Follow the cursors through all the pages.
+		pm := things.PageMetadata{Limit: 4, Dir: dir}
+		var retrieved []string
+		for i := 0; i < n; i++ {
+			page, err := chanRepo.RetrieveAll(context.Background(), email, nil, pm)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", dir, err))
+			assert.Equal(t, uint64(n), page.Total, fmt.Sprintf("%s: expected total %d got %d", dir, n, page.Total))
+			for _, ch := range page.Channels {
+				retrieved = append(retrieved, ch.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			pm.Cursor = page.NextCursor
+		}
+		assert.ElementsMatch(t, ids, retrieved, fmt.Sprintf("%s: expected %v got %v", dir, ids, retrieved))
+	}
+
+	_, err := chanRepo.RetrieveAll(context.Background(), email, nil, things.PageMetadata{Limit: 4, Order: "name", Cursor: things.EncodeCursor(ids[0])})
+	assert.True(t, errors.Contains(err, things.ErrMalformedEntity), fmt.Sprintf("expected %s got %s", things.ErrMalformedEntity, err))
+}
+
 func TestRetrieveByThing(t *testing.T) {
 	email := "channel-multi-retrieval-by-thing@example.com"
 	dbMiddleware := postgres.NewDatabase(db)
@@ -401,7 +535,7 @@ func TestRetrieveByThing(t *testing.T) {
 			break
 		}
 
-		err = chanRepo.Connect(context.Background(), email, []string{cid}, []string{thID})
+		err = chanRepo.Connect(context.Background(), email, []string{cid}, []string{thID}, connACL)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	}
 
@@ -637,7 +771,7 @@ func TestConnect(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := chanRepo.Connect(context.Background(), tc.owner, []string{tc.chID}, []string{tc.thID})
+		err := chanRepo.Connect(context.Background(), tc.owner, []string{tc.chID}, []string{tc.thID}, connACL)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
@@ -670,7 +804,7 @@ func TestDisconnect(t *testing.T) {
 	})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	chID = chs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{chID}, []string{thID})
+	chanRepo.Connect(context.Background(), email, []string{chID}, []string{thID}, connACL)
 
 	nonexistentThingID, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -756,7 +890,7 @@ func TestHasThing(t *testing.T) {
 	})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	chID = chs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{chID}, []string{thID})
+	chanRepo.Connect(context.Background(), email, []string{chID}, []string{thID}, things.ConnectionACL{Actions: []string{things.PublishAction}})
 
 	nonexistentChanID, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -764,27 +898,43 @@ func TestHasThing(t *testing.T) {
 	cases := map[string]struct {
 		chID      string
 		key       string
+		action    string
 		hasAccess bool
 	}{
 		"access check for thing that has access": {
 			chID:      chID,
 			key:       th.Key,
+			action:    things.PublishAction,
+			hasAccess: true,
+		},
+		"access check for thing without access for action": {
+			chID:      chID,
+			key:       th.Key,
+			action:    things.SubscribeAction,
+			hasAccess: false,
+		},
+		"access check for thing with access for any action": {
+			chID:      chID,
+			key:       th.Key,
+			action:    "",
 			hasAccess: true,
 		},
 		"access check for thing without access": {
 			chID:      chID,
 			key:       wrongValue,
+			action:    things.PublishAction,
 			hasAccess: false,
 		},
 		"access check for non-existing channel": {
 			chID:      nonexistentChanID,
 			key:       th.Key,
+			action:    things.PublishAction,
 			hasAccess: false,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := chanRepo.HasThing(context.Background(), tc.chID, tc.key)
+		_, err := chanRepo.HasThing(context.Background(), tc.chID, tc.key, tc.action)
 		hasAccess := err == nil
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
 	}
@@ -831,7 +981,18 @@ func TestHasThingByID(t *testing.T) {
 	})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	chID = chs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{chID}, []string{thID})
+	chanRepo.Connect(context.Background(), email, []string{chID}, []string{thID}, things.ConnectionACL{Actions: []string{things.PublishAction}})
+
+	disabledChID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	chs, err = chanRepo.Save(context.Background(), things.Channel{
+		ID:    disabledChID,
+		Owner: email,
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	chanRepo.Connect(context.Background(), email, []string{disabledChID}, []string{thID}, things.ConnectionACL{})
+	err = chanRepo.UpdateStatus(context.Background(), email, disabledChID, things.DisabledStatus)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	nonexistentChanID, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -839,37 +1000,119 @@ func TestHasThingByID(t *testing.T) {
 	cases := map[string]struct {
 		chID      string
 		thID      string
+		action    string
 		hasAccess bool
 	}{
+		"access check for disabled channel": {
+			chID:      disabledChID,
+			thID:      thID,
+			action:    things.PublishAction,
+			hasAccess: false,
+		},
 		"access check for thing that has access": {
 			chID:      chID,
 			thID:      thID,
+			action:    things.PublishAction,
 			hasAccess: true,
 		},
+		"access check for thing without access for action": {
+			chID:      chID,
+			thID:      thID,
+			action:    things.SubscribeAction,
+			hasAccess: false,
+		},
 		"access check for thing without access": {
 			chID:      chID,
 			thID:      disconnectedThingID,
+			action:    things.PublishAction,
 			hasAccess: false,
 		},
 		"access check for non-existing channel": {
 			chID:      nonexistentChanID,
 			thID:      thID,
+			action:    things.PublishAction,
 			hasAccess: false,
 		},
 		"access check for non-existing thing": {
 			chID:      chID,
 			thID:      wrongValue,
+			action:    things.PublishAction,
 			hasAccess: false,
 		},
 	}
 
 	for desc, tc := range cases {
-		err := chanRepo.HasThingByID(context.Background(), tc.chID, tc.thID)
+		err := chanRepo.HasThingByID(context.Background(), tc.chID, tc.thID, tc.action)
 		hasAccess := err == nil
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
 	}
 }
 
+func TestRetrieveACL(t *testing.T) {
+	email := "channel-retrieve-acl@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+
+	thID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thkey, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ths, err := thingRepo.Save(context.Background(), things.Thing{
+		ID:    thID,
+		Owner: email,
+		Key:   thkey,
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	thID = ths[0].ID
+
+	chID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	chs, err := chanRepo.Save(context.Background(), things.Channel{
+		ID:    chID,
+		Owner: email,
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	chID = chs[0].ID
+
+	acl := things.ConnectionACL{
+		Actions: []string{things.PublishAction},
+		Allow:   []string{"site.dev1.>"},
+		Deny:    []string{"site.dev1.secret"},
+	}
+	err = chanRepo.Connect(context.Background(), email, []string{chID}, []string{thID}, acl)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	nonexistentChanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := map[string]struct {
+		chID string
+		thID string
+		acl  things.ConnectionACL
+		err  error
+	}{
+		"retrieve access rules of existing connection": {
+			chID: chID,
+			thID: thID,
+			acl:  acl,
+			err:  nil,
+		},
+		"retrieve access rules of non-existing connection": {
+			chID: nonexistentChanID,
+			thID: thID,
+			acl:  things.ConnectionACL{},
+			err:  things.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		acl, err := chanRepo.RetrieveACL(context.Background(), tc.chID, tc.thID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		assert.Equal(t, tc.acl, acl, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.acl, acl))
+	}
+}
+
 func testSortChannels(t *testing.T, pm things.PageMetadata, chs []things.Channel) {
 	switch pm.Order {
 	case "name":
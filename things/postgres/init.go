@@ -99,6 +99,90 @@ func migrateDB(db *sqlx.DB) error {
 					`ALTER TABLE IF EXISTS things ADD CONSTRAINT things_id_key UNIQUE (id)`,
 				},
 			},
+			{
+				Id: "things_5",
+				Up: []string{
+					`ALTER TABLE IF EXISTS connections ADD COLUMN IF NOT EXISTS
+					 actions TEXT[] NOT NULL DEFAULT '{publish,subscribe}'`,
+				},
+			},
+			{
+				Id: "things_6",
+				Up: []string{
+					`ALTER TABLE IF EXISTS connections ADD COLUMN IF NOT EXISTS allow TEXT[]`,
+					`ALTER TABLE IF EXISTS connections ADD COLUMN IF NOT EXISTS deny TEXT[]`,
+				},
+			},
+			{
+				Id: "things_7",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS thing_keys (
+						thing_id   UUID,
+						name       VARCHAR(1024),
+						key        VARCHAR(4096) UNIQUE NOT NULL,
+						expires_at TIMESTAMP,
+						FOREIGN KEY (thing_id) REFERENCES things (id) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (thing_id, name)
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE thing_keys",
+				},
+			},
+			{
+				Id: "things_8",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS key_values (
+						key VARCHAR(4096) PRIMARY KEY
+					)`,
+					`INSERT INTO key_values (key)
+					 SELECT key FROM things UNION SELECT key FROM thing_keys
+					 ON CONFLICT DO NOTHING`,
+					`CREATE OR REPLACE FUNCTION register_key()
+					 RETURNS trigger
+					 LANGUAGE PLPGSQL
+					 AS
+					 $$
+					 BEGIN
+					 IF TG_OP <> 'INSERT' THEN
+						DELETE FROM key_values WHERE key = OLD.key;
+					 END IF;
+					 IF TG_OP = 'DELETE' THEN
+						RETURN OLD;
+					 END IF;
+					 INSERT INTO key_values (key) VALUES (NEW.key);
+					 RETURN NEW;
+					 END;
+					 $$`,
+					`CREATE TRIGGER register_thing_key_tr
+					 AFTER INSERT OR DELETE OR UPDATE OF key
+					 ON things
+					 FOR EACH ROW
+					 EXECUTE PROCEDURE register_key();`,
+					`CREATE TRIGGER register_named_key_tr
+					 AFTER INSERT OR DELETE OR UPDATE OF key
+					 ON thing_keys
+					 FOR EACH ROW
+					 EXECUTE PROCEDURE register_key();`,
+				},
+				Down: []string{
+					"DROP TRIGGER IF EXISTS register_thing_key_tr ON things",
+					"DROP TRIGGER IF EXISTS register_named_key_tr ON thing_keys",
+					"DROP FUNCTION IF EXISTS register_key",
+					"DROP TABLE IF EXISTS key_values",
+				},
+			},
+			{
+				Id: "things_9",
+				Up: []string{
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS status VARCHAR(32) NOT NULL DEFAULT 'enabled'`,
+					`ALTER TABLE IF EXISTS channels ADD COLUMN IF NOT EXISTS status VARCHAR(32) NOT NULL DEFAULT 'enabled'`,
+				},
+				Down: []string{
+					"ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS status",
+					"ALTER TABLE IF EXISTS channels DROP COLUMN IF EXISTS status",
+				},
+			},
 		},
 	}
 
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/lib/pq" // required for DB access
@@ -43,8 +44,8 @@ func (tr thingRepository) Save(ctx context.Context, ths ...things.Thing) ([]thin
 		return []things.Thing{}, errors.Wrap(things.ErrCreateEntity, err)
 	}
 
-	q := `INSERT INTO things (id, owner, name, key, metadata)
-		  VALUES (:id, :owner, :name, :key, :metadata);`
+	q := `INSERT INTO things (id, owner, name, key, metadata, status)
+		  VALUES (:id, :owner, :name, :key, :metadata, :status);`
 
 	for _, thing := range ths {
 		dbth, err := toDBThing(thing)
@@ -108,7 +109,43 @@ func (tr thingRepository) Update(ctx context.Context, t things.Thing) error {
 	return nil
 }
 
-func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string) error {
+func (tr thingRepository) UpdateStatus(ctx context.Context, owner, id, status string) error {
+	q := `UPDATE things SET status = :status WHERE owner = :owner AND id = :id;`
+
+	dbth := dbThing{
+		ID:     id,
+		Owner:  owner,
+		Status: status,
+	}
+
+	res, err := tr.db.NamedExecContext(ctx, q, dbth)
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && errInvalid == pqErr.Code.Name() {
+			return errors.Wrap(things.ErrNotFound, err)
+		}
+
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return things.ErrNotFound
+	}
+
+	return nil
+}
+
+func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string, prev things.Key) error {
+	tx, err := tr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
 	q := `UPDATE things SET key = :key WHERE owner = :owner AND id = :id;`
 
 	dbth := dbThing{
@@ -117,24 +154,72 @@ func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string)
 		Key:   key,
 	}
 
-	res, err := tr.db.NamedExecContext(ctx, q, dbth)
+	res, err := tx.NamedExecContext(ctx, q, dbth)
+	if err != nil {
+		tx.Rollback()
+		return updateKeyError(err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		tx.Rollback()
+		return things.ErrNotFound
+	}
+
+	if prev.Name != "" {
+		q = `DELETE FROM thing_keys WHERE thing_id = :thing_id AND name = :name;`
+		if _, err := tx.NamedExecContext(ctx, q, toDBKey(prev)); err != nil {
+			tx.Rollback()
+			return errors.Wrap(things.ErrUpdateEntity, err)
+		}
+	}
+
+	if prev.Value != "" {
+		q = `INSERT INTO thing_keys (thing_id, name, key, expires_at)
+		     VALUES (:thing_id, :name, :key, :expires_at);`
+		if _, err := tx.NamedExecContext(ctx, q, toDBKey(prev)); err != nil {
+			tx.Rollback()
+			return updateKeyError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (tr thingRepository) SaveKey(ctx context.Context, owner string, key things.Key) error {
+	q := `INSERT INTO thing_keys (thing_id, name, key, expires_at)
+	      SELECT id, :name, :key, :expires_at FROM things WHERE id = :thing_id AND owner = :owner;`
+
+	dbk := toDBKey(key)
+	dbk.Owner = owner
+
+	res, err := tr.db.NamedExecContext(ctx, q, dbk)
 	if err != nil {
 		pqErr, ok := err.(*pq.Error)
 		if ok {
 			switch pqErr.Code.Name() {
-			case errInvalid:
+			case errInvalid, errTruncation:
 				return errors.Wrap(things.ErrMalformedEntity, err)
 			case errDuplicate:
 				return errors.Wrap(things.ErrConflict, err)
 			}
 		}
 
-		return errors.Wrap(things.ErrUpdateEntity, err)
+		return errors.Wrap(things.ErrCreateEntity, err)
 	}
 
 	cnt, err := res.RowsAffected()
 	if err != nil {
-		return errors.Wrap(things.ErrUpdateEntity, err)
+		return errors.Wrap(things.ErrCreateEntity, err)
 	}
 
 	if cnt == 0 {
@@ -144,8 +229,57 @@ func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string)
 	return nil
 }
 
+func (tr thingRepository) RetrieveKeys(ctx context.Context, owner, id string) ([]things.Key, error) {
+	q := `SELECT tk.thing_id, tk.name, tk.key, tk.expires_at FROM thing_keys tk
+	      INNER JOIN things th ON th.id = tk.thing_id
+	      WHERE th.owner = :owner AND tk.thing_id = :thing_id ORDER BY tk.name;`
+
+	dbk := dbKey{
+		ThingID: id,
+		Owner:   owner,
+	}
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, dbk)
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && errInvalid == pqErr.Code.Name() {
+			return []things.Key{}, errors.Wrap(things.ErrNotFound, err)
+		}
+		return []things.Key{}, errors.Wrap(things.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	keys := []things.Key{}
+	for rows.Next() {
+		dbk := dbKey{}
+		if err := rows.StructScan(&dbk); err != nil {
+			return []things.Key{}, errors.Wrap(things.ErrSelectEntity, err)
+		}
+
+		keys = append(keys, toKey(dbk))
+	}
+
+	return keys, nil
+}
+
+func (tr thingRepository) RemoveKey(ctx context.Context, owner, id, name string) error {
+	q := `DELETE FROM thing_keys tk USING things th
+	      WHERE th.id = tk.thing_id AND th.owner = :owner AND tk.thing_id = :thing_id AND tk.name = :name;`
+
+	dbk := dbKey{
+		ThingID: id,
+		Owner:   owner,
+		Name:    name,
+	}
+
+	if _, err := tr.db.NamedExecContext(ctx, q, dbk); err != nil {
+		return errors.Wrap(things.ErrRemoveEntity, err)
+	}
+	return nil
+}
+
 func (tr thingRepository) RetrieveByID(ctx context.Context, owner, id string) (things.Thing, error) {
-	q := `SELECT name, key, metadata FROM things WHERE id = $1 AND owner = $2;`
+	q := `SELECT name, key, metadata, status FROM things WHERE id = $1 AND owner = $2;`
 
 	dbth := dbThing{
 		ID:    id,
@@ -163,18 +297,24 @@ func (tr thingRepository) RetrieveByID(ctx context.Context, owner, id string) (t
 	return toThing(dbth)
 }
 
-func (tr thingRepository) RetrieveByKey(ctx context.Context, key string) (string, error) {
-	q := `SELECT id FROM things WHERE key = $1;`
-
-	var id string
-	if err := tr.db.QueryRowxContext(ctx, q, key).Scan(&id); err != nil {
+func (tr thingRepository) RetrieveByKey(ctx context.Context, key string) (things.Key, error) {
+	q := `SELECT id AS thing_id, '' AS name, key, NULL AS expires_at FROM things
+	      WHERE key = $1 AND status = $3
+	      UNION ALL
+	      SELECT tk.thing_id, tk.name, tk.key, tk.expires_at FROM thing_keys tk
+	      INNER JOIN things th ON th.id = tk.thing_id
+	      WHERE tk.key = $1 AND (tk.expires_at IS NULL OR tk.expires_at > $2) AND th.status = $3
+	      LIMIT 1;`
+
+	dbk := dbKey{}
+	if err := tr.db.QueryRowxContext(ctx, q, key, time.Now().UTC(), things.EnabledStatus).StructScan(&dbk); err != nil {
 		if err == sql.ErrNoRows {
-			return "", errors.Wrap(things.ErrNotFound, err)
+			return things.Key{}, errors.Wrap(things.ErrNotFound, err)
 		}
-		return "", errors.Wrap(things.ErrSelectEntity, err)
+		return things.Key{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
-	return id, nil
+	return toKey(dbk), nil
 }
 
 func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string, pm things.PageMetadata) (things.Page, error) {
@@ -183,6 +323,7 @@ func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string,
 	}
 
 	nq, name := getNameQuery(pm.Name)
+	sq := getStatusQuery(pm.Status)
 	oq := getOrderQuery(pm.Order)
 	dq := getDirQuery(pm.Dir)
 	idq := fmt.Sprintf("WHERE id IN ('%s') ", strings.Join(thingIDs, "','"))
@@ -192,14 +333,15 @@ func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string,
 		return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
-	q := fmt.Sprintf(`SELECT id, owner, name, key, metadata FROM things
-					   %s%s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, idq, mq, nq, oq, dq)
+	q := fmt.Sprintf(`SELECT id, owner, name, key, metadata, status FROM things
+					   %s%s%s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, idq, mq, nq, sq, oq, dq)
 
 	params := map[string]interface{}{
 		"limit":    pm.Limit,
 		"offset":   pm.Offset,
 		"name":     name,
 		"metadata": m,
+		"status":   pm.Status,
 	}
 
 	rows, err := tr.db.NamedQueryContext(ctx, q, params)
@@ -223,7 +365,7 @@ func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string,
 		items = append(items, th)
 	}
 
-	cq := fmt.Sprintf(`SELECT COUNT(*) FROM things %s%s%s;`, idq, mq, nq)
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM things %s%s%s%s;`, idq, mq, nq, sq)
 
 	total, err := total(ctx, tr.db, cq, params)
 	if err != nil {
@@ -244,23 +386,32 @@ func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string,
 	return page, nil
 }
 
-func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, pm things.PageMetadata) (things.Page, error) {
+func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, shared []string, pm things.PageMetadata) (things.Page, error) {
 	nq, name := getNameQuery(pm.Name)
+	sq := getStatusQuery(pm.Status)
 	oq := getOrderQuery(pm.Order)
 	dq := getDirQuery(pm.Dir)
+	ownq, ids := getOwnerQuery(shared)
+	curq, cursor, err := getCursorQuery(pm.Cursor, pm.Order, pm.Dir)
+	if err != nil {
+		return things.Page{}, errors.Wrap(things.ErrMalformedEntity, err)
+	}
 	m, mq, err := getMetadataQuery(pm.Metadata)
 	if err != nil {
 		return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, key, metadata FROM things
-	      WHERE owner = :owner %s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, mq, nq, oq, dq)
+	q := fmt.Sprintf(`SELECT id, owner, name, key, metadata, status FROM things
+	      WHERE %s%s%s%s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, ownq, mq, nq, sq, curq, oq, dq)
 	params := map[string]interface{}{
 		"owner":    owner,
+		"shared":   ids,
 		"limit":    pm.Limit,
 		"offset":   pm.Offset,
 		"name":     name,
 		"metadata": m,
+		"status":   pm.Status,
+		"cursor":   cursor,
 	}
 
 	rows, err := tr.db.NamedQueryContext(ctx, q, params)
@@ -271,7 +422,7 @@ func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, pm thin
 
 	var items []things.Thing
 	for rows.Next() {
-		dbth := dbThing{Owner: owner}
+		dbth := dbThing{}
 		if err := rows.StructScan(&dbth); err != nil {
 			return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
 		}
@@ -284,7 +435,7 @@ func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, pm thin
 		items = append(items, th)
 	}
 
-	cq := fmt.Sprintf(`SELECT COUNT(*) FROM things WHERE owner = :owner %s%s;`, nq, mq)
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM things WHERE %s%s%s%s;`, ownq, nq, mq, sq)
 
 	total, err := total(ctx, tr.db, cq, params)
 	if err != nil {
@@ -301,6 +452,9 @@ func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, pm thin
 			Dir:    pm.Dir,
 		},
 	}
+	if n := len(items); oq == "id" && n > 0 && uint64(n) == pm.Limit {
+		page.NextCursor = things.EncodeCursor(items[n-1].ID)
+	}
 
 	return page, nil
 }
@@ -317,7 +471,7 @@ func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, chID str
 	var q, qc string
 	switch pm.Disconnected {
 	case true:
-		q = fmt.Sprintf(`SELECT id, name, key, metadata
+		q = fmt.Sprintf(`SELECT id, name, key, metadata, status
 		        FROM things th
 		        WHERE th.owner = :owner AND th.id NOT IN
 		        (SELECT id FROM things th
@@ -336,7 +490,7 @@ func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, chID str
 		          ON th.id = conn.thing_id
 		          WHERE th.owner = $1 AND conn.channel_id = $2);`
 	default:
-		q = fmt.Sprintf(`SELECT id, name, key, metadata
+		q = fmt.Sprintf(`SELECT id, name, key, metadata, status
 		        FROM things th
 		        INNER JOIN connections conn
 		        ON th.id = conn.thing_id
@@ -413,6 +567,54 @@ type dbThing struct {
 	Name     string `db:"name"`
 	Key      string `db:"key"`
 	Metadata []byte `db:"metadata"`
+	Status   string `db:"status"`
+}
+
+type dbKey struct {
+	ThingID   string       `db:"thing_id"`
+	Owner     string       `db:"owner"`
+	Name      string       `db:"name"`
+	Key       string       `db:"key"`
+	ExpiresAt sql.NullTime `db:"expires_at"`
+}
+
+func toDBKey(k things.Key) dbKey {
+	return dbKey{
+		ThingID: k.ThingID,
+		Name:    k.Name,
+		Key:     k.Value,
+		ExpiresAt: sql.NullTime{
+			Time:  k.ExpiresAt,
+			Valid: !k.ExpiresAt.IsZero(),
+		},
+	}
+}
+
+func toKey(dbk dbKey) things.Key {
+	k := things.Key{
+		ThingID: dbk.ThingID,
+		Name:    dbk.Name,
+		Value:   dbk.Key,
+	}
+	if dbk.ExpiresAt.Valid {
+		k.ExpiresAt = dbk.ExpiresAt.Time
+	}
+
+	return k
+}
+
+func updateKeyError(err error) error {
+	pqErr, ok := err.(*pq.Error)
+	if ok {
+		switch pqErr.Code.Name() {
+		case errInvalid:
+			return errors.Wrap(things.ErrMalformedEntity, err)
+		case errDuplicate:
+			return errors.Wrap(things.ErrConflict, err)
+		}
+	}
+
+	return errors.Wrap(things.ErrUpdateEntity, err)
 }
 
 func toDBThing(th things.Thing) (dbThing, error) {
@@ -425,12 +627,18 @@ func toDBThing(th things.Thing) (dbThing, error) {
 		data = b
 	}
 
+	status := th.Status
+	if status == "" {
+		status = things.EnabledStatus
+	}
+
 	return dbThing{
 		ID:       th.ID,
 		Owner:    th.Owner,
 		Name:     th.Name,
 		Key:      th.Key,
 		Metadata: data,
+		Status:   status,
 	}, nil
 }
 
@@ -446,5 +654,6 @@ func toThing(dbth dbThing) (things.Thing, error) {
 		Name:     dbth.Name,
 		Key:      dbth.Key,
 		Metadata: metadata,
+		Status:   dbth.Status,
 	}, nil
 }
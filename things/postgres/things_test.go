@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -188,6 +189,85 @@ func TestThingUpdate(t *testing.T) {
 	}
 }
 
+func TestThingUpdateStatus(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	email := "thing-update-status@example.com"
+
+	thID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thkey, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	th := things.Thing{
+		ID:    thID,
+		Owner: email,
+		Key:   thkey,
+	}
+
+	ths, err := thingRepo.Save(context.Background(), th)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	th.ID = ths[0].ID
+
+	nonexistentThingID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		owner  string
+		id     string
+		status string
+		err    error
+	}{
+		{
+			desc:   "disable existing thing",
+			owner:  email,
+			id:     th.ID,
+			status: things.DisabledStatus,
+			err:    nil,
+		},
+		{
+			desc:   "enable existing thing",
+			owner:  email,
+			id:     th.ID,
+			status: things.EnabledStatus,
+			err:    nil,
+		},
+		{
+			desc:   "update status of existing thing with non-existing user",
+			owner:  wrongValue,
+			id:     th.ID,
+			status: things.DisabledStatus,
+			err:    things.ErrNotFound,
+		},
+		{
+			desc:   "update status of non-existing thing",
+			owner:  email,
+			id:     nonexistentThingID,
+			status: things.DisabledStatus,
+			err:    things.ErrNotFound,
+		},
+		{
+			desc:   "update status of thing with invalid ID",
+			owner:  email,
+			id:     wrongValue,
+			status: things.DisabledStatus,
+			err:    things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := thingRepo.UpdateStatus(context.Background(), tc.owner, tc.id, tc.status)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			th, err := thingRepo.RetrieveByID(context.Background(), tc.owner, tc.id)
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+			assert.Equal(t, tc.status, th.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, th.Status))
+		}
+	}
+}
+
 func TestUpdateKey(t *testing.T) {
 	email := "thing-update=key@example.com"
 	newKey := "new-key"
@@ -228,6 +308,7 @@ func TestUpdateKey(t *testing.T) {
 		owner string
 		id    string
 		key   string
+		prev  things.Key
 		err   error
 	}{
 		{
@@ -237,6 +318,32 @@ func TestUpdateKey(t *testing.T) {
 			key:   newKey,
 			err:   nil,
 		},
+		{
+			desc:  "update key of an existing thing keeping the previous key",
+			owner: th2.Owner,
+			id:    th2.ID,
+			key:   "newer-key",
+			prev: things.Key{
+				ThingID:   th2.ID,
+				Name:      things.PreviousKey,
+				Value:     newKey,
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+			err: nil,
+		},
+		{
+			desc:  "update key of an existing thing replacing the previous key",
+			owner: th2.Owner,
+			id:    th2.ID,
+			key:   "newest-key",
+			prev: things.Key{
+				ThingID:   th2.ID,
+				Name:      things.PreviousKey,
+				Value:     "newer-key",
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+			err: nil,
+		},
 		{
 			desc:  "update key of a non-existing thing with existing user",
 			owner: th2.Owner,
@@ -268,9 +375,14 @@ func TestUpdateKey(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := thingRepo.UpdateKey(context.Background(), tc.owner, tc.id, tc.key)
+		err := thingRepo.UpdateKey(context.Background(), tc.owner, tc.id, tc.key, tc.prev)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
+
+	keys, err := thingRepo.RetrieveKeys(context.Background(), th2.Owner, th2.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	require.Len(t, keys, 1, fmt.Sprintf("expected single previous key got %d keys\n", len(keys)))
+	assert.Equal(t, "newer-key", keys[0].Value, fmt.Sprintf("expected previous key %s got %s\n", "newer-key", keys[0].Value))
 }
 
 func TestSingleThingRetrieval(t *testing.T) {
@@ -348,16 +460,55 @@ func TestThingRetrieveByKey(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th.ID = ths[0].ID
 
+	named := saveKey(t, thingRepo, th, "named", time.Time{})
+	expired := saveKey(t, thingRepo, th, "expired", time.Now().Add(-time.Hour))
+
+	disabledID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	disabledKey, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	disabled := things.Thing{
+		ID:    disabledID,
+		Owner: email,
+		Key:   disabledKey,
+	}
+	_, err = thingRepo.Save(context.Background(), disabled)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	disabledNamed := saveKey(t, thingRepo, disabled, "named", time.Time{})
+	err = thingRepo.UpdateStatus(context.Background(), email, disabledID, things.DisabledStatus)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
 	cases := map[string]struct {
 		key string
 		ID  string
 		err error
 	}{
+		"retrieve disabled thing by key": {
+			key: disabled.Key,
+			ID:  "",
+			err: things.ErrNotFound,
+		},
+		"retrieve disabled thing by named key": {
+			key: disabledNamed.Value,
+			ID:  "",
+			err: things.ErrNotFound,
+		},
 		"retrieve existing thing by key": {
 			key: th.Key,
 			ID:  th.ID,
 			err: nil,
 		},
+		"retrieve existing thing by named key": {
+			key: named.Value,
+			ID:  th.ID,
+			err: nil,
+		},
+		"retrieve existing thing by expired key": {
+			key: expired.Value,
+			ID:  "",
+			err: things.ErrNotFound,
+		},
 		"retrieve non-existent thing by key": {
 			key: wrongValue,
 			ID:  "",
@@ -366,12 +517,233 @@ func TestThingRetrieveByKey(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		id, err := thingRepo.RetrieveByKey(context.Background(), tc.key)
-		assert.Equal(t, tc.ID, id, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.ID, id))
+		k, err := thingRepo.RetrieveByKey(context.Background(), tc.key)
+		assert.Equal(t, tc.ID, k.ThingID, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.ID, k.ThingID))
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
 
+func TestThingKeyUniqueness(t *testing.T) {
+	email := "thing-key-uniqueness@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	var ths []things.Thing
+	for i := 0; i < 2; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		key, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		th := things.Thing{
+			ID:    id,
+			Owner: email,
+			Key:   key,
+		}
+		_, err = thingRepo.Save(context.Background(), th)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		ths = append(ths, th)
+	}
+	th, other := ths[0], ths[1]
+
+	named := saveKey(t, thingRepo, th, "named", time.Time{})
+	expired := saveKey(t, thingRepo, th, "expired", time.Now().Add(-time.Hour))
+	err := thingRepo.UpdateStatus(context.Background(), email, th.ID, things.DisabledStatus)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	_, err = thingRepo.Save(context.Background(), things.Thing{ID: id, Owner: email, Key: named.Value})
+	assert.True(t, errors.Contains(err, things.ErrConflict), fmt.Sprintf("save thing with the named key: expected %s got %s\n", things.ErrConflict, err))
+
+	cases := map[string]string{
+		"key of disabled thing":       th.Key,
+		"named key of disabled thing": named.Value,
+		"expired key":                 expired.Value,
+	}
+
+	for desc, key := range cases {
+		err := thingRepo.UpdateKey(context.Background(), email, other.ID, key, things.Key{})
+		assert.True(t, errors.Contains(err, things.ErrConflict), fmt.Sprintf("update key to the %s: expected %s got %s\n", desc, things.ErrConflict, err))
+
+		err = thingRepo.SaveKey(context.Background(), email, things.Key{ThingID: other.ID, Name: "other", Value: key})
+		assert.True(t, errors.Contains(err, things.ErrConflict), fmt.Sprintf("save key with the %s: expected %s got %s\n", desc, things.ErrConflict, err))
+	}
+
+	// Removed keys can be taken again.
+	err = thingRepo.RemoveKey(context.Background(), email, th.ID, named.Name)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = thingRepo.UpdateKey(context.Background(), email, other.ID, named.Value, things.Key{})
+	assert.Nil(t, err, fmt.Sprintf("update key to the removed key: unexpected error: %s\n", err))
+}
+
+func TestThingSaveKey(t *testing.T) {
+	email := "thing-save-key@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	key, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	th := things.Thing{
+		ID:    id,
+		Owner: email,
+		Key:   key,
+	}
+
+	_, err = thingRepo.Save(context.Background(), th)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	value, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	nonexistentThingID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		key   things.Key
+		err   error
+	}{
+		{
+			desc:  "save key of existing thing",
+			owner: th.Owner,
+			key:   things.Key{ThingID: th.ID, Name: "named", Value: value, ExpiresAt: time.Now().Add(time.Hour)},
+			err:   nil,
+		},
+		{
+			desc:  "save key with existing name",
+			owner: th.Owner,
+			key:   things.Key{ThingID: th.ID, Name: "named", Value: "other-value"},
+			err:   things.ErrConflict,
+		},
+		{
+			desc:  "save key with existing value",
+			owner: th.Owner,
+			key:   things.Key{ThingID: th.ID, Name: "other", Value: value},
+			err:   things.ErrConflict,
+		},
+		{
+			desc:  "save key of existing thing with non-existing user",
+			owner: wrongValue,
+			key:   things.Key{ThingID: th.ID, Name: "foreign", Value: "foreign-value"},
+			err:   things.ErrNotFound,
+		},
+		{
+			desc:  "save key of non-existing thing",
+			owner: th.Owner,
+			key:   things.Key{ThingID: nonexistentThingID, Name: "missing", Value: "missing-value"},
+			err:   things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := thingRepo.SaveKey(context.Background(), tc.owner, tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestThingRetrieveKeys(t *testing.T) {
+	email := "thing-retrieve-keys@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	key, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	th := things.Thing{
+		ID:    id,
+		Owner: email,
+		Key:   key,
+	}
+
+	_, err = thingRepo.Save(context.Background(), th)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	expired := saveKey(t, thingRepo, th, "b-expired", time.Now().Add(-time.Hour))
+	named := saveKey(t, thingRepo, th, "a-named", time.Time{})
+
+	cases := map[string]struct {
+		owner string
+		id    string
+		keys  []things.Key
+	}{
+		"retrieve keys of existing thing": {
+			owner: th.Owner,
+			id:    th.ID,
+			keys:  []things.Key{named, expired},
+		},
+		"retrieve keys of existing thing with non-existing user": {
+			owner: wrongValue,
+			id:    th.ID,
+			keys:  []things.Key{},
+		},
+	}
+
+	for desc, tc := range cases {
+		keys, err := thingRepo.RetrieveKeys(context.Background(), tc.owner, tc.id)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", desc, err))
+		require.Len(t, keys, len(tc.keys), fmt.Sprintf("%s: expected %d keys got %d\n", desc, len(tc.keys), len(keys)))
+		for i, k := range keys {
+			assert.Equal(t, tc.keys[i].Name, k.Name, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.keys[i].Name, k.Name))
+			assert.Equal(t, tc.keys[i].Value, k.Value, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.keys[i].Value, k.Value))
+			assert.WithinDuration(t, tc.keys[i].ExpiresAt, k.ExpiresAt, time.Millisecond, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.keys[i].ExpiresAt, k.ExpiresAt))
+		}
+	}
+}
+
+func TestThingRemoveKey(t *testing.T) {
+	email := "thing-remove-key@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	key, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	th := things.Thing{
+		ID:    id,
+		Owner: email,
+		Key:   key,
+	}
+
+	_, err = thingRepo.Save(context.Background(), th)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	named := saveKey(t, thingRepo, th, "named", time.Time{})
+
+	cases := []struct {
+		desc    string
+		owner   string
+		removed bool
+	}{
+		{
+			desc:    "remove key of existing thing with non-existing user",
+			owner:   wrongValue,
+			removed: false,
+		},
+		{
+			desc:    "remove key of existing thing",
+			owner:   th.Owner,
+			removed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := thingRepo.RemoveKey(context.Background(), tc.owner, th.ID, named.Name)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+
+		_, err = thingRepo.RetrieveByKey(context.Background(), named.Value)
+		assert.Equal(t, tc.removed, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("%s: expected removed %t got %s\n", tc.desc, tc.removed, err))
+	}
+}
+
 func TestMultiThingRetrieval(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	thingRepo := postgres.NewThingRepository(dbMiddleware)
@@ -395,11 +767,16 @@ func TestMultiThingRetrieval(t *testing.T) {
 	nameNum := uint64(3)
 	metaNum := uint64(3)
 	nameMetaNum := uint64(2)
+	sharedNum := uint64(4)
 
 	n := uint64(10)
+	var shared []string
 	for i := uint64(0); i < n; i++ {
 		id, err := idProvider.ID()
 		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		if i < sharedNum {
+			shared = append(shared, id)
+		}
 		key, err := idProvider.ID()
 		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
 		th := things.Thing{
@@ -428,6 +805,7 @@ func TestMultiThingRetrieval(t *testing.T) {
 
 	cases := map[string]struct {
 		owner        string
+		shared       []string
 		pageMetadata things.PageMetadata
 		size         uint64
 	}{
@@ -458,6 +836,26 @@ func TestMultiThingRetrieval(t *testing.T) {
 			},
 			size: 0,
 		},
+		"retrieve things shared with non-owner": {
+			owner:  wrongValue,
+			shared: shared,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  sharedNum,
+			},
+			size: sharedNum,
+		},
+		"retrieve all things with existing owner and shared things": {
+			owner:  email,
+			shared: shared,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  n,
+			},
+			size: n,
+		},
 		"retrieve things with existing name": {
 			owner: email,
 			pageMetadata: things.PageMetadata{
@@ -544,7 +942,7 @@ func TestMultiThingRetrieval(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		page, err := thingRepo.RetrieveAll(context.Background(), tc.owner, tc.pageMetadata)
+		page, err := thingRepo.RetrieveAll(context.Background(), tc.owner, tc.shared, tc.pageMetadata)
 		size := uint64(len(page.Things))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
 		assert.Equal(t, tc.pageMetadata.Total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.pageMetadata.Total, page.Total))
@@ -555,6 +953,50 @@ func TestMultiThingRetrieval(t *testing.T) {
 	}
 }
 
+func TestMultiThingRetrievalByCursor(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	email := "thing-multi-retrieval-by-cursor@example.com"
+
+	n := 10
+	var ids []string
+	for i := 0; i < n; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		key, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		_, err = thingRepo.Save(context.Background(), things.Thing{Owner: email, ID: id, Key: key})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		ids = append(ids, id)
+	}
+
+	for _, dir := range []string{"asc", "desc"} {
+		// Follow the cursors through all the pages.
+		pm := things.PageMetadata{Limit: 4, Dir: dir}
+		var retrieved []string
+		for i := 0; i < n; i++ {
+			page, err := thingRepo.RetrieveAll(context.Background(), email, nil, pm)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", dir, err))
+			assert.Equal(t, uint64(n), page.Total, fmt.Sprintf("%s: expected total %d got %d", dir, n, page.Total))
+			for _, th := range page.Things {
+				retrieved = append(retrieved, th.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			pm.Cursor = page.NextCursor
+		}
+		assert.ElementsMatch(t, ids, retrieved, fmt.Sprintf("%s: expected %v got %v", dir, ids, retrieved))
+	}
+
+	_, err := thingRepo.RetrieveAll(context.Background(), email, nil, things.PageMetadata{Limit: 4, Cursor: things.EncodeCursor(wrongValue)})
+	assert.True(t, errors.Contains(err, things.ErrMalformedEntity), fmt.Sprintf("expected %s got %s", things.ErrMalformedEntity, err))
+
+	_, err = thingRepo.RetrieveAll(context.Background(), email, nil, things.PageMetadata{Limit: 4, Order: "name", Cursor: things.EncodeCursor(ids[0])})
+	assert.True(t, errors.Contains(err, things.ErrMalformedEntity), fmt.Sprintf("expected %s got %s", things.ErrMalformedEntity, err))
+}
+
 func TestMultiThingRetrievalByChannel(t *testing.T) {
 	email := "thing-multi-retrieval-by-channel@example.com"
 
@@ -594,7 +1036,7 @@ func TestMultiThingRetrievalByChannel(t *testing.T) {
 			break
 		}
 
-		err = channelRepo.Connect(context.Background(), email, []string{chID}, []string{thID})
+		err = channelRepo.Connect(context.Background(), email, []string{chID}, []string{thID}, connACL)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	}
 
@@ -769,3 +1211,19 @@ func testSortThings(t *testing.T, pm things.PageMetadata, ths []things.Thing) {
 		break
 	}
 }
+
+func saveKey(t *testing.T, repo things.ThingRepository, th things.Thing, name string, expiresAt time.Time) things.Key {
+	value, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	key := things.Key{
+		ThingID:   th.ID,
+		Name:      name,
+		Value:     value,
+		ExpiresAt: expiresAt.UTC(),
+	}
+	err = repo.SaveKey(context.Background(), th.Owner, key)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	return key
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+	"time"
+)
+
+// Presence values the things can be filtered by.
+const (
+	OnlinePresence  = "online"
+	OfflinePresence = "offline"
+)
+
+// Presence represents the connection state of the thing. A thing is online
+// while it's connected to the MQTT adapter, and it's seen whenever it
+// connects or publishes a message using any of the adapters.
+type Presence struct {
+	ThingID        string
+	Online         bool
+	ConnectedAt    time.Time
+	DisconnectedAt time.Time
+	LastSeen       time.Time
+}
+
+// PresenceRepository specifies presence persistence API.
+type PresenceRepository interface {
+	// Connect marks the thing as online and seen at the given time.
+	Connect(ctx context.Context, thingID string, at time.Time) error
+
+	// Disconnect marks the thing as offline, unless it connected again after
+	// the given time.
+	Disconnect(ctx context.Context, thingID string, at time.Time) error
+
+	// Seen marks the thing as seen at the given time.
+	Seen(ctx context.Context, thingID string, at time.Time) error
+
+	// Retrieve retrieves the presence of the thing. Things that have never
+	// been seen are offline.
+	Retrieve(ctx context.Context, thingID string) (Presence, error)
+
+	// RetrieveOnline retrieves IDs of the given things that are online.
+	RetrieveOnline(ctx context.Context, thingIDs []string) ([]string, error)
+
+	// Remove removes the presence of the thing.
+	Remove(ctx context.Context, thingID string) error
+}
@@ -25,37 +25,50 @@ func NewChannelCache(client *redis.Client) things.ChannelCache {
 	return channelCache{client: client}
 }
 
-func (cc channelCache) Connect(ctx context.Context, chanID, thingID string) error {
-	cid, tid := kv(chanID, thingID)
+// actions lists the cached connection actions. The empty action holds the
+// things that are connected to the channel regardless of the action.
+var actions = []string{"", things.PublishAction, things.SubscribeAction}
+
+func (cc channelCache) Connect(ctx context.Context, chanID, thingID, action string) error {
+	cid, tid := kv(chanID, thingID, action)
 	if err := cc.client.SAdd(ctx, cid, tid).Err(); err != nil {
 		return errors.Wrap(things.ErrConnect, err)
 	}
 	return nil
 }
 
-func (cc channelCache) HasThing(ctx context.Context, chanID, thingID string) bool {
-	cid, tid := kv(chanID, thingID)
+func (cc channelCache) HasThing(ctx context.Context, chanID, thingID, action string) bool {
+	cid, tid := kv(chanID, thingID, action)
 	return cc.client.SIsMember(ctx, cid, tid).Val()
 }
 
 func (cc channelCache) Disconnect(ctx context.Context, chanID, thingID string) error {
-	cid, tid := kv(chanID, thingID)
-	if err := cc.client.SRem(ctx, cid, tid).Err(); err != nil {
-		return errors.Wrap(things.ErrDisconnect, err)
+	for _, action := range actions {
+		cid, tid := kv(chanID, thingID, action)
+		if err := cc.client.SRem(ctx, cid, tid).Err(); err != nil {
+			return errors.Wrap(things.ErrDisconnect, err)
+		}
 	}
 	return nil
 }
 
 func (cc channelCache) Remove(ctx context.Context, chanID string) error {
-	cid, _ := kv(chanID, "0")
-	if err := cc.client.Del(ctx, cid).Err(); err != nil {
+	var cids []string
+	for _, action := range actions {
+		cid, _ := kv(chanID, "0", action)
+		cids = append(cids, cid)
+	}
+	if err := cc.client.Del(ctx, cids...).Err(); err != nil {
 		return errors.Wrap(things.ErrRemoveEntity, err)
 	}
 	return nil
 }
 
 // Generates key-value pair
-func kv(chanID, thingID string) (string, string) {
+func kv(chanID, thingID, action string) (string, string) {
 	cid := fmt.Sprintf("%s:%s", chanPrefix, chanID)
+	if action != "" {
+		cid = fmt.Sprintf("%s:%s", cid, action)
+	}
 	return cid, thingID
 }
@@ -0,0 +1,12 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import "time"
+
+// Presence event is either connect, disconnect or publish event.
+type presenceEvent struct {
+	thingID   string
+	timestamp time.Time
+}
@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/things"
+)
+
+const (
+	stream = "mainflux.mqtt"
+	group  = "mainflux.things"
+
+	thingConnect    = "connect"
+	thingDisconnect = "disconnect"
+	thingPublish    = "publish"
+
+	exists = "BUSYGROUP Consumer Group name already exists"
+)
+
+// Subscriber represents event source for things presence.
+type Subscriber interface {
+	// Subscribes to given subject and receives events.
+	Subscribe(context.Context, string) error
+}
+
+type eventStore struct {
+	svc      things.Service
+	client   *redis.Client
+	consumer string
+	logger   logger.Logger
+}
+
+// NewEventStore returns new event store instance.
+func NewEventStore(svc things.Service, client *redis.Client, consumer string, log logger.Logger) Subscriber {
+	return eventStore{
+		svc:      svc,
+		client:   client,
+		consumer: consumer,
+		logger:   log,
+	}
+}
+
+func (es eventStore) Subscribe(ctx context.Context, subject string) error {
+	err := es.client.XGroupCreateMkStream(ctx, stream, group, "$").Err()
+	if err != nil && err.Error() != exists {
+		return err
+	}
+
+	for {
+		streams, err := es.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: es.consumer,
+			Streams:  []string{stream, ">"},
+			Count:    100,
+		}).Result()
+		if err != nil || len(streams) == 0 {
+			continue
+		}
+
+		for _, msg := range streams[0].Messages {
+			event := msg.Values
+
+			var err error
+			switch event["event_type"] {
+			case thingConnect:
+				pe := decodePresence(event)
+				err = es.svc.ThingConnectedHandler(ctx, pe.thingID, pe.timestamp)
+			case thingDisconnect:
+				pe := decodePresence(event)
+				err = es.svc.ThingDisconnectedHandler(ctx, pe.thingID, pe.timestamp)
+			case thingPublish:
+				pe := decodePresence(event)
+				err = es.svc.ThingPublishedHandler(ctx, pe.thingID, pe.timestamp)
+			}
+			if err != nil {
+				es.logger.Warn(fmt.Sprintf("Failed to handle event sourcing: %s", err.Error()))
+				break
+			}
+			es.client.XAck(ctx, stream, group, msg.ID)
+		}
+	}
+}
+
+func decodePresence(event map[string]interface{}) presenceEvent {
+	// Events without valid timestamp are handled as if they just happened.
+	timestamp := time.Now()
+	if sec, err := strconv.ParseInt(read(event, "timestamp", ""), 10, 64); err == nil {
+		timestamp = time.Unix(sec, 0)
+	}
+
+	return presenceEvent{
+		thingID:   read(event, "thing_id", ""),
+		timestamp: timestamp,
+	}
+}
+
+func read(event map[string]interface{}, key, def string) string {
+	val, ok := event[key].(string)
+	if !ok {
+		return def
+	}
+
+	return val
+}
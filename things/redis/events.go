@@ -1,11 +1,15 @@
 package redis
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+)
 
 const (
 	thingPrefix     = "thing."
 	thingCreate     = thingPrefix + "create"
 	thingUpdate     = thingPrefix + "update"
+	thingStatus     = thingPrefix + "status"
 	thingRemove     = thingPrefix + "remove"
 	thingConnect    = thingPrefix + "connect"
 	thingDisconnect = thingPrefix + "disconnect"
@@ -13,6 +17,7 @@ const (
 	channelPrefix = "channel."
 	channelCreate = channelPrefix + "create"
 	channelUpdate = channelPrefix + "update"
+	channelStatus = channelPrefix + "status"
 	channelRemove = channelPrefix + "remove"
 )
 
@@ -23,9 +28,11 @@ type event interface {
 var (
 	_ event = (*createThingEvent)(nil)
 	_ event = (*updateThingEvent)(nil)
+	_ event = (*updateThingStatusEvent)(nil)
 	_ event = (*removeThingEvent)(nil)
 	_ event = (*createChannelEvent)(nil)
 	_ event = (*updateChannelEvent)(nil)
+	_ event = (*updateChannelStatusEvent)(nil)
 	_ event = (*removeChannelEvent)(nil)
 	_ event = (*connectThingEvent)(nil)
 	_ event = (*disconnectThingEvent)(nil)
@@ -89,6 +96,19 @@ func (ute updateThingEvent) Encode() map[string]interface{} {
 	return val
 }
 
+type updateThingStatusEvent struct {
+	id     string
+	status string
+}
+
+func (utse updateThingStatusEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        utse.id,
+		"status":    utse.status,
+		"operation": thingStatus,
+	}
+}
+
 type removeThingEvent struct {
 	id string
 }
@@ -158,6 +178,19 @@ func (uce updateChannelEvent) Encode() map[string]interface{} {
 	return val
 }
 
+type updateChannelStatusEvent struct {
+	id     string
+	status string
+}
+
+func (ucse updateChannelStatusEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        ucse.id,
+		"status":    ucse.status,
+		"operation": channelStatus,
+	}
+}
+
 type removeChannelEvent struct {
 	id string
 }
@@ -172,14 +205,31 @@ func (rce removeChannelEvent) Encode() map[string]interface{} {
 type connectThingEvent struct {
 	chanID  string
 	thingID string
+	actions []string
+	allow   []string
+	deny    []string
 }
 
 func (cte connectThingEvent) Encode() map[string]interface{} {
-	return map[string]interface{}{
+	val := map[string]interface{}{
 		"chan_id":   cte.chanID,
 		"thing_id":  cte.thingID,
 		"operation": thingConnect,
 	}
+
+	if len(cte.actions) > 0 {
+		val["actions"] = strings.Join(cte.actions, ",")
+	}
+
+	if len(cte.allow) > 0 {
+		val["allow"] = strings.Join(cte.allow, ",")
+	}
+
+	if len(cte.deny) > 0 {
+		val["deny"] = strings.Join(cte.deny, ",")
+	}
+
+	return val
 }
 
 type disconnectThingEvent struct {
@@ -0,0 +1,158 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/things"
+)
+
+const (
+	presencePrefix = "presence"
+	onlineKey      = "presence_online"
+
+	onlineField         = "online"
+	connectedAtField    = "connected_at"
+	disconnectedAtField = "disconnected_at"
+	lastSeenField       = "last_seen"
+)
+
+// disconnectScript marks the thing as offline, unless the connection time
+// stored in the presence hash is after the disconnection time.
+var disconnectScript = redis.NewScript(`
+local connected = redis.call("HGET", KEYS[1], "connected_at")
+if connected and tonumber(connected) > tonumber(ARGV[2]) then
+	return 0
+end
+redis.call("HSET", KEYS[1], "online", "0", "disconnected_at", ARGV[2])
+redis.call("SREM", KEYS[2], ARGV[1])
+return 1
+`)
+
+var _ things.PresenceRepository = (*presenceRepository)(nil)
+
+type presenceRepository struct {
+	client *redis.Client
+}
+
+// NewPresenceRepository returns redis thing presence repository
+// implementation.
+func NewPresenceRepository(client *redis.Client) things.PresenceRepository {
+	return &presenceRepository{
+		client: client,
+	}
+}
+
+func (pr *presenceRepository) Connect(ctx context.Context, thingID string, at time.Time) error {
+	pkey := fmt.Sprintf("%s:%s", presencePrefix, thingID)
+	ts := at.UnixNano()
+
+	_, err := pr.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, pkey, onlineField, "1", connectedAtField, ts, lastSeenField, ts)
+		pipe.SAdd(ctx, onlineKey, thingID)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+	return nil
+}
+
+func (pr *presenceRepository) Disconnect(ctx context.Context, thingID string, at time.Time) error {
+	pkey := fmt.Sprintf("%s:%s", presencePrefix, thingID)
+	if err := disconnectScript.Run(ctx, pr.client, []string{pkey, onlineKey}, thingID, at.UnixNano()).Err(); err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+	return nil
+}
+
+func (pr *presenceRepository) Seen(ctx context.Context, thingID string, at time.Time) error {
+	pkey := fmt.Sprintf("%s:%s", presencePrefix, thingID)
+	if err := pr.client.HSet(ctx, pkey, lastSeenField, at.UnixNano()).Err(); err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
+	}
+	return nil
+}
+
+func (pr *presenceRepository) Retrieve(ctx context.Context, thingID string) (things.Presence, error) {
+	pkey := fmt.Sprintf("%s:%s", presencePrefix, thingID)
+	fields, err := pr.client.HGetAll(ctx, pkey).Result()
+	if err != nil {
+		return things.Presence{}, errors.Wrap(things.ErrViewEntity, err)
+	}
+
+	p := things.Presence{
+		ThingID: thingID,
+		Online:  fields[onlineField] == "1",
+	}
+	if p.ConnectedAt, err = parseTime(fields[connectedAtField]); err != nil {
+		return things.Presence{}, errors.Wrap(things.ErrViewEntity, err)
+	}
+	if p.DisconnectedAt, err = parseTime(fields[disconnectedAtField]); err != nil {
+		return things.Presence{}, errors.Wrap(things.ErrViewEntity, err)
+	}
+	if p.LastSeen, err = parseTime(fields[lastSeenField]); err != nil {
+		return things.Presence{}, errors.Wrap(things.ErrViewEntity, err)
+	}
+
+	return p, nil
+}
+
+func (pr *presenceRepository) RetrieveOnline(ctx context.Context, thingIDs []string) ([]string, error) {
+	if len(thingIDs) == 0 {
+		return []string{}, nil
+	}
+
+	cmds, err := pr.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range thingIDs {
+			pipe.SIsMember(ctx, onlineKey, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(things.ErrViewEntity, err)
+	}
+
+	ids := []string{}
+	for i, cmd := range cmds {
+		if cmd.(*redis.BoolCmd).Val() {
+			ids = append(ids, thingIDs[i])
+		}
+	}
+	return ids, nil
+}
+
+func (pr *presenceRepository) Remove(ctx context.Context, thingID string) error {
+	pkey := fmt.Sprintf("%s:%s", presencePrefix, thingID)
+
+	_, err := pr.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, pkey)
+		pipe.SRem(ctx, onlineKey, thingID)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(things.ErrRemoveEntity, err)
+	}
+	return nil
+}
+
+// parseTime parses the time stored as the number of nanoseconds since
+// the Unix epoch. Missing times are zero.
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	ns, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, ns).UTC(), nil
+}
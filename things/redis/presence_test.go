@@ -0,0 +1,126 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/things"
+	"github.com/mainflux/mainflux/things/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresenceConnect(t *testing.T) {
+	presenceRepo := redis.NewPresenceRepository(redisClient)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	err = presenceRepo.Connect(context.Background(), id, now)
+	require.Nil(t, err, fmt.Sprintf("Connect thing: expected nil got %s", err))
+
+	p, err := presenceRepo.Retrieve(context.Background(), id)
+	require.Nil(t, err, fmt.Sprintf("Retrieve presence: expected nil got %s", err))
+	expected := things.Presence{ThingID: id, Online: true, ConnectedAt: now, LastSeen: now}
+	assert.Equal(t, expected, p, fmt.Sprintf("Retrieve presence: expected %v got %v", expected, p))
+
+	offline, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	online, err := presenceRepo.RetrieveOnline(context.Background(), []string{id, offline})
+	require.Nil(t, err, fmt.Sprintf("Retrieve online things: expected nil got %s", err))
+	assert.Equal(t, []string{id}, online, fmt.Sprintf("Retrieve online things: expected only %s to be online", id))
+}
+
+func TestPresenceDisconnect(t *testing.T) {
+	presenceRepo := redis.NewPresenceRepository(redisClient)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	err = presenceRepo.Connect(context.Background(), id, now)
+	require.Nil(t, err, fmt.Sprintf("Connect thing: expected nil got %s", err))
+
+	cases := []struct {
+		desc     string
+		at       time.Time
+		presence things.Presence
+	}{
+		{
+			desc:     "disconnect thing before it connected",
+			at:       now.Add(-time.Second),
+			presence: things.Presence{ThingID: id, Online: true, ConnectedAt: now, LastSeen: now},
+		},
+		{
+			desc:     "disconnect connected thing",
+			at:       now.Add(time.Second),
+			presence: things.Presence{ThingID: id, Online: false, ConnectedAt: now, DisconnectedAt: now.Add(time.Second), LastSeen: now},
+		},
+	}
+
+	for _, tc := range cases {
+		err := presenceRepo.Disconnect(context.Background(), id, tc.at)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s", tc.desc, err))
+
+		p, err := presenceRepo.Retrieve(context.Background(), id)
+		require.Nil(t, err, fmt.Sprintf("%s: expected nil got %s", tc.desc, err))
+		assert.Equal(t, tc.presence, p, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.presence, p))
+
+		online, err := presenceRepo.RetrieveOnline(context.Background(), []string{id})
+		require.Nil(t, err, fmt.Sprintf("%s: expected nil got %s", tc.desc, err))
+		assert.Equal(t, tc.presence.Online, contains(online, id), fmt.Sprintf("%s: expected online %t", tc.desc, tc.presence.Online))
+	}
+}
+
+func TestPresenceSeen(t *testing.T) {
+	presenceRepo := redis.NewPresenceRepository(redisClient)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	err = presenceRepo.Seen(context.Background(), id, now)
+	require.Nil(t, err, fmt.Sprintf("Seen thing: expected nil got %s", err))
+
+	p, err := presenceRepo.Retrieve(context.Background(), id)
+	require.Nil(t, err, fmt.Sprintf("Retrieve presence: expected nil got %s", err))
+	expected := things.Presence{ThingID: id, LastSeen: now}
+	assert.Equal(t, expected, p, fmt.Sprintf("Retrieve presence: expected %v got %v", expected, p))
+}
+
+func TestPresenceRemove(t *testing.T) {
+	presenceRepo := redis.NewPresenceRepository(redisClient)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = presenceRepo.Connect(context.Background(), id, time.Now())
+	require.Nil(t, err, fmt.Sprintf("Connect thing: expected nil got %s", err))
+
+	err = presenceRepo.Remove(context.Background(), id)
+	assert.Nil(t, err, fmt.Sprintf("Remove presence: expected nil got %s", err))
+
+	p, err := presenceRepo.Retrieve(context.Background(), id)
+	require.Nil(t, err, fmt.Sprintf("Retrieve presence: expected nil got %s", err))
+	expected := things.Presence{ThingID: id}
+	assert.Equal(t, expected, p, fmt.Sprintf("Retrieve removed presence: expected %v got %v", expected, p))
+
+	online, err := presenceRepo.RetrieveOnline(context.Background(), []string{id})
+	require.Nil(t, err, fmt.Sprintf("Retrieve online things: expected nil got %s", err))
+	assert.NotContains(t, online, id, fmt.Sprintf("Retrieve online things: expected %s to be offline", id))
+}
+
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
@@ -5,6 +5,7 @@ package redis
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/mainflux/mainflux/things"
@@ -75,17 +76,53 @@ func (es eventStore) UpdateThing(ctx context.Context, token string, thing things
 	return nil
 }
 
+func (es eventStore) UpdateThingStatus(ctx context.Context, token, id, status string) error {
+	if err := es.svc.UpdateThingStatus(ctx, token, id, status); err != nil {
+		return err
+	}
+
+	event := updateThingStatusEvent{
+		id:     id,
+		status: status,
+	}
+	record := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       event.Encode(),
+	}
+	es.client.XAdd(ctx, record).Err()
+
+	return nil
+}
+
 // UpdateKey doesn't send event because key shouldn't be sent over stream.
 // Maybe we can start publishing this event at some point, without key value
 // in order to notify adapters to disconnect connected things after key update.
-func (es eventStore) UpdateKey(ctx context.Context, token, id, key string) error {
-	return es.svc.UpdateKey(ctx, token, id, key)
+func (es eventStore) UpdateKey(ctx context.Context, token, id, key string, grace time.Duration) error {
+	return es.svc.UpdateKey(ctx, token, id, key, grace)
+}
+
+// AddKey doesn't send event for the same reason as UpdateKey.
+func (es eventStore) AddKey(ctx context.Context, token, id string, key things.Key) (things.Key, error) {
+	return es.svc.AddKey(ctx, token, id, key)
+}
+
+func (es eventStore) ListKeys(ctx context.Context, token, id string) ([]things.Key, error) {
+	return es.svc.ListKeys(ctx, token, id)
+}
+
+func (es eventStore) RevokeKey(ctx context.Context, token, id, name string) error {
+	return es.svc.RevokeKey(ctx, token, id, name)
 }
 
 func (es eventStore) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
 	return es.svc.ViewThing(ctx, token, id)
 }
 
+func (es eventStore) ViewPresence(ctx context.Context, token, id string) (things.Presence, error) {
+	return es.svc.ViewPresence(ctx, token, id)
+}
+
 func (es eventStore) ListThings(ctx context.Context, token string, pm things.PageMetadata) (things.Page, error) {
 	return es.svc.ListThings(ctx, token, pm)
 }
@@ -156,6 +193,25 @@ func (es eventStore) UpdateChannel(ctx context.Context, token string, channel th
 	return nil
 }
 
+func (es eventStore) UpdateChannelStatus(ctx context.Context, token, id, status string) error {
+	if err := es.svc.UpdateChannelStatus(ctx, token, id, status); err != nil {
+		return err
+	}
+
+	event := updateChannelStatusEvent{
+		id:     id,
+		status: status,
+	}
+	record := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       event.Encode(),
+	}
+	es.client.XAdd(ctx, record).Err()
+
+	return nil
+}
+
 func (es eventStore) ViewChannel(ctx context.Context, token, id string) (things.Channel, error) {
 	return es.svc.ViewChannel(ctx, token, id)
 }
@@ -186,8 +242,8 @@ func (es eventStore) RemoveChannel(ctx context.Context, token, id string) error
 	return nil
 }
 
-func (es eventStore) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
-	if err := es.svc.Connect(ctx, token, chIDs, thIDs); err != nil {
+func (es eventStore) Connect(ctx context.Context, token string, chIDs, thIDs []string, acl things.ConnectionACL) error {
+	if err := es.svc.Connect(ctx, token, chIDs, thIDs, acl); err != nil {
 		return err
 	}
 
@@ -196,6 +252,9 @@ func (es eventStore) Connect(ctx context.Context, token string, chIDs, thIDs []s
 			event := connectThingEvent{
 				chanID:  chID,
 				thingID: thID,
+				actions: acl.Actions,
+				allow:   acl.Allow,
+				deny:    acl.Deny,
 			}
 			record := &redis.XAddArgs{
 				Stream:       streamID,
@@ -232,12 +291,12 @@ func (es eventStore) Disconnect(ctx context.Context, token string, chIDs, thIDs
 	return nil
 }
 
-func (es eventStore) CanAccessByKey(ctx context.Context, chanID string, key string) (string, error) {
-	return es.svc.CanAccessByKey(ctx, chanID, key)
+func (es eventStore) CanAccessByKey(ctx context.Context, chanID, key, action, subtopic string) (string, error) {
+	return es.svc.CanAccessByKey(ctx, chanID, key, action, subtopic)
 }
 
-func (es eventStore) CanAccessByID(ctx context.Context, chanID string, thingID string) error {
-	return es.svc.CanAccessByID(ctx, chanID, thingID)
+func (es eventStore) CanAccessByID(ctx context.Context, chanID, thingID, action, subtopic string) error {
+	return es.svc.CanAccessByID(ctx, chanID, thingID, action, subtopic)
 }
 
 func (es eventStore) IsChannelOwner(ctx context.Context, owner, chanID string) error {
@@ -251,3 +310,31 @@ func (es eventStore) Identify(ctx context.Context, key string) (string, error) {
 func (es eventStore) ListMembers(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.Page, error) {
 	return es.svc.ListMembers(ctx, token, groupID, pm)
 }
+
+func (es eventStore) ShareThing(ctx context.Context, token, thingID string, actions, subjectIDs []string) error {
+	return es.svc.ShareThing(ctx, token, thingID, actions, subjectIDs)
+}
+
+func (es eventStore) UnshareThing(ctx context.Context, token, thingID string, actions, subjectIDs []string) error {
+	return es.svc.UnshareThing(ctx, token, thingID, actions, subjectIDs)
+}
+
+func (es eventStore) ShareChannel(ctx context.Context, token, chanID string, actions, subjectIDs []string) error {
+	return es.svc.ShareChannel(ctx, token, chanID, actions, subjectIDs)
+}
+
+func (es eventStore) UnshareChannel(ctx context.Context, token, chanID string, actions, subjectIDs []string) error {
+	return es.svc.UnshareChannel(ctx, token, chanID, actions, subjectIDs)
+}
+
+func (es eventStore) ThingConnectedHandler(ctx context.Context, thingID string, at time.Time) error {
+	return es.svc.ThingConnectedHandler(ctx, thingID, at)
+}
+
+func (es eventStore) ThingDisconnectedHandler(ctx context.Context, thingID string, at time.Time) error {
+	return es.svc.ThingDisconnectedHandler(ctx, thingID, at)
+}
+
+func (es eventStore) ThingPublishedHandler(ctx context.Context, thingID string, at time.Time) error {
+	return es.svc.ThingPublishedHandler(ctx, thingID, at)
+}
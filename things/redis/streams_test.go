@@ -28,6 +28,7 @@ const (
 	thingPrefix     = "thing."
 	thingCreate     = thingPrefix + "create"
 	thingUpdate     = thingPrefix + "update"
+	thingStatus     = thingPrefix + "status"
 	thingRemove     = thingPrefix + "remove"
 	thingConnect    = thingPrefix + "connect"
 	thingDisconnect = thingPrefix + "disconnect"
@@ -35,6 +36,7 @@ const (
 	channelPrefix = "channel."
 	channelCreate = channelPrefix + "create"
 	channelUpdate = channelPrefix + "update"
+	channelStatus = channelPrefix + "status"
 	channelRemove = channelPrefix + "remove"
 )
 
@@ -45,9 +47,10 @@ func newService(tokens map[string]string) things.Service {
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
+	presenceRepo := mocks.NewPresenceRepository()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, presenceRepo, idProvider)
 }
 
 func TestCreateThings(t *testing.T) {
@@ -168,6 +171,80 @@ func TestUpdateThing(t *testing.T) {
 	}
 }
 
+func TestUpdateThingStatus(t *testing.T) {
+	_ = redisClient.FlushAll(context.Background()).Err()
+
+	svc := newService(map[string]string{token: email})
+	// Create thing without sending event.
+	sths, err := svc.CreateThings(context.Background(), token, things.Thing{Name: "a"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	sth := sths[0]
+
+	svc = redis.NewEventStoreMiddleware(svc, redisClient)
+
+	cases := []struct {
+		desc   string
+		id     string
+		status string
+		key    string
+		err    error
+		event  map[string]interface{}
+	}{
+		{
+			desc:   "disable existing thing successfully",
+			id:     sth.ID,
+			status: things.DisabledStatus,
+			key:    token,
+			err:    nil,
+			event: map[string]interface{}{
+				"id":        sth.ID,
+				"status":    things.DisabledStatus,
+				"operation": thingStatus,
+			},
+		},
+		{
+			desc:   "enable existing thing successfully",
+			id:     sth.ID,
+			status: things.EnabledStatus,
+			key:    token,
+			err:    nil,
+			event: map[string]interface{}{
+				"id":        sth.ID,
+				"status":    things.EnabledStatus,
+				"operation": thingStatus,
+			},
+		},
+		{
+			desc:   "update status of non-existent thing",
+			id:     strconv.FormatUint(math.MaxUint64, 10),
+			status: things.DisabledStatus,
+			key:    token,
+			err:    things.ErrNotFound,
+			event:  nil,
+		},
+	}
+
+	lastID := "0"
+	for _, tc := range cases {
+		err := svc.UpdateThingStatus(context.Background(), tc.key, tc.id, tc.status)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		streams := redisClient.XRead(context.Background(), &r.XReadArgs{
+			Streams: []string{streamID, lastID},
+			Count:   1,
+			Block:   time.Second,
+		}).Val()
+
+		var event map[string]interface{}
+		if len(streams) > 0 && len(streams[0].Messages) > 0 {
+			msg := streams[0].Messages[0]
+			event = msg.Values
+			lastID = msg.ID
+		}
+
+		assert.Equal(t, tc.event, event, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.event, event))
+	}
+}
+
 func TestViewThing(t *testing.T) {
 	_ = redisClient.FlushAll(context.Background()).Err()
 
@@ -210,7 +287,7 @@ func TestListThingsByChannel(t *testing.T) {
 	schs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a"})
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 	sch := schs[0]
-	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, things.ConnectionACL{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 
 	essvc := redis.NewEventStoreMiddleware(svc, redisClient)
@@ -404,6 +481,80 @@ func TestUpdateChannel(t *testing.T) {
 	}
 }
 
+func TestUpdateChannelStatus(t *testing.T) {
+	_ = redisClient.FlushAll(context.Background()).Err()
+
+	svc := newService(map[string]string{token: email})
+	// Create channel without sending event.
+	schs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	sch := schs[0]
+
+	svc = redis.NewEventStoreMiddleware(svc, redisClient)
+
+	cases := []struct {
+		desc   string
+		id     string
+		status string
+		key    string
+		err    error
+		event  map[string]interface{}
+	}{
+		{
+			desc:   "disable existing channel successfully",
+			id:     sch.ID,
+			status: things.DisabledStatus,
+			key:    token,
+			err:    nil,
+			event: map[string]interface{}{
+				"id":        sch.ID,
+				"status":    things.DisabledStatus,
+				"operation": channelStatus,
+			},
+		},
+		{
+			desc:   "enable existing channel successfully",
+			id:     sch.ID,
+			status: things.EnabledStatus,
+			key:    token,
+			err:    nil,
+			event: map[string]interface{}{
+				"id":        sch.ID,
+				"status":    things.EnabledStatus,
+				"operation": channelStatus,
+			},
+		},
+		{
+			desc:   "update status of non-existent channel",
+			id:     strconv.FormatUint(math.MaxUint64, 10),
+			status: things.DisabledStatus,
+			key:    token,
+			err:    things.ErrNotFound,
+			event:  nil,
+		},
+	}
+
+	lastID := "0"
+	for _, tc := range cases {
+		err := svc.UpdateChannelStatus(context.Background(), tc.key, tc.id, tc.status)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		streams := redisClient.XRead(context.Background(), &r.XReadArgs{
+			Streams: []string{streamID, lastID},
+			Count:   1,
+			Block:   time.Second,
+		}).Val()
+
+		var event map[string]interface{}
+		if len(streams) > 0 && len(streams[0].Messages) > 0 {
+			msg := streams[0].Messages[0]
+			event = msg.Values
+			lastID = msg.ID
+		}
+
+		assert.Equal(t, tc.event, event, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.event, event))
+	}
+}
+
 func TestViewChannel(t *testing.T) {
 	_ = redisClient.FlushAll(context.Background()).Err()
 
@@ -446,7 +597,7 @@ func TestListChannelsByThing(t *testing.T) {
 	schs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a"})
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 	sch := schs[0]
-	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, things.ConnectionACL{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 
 	essvc := redis.NewEventStoreMiddleware(svc, redisClient)
@@ -523,7 +674,7 @@ func TestConnectEvent(t *testing.T) {
 	sths, err := svc.CreateThings(context.Background(), token, things.Thing{Name: "a"})
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 	sth := sths[0]
-	schs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a"})
+	schs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a"}, things.Channel{Name: "b"})
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 	sch := schs[0]
 
@@ -534,6 +685,7 @@ func TestConnectEvent(t *testing.T) {
 		thingID string
 		chanID  string
 		key     string
+		actions []string
 		err     error
 		event   map[string]interface{}
 	}{
@@ -549,6 +701,20 @@ func TestConnectEvent(t *testing.T) {
 				"operation": thingConnect,
 			},
 		},
+		{
+			desc:    "connect existing thing to existing channel with publish action",
+			thingID: sth.ID,
+			chanID:  schs[1].ID,
+			key:     token,
+			actions: []string{things.PublishAction},
+			err:     nil,
+			event: map[string]interface{}{
+				"chan_id":   schs[1].ID,
+				"thing_id":  sth.ID,
+				"actions":   things.PublishAction,
+				"operation": thingConnect,
+			},
+		},
 		{
 			desc:    "connect non-existent thing to channel",
 			thingID: strconv.FormatUint(math.MaxUint64, 10),
@@ -561,7 +727,7 @@ func TestConnectEvent(t *testing.T) {
 
 	lastID := "0"
 	for _, tc := range cases {
-		err := svc.Connect(context.Background(), tc.key, []string{tc.chanID}, []string{tc.thingID})
+		err := svc.Connect(context.Background(), tc.key, []string{tc.chanID}, []string{tc.thingID}, things.ConnectionACL{Actions: tc.actions})
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 
 		streams := redisClient.XRead(context.Background(), &r.XReadArgs{
@@ -592,7 +758,7 @@ func TestDisconnectEvent(t *testing.T) {
 	schs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a"})
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 	sch := schs[0]
-	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID})
+	err = svc.Connect(context.Background(), token, []string{sch.ID}, []string{sth.ID}, things.ConnectionACL{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 
 	svc = redis.NewEventStoreMiddleware(svc, redisClient)
@@ -6,6 +6,7 @@ package redis
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -14,7 +15,7 @@ import (
 
 const (
 	keyPrefix = "thing_key"
-	idPrefix  = "thing"
+	idPrefix  = "thing_keys"
 )
 
 var _ things.ThingCache = (*thingCache)(nil)
@@ -30,14 +31,23 @@ func NewThingCache(client *redis.Client) things.ThingCache {
 	}
 }
 
-func (tc *thingCache) Save(ctx context.Context, thingKey string, thingID string) error {
-	tkey := fmt.Sprintf("%s:%s", keyPrefix, thingKey)
-	if err := tc.client.Set(ctx, tkey, thingID, 0).Err(); err != nil {
+func (tc *thingCache) Save(ctx context.Context, key things.Key) error {
+	var exp time.Duration
+	if !key.ExpiresAt.IsZero() {
+		exp = time.Until(key.ExpiresAt)
+		// Expired keys are not cached, since zero expiration would keep them forever.
+		if exp <= 0 {
+			return nil
+		}
+	}
+
+	tkey := fmt.Sprintf("%s:%s", keyPrefix, key.Value)
+	if err := tc.client.Set(ctx, tkey, key.ThingID, exp).Err(); err != nil {
 		return errors.Wrap(things.ErrCreateEntity, err)
 	}
 
-	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
-	if err := tc.client.Set(ctx, tid, thingKey, 0).Err(); err != nil {
+	tid := fmt.Sprintf("%s:%s", idPrefix, key.ThingID)
+	if err := tc.client.SAdd(ctx, tid, key.Value).Err(); err != nil {
 		return errors.Wrap(things.ErrCreateEntity, err)
 	}
 	return nil
@@ -55,7 +65,24 @@ func (tc *thingCache) ID(ctx context.Context, thingKey string) (string, error) {
 
 func (tc *thingCache) Remove(ctx context.Context, thingID string) error {
 	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
-	key, err := tc.client.Get(ctx, tid).Result()
+	keys, err := tc.client.SMembers(ctx, tid).Result()
+	if err != nil {
+		return errors.Wrap(things.ErrRemoveEntity, err)
+	}
+
+	tkeys := []string{tid}
+	for _, key := range keys {
+		tkeys = append(tkeys, fmt.Sprintf("%s:%s", keyPrefix, key))
+	}
+	if err := tc.client.Del(ctx, tkeys...).Err(); err != nil {
+		return errors.Wrap(things.ErrRemoveEntity, err)
+	}
+	return nil
+}
+
+func (tc *thingCache) RemoveKey(ctx context.Context, thingKey string) error {
+	tkey := fmt.Sprintf("%s:%s", keyPrefix, thingKey)
+	thingID, err := tc.client.Get(ctx, tkey).Result()
 	// Redis returns Nil Reply when key does not exist.
 	if err == redis.Nil {
 		return nil
@@ -64,8 +91,12 @@ func (tc *thingCache) Remove(ctx context.Context, thingID string) error {
 		return errors.Wrap(things.ErrRemoveEntity, err)
 	}
 
-	tkey := fmt.Sprintf("%s:%s", keyPrefix, key)
-	if err := tc.client.Del(ctx, tkey, tid).Err(); err != nil {
+	if err := tc.client.Del(ctx, tkey).Err(); err != nil {
+		return errors.Wrap(things.ErrRemoveEntity, err)
+	}
+
+	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
+	if err := tc.client.SRem(ctx, tid, thingKey).Err(); err != nil {
 		return errors.Wrap(things.ErrRemoveEntity, err)
 	}
 	return nil
@@ -7,10 +7,12 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	r "github.com/go-redis/redis/v8"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/things"
 	"github.com/mainflux/mainflux/things/redis"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,7 +27,7 @@ func TestThingSave(t *testing.T) {
 	id := "123"
 	id2 := "124"
 
-	err = thingCache.Save(context.Background(), key, id2)
+	err = thingCache.Save(context.Background(), things.Key{ThingID: id2, Value: key})
 	require.Nil(t, err, fmt.Sprintf("Save thing to cache: expected nil got %s", err))
 
 	cases := []struct {
@@ -49,7 +51,7 @@ func TestThingSave(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := thingCache.Save(context.Background(), tc.key, tc.ID)
+		err := thingCache.Save(context.Background(), things.Key{ThingID: tc.ID, Value: tc.key})
 		assert.Nil(t, err, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
 
 	}
@@ -61,7 +63,17 @@ func TestThingID(t *testing.T) {
 	key, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
 	id := "123"
-	err = thingCache.Save(context.Background(), key, id)
+	err = thingCache.Save(context.Background(), things.Key{ThingID: id, Value: key})
+	require.Nil(t, err, fmt.Sprintf("Save thing to cache: expected nil got %s", err))
+
+	expiring, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = thingCache.Save(context.Background(), things.Key{ThingID: id, Value: expiring, ExpiresAt: time.Now().Add(time.Second)})
+	require.Nil(t, err, fmt.Sprintf("Save thing to cache: expected nil got %s", err))
+
+	expired, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = thingCache.Save(context.Background(), things.Key{ThingID: id, Value: expired, ExpiresAt: time.Now().Add(-time.Second)})
 	require.Nil(t, err, fmt.Sprintf("Save thing to cache: expected nil got %s", err))
 
 	cases := map[string]struct {
@@ -74,6 +86,16 @@ func TestThingID(t *testing.T) {
 			key: key,
 			err: nil,
 		},
+		"Get ID by expiring thing-key": {
+			ID:  id,
+			key: expiring,
+			err: nil,
+		},
+		"Get ID by expired thing-key": {
+			ID:  "",
+			key: expired,
+			err: r.Nil,
+		},
 		"Get ID by non-existing thing-key": {
 			ID:  "",
 			key: wrongValue,
@@ -95,7 +117,7 @@ func TestThingRemove(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
 	id := "123"
 	id2 := "321"
-	thingCache.Save(context.Background(), key, id)
+	thingCache.Save(context.Background(), things.Key{ThingID: id, Value: key})
 
 	cases := []struct {
 		desc string
@@ -120,3 +142,43 @@ func TestThingRemove(t *testing.T) {
 	}
 
 }
+
+func TestThingRemoveKey(t *testing.T) {
+	thingCache := redis.NewThingCache(redisClient)
+
+	key, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	key2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	id := "123"
+	thingCache.Save(context.Background(), things.Key{ThingID: id, Value: key})
+	thingCache.Save(context.Background(), things.Key{ThingID: id, Value: key2})
+
+	cases := []struct {
+		desc string
+		key  string
+		err  error
+	}{
+		{
+			desc: "Remove existing thing key from cache",
+			key:  key,
+			err:  nil,
+		},
+		{
+			desc: "Remove non-existing thing key from cache",
+			key:  wrongValue,
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := thingCache.RemoveKey(context.Background(), tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = thingCache.ID(context.Background(), key)
+	assert.True(t, errors.Contains(err, r.Nil), fmt.Sprintf("Get ID by removed thing-key: expected %s got %s\n", r.Nil, err))
+	cacheID, err := thingCache.ID(context.Background(), key2)
+	assert.Nil(t, err, fmt.Sprintf("Get ID by remaining thing-key: unexpected error %s\n", err))
+	assert.Equal(t, id, cacheID, fmt.Sprintf("Get ID by remaining thing-key: expected %s got %s\n", id, cacheID))
+}
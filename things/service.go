@@ -5,6 +5,7 @@ package things
 
 import (
 	"context"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/errors"
 
@@ -40,8 +41,40 @@ var (
 
 	// ErrFailedToRetrieveThings failed to retrieve things.
 	ErrFailedToRetrieveThings = errors.New("failed to retrieve group members")
+
+	// ErrShare indicates error in sharing entity
+	ErrShare = errors.New("share entity failed")
+
+	// ErrSubtopicDenied indicates that the connection does not allow access
+	// to the subtopic.
+	ErrSubtopicDenied = errors.New("subtopic access denied")
+)
+
+// Actions that can be granted to the users and user groups the things and
+// channels are shared with.
+const (
+	ReadAction    = "read"
+	WriteAction   = "write"
+	DeleteAction  = "delete"
+	ConnectAction = "connect"
 )
 
+// Actions that can be carried by the thing-channel connections. A connected
+// thing may publish messages to the channel, subscribe to the channel's
+// messages, or both.
+const (
+	PublishAction   = "publish"
+	SubscribeAction = "subscribe"
+)
+
+// ownerAction is the action the auth service grants to the owner of the
+// object. The owner is the only one allowed to revoke the shared actions.
+const ownerAction = "owner"
+
+// presenceBatchSize is the number of things whose presence is checked at
+// once when listing the things by presence.
+const presenceBatchSize = 100
+
 // Service specifies an API that must be fullfiled by the domain service
 // implementation, and all of its decorators (e.g. logging & metrics).
 type Service interface {
@@ -52,16 +85,38 @@ type Service interface {
 	// belongs to the user identified by the provided key.
 	UpdateThing(ctx context.Context, token string, thing Thing) error
 
-	// UpdateKey updates key value of the existing thing. A non-nil error is
-	// returned to indicate operation failure.
-	UpdateKey(ctx context.Context, token, id, key string) error
+	// UpdateThingStatus enables or disables the thing identified by the
+	// provided ID, that belongs to the user identified by the provided key.
+	UpdateThingStatus(ctx context.Context, token, id, status string) error
+
+	// UpdateKey updates key value of the existing thing. If the grace period
+	// is positive, the replaced key remains valid as the thing's previous key
+	// until the grace period expires. A non-nil error is returned to indicate
+	// operation failure.
+	UpdateKey(ctx context.Context, token, id, key string, grace time.Duration) error
+
+	// AddKey adds the named key to the existing thing. The key value is
+	// generated if it's not provided.
+	AddKey(ctx context.Context, token, id string, key Key) (Key, error)
+
+	// ListKeys retrieves the named keys of the existing thing.
+	ListKeys(ctx context.Context, token, id string) ([]Key, error)
+
+	// RevokeKey revokes the named key of the existing thing.
+	RevokeKey(ctx context.Context, token, id, name string) error
 
 	// ViewThing retrieves data about the thing identified with the provided
-	// ID, that belongs to the user identified by the provided key.
+	// ID, that belongs to the user identified by the provided key. The key
+	// of the thing is hidden from the users it has only been shared with.
 	ViewThing(ctx context.Context, token, id string) (Thing, error)
 
+	// ViewPresence retrieves the presence of the thing identified with the
+	// provided ID, that belongs to the user identified by the provided key.
+	ViewPresence(ctx context.Context, token, id string) (Presence, error)
+
 	// ListThings retrieves data about subset of things that belongs to the
-	// user identified by the provided key.
+	// user identified by the provided key. The keys of the shared things
+	// are hidden.
 	ListThings(ctx context.Context, token string, pm PageMetadata) (Page, error)
 
 	// ListThingsByChannel retrieves data about subset of things that are
@@ -80,6 +135,10 @@ type Service interface {
 	// belongs to the user identified by the provided key.
 	UpdateChannel(ctx context.Context, token string, channel Channel) error
 
+	// UpdateChannelStatus enables or disables the channel identified by the
+	// provided ID, that belongs to the user identified by the provided key.
+	UpdateChannelStatus(ctx context.Context, token, id, status string) error
+
 	// ViewChannel retrieves data about the channel identified by the provided
 	// ID, that belongs to the user identified by the provided key.
 	ViewChannel(ctx context.Context, token, id string) (Channel, error)
@@ -97,20 +156,24 @@ type Service interface {
 	// belongs to the user identified by the provided key.
 	RemoveChannel(ctx context.Context, token, id string) error
 
-	// Connect adds things to the channels list of connected things.
-	Connect(ctx context.Context, token string, chIDs, thIDs []string) error
+	// Connect adds things to the channels list of connected things. The
+	// connections carry the provided access rules, allowing both publish and
+	// subscribe actions if no actions are provided.
+	Connect(ctx context.Context, token string, chIDs, thIDs []string, acl ConnectionACL) error
 
 	// Disconnect removes things from the channels list of connected
 	// things.
 	Disconnect(ctx context.Context, token string, chIDs, thIDs []string) error
 
-	// CanAccessByKey determines whether the channel can be accessed using the
-	// provided key and returns thing's id if access is allowed.
-	CanAccessByKey(ctx context.Context, chanID, key string) (string, error)
+	// CanAccessByKey determines whether the channel subtopic can be accessed
+	// using the provided key for the given action and returns thing's id if
+	// access is allowed. An empty action is allowed by any connection.
+	CanAccessByKey(ctx context.Context, chanID, key, action, subtopic string) (string, error)
 
-	// CanAccessByID determines whether the channel can be accessed by
-	// the given thing and returns error if it cannot.
-	CanAccessByID(ctx context.Context, chanID, thingID string) error
+	// CanAccessByID determines whether the channel subtopic can be accessed
+	// by the given thing for the given action and returns error if it cannot.
+	// An empty action is allowed by any connection.
+	CanAccessByID(ctx context.Context, chanID, thingID, action, subtopic string) error
 
 	// IsChannelOwner determines whether the channel can be accessed by
 	// the given user and returns error if it cannot.
@@ -121,6 +184,39 @@ type Service interface {
 
 	// ListMembers retrieves everything that is assigned to a group identified by groupID.
 	ListMembers(ctx context.Context, token, groupID string, pm PageMetadata) (Page, error)
+
+	// ShareThing grants the actions on the thing identified by the provided
+	// ID to the users or user groups. The user identified by the provided key
+	// must either own the thing or be allowed to perform all of the actions.
+	ShareThing(ctx context.Context, token, thingID string, actions, subjectIDs []string) error
+
+	// UnshareThing revokes the actions on the thing identified by the
+	// provided ID from the users or user groups. Only the owner of the
+	// thing is allowed to revoke them.
+	UnshareThing(ctx context.Context, token, thingID string, actions, subjectIDs []string) error
+
+	// ShareChannel grants the actions on the channel identified by the
+	// provided ID to the users or user groups. The user identified by the
+	// provided key must either own the channel or be allowed to perform all
+	// of the actions.
+	ShareChannel(ctx context.Context, token, chanID string, actions, subjectIDs []string) error
+
+	// UnshareChannel revokes the actions on the channel identified by the
+	// provided ID from the users or user groups. Only the owner of the
+	// channel is allowed to revoke them.
+	UnshareChannel(ctx context.Context, token, chanID string, actions, subjectIDs []string) error
+
+	// ThingConnectedHandler marks the thing as online when it connects to
+	// the MQTT adapter.
+	ThingConnectedHandler(ctx context.Context, thingID string, at time.Time) error
+
+	// ThingDisconnectedHandler marks the thing as offline when it
+	// disconnects from the MQTT adapter.
+	ThingDisconnectedHandler(ctx context.Context, thingID string, at time.Time) error
+
+	// ThingPublishedHandler marks the thing as seen when it publishes a
+	// message through the MQTT adapter.
+	ThingPublishedHandler(ctx context.Context, thingID string, at time.Time) error
 }
 
 // PageMetadata contains page metadata that helps navigation.
@@ -132,6 +228,10 @@ type PageMetadata struct {
 	Order        string                 `json:"order,omitempty"`
 	Dir          string                 `json:"dir,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Status       string                 `json:"status,omitempty"`
+	Presence     string                 `json:"presence,omitempty"`
+	Cursor       string                 `json:"cursor,omitempty"`
+	NextCursor   string                 `json:"next_cursor,omitempty"`
 	Disconnected bool                   // Used for connected or disconnected lists
 }
 
@@ -143,18 +243,20 @@ type thingsService struct {
 	channels     ChannelRepository
 	channelCache ChannelCache
 	thingCache   ThingCache
+	presence     PresenceRepository
 	idProvider   mainflux.IDProvider
 	ulidProvider mainflux.IDProvider
 }
 
 // New instantiates the things service implementation.
-func New(auth mainflux.AuthServiceClient, things ThingRepository, channels ChannelRepository, ccache ChannelCache, tcache ThingCache, idp mainflux.IDProvider) Service {
+func New(auth mainflux.AuthServiceClient, things ThingRepository, channels ChannelRepository, ccache ChannelCache, tcache ThingCache, presence PresenceRepository, idp mainflux.IDProvider) Service {
 	return &thingsService{
 		auth:         auth,
 		things:       things,
 		channels:     channels,
 		channelCache: ccache,
 		thingCache:   tcache,
+		presence:     presence,
 		idProvider:   idp,
 		ulidProvider: ulid.New(),
 	}
@@ -173,6 +275,7 @@ func (ts *thingsService) CreateThings(ctx context.Context, token string, things
 		}
 
 		things[i].Owner = res.GetEmail()
+		things[i].Status = EnabledStatus
 
 		if things[i].Key == "" {
 			things[i].Key, err = ts.idProvider.ID()
@@ -180,6 +283,10 @@ func (ts *thingsService) CreateThings(ctx context.Context, token string, things
 				return []Thing{}, errors.Wrap(ErrCreateUUID, err)
 			}
 		}
+
+		if err := ts.claim(ctx, token, res, things[i].ID); err != nil {
+			return []Thing{}, errors.Wrap(ErrCreateEntity, err)
+		}
 	}
 
 	return ts.things.Save(ctx, things...)
@@ -191,20 +298,204 @@ func (ts *thingsService) UpdateThing(ctx context.Context, token string, thing Th
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	thing.Owner = res.GetEmail()
+	th, err := ts.retrieveThing(ctx, res, thing.ID, WriteAction)
+	if err != nil {
+		return err
+	}
+	thing.Owner = th.Owner
 
 	return ts.things.Update(ctx, thing)
 }
 
-func (ts *thingsService) UpdateKey(ctx context.Context, token, id, key string) error {
+func (ts *thingsService) UpdateThingStatus(ctx context.Context, token, id, status string) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if status != EnabledStatus && status != DisabledStatus {
+		return ErrMalformedEntity
+	}
+
+	th, err := ts.retrieveThing(ctx, res, id, WriteAction)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.things.UpdateStatus(ctx, th.Owner, id, status); err != nil {
+		return err
+	}
+
+	if status == EnabledStatus {
+		return nil
+	}
+
+	// Cached keys and connections of the disabled thing are evicted, since
+	// the adapters use them without asking the service.
+	if err := ts.thingCache.Remove(ctx, id); err != nil {
+		return err
+	}
+
+	return ts.disconnectCached(ctx, th.Owner, id)
+}
+
+// disconnectCached evicts cached connections of the thing to all of the
+// channels it's connected to.
+func (ts *thingsService) disconnectCached(ctx context.Context, owner, thingID string) error {
+	pm := PageMetadata{Limit: 100}
+	for {
+		page, err := ts.channels.RetrieveByThing(ctx, owner, thingID, pm)
+		if err != nil {
+			return err
+		}
+
+		for _, ch := range page.Channels {
+			if err := ts.channelCache.Disconnect(ctx, ch.ID, thingID); err != nil {
+				return err
+			}
+		}
+
+		pm.Offset += pm.Limit
+		if len(page.Channels) == 0 || pm.Offset >= page.Total {
+			return nil
+		}
+	}
+}
+
+func (ts *thingsService) UpdateKey(ctx context.Context, token, id, key string, grace time.Duration) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if grace < 0 {
+		return ErrMalformedEntity
+	}
+
+	th, err := ts.retrieveThing(ctx, res, id, WriteAction)
+	if err != nil {
+		return err
+	}
+
+	keys, err := ts.things.RetrieveKeys(ctx, th.Owner, id)
+	if err != nil {
+		return err
+	}
+
+	prev := Key{ThingID: id, Name: PreviousKey}
+	if grace > 0 {
+		prev.Value = th.Key
+		prev.ExpiresAt = time.Now().Add(grace).UTC()
+	}
+
+	if err := ts.things.UpdateKey(ctx, th.Owner, id, key, prev); err != nil {
+		return err
+	}
+
+	// Replaced keys are evicted from cache, so that the key kept for the
+	// grace period is cached again along with its expiration time.
+	if err := ts.thingCache.RemoveKey(ctx, th.Key); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if k.Name != PreviousKey {
+			continue
+		}
+		if err := ts.thingCache.RemoveKey(ctx, k.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ts *thingsService) AddKey(ctx context.Context, token, id string, key Key) (Key, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Key{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if key.Name == "" || key.Name == PreviousKey || key.Expired() {
+		return Key{}, ErrMalformedEntity
+	}
+
+	th, err := ts.retrieveThing(ctx, res, id, WriteAction)
+	if err != nil {
+		return Key{}, err
+	}
+
+	if key.Value == "" {
+		key.Value, err = ts.idProvider.ID()
+		if err != nil {
+			return Key{}, errors.Wrap(ErrCreateUUID, err)
+		}
+	}
+
+	key.ThingID = id
+	if !key.ExpiresAt.IsZero() {
+		key.ExpiresAt = key.ExpiresAt.UTC()
+	}
+
+	if err := ts.things.SaveKey(ctx, th.Owner, key); err != nil {
+		return Key{}, err
+	}
+
+	return key, nil
+}
+
+func (ts *thingsService) ListKeys(ctx context.Context, token, id string) ([]Key, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return []Key{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	th, err := ts.retrieveThing(ctx, res, id, ReadAction)
+	if err != nil {
+		return []Key{}, err
+	}
+
+	keys, err := ts.things.RetrieveKeys(ctx, th.Owner, id)
+	if err != nil {
+		return []Key{}, err
+	}
+
+	// As with the thing key, the key values are only returned to the owner.
+	if th.Owner != res.GetEmail() {
+		for i := range keys {
+			keys[i].Value = ""
+		}
+	}
+
+	return keys, nil
+}
+
+func (ts *thingsService) RevokeKey(ctx context.Context, token, id, name string) error {
 	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	owner := res.GetEmail()
+	th, err := ts.retrieveThing(ctx, res, id, WriteAction)
+	if err != nil {
+		return err
+	}
+
+	keys, err := ts.things.RetrieveKeys(ctx, th.Owner, id)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if k.Name != name {
+			continue
+		}
+		if err := ts.things.RemoveKey(ctx, th.Owner, id, name); err != nil {
+			return err
+		}
+		return ts.thingCache.RemoveKey(ctx, k.Value)
+	}
 
-	return ts.things.UpdateKey(ctx, owner, id, key)
+	return ErrNotFound
 }
 
 func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing, error) {
@@ -213,7 +504,25 @@ func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing
 		return Thing{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.things.RetrieveByID(ctx, res.GetEmail(), id)
+	th, err := ts.retrieveThing(ctx, res, id, ReadAction)
+	if err != nil {
+		return Thing{}, err
+	}
+
+	return hideKey(res, th), nil
+}
+
+func (ts *thingsService) ViewPresence(ctx context.Context, token, id string) (Presence, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Presence{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if _, err := ts.retrieveThing(ctx, res, id, ReadAction); err != nil {
+		return Presence{}, err
+	}
+
+	return ts.presence.Retrieve(ctx, id)
 }
 
 func (ts *thingsService) ListThings(ctx context.Context, token string, pm PageMetadata) (Page, error) {
@@ -222,7 +531,84 @@ func (ts *thingsService) ListThings(ctx context.Context, token string, pm PageMe
 		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.things.RetrieveAll(ctx, res.GetEmail(), pm)
+	shared, err := ts.sharedObjects(ctx, res)
+	if err != nil {
+		return Page{}, err
+	}
+
+	var page Page
+	switch pm.Presence {
+	case "":
+		page, err = ts.things.RetrieveAll(ctx, res.GetEmail(), shared, pm)
+	case OnlinePresence, OfflinePresence:
+		page, err = ts.listByPresence(ctx, res.GetEmail(), shared, pm)
+	default:
+		return Page{}, ErrMalformedEntity
+	}
+	if err != nil {
+		return Page{}, err
+	}
+
+	return hideKeys(res, page), nil
+}
+
+// listByPresence lists the things matching the presence. The presence isn't
+// stored with the things, so the things of the user are retrieved in batches,
+// and the presence of each batch is checked to fill the page.
+func (ts *thingsService) listByPresence(ctx context.Context, owner string, shared []string, pm PageMetadata) (Page, error) {
+	bpm := pm
+	bpm.Presence = ""
+	bpm.Offset = 0
+	bpm.Limit = presenceBatchSize
+
+	page := Page{
+		PageMetadata: PageMetadata{
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+			Order:  pm.Order,
+			Dir:    pm.Dir,
+		},
+	}
+	for {
+		batch, err := ts.things.RetrieveAll(ctx, owner, shared, bpm)
+		if err != nil {
+			return Page{}, err
+		}
+
+		ids := make([]string, len(batch.Things))
+		for i, th := range batch.Things {
+			ids[i] = th.ID
+		}
+		online, err := ts.presence.RetrieveOnline(ctx, ids)
+		if err != nil {
+			return Page{}, err
+		}
+		isOnline := make(map[string]bool, len(online))
+		for _, id := range online {
+			isOnline[id] = true
+		}
+
+		for _, th := range batch.Things {
+			if isOnline[th.ID] != (pm.Presence == OnlinePresence) {
+				continue
+			}
+			if page.Total >= pm.Offset && uint64(len(page.Things)) < pm.Limit {
+				page.Things = append(page.Things, th)
+			}
+			page.Total++
+		}
+
+		if uint64(len(batch.Things)) < bpm.Limit {
+			break
+		}
+		bpm.Offset += bpm.Limit
+	}
+
+	if n := len(page.Things); (pm.Order == "" || pm.Order == "id") && n > 0 && uint64(n) == pm.Limit {
+		page.NextCursor = EncodeCursor(page.Things[n-1].ID)
+	}
+
+	return page, nil
 }
 
 func (ts *thingsService) ListThingsByChannel(ctx context.Context, token, chID string, pm PageMetadata) (Page, error) {
@@ -231,7 +617,17 @@ func (ts *thingsService) ListThingsByChannel(ctx context.Context, token, chID st
 		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.things.RetrieveByChannel(ctx, res.GetEmail(), chID, pm)
+	owner, err := ts.channelOwner(ctx, res, chID, ReadAction)
+	if err != nil {
+		return Page{}, err
+	}
+
+	page, err := ts.things.RetrieveByChannel(ctx, owner, chID, pm)
+	if err != nil {
+		return Page{}, err
+	}
+
+	return hideKeys(res, page), nil
 }
 
 func (ts *thingsService) RemoveThing(ctx context.Context, token, id string) error {
@@ -240,10 +636,18 @@ func (ts *thingsService) RemoveThing(ctx context.Context, token, id string) erro
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
+	owner, err := ts.thingOwner(ctx, res, id, DeleteAction)
+	if err != nil {
+		return err
+	}
+
 	if err := ts.thingCache.Remove(ctx, id); err != nil {
 		return err
 	}
-	return ts.things.Remove(ctx, res.GetEmail(), id)
+	if err := ts.presence.Remove(ctx, id); err != nil {
+		return err
+	}
+	return ts.things.Remove(ctx, owner, id)
 }
 
 func (ts *thingsService) CreateChannels(ctx context.Context, token string, channels ...Channel) ([]Channel, error) {
@@ -259,6 +663,11 @@ func (ts *thingsService) CreateChannels(ctx context.Context, token string, chann
 		}
 
 		channels[i].Owner = res.GetEmail()
+		channels[i].Status = EnabledStatus
+
+		if err := ts.claim(ctx, token, res, channels[i].ID); err != nil {
+			return []Channel{}, errors.Wrap(ErrCreateEntity, err)
+		}
 	}
 
 	return ts.channels.Save(ctx, channels...)
@@ -270,17 +679,48 @@ func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channe
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	channel.Owner = res.GetEmail()
+	ch, err := ts.retrieveChannel(ctx, res, channel.ID, WriteAction)
+	if err != nil {
+		return err
+	}
+
+	channel.Owner = ch.Owner
 	return ts.channels.Update(ctx, channel)
 }
 
+func (ts *thingsService) UpdateChannelStatus(ctx context.Context, token, id, status string) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if status != EnabledStatus && status != DisabledStatus {
+		return ErrMalformedEntity
+	}
+
+	ch, err := ts.retrieveChannel(ctx, res, id, WriteAction)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.channels.UpdateStatus(ctx, ch.Owner, id, status); err != nil {
+		return err
+	}
+
+	if status == EnabledStatus {
+		return nil
+	}
+
+	return ts.channelCache.Remove(ctx, id)
+}
+
 func (ts *thingsService) ViewChannel(ctx context.Context, token, id string) (Channel, error) {
 	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
 		return Channel{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.channels.RetrieveByID(ctx, res.GetEmail(), id)
+	return ts.retrieveChannel(ctx, res, id, ReadAction)
 }
 
 func (ts *thingsService) ListChannels(ctx context.Context, token string, pm PageMetadata) (ChannelsPage, error) {
@@ -289,7 +729,12 @@ func (ts *thingsService) ListChannels(ctx context.Context, token string, pm Page
 		return ChannelsPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.channels.RetrieveAll(ctx, res.GetEmail(), pm)
+	shared, err := ts.sharedObjects(ctx, res)
+	if err != nil {
+		return ChannelsPage{}, err
+	}
+
+	return ts.channels.RetrieveAll(ctx, res.GetEmail(), shared, pm)
 }
 
 func (ts *thingsService) ListChannelsByThing(ctx context.Context, token, thID string, pm PageMetadata) (ChannelsPage, error) {
@@ -298,7 +743,12 @@ func (ts *thingsService) ListChannelsByThing(ctx context.Context, token, thID st
 		return ChannelsPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.channels.RetrieveByThing(ctx, res.GetEmail(), thID, pm)
+	owner, err := ts.thingOwner(ctx, res, thID, ReadAction)
+	if err != nil {
+		return ChannelsPage{}, err
+	}
+
+	return ts.channels.RetrieveByThing(ctx, owner, thID, pm)
 }
 
 func (ts *thingsService) RemoveChannel(ctx context.Context, token, id string) error {
@@ -307,20 +757,37 @@ func (ts *thingsService) RemoveChannel(ctx context.Context, token, id string) er
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
+	owner, err := ts.channelOwner(ctx, res, id, DeleteAction)
+	if err != nil {
+		return err
+	}
+
 	if err := ts.channelCache.Remove(ctx, id); err != nil {
 		return err
 	}
 
-	return ts.channels.Remove(ctx, res.GetEmail(), id)
+	return ts.channels.Remove(ctx, owner, id)
 }
 
-func (ts *thingsService) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
+func (ts *thingsService) Connect(ctx context.Context, token string, chIDs, thIDs []string, acl ConnectionACL) error {
 	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.channels.Connect(ctx, res.GetEmail(), chIDs, thIDs)
+	if err := acl.validate(); err != nil {
+		return err
+	}
+	if len(acl.Actions) == 0 {
+		acl.Actions = []string{PublishAction, SubscribeAction}
+	}
+
+	owner, err := ts.connectionsOwner(ctx, res, chIDs, thIDs)
+	if err != nil {
+		return err
+	}
+
+	return ts.channels.Connect(ctx, owner, chIDs, thIDs, acl)
 }
 
 func (ts *thingsService) Disconnect(ctx context.Context, token string, chIDs, thIDs []string) error {
@@ -329,6 +796,11 @@ func (ts *thingsService) Disconnect(ctx context.Context, token string, chIDs, th
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
+	owner, err := ts.connectionsOwner(ctx, res, chIDs, thIDs)
+	if err != nil {
+		return err
+	}
+
 	for _, chID := range chIDs {
 		for _, thID := range thIDs {
 			if err := ts.channelCache.Disconnect(ctx, chID, thID); err != nil {
@@ -337,41 +809,73 @@ func (ts *thingsService) Disconnect(ctx context.Context, token string, chIDs, th
 		}
 	}
 
-	return ts.channels.Disconnect(ctx, res.GetEmail(), chIDs, thIDs)
+	return ts.channels.Disconnect(ctx, owner, chIDs, thIDs)
 }
 
-func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, thingKey string) (string, error) {
-	thingID, err := ts.hasThing(ctx, chanID, thingKey)
-	if err == nil {
-		return thingID, nil
-	}
-
-	thingID, err = ts.channels.HasThing(ctx, chanID, thingKey)
+func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, thingKey, action, subtopic string) (string, error) {
+	thingID, err := ts.hasThing(ctx, chanID, thingKey, action)
 	if err != nil {
-		return "", err
+		if thingID, err = ts.Identify(ctx, thingKey); err != nil {
+			return "", err
+		}
+
+		if err := ts.canAccess(ctx, chanID, thingID, action, subtopic); err != nil {
+			return "", err
+		}
 	}
 
-	if err := ts.thingCache.Save(ctx, thingKey, thingID); err != nil {
-		return "", err
+	ts.seen(ctx, thingID, action)
+	return thingID, nil
+}
+
+func (ts *thingsService) CanAccessByID(ctx context.Context, chanID, thingID, action, subtopic string) error {
+	if err := ts.canAccess(ctx, chanID, thingID, action, subtopic); err != nil {
+		return err
 	}
-	if err := ts.channelCache.Connect(ctx, chanID, thingID); err != nil {
-		return "", err
+
+	ts.seen(ctx, thingID, action)
+	return nil
+}
+
+// seen records the time the thing was last seen if the access is granted
+// for publishing. Recording is best effort, since failing to do so must not
+// prevent the thing from publishing.
+func (ts *thingsService) seen(ctx context.Context, thingID, action string) {
+	if action != PublishAction {
+		return
 	}
-	return thingID, nil
+	_ = ts.presence.Seen(ctx, thingID, time.Now().UTC())
 }
 
-func (ts *thingsService) CanAccessByID(ctx context.Context, chanID, thingID string) error {
-	if connected := ts.channelCache.HasThing(ctx, chanID, thingID); connected {
+func (ts *thingsService) canAccess(ctx context.Context, chanID, thingID, action, subtopic string) error {
+	if connected := ts.channelCache.HasThing(ctx, chanID, thingID, action); connected {
 		return nil
 	}
 
-	if err := ts.channels.HasThingByID(ctx, chanID, thingID); err != nil {
+	if err := ts.channels.HasThingByID(ctx, chanID, thingID, action); err != nil {
 		return err
 	}
 
-	if err := ts.channelCache.Connect(ctx, chanID, thingID); err != nil {
+	return ts.accessSubtopic(ctx, chanID, thingID, action, subtopic)
+}
+
+// accessSubtopic checks the subtopic against the connection's subtopic
+// patterns. Only the connections which are not restricted to a subset of
+// subtopics are cached, so that the cached connections can be used for any
+// subtopic.
+func (ts *thingsService) accessSubtopic(ctx context.Context, chanID, thingID, action, subtopic string) error {
+	acl, err := ts.channels.RetrieveACL(ctx, chanID, thingID)
+	if err != nil {
 		return err
 	}
+
+	if !acl.Restricted() {
+		return ts.channelCache.Connect(ctx, chanID, thingID, action)
+	}
+
+	if !acl.AllowsSubtopic(subtopic) {
+		return ErrSubtopicDenied
+	}
 	return nil
 }
 
@@ -388,24 +892,24 @@ func (ts *thingsService) Identify(ctx context.Context, key string) (string, erro
 		return id, nil
 	}
 
-	id, err = ts.things.RetrieveByKey(ctx, key)
+	k, err := ts.things.RetrieveByKey(ctx, key)
 	if err != nil {
 		return "", err
 	}
 
-	if err := ts.thingCache.Save(ctx, key, id); err != nil {
+	if err := ts.thingCache.Save(ctx, k); err != nil {
 		return "", err
 	}
-	return id, nil
+	return k.ThingID, nil
 }
 
-func (ts *thingsService) hasThing(ctx context.Context, chanID, thingKey string) (string, error) {
+func (ts *thingsService) hasThing(ctx context.Context, chanID, thingKey, action string) (string, error) {
 	thingID, err := ts.thingCache.ID(ctx, thingKey)
 	if err != nil {
 		return "", err
 	}
 
-	if connected := ts.channelCache.HasThing(ctx, chanID, thingID); !connected {
+	if connected := ts.channelCache.HasThing(ctx, chanID, thingID, action); !connected {
 		return "", ErrEntityConnected
 	}
 	return thingID, nil
@@ -439,3 +943,328 @@ func (ts *thingsService) members(ctx context.Context, token, groupID, groupType
 	}
 	return res.Members, nil
 }
+
+func (ts *thingsService) ShareThing(ctx context.Context, token, thingID string, actions, subjectIDs []string) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if err := ts.canShare(ctx, res, thingID, actions, ts.canAccessThing); err != nil {
+		return err
+	}
+
+	// The things created before the ownership was kept by the auth service
+	// are claimed by their owners the first time they are shared.
+	if _, err := ts.things.RetrieveByID(ctx, res.GetEmail(), thingID); err == nil {
+		if err := ts.claim(ctx, token, res, thingID); err != nil {
+			return errors.Wrap(ErrShare, err)
+		}
+	}
+
+	return ts.share(ctx, token, thingID, actions, subjectIDs)
+}
+
+func (ts *thingsService) UnshareThing(ctx context.Context, token, thingID string, actions, subjectIDs []string) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if err := validActions(actions); err != nil {
+		return err
+	}
+
+	if _, err := ts.things.RetrieveByID(ctx, res.GetEmail(), thingID); err != nil {
+		return err
+	}
+
+	if err := ts.claim(ctx, token, res, thingID); err != nil {
+		return errors.Wrap(ErrShare, err)
+	}
+
+	return ts.unshare(ctx, token, thingID, actions, subjectIDs)
+}
+
+func (ts *thingsService) ShareChannel(ctx context.Context, token, chanID string, actions, subjectIDs []string) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if err := ts.canShare(ctx, res, chanID, actions, ts.canAccessChannel); err != nil {
+		return err
+	}
+
+	// The channels created before the ownership was kept by the auth
+	// service are claimed by their owners the first time they are shared.
+	if _, err := ts.channels.RetrieveByID(ctx, res.GetEmail(), chanID); err == nil {
+		if err := ts.claim(ctx, token, res, chanID); err != nil {
+			return errors.Wrap(ErrShare, err)
+		}
+	}
+
+	return ts.share(ctx, token, chanID, actions, subjectIDs)
+}
+
+func (ts *thingsService) UnshareChannel(ctx context.Context, token, chanID string, actions, subjectIDs []string) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if err := validActions(actions); err != nil {
+		return err
+	}
+
+	if _, err := ts.channels.RetrieveByID(ctx, res.GetEmail(), chanID); err != nil {
+		return err
+	}
+
+	if err := ts.claim(ctx, token, res, chanID); err != nil {
+		return errors.Wrap(ErrShare, err)
+	}
+
+	return ts.unshare(ctx, token, chanID, actions, subjectIDs)
+}
+
+func (ts *thingsService) ThingConnectedHandler(ctx context.Context, thingID string, at time.Time) error {
+	return ts.presence.Connect(ctx, thingID, at.UTC())
+}
+
+func (ts *thingsService) ThingDisconnectedHandler(ctx context.Context, thingID string, at time.Time) error {
+	return ts.presence.Disconnect(ctx, thingID, at.UTC())
+}
+
+func (ts *thingsService) ThingPublishedHandler(ctx context.Context, thingID string, at time.Time) error {
+	return ts.presence.Seen(ctx, thingID, at.UTC())
+}
+
+func (ts *thingsService) share(ctx context.Context, token, id string, actions, subjectIDs []string) error {
+	for _, sub := range subjectIDs {
+		for _, act := range actions {
+			req := mainflux.PolicyReq{Token: token, Sub: sub, Obj: id, Act: act}
+			if _, err := ts.auth.AddPolicy(ctx, &req); err != nil {
+				return errors.Wrap(ErrShare, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (ts *thingsService) unshare(ctx context.Context, token, id string, actions, subjectIDs []string) error {
+	for _, sub := range subjectIDs {
+		for _, act := range actions {
+			req := mainflux.PolicyReq{Token: token, Sub: sub, Obj: id, Act: act}
+			if _, err := ts.auth.DeletePolicy(ctx, &req); err != nil {
+				return errors.Wrap(ErrShare, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// claim makes the user the owner of the entity in the auth service, which
+// lets the user grant and revoke the actions on it. Claiming the entity the
+// user already owns is a no-op.
+func (ts *thingsService) claim(ctx context.Context, token string, user *mainflux.UserIdentity, id string) error {
+	req := mainflux.PolicyReq{Token: token, Sub: user.GetId(), Obj: id, Act: ownerAction}
+	_, err := ts.auth.AddPolicy(ctx, &req)
+	return err
+}
+
+// canShare checks whether the user is allowed to share the actions on the
+// entity. Owners can share any action, while the others can only share the
+// actions they have been granted themselves.
+func (ts *thingsService) canShare(ctx context.Context, user *mainflux.UserIdentity, id string, actions []string, canAccess func(context.Context, *mainflux.UserIdentity, string, string) error) error {
+	if err := validActions(actions); err != nil {
+		return err
+	}
+
+	for _, act := range actions {
+		if err := canAccess(ctx, user, id, act); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hideKey blanks the key of the thing the user has only been granted
+// access to, since the key lets its holder act on behalf of the thing.
+func hideKey(user *mainflux.UserIdentity, th Thing) Thing {
+	if th.Owner != user.GetEmail() {
+		th.Key = ""
+	}
+
+	return th
+}
+
+func hideKeys(user *mainflux.UserIdentity, page Page) Page {
+	for i, th := range page.Things {
+		page.Things[i] = hideKey(user, th)
+	}
+
+	return page
+}
+
+func (ts *thingsService) canAccessThing(ctx context.Context, user *mainflux.UserIdentity, id, action string) error {
+	_, err := ts.retrieveThing(ctx, user, id, action)
+	return err
+}
+
+func (ts *thingsService) canAccessChannel(ctx context.Context, user *mainflux.UserIdentity, id, action string) error {
+	_, err := ts.retrieveChannel(ctx, user, id, action)
+	return err
+}
+
+// retrieveThing retrieves the thing if the user either owns it or has been
+// granted the action on it.
+func (ts *thingsService) retrieveThing(ctx context.Context, user *mainflux.UserIdentity, id, action string) (Thing, error) {
+	th, err := ts.things.RetrieveByID(ctx, user.GetEmail(), id)
+	if err == nil || !errors.Contains(err, ErrNotFound) {
+		return th, err
+	}
+
+	if !ts.authorized(ctx, user, id, action) {
+		return Thing{}, err
+	}
+
+	page, err := ts.things.RetrieveByIDs(ctx, []string{id}, PageMetadata{Limit: 1})
+	if err != nil {
+		return Thing{}, err
+	}
+	if len(page.Things) == 0 {
+		return Thing{}, ErrNotFound
+	}
+
+	return page.Things[0], nil
+}
+
+// retrieveChannel retrieves the channel if the user either owns it or has
+// been granted the action on it.
+func (ts *thingsService) retrieveChannel(ctx context.Context, user *mainflux.UserIdentity, id, action string) (Channel, error) {
+	ch, err := ts.channels.RetrieveByID(ctx, user.GetEmail(), id)
+	if err == nil || !errors.Contains(err, ErrNotFound) {
+		return ch, err
+	}
+
+	if !ts.authorized(ctx, user, id, action) {
+		return Channel{}, err
+	}
+
+	page, err := ts.channels.RetrieveByIDs(ctx, []string{id}, PageMetadata{Limit: 1})
+	if err != nil {
+		return Channel{}, err
+	}
+	if len(page.Channels) == 0 {
+		return Channel{}, ErrNotFound
+	}
+
+	return page.Channels[0], nil
+}
+
+// thingOwner returns the owner of the thing the user is allowed to perform
+// the action on. If there is no such thing, the user is returned, leaving
+// it to the repository to handle the operation on a missing thing.
+func (ts *thingsService) thingOwner(ctx context.Context, user *mainflux.UserIdentity, id, action string) (string, error) {
+	th, err := ts.retrieveThing(ctx, user, id, action)
+	switch {
+	case err == nil:
+		return th.Owner, nil
+	case errors.Contains(err, ErrNotFound):
+		return user.GetEmail(), nil
+	default:
+		return "", err
+	}
+}
+
+// channelOwner returns the owner of the channel the user is allowed to
+// perform the action on. If there is no such channel, the user is returned,
+// leaving it to the repository to handle the operation on a missing channel.
+func (ts *thingsService) channelOwner(ctx context.Context, user *mainflux.UserIdentity, id, action string) (string, error) {
+	ch, err := ts.retrieveChannel(ctx, user, id, action)
+	switch {
+	case err == nil:
+		return ch.Owner, nil
+	case errors.Contains(err, ErrNotFound):
+		return user.GetEmail(), nil
+	default:
+		return "", err
+	}
+}
+
+// connectionsOwner returns the owner of the channels and things if the user
+// is allowed to connect all of them. Since only the entities of the same
+// owner can be connected, ErrNotFound is returned if the owners differ.
+func (ts *thingsService) connectionsOwner(ctx context.Context, user *mainflux.UserIdentity, chIDs, thIDs []string) (string, error) {
+	owner := ""
+	check := func(o string, err error) error {
+		if err != nil {
+			return err
+		}
+		if owner != "" && owner != o {
+			return ErrNotFound
+		}
+		owner = o
+		return nil
+	}
+
+	for _, chID := range chIDs {
+		if err := check(ts.channelOwner(ctx, user, chID, ConnectAction)); err != nil {
+			return "", err
+		}
+	}
+	for _, thID := range thIDs {
+		if err := check(ts.thingOwner(ctx, user, thID, ConnectAction)); err != nil {
+			return "", err
+		}
+	}
+
+	return owner, nil
+}
+
+// authorized checks whether the action on the object has been granted to
+// the user. Failing authorization requests are treated as denials.
+func (ts *thingsService) authorized(ctx context.Context, user *mainflux.UserIdentity, obj, action string) bool {
+	req := mainflux.AuthorizeReq{Sub: user.GetId(), Obj: obj, Act: action}
+	res, err := ts.auth.Authorize(ctx, &req)
+	if err != nil {
+		return false
+	}
+
+	return res.GetAuthorized()
+}
+
+// sharedObjects returns IDs of the entities shared with the user for
+// reading.
+func (ts *thingsService) sharedObjects(ctx context.Context, user *mainflux.UserIdentity) ([]string, error) {
+	req := mainflux.ListObjectsReq{Sub: user.GetId(), Act: ReadAction}
+	res, err := ts.auth.ListObjects(ctx, &req)
+	if err != nil {
+		return nil, errors.Wrap(ErrViewEntity, err)
+	}
+
+	return res.GetObjects(), nil
+}
+
+func validAction(act string) bool {
+	switch act {
+	case ReadAction, WriteAction, DeleteAction, ConnectAction:
+		return true
+	default:
+		return false
+	}
+}
+
+func validActions(actions []string) error {
+	for _, act := range actions {
+		if !validAction(act) {
+			return ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
@@ -23,6 +23,7 @@ const (
 	email      = "user@example.com"
 	token      = "token"
 	token2     = "token2"
+	email2     = "user2@example.com"
 	n          = uint64(10)
 )
 
@@ -38,9 +39,10 @@ func newService(tokens map[string]string) things.Service {
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
+	presenceRepo := mocks.NewPresenceRepository()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, chanCache, thingCache, presenceRepo, idProvider)
 }
 
 func TestCreateThings(t *testing.T) {
@@ -111,18 +113,105 @@ func TestUpdateThing(t *testing.T) {
 	}
 }
 
+func TestUpdateThingStatus(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, things.ConnectionACL{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	// Cache the thing key and the connection.
+	_, err = svc.CanAccessByKey(context.Background(), ch.ID, th.Key, "", "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		status string
+		err    error
+		access error
+	}{
+		{
+			desc:   "disable existing thing",
+			token:  token,
+			id:     th.ID,
+			status: things.DisabledStatus,
+			err:    nil,
+			access: things.ErrNotFound,
+		},
+		{
+			desc:   "disable disabled thing",
+			token:  token,
+			id:     th.ID,
+			status: things.DisabledStatus,
+			err:    nil,
+			access: things.ErrNotFound,
+		},
+		{
+			desc:   "update thing status with invalid status",
+			token:  token,
+			id:     th.ID,
+			status: wrongValue,
+			err:    things.ErrMalformedEntity,
+			access: things.ErrNotFound,
+		},
+		{
+			desc:   "update thing status with wrong credentials",
+			token:  wrongValue,
+			id:     th.ID,
+			status: things.EnabledStatus,
+			err:    things.ErrUnauthorizedAccess,
+			access: things.ErrNotFound,
+		},
+		{
+			desc:   "update status of non-existing thing",
+			token:  token,
+			id:     wrongID,
+			status: things.EnabledStatus,
+			err:    things.ErrNotFound,
+			access: things.ErrNotFound,
+		},
+		{
+			desc:   "enable disabled thing",
+			token:  token,
+			id:     th.ID,
+			status: things.EnabledStatus,
+			err:    nil,
+			access: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateThingStatus(context.Background(), tc.token, tc.id, tc.status)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		_, err = svc.CanAccessByKey(context.Background(), ch.ID, th.Key, "", "")
+		assert.True(t, errors.Contains(err, tc.access), fmt.Sprintf("%s: expected access error %s got %s\n", tc.desc, tc.access, err))
+	}
+}
+
 func TestUpdateKey(t *testing.T) {
 	key := "new-key"
 	svc := newService(map[string]string{token: email})
 	ths, err := svc.CreateThings(context.Background(), token, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th := ths[0]
+	ths, err = svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	disabled := ths[0]
+	err = svc.UpdateThingStatus(context.Background(), token, disabled.ID, things.DisabledStatus)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := []struct {
 		desc  string
 		token string
 		id    string
 		key   string
+		grace time.Duration
 		err   error
 	}{
 		{
@@ -132,6 +221,36 @@ func TestUpdateKey(t *testing.T) {
 			key:   key,
 			err:   nil,
 		},
+		{
+			desc:  "update key with grace period",
+			token: token,
+			id:    th.ID,
+			key:   "newer-key",
+			grace: time.Hour,
+			err:   nil,
+		},
+		{
+			desc:  "update key with negative grace period",
+			token: token,
+			id:    th.ID,
+			key:   "newest-key",
+			grace: -time.Hour,
+			err:   things.ErrMalformedEntity,
+		},
+		{
+			desc:  "update key to the key of a thing",
+			token: token,
+			id:    th.ID,
+			key:   key,
+			err:   things.ErrConflict,
+		},
+		{
+			desc:  "update key to the key of a disabled thing",
+			token: token,
+			id:    th.ID,
+			key:   disabled.Key,
+			err:   things.ErrConflict,
+		},
 		{
 			desc:  "update key with invalid credentials",
 			token: wrongValue,
@@ -149,11 +268,302 @@ func TestUpdateKey(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := svc.UpdateKey(context.Background(), tc.token, tc.id, tc.key)
+		err := svc.UpdateKey(context.Background(), tc.token, tc.id, tc.key, tc.grace)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUpdateKeyGracePeriod(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	// Identify the thing to cache its initial key.
+	_, err = svc.Identify(context.Background(), th.Key)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		key     string
+		grace   time.Duration
+		valid   []string
+		revoked []string
+	}{
+		{
+			desc:    "rotate key keeping the replaced key valid",
+			key:     "key-1",
+			grace:   time.Hour,
+			valid:   []string{th.Key, "key-1"},
+			revoked: []string{},
+		},
+		{
+			desc:    "rotate key replacing the previous key",
+			key:     "key-2",
+			grace:   time.Hour,
+			valid:   []string{"key-1", "key-2"},
+			revoked: []string{th.Key},
+		},
+		{
+			desc:    "rotate key with expired grace period",
+			key:     "key-3",
+			grace:   time.Nanosecond,
+			valid:   []string{"key-3"},
+			revoked: []string{"key-1", "key-2"},
+		},
+		{
+			desc:    "rotate key without grace period",
+			key:     "key-4",
+			valid:   []string{"key-4"},
+			revoked: []string{"key-3"},
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateKey(context.Background(), token, th.ID, tc.key, tc.grace)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		time.Sleep(time.Millisecond)
+
+		for _, key := range tc.valid {
+			id, err := svc.Identify(context.Background(), key)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected key %s to be valid got %s\n", tc.desc, key, err))
+			assert.Equal(t, th.ID, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, th.ID, id))
+		}
+		for _, key := range tc.revoked {
+			_, err := svc.Identify(context.Background(), key)
+			assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("%s: expected key %s to be revoked got %s\n", tc.desc, key, err))
+		}
+	}
+}
+
+func TestAddKey(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		key   things.Key
+		err   error
+	}{
+		{
+			desc:  "add key with generated value",
+			token: token,
+			id:    th.ID,
+			key:   things.Key{Name: "generated"},
+			err:   nil,
+		},
+		{
+			desc:  "add key with provided value and expiration time",
+			token: token,
+			id:    th.ID,
+			key:   things.Key{Name: "provided", Value: "provided-key", ExpiresAt: time.Now().Add(time.Hour)},
+			err:   nil,
+		},
+		{
+			desc:  "add key with existing name",
+			token: token,
+			id:    th.ID,
+			key:   things.Key{Name: "provided"},
+			err:   things.ErrConflict,
+		},
+		{
+			desc:  "add key with value of the thing key",
+			token: token,
+			id:    th.ID,
+			key:   things.Key{Name: "duplicate", Value: th.Key},
+			err:   things.ErrConflict,
+		},
+		{
+			desc:  "add key with value of the named key",
+			token: token,
+			id:    th.ID,
+			key:   things.Key{Name: "duplicate", Value: "provided-key"},
+			err:   things.ErrConflict,
+		},
+		{
+			desc:  "add key without name",
+			token: token,
+			id:    th.ID,
+			key:   things.Key{},
+			err:   things.ErrMalformedEntity,
+		},
+		{
+			desc:  "add key with reserved name",
+			token: token,
+			id:    th.ID,
+			key:   things.Key{Name: things.PreviousKey},
+			err:   things.ErrMalformedEntity,
+		},
+		{
+			desc:  "add expired key",
+			token: token,
+			id:    th.ID,
+			key:   things.Key{Name: "expired", ExpiresAt: time.Now().Add(-time.Hour)},
+			err:   things.ErrMalformedEntity,
+		},
+		{
+			desc:  "add key to thing of other user",
+			token: token2,
+			id:    th.ID,
+			key:   things.Key{Name: "foreign"},
+			err:   things.ErrNotFound,
+		},
+		{
+			desc:  "add key with invalid credentials",
+			token: wrongValue,
+			id:    th.ID,
+			key:   things.Key{Name: "unauthorized"},
+			err:   things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "add key to non-existing thing",
+			token: token,
+			id:    wrongID,
+			key:   things.Key{Name: "missing"},
+			err:   things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		key, err := svc.AddKey(context.Background(), tc.token, tc.id, tc.key)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+
+		id, err := svc.Identify(context.Background(), key.Value)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, th.ID, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, th.ID, id))
 	}
 }
 
+func TestListKeys(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	names := []string{"b", "a", "c"}
+	for _, name := range names {
+		_, err := svc.AddKey(context.Background(), token, th.ID, things.Key{Name: name})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		names []string
+		err   error
+	}{
+		{
+			desc:  "list keys of existing thing",
+			token: token,
+			id:    th.ID,
+			names: []string{"a", "b", "c"},
+			err:   nil,
+		},
+		{
+			desc:  "list keys of thing of other user",
+			token: token2,
+			id:    th.ID,
+			names: []string{},
+			err:   things.ErrNotFound,
+		},
+		{
+			desc:  "list keys with invalid credentials",
+			token: wrongValue,
+			id:    th.ID,
+			names: []string{},
+			err:   things.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		keys, err := svc.ListKeys(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		names := []string{}
+		for _, k := range keys {
+			names = append(names, k.Name)
+		}
+		assert.Equal(t, tc.names, names, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.names, names))
+	}
+
+	err = svc.ShareThing(context.Background(), token, th.ID, []string{things.ReadAction}, []string{email2})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	keys, err := svc.ListKeys(context.Background(), token2, th.ID)
+	assert.Nil(t, err, fmt.Sprintf("list keys of shared thing: unexpected error: %s\n", err))
+	assert.Equal(t, len(names), len(keys), fmt.Sprintf("list keys of shared thing: expected %d keys got %d\n", len(names), len(keys)))
+	for _, k := range keys {
+		assert.Empty(t, k.Value, fmt.Sprintf("list keys of shared thing: expected hidden value of key %s got %s\n", k.Name, k.Value))
+	}
+}
+
+func TestRevokeKey(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	key, err := svc.AddKey(context.Background(), token, th.ID, things.Key{Name: "revoked"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	// Identify the thing to cache the key.
+	_, err = svc.Identify(context.Background(), key.Value)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		name  string
+		err   error
+	}{
+		{
+			desc:  "revoke key of thing of other user",
+			token: token2,
+			id:    th.ID,
+			name:  key.Name,
+			err:   things.ErrNotFound,
+		},
+		{
+			desc:  "revoke key with invalid credentials",
+			token: wrongValue,
+			id:    th.ID,
+			name:  key.Name,
+			err:   things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "revoke existing key",
+			token: token,
+			id:    th.ID,
+			name:  key.Name,
+			err:   nil,
+		},
+		{
+			desc:  "revoke already revoked key",
+			token: token,
+			id:    th.ID,
+			name:  key.Name,
+			err:   things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RevokeKey(context.Background(), tc.token, tc.id, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.Identify(context.Background(), key.Value)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("identify thing by revoked key: expected %s got %s\n", things.ErrNotFound, err))
+}
+
 func TestViewThing(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ths, err := svc.CreateThings(context.Background(), token, thing)
@@ -175,7 +585,82 @@ func TestViewThing(t *testing.T) {
 			token: wrongValue,
 			err:   things.ErrUnauthorizedAccess,
 		},
-		"view non-existing thing": {
+		"view non-existing thing": {
+			id:    wrongID,
+			token: token,
+			err:   things.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		_, err := svc.ViewThing(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestViewPresence(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Connect(context.Background(), token, []string{chs[0].ID}, []string{ths[1].ID, ths[2].ID}, things.ConnectionACL{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	connected := time.Now().UTC()
+	err = svc.ThingConnectedHandler(context.Background(), ths[0].ID, connected)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	disconnected := connected.Add(time.Second)
+	err = svc.ThingDisconnectedHandler(context.Background(), ths[0].ID, disconnected)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ThingConnectedHandler(context.Background(), ths[1].ID, connected)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	_, err = svc.CanAccessByKey(context.Background(), chs[0].ID, ths[2].Key, things.PublishAction, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ThingPublishedHandler(context.Background(), ths[3].ID, connected)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		id       string
+		token    string
+		online   bool
+		lastSeen bool
+		err      error
+	}{
+		"view presence of disconnected thing": {
+			id:       ths[0].ID,
+			token:    token,
+			online:   false,
+			lastSeen: true,
+			err:      nil,
+		},
+		"view presence of connected thing": {
+			id:       ths[1].ID,
+			token:    token,
+			online:   true,
+			lastSeen: true,
+			err:      nil,
+		},
+		"view presence of thing that published": {
+			id:       ths[2].ID,
+			token:    token,
+			online:   false,
+			lastSeen: true,
+			err:      nil,
+		},
+		"view presence of thing that published through MQTT": {
+			id:       ths[3].ID,
+			token:    token,
+			online:   false,
+			lastSeen: true,
+			err:      nil,
+		},
+		"view presence with wrong credentials": {
+			id:    ths[0].ID,
+			token: wrongValue,
+			err:   things.ErrUnauthorizedAccess,
+		},
+		"view presence of non-existing thing": {
 			id:    wrongID,
 			token: token,
 			err:   things.ErrNotFound,
@@ -183,8 +668,10 @@ func TestViewThing(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		_, err := svc.ViewThing(context.Background(), tc.token, tc.id)
+		p, err := svc.ViewPresence(context.Background(), tc.token, tc.id)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		assert.Equal(t, tc.online, p.Online, fmt.Sprintf("%s: expected online %t got %t\n", desc, tc.online, p.Online))
+		assert.Equal(t, tc.lastSeen, !p.LastSeen.IsZero(), fmt.Sprintf("%s: expected last seen %t got %s\n", desc, tc.lastSeen, p.LastSeen))
 	}
 }
 
@@ -202,7 +689,11 @@ func TestListThings(t *testing.T) {
 		ths = append(ths, th)
 	}
 
-	_, err := svc.CreateThings(context.Background(), token, ths...)
+	saved, err := svc.CreateThings(context.Background(), token, ths...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.UpdateThingStatus(context.Background(), token, saved[0].ID, things.DisabledStatus)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.ThingConnectedHandler(context.Background(), saved[1].ID, time.Now())
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	cases := map[string]struct {
@@ -297,6 +788,76 @@ func TestListThings(t *testing.T) {
 			size: n,
 			err:  nil,
 		},
+		"list enabled things": {
+			token: token,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Status: things.EnabledStatus,
+			},
+			size: n - 1,
+			err:  nil,
+		},
+		"list disabled things": {
+			token: token,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Status: things.DisabledStatus,
+			},
+			size: 1,
+			err:  nil,
+		},
+		"list things of all statuses": {
+			token: token,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Status: things.AllStatus,
+			},
+			size: n,
+			err:  nil,
+		},
+		"list online things": {
+			token: token,
+			pageMetadata: things.PageMetadata{
+				Offset:   0,
+				Limit:    n,
+				Presence: things.OnlinePresence,
+			},
+			size: 1,
+			err:  nil,
+		},
+		"list offline things": {
+			token: token,
+			pageMetadata: things.PageMetadata{
+				Offset:   0,
+				Limit:    n,
+				Presence: things.OfflinePresence,
+			},
+			size: n - 1,
+			err:  nil,
+		},
+		"list offline things with offset": {
+			token: token,
+			pageMetadata: things.PageMetadata{
+				Offset:   1,
+				Limit:    n,
+				Presence: things.OfflinePresence,
+			},
+			size: n - 2,
+			err:  nil,
+		},
+		"list things with invalid presence": {
+			token: token,
+			pageMetadata: things.PageMetadata{
+				Offset:   0,
+				Limit:    n,
+				Presence: wrongValue,
+			},
+			size: 0,
+			err:  things.ErrMalformedEntity,
+		},
 	}
 
 	for desc, tc := range cases {
@@ -335,7 +896,7 @@ func TestListThingsByChannel(t *testing.T) {
 	}
 	chIDs := []string{chs[0].ID}
 
-	err = svc.Connect(context.Background(), token, chIDs, thIDs[0:n-thsDisconNum])
+	err = svc.Connect(context.Background(), token, chIDs, thIDs[0:n-thsDisconNum], things.ConnectionACL{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	// Wait for things and channels to connect
@@ -604,6 +1165,79 @@ func TestUpdateChannel(t *testing.T) {
 	}
 }
 
+func TestUpdateChannelStatus(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, things.ConnectionACL{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	// Cache the connection.
+	err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, "", "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		status string
+		err    error
+		access error
+	}{
+		{
+			desc:   "disable existing channel",
+			token:  token,
+			id:     ch.ID,
+			status: things.DisabledStatus,
+			err:    nil,
+			access: things.ErrEntityConnected,
+		},
+		{
+			desc:   "update channel status with invalid status",
+			token:  token,
+			id:     ch.ID,
+			status: wrongValue,
+			err:    things.ErrMalformedEntity,
+			access: things.ErrEntityConnected,
+		},
+		{
+			desc:   "update channel status with wrong credentials",
+			token:  wrongValue,
+			id:     ch.ID,
+			status: things.EnabledStatus,
+			err:    things.ErrUnauthorizedAccess,
+			access: things.ErrEntityConnected,
+		},
+		{
+			desc:   "update status of non-existing channel",
+			token:  token,
+			id:     wrongID,
+			status: things.EnabledStatus,
+			err:    things.ErrNotFound,
+			access: things.ErrEntityConnected,
+		},
+		{
+			desc:   "enable disabled channel",
+			token:  token,
+			id:     ch.ID,
+			status: things.EnabledStatus,
+			err:    nil,
+			access: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateChannelStatus(context.Background(), tc.token, tc.id, tc.status)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, "", "")
+		assert.True(t, errors.Contains(err, tc.access), fmt.Sprintf("%s: expected access error %s got %s\n", tc.desc, tc.access, err))
+	}
+}
+
 func TestViewChannel(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
@@ -810,7 +1444,7 @@ func TestListChannelsByThing(t *testing.T) {
 	}
 	thIDs := []string{ths[0].ID}
 
-	err = svc.Connect(context.Background(), token, chIDs[0:n-chsDisconNum], thIDs)
+	err = svc.Connect(context.Background(), token, chIDs[0:n-chsDisconNum], thIDs, things.ConnectionACL{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	// Wait for things and channels to connect.
@@ -1026,6 +1660,7 @@ func TestConnect(t *testing.T) {
 		token   string
 		chanID  string
 		thingID string
+		acl     things.ConnectionACL
 		err     error
 	}{
 		{
@@ -1035,6 +1670,34 @@ func TestConnect(t *testing.T) {
 			thingID: th.ID,
 			err:     nil,
 		},
+		{
+			desc:    "connect thing with subtopic patterns",
+			token:   token,
+			chanID:  ch.ID,
+			thingID: th.ID,
+			acl: things.ConnectionACL{
+				Actions: []string{things.PublishAction},
+				Allow:   []string{"site.*.>"},
+				Deny:    []string{"site.admin.>"},
+			},
+			err: nil,
+		},
+		{
+			desc:    "connect thing with invalid action",
+			token:   token,
+			chanID:  ch.ID,
+			thingID: th.ID,
+			acl:     things.ConnectionACL{Actions: []string{wrongValue}},
+			err:     things.ErrMalformedEntity,
+		},
+		{
+			desc:    "connect thing with invalid subtopic pattern",
+			token:   token,
+			chanID:  ch.ID,
+			thingID: th.ID,
+			acl:     things.ConnectionACL{Allow: []string{"site.>.temp"}},
+			err:     things.ErrMalformedEntity,
+		},
 		{
 			desc:    "connect thing with wrong credentials",
 			token:   wrongValue,
@@ -1059,7 +1722,7 @@ func TestConnect(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := svc.Connect(context.Background(), tc.token, []string{tc.chanID}, []string{tc.thingID})
+		err := svc.Connect(context.Background(), tc.token, []string{tc.chanID}, []string{tc.thingID}, tc.acl)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
@@ -1073,7 +1736,7 @@ func TestDisconnect(t *testing.T) {
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, things.ConnectionACL{})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := []struct {
@@ -1132,21 +1795,97 @@ func TestCanAccessByKey(t *testing.T) {
 
 	ths, err := svc.CreateThings(context.Background(), token, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel, channel, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	err = svc.Connect(context.Background(), token, []string{chs[0].ID}, []string{ths[0].ID})
+	err = svc.Connect(context.Background(), token, []string{chs[0].ID}, []string{ths[0].ID}, things.ConnectionACL{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Connect(context.Background(), token, []string{chs[2].ID}, []string{ths[0].ID}, things.ConnectionACL{Actions: []string{things.PublishAction}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	acl := things.ConnectionACL{
+		Allow: []string{"site.dev1.>"},
+		Deny:  []string{"site.dev1.secret"},
+	}
+	err = svc.Connect(context.Background(), token, []string{chs[3].ID}, []string{ths[0].ID}, acl)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := map[string]struct {
-		token   string
-		channel string
-		err     error
+		token    string
+		channel  string
+		action   string
+		subtopic string
+		err      error
 	}{
 		"allowed access": {
 			token:   ths[0].Key,
 			channel: chs[0].ID,
 			err:     nil,
 		},
+		"allowed publish access": {
+			token:   ths[0].Key,
+			channel: chs[0].ID,
+			action:  things.PublishAction,
+			err:     nil,
+		},
+		"allowed subscribe access": {
+			token:   ths[0].Key,
+			channel: chs[0].ID,
+			action:  things.SubscribeAction,
+			err:     nil,
+		},
+		"allowed publish access to publish-only connection": {
+			token:   ths[0].Key,
+			channel: chs[2].ID,
+			action:  things.PublishAction,
+			err:     nil,
+		},
+		"subscribe access to publish-only connection": {
+			token:   ths[0].Key,
+			channel: chs[2].ID,
+			action:  things.SubscribeAction,
+			err:     things.ErrEntityConnected,
+		},
+		"allowed access to allowed subtopic": {
+			token:    ths[0].Key,
+			channel:  chs[3].ID,
+			action:   things.PublishAction,
+			subtopic: "site.dev1.temperature",
+			err:      nil,
+		},
+		"allowed subscription to allowed subtopics": {
+			token:    ths[0].Key,
+			channel:  chs[3].ID,
+			action:   things.SubscribeAction,
+			subtopic: "site.dev1.temperature.>",
+			err:      nil,
+		},
+		"access to subtopic that is not allowed": {
+			token:    ths[0].Key,
+			channel:  chs[3].ID,
+			action:   things.PublishAction,
+			subtopic: "site.dev2.temperature",
+			err:      things.ErrSubtopicDenied,
+		},
+		"access to denied subtopic": {
+			token:    ths[0].Key,
+			channel:  chs[3].ID,
+			action:   things.PublishAction,
+			subtopic: "site.dev1.secret",
+			err:      things.ErrSubtopicDenied,
+		},
+		"subscription to subtopics including denied subtopic": {
+			token:    ths[0].Key,
+			channel:  chs[3].ID,
+			action:   things.SubscribeAction,
+			subtopic: "site.dev1.*",
+			err:      things.ErrSubtopicDenied,
+		},
+		"access to channel without subtopic": {
+			token:   ths[0].Key,
+			channel: chs[3].ID,
+			action:  things.PublishAction,
+			err:     things.ErrSubtopicDenied,
+		},
 		"non-existing thing": {
 			token:   wrongValue,
 			channel: chs[0].ID,
@@ -1165,7 +1904,7 @@ func TestCanAccessByKey(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		_, err := svc.CanAccessByKey(context.Background(), tc.channel, tc.token)
+		_, err := svc.CanAccessByKey(context.Background(), tc.channel, tc.token, tc.action, tc.subtopic)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected '%s' got '%s'\n", desc, tc.err, err))
 	}
 }
@@ -1176,15 +1915,18 @@ func TestCanAccessByID(t *testing.T) {
 	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th := ths[0]
-	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, things.ConnectionACL{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Connect(context.Background(), token, []string{chs[1].ID}, []string{th.ID}, things.ConnectionACL{Actions: []string{things.SubscribeAction}})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := map[string]struct {
 		thingID string
 		channel string
+		action  string
 		err     error
 	}{
 		"allowed access": {
@@ -1192,6 +1934,18 @@ func TestCanAccessByID(t *testing.T) {
 			channel: ch.ID,
 			err:     nil,
 		},
+		"allowed subscribe access to subscribe-only connection": {
+			thingID: th.ID,
+			channel: chs[1].ID,
+			action:  things.SubscribeAction,
+			err:     nil,
+		},
+		"publish access to subscribe-only connection": {
+			thingID: th.ID,
+			channel: chs[1].ID,
+			action:  things.PublishAction,
+			err:     things.ErrEntityConnected,
+		},
 		"access to non-existing thing": {
 			thingID: wrongValue,
 			channel: ch.ID,
@@ -1210,7 +1964,7 @@ func TestCanAccessByID(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		err := svc.CanAccessByID(context.Background(), tc.channel, tc.thingID)
+		err := svc.CanAccessByID(context.Background(), tc.channel, tc.thingID, tc.action, "")
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
@@ -1252,9 +2006,11 @@ func TestIsChannelOwner(t *testing.T) {
 func TestIdentify(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 
-	ths, err := svc.CreateThings(context.Background(), token, thing)
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th := ths[0]
+	err = svc.UpdateThingStatus(context.Background(), token, ths[1].ID, things.DisabledStatus)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := map[string]struct {
 		token string
@@ -1266,6 +2022,11 @@ func TestIdentify(t *testing.T) {
 			id:    th.ID,
 			err:   nil,
 		},
+		"identify disabled thing": {
+			token: ths[1].Key,
+			id:    wrongID,
+			err:   things.ErrNotFound,
+		},
 		"identify non-existing thing": {
 			token: wrongValue,
 			id:    wrongID,
@@ -1315,3 +2076,194 @@ func testSortChannels(t *testing.T, pm things.PageMetadata, chs []things.Channel
 		break
 	}
 }
+
+func TestShareThing(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		actions  []string
+		subjects []string
+		err      error
+	}{
+		{
+			desc:     "share thing",
+			token:    token,
+			id:       th.ID,
+			actions:  []string{things.ReadAction},
+			subjects: []string{email2},
+			err:      nil,
+		},
+		{
+			desc:     "share thing with wrong credentials",
+			token:    wrongValue,
+			id:       th.ID,
+			actions:  []string{things.ReadAction},
+			subjects: []string{email2},
+			err:      things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "share thing with invalid action",
+			token:    token,
+			id:       th.ID,
+			actions:  []string{wrongValue},
+			subjects: []string{email2},
+			err:      things.ErrMalformedEntity,
+		},
+		{
+			desc:     "share non-existing thing",
+			token:    token,
+			id:       wrongID,
+			actions:  []string{things.ReadAction},
+			subjects: []string{email2},
+			err:      things.ErrNotFound,
+		},
+		{
+			desc:     "share granted action by non-owner",
+			token:    token2,
+			id:       th.ID,
+			actions:  []string{things.ReadAction},
+			subjects: []string{wrongValue},
+			err:      nil,
+		},
+		{
+			desc:     "share not granted action by non-owner",
+			token:    token2,
+			id:       th.ID,
+			actions:  []string{things.WriteAction},
+			subjects: []string{wrongValue},
+			err:      things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ShareThing(context.Background(), tc.token, tc.id, tc.actions, tc.subjects)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSharedThingAccess(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	_, err = svc.ViewThing(context.Background(), token2, th.ID)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("view not shared thing: expected %s got %s\n", things.ErrNotFound, err))
+
+	err = svc.ShareThing(context.Background(), token, th.ID, []string{things.ReadAction}, []string{email2})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	shared, err := svc.ViewThing(context.Background(), token2, th.ID)
+	assert.Nil(t, err, fmt.Sprintf("view shared thing: expected no error got %s\n", err))
+	assert.Empty(t, shared.Key, fmt.Sprintf("view shared thing: expected hidden key got %s\n", shared.Key))
+
+	owned, err := svc.ViewThing(context.Background(), token, th.ID)
+	assert.Nil(t, err, fmt.Sprintf("view owned thing: expected no error got %s\n", err))
+	assert.Equal(t, th.Key, owned.Key, fmt.Sprintf("view owned thing: expected key %s got %s\n", th.Key, owned.Key))
+
+	page, err := svc.ListThings(context.Background(), token2, things.PageMetadata{Offset: 0, Limit: n})
+	assert.Nil(t, err, fmt.Sprintf("list shared things: expected no error got %s\n", err))
+	require.Equal(t, 1, len(page.Things), fmt.Sprintf("list shared things: expected %d got %d\n", 1, len(page.Things)))
+	assert.Empty(t, page.Things[0].Key, fmt.Sprintf("list shared things: expected hidden key got %s\n", page.Things[0].Key))
+
+	th.Name = "updated"
+	err = svc.UpdateThing(context.Background(), token2, th)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("update thing without write permission: expected %s got %s\n", things.ErrNotFound, err))
+
+	err = svc.ShareThing(context.Background(), token, th.ID, []string{things.WriteAction}, []string{email2})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.UpdateThing(context.Background(), token2, th)
+	assert.Nil(t, err, fmt.Sprintf("update thing with write permission: expected no error got %s\n", err))
+
+	err = svc.UnshareThing(context.Background(), token2, th.ID, []string{things.ReadAction}, []string{email2})
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("unshare thing by non-owner: expected %s got %s\n", things.ErrNotFound, err))
+
+	err = svc.UnshareThing(context.Background(), token, th.ID, []string{things.ReadAction, things.WriteAction}, []string{email2})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	_, err = svc.ViewThing(context.Background(), token2, th.ID)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("view unshared thing: expected %s got %s\n", things.ErrNotFound, err))
+
+	page, err = svc.ListThings(context.Background(), token2, things.PageMetadata{Offset: 0, Limit: n})
+	assert.Nil(t, err, fmt.Sprintf("list unshared things: expected no error got %s\n", err))
+	assert.Equal(t, 0, len(page.Things), fmt.Sprintf("list unshared things: expected %d got %d\n", 0, len(page.Things)))
+}
+
+func TestShareChannel(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		actions  []string
+		subjects []string
+		err      error
+	}{
+		{
+			desc:     "share channel",
+			token:    token,
+			id:       ch.ID,
+			actions:  []string{things.ReadAction, things.ConnectAction},
+			subjects: []string{email2},
+			err:      nil,
+		},
+		{
+			desc:     "share channel with wrong credentials",
+			token:    wrongValue,
+			id:       ch.ID,
+			actions:  []string{things.ReadAction},
+			subjects: []string{email2},
+			err:      things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "share channel with invalid action",
+			token:    token,
+			id:       ch.ID,
+			actions:  []string{wrongValue},
+			subjects: []string{email2},
+			err:      things.ErrMalformedEntity,
+		},
+		{
+			desc:     "share non-existing channel",
+			token:    token,
+			id:       wrongID,
+			actions:  []string{things.ReadAction},
+			subjects: []string{email2},
+			err:      things.ErrNotFound,
+		},
+		{
+			desc:     "share not granted action by non-owner",
+			token:    token2,
+			id:       ch.ID,
+			actions:  []string{things.DeleteAction},
+			subjects: []string{wrongValue},
+			err:      things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ShareChannel(context.Background(), tc.token, tc.id, tc.actions, tc.subjects)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.ViewChannel(context.Background(), token2, ch.ID)
+	assert.Nil(t, err, fmt.Sprintf("view shared channel: expected no error got %s\n", err))
+
+	page, err := svc.ListChannels(context.Background(), token2, things.PageMetadata{Offset: 0, Limit: n})
+	assert.Nil(t, err, fmt.Sprintf("list shared channels: expected no error got %s\n", err))
+	assert.Equal(t, 1, len(page.Channels), fmt.Sprintf("list shared channels: expected %d got %d\n", 1, len(page.Channels)))
+}
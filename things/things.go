@@ -30,6 +30,15 @@ var (
 	ErrEntityConnected = errors.New("check thing-channel connection in database error")
 )
 
+// Statuses of things and channels. Disabled things can't be authenticated and
+// disabled channels can't be accessed, while both keep their metadata and
+// connections. AllStatus is used to list the entities regardless of status.
+const (
+	EnabledStatus  = "enabled"
+	DisabledStatus = "disabled"
+	AllStatus      = "all"
+)
+
 // Metadata to be used for Mainflux thing or channel for customized
 // describing of particular thing or channel.
 type Metadata map[string]interface{}
@@ -41,6 +50,7 @@ type Thing struct {
 	Owner    string
 	Name     string
 	Key      string
+	Status   string
 	Metadata Metadata
 }
 
@@ -55,26 +65,48 @@ type Page struct {
 type ThingRepository interface {
 	// Save persists multiple things. Things are saved using a transaction. If one thing
 	// fails then none will be saved. Successful operation is indicated by non-nil
-	// error response.
+	// error response. Key values are unique among all the thing keys and named
+	// keys, and ErrConflict is returned if the key value is taken.
 	Save(ctx context.Context, ths ...Thing) ([]Thing, error)
 
 	// Update performs an update to the existing thing. A non-nil error is
 	// returned to indicate operation failure.
 	Update(ctx context.Context, t Thing) error
 
-	// UpdateKey updates key value of the existing thing. A non-nil error is
+	// UpdateStatus updates status of the existing thing. A non-nil error is
 	// returned to indicate operation failure.
-	UpdateKey(ctx context.Context, owner, id, key string) error
+	UpdateStatus(ctx context.Context, owner, id, status string) error
+
+	// UpdateKey updates key value of the existing thing and replaces the thing
+	// key having the name of the provided previous key. If the previous key has
+	// no value, the key having its name is only removed. ErrConflict is
+	// returned if the key value is taken.
+	UpdateKey(ctx context.Context, owner, id, key string, prev Key) error
+
+	// SaveKey persists the named key of the existing thing. ErrConflict is
+	// returned if the key value is taken.
+	SaveKey(ctx context.Context, owner string, key Key) error
+
+	// RetrieveKeys retrieves the named keys of the existing thing, including
+	// the expired ones.
+	RetrieveKeys(ctx context.Context, owner, id string) ([]Key, error)
+
+	// RemoveKey removes the named key of the existing thing.
+	RemoveKey(ctx context.Context, owner, id, name string) error
 
 	// RetrieveByID retrieves the thing having the provided identifier, that is owned
 	// by the specified user.
 	RetrieveByID(ctx context.Context, owner, id string) (Thing, error)
 
-	// RetrieveByKey returns thing ID for given thing key.
-	RetrieveByKey(ctx context.Context, key string) (string, error)
+	// RetrieveByKey returns the thing key, containing the thing ID, for given
+	// key value. Expired keys and keys of disabled things are not retrieved.
+	RetrieveByKey(ctx context.Context, key string) (Key, error)
 
 	// RetrieveAll retrieves the subset of things owned by the specified user
-	RetrieveAll(ctx context.Context, owner string, pm PageMetadata) (Page, error)
+	// or identified by one of the shared thing IDs. If the cursor is set,
+	// the things following the cursor are retrieved in the order of their IDs
+	// instead of skipping the offset.
+	RetrieveAll(ctx context.Context, owner string, shared []string, pm PageMetadata) (Page, error)
 
 	// RetrieveByIDs retrieves the subset of things specified by given thing ids.
 	RetrieveByIDs(ctx context.Context, thingIDs []string, pm PageMetadata) (Page, error)
@@ -90,12 +122,16 @@ type ThingRepository interface {
 
 // ThingCache contains thing caching interface.
 type ThingCache interface {
-	// Save stores pair thing key, thing id.
-	Save(context.Context, string, string) error
+	// Save stores pair thing key, thing id. Keys with expiration time are
+	// evicted once they expire.
+	Save(context.Context, Key) error
 
 	// ID returns thing ID for given key.
 	ID(context.Context, string) (string, error)
 
-	// Removes thing from cache.
+	// Removes thing and all of its keys from cache.
 	Remove(context.Context, string) error
+
+	// RemoveKey removes single thing key from cache.
+	RemoveKey(context.Context, string) error
 }
@@ -13,14 +13,17 @@ import (
 const (
 	saveChannelsOp            = "save_channels"
 	updateChannelOp           = "update_channel"
+	updateChannelStatusOp     = "update_channel_status"
 	retrieveChannelByIDOp     = "retrieve_channel_by_id"
 	retrieveAllChannelsOp     = "retrieve_all_channels"
+	retrieveChannelsByIDsOp   = "retrieve_channels_by_ids"
 	retrieveChannelsByThingOp = "retrieve_channels_by_thing"
 	removeChannelOp           = "retrieve_channel"
 	connectOp                 = "connect"
 	disconnectOp              = "disconnect"
 	hasThingOp                = "has_thing"
 	hasThingByIDOp            = "has_thing_by_id"
+	retrieveACLOp             = "retrieve_acl"
 )
 
 var (
@@ -58,6 +61,14 @@ func (crm channelRepositoryMiddleware) Update(ctx context.Context, ch things.Cha
 	return crm.repo.Update(ctx, ch)
 }
 
+func (crm channelRepositoryMiddleware) UpdateStatus(ctx context.Context, owner, id, status string) error {
+	span := createSpan(ctx, crm.tracer, updateChannelStatusOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.UpdateStatus(ctx, owner, id, status)
+}
+
 func (crm channelRepositoryMiddleware) RetrieveByID(ctx context.Context, owner, id string) (things.Channel, error) {
 	span := createSpan(ctx, crm.tracer, retrieveChannelByIDOp)
 	defer span.Finish()
@@ -66,12 +77,20 @@ func (crm channelRepositoryMiddleware) RetrieveByID(ctx context.Context, owner,
 	return crm.repo.RetrieveByID(ctx, owner, id)
 }
 
-func (crm channelRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, pm things.PageMetadata) (things.ChannelsPage, error) {
+func (crm channelRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, shared []string, pm things.PageMetadata) (things.ChannelsPage, error) {
 	span := createSpan(ctx, crm.tracer, retrieveAllChannelsOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.RetrieveAll(ctx, owner, pm)
+	return crm.repo.RetrieveAll(ctx, owner, shared, pm)
+}
+
+func (crm channelRepositoryMiddleware) RetrieveByIDs(ctx context.Context, chIDs []string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	span := createSpan(ctx, crm.tracer, retrieveChannelsByIDsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveByIDs(ctx, chIDs, pm)
 }
 
 func (crm channelRepositoryMiddleware) RetrieveByThing(ctx context.Context, owner, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
@@ -90,12 +109,12 @@ func (crm channelRepositoryMiddleware) Remove(ctx context.Context, owner, id str
 	return crm.repo.Remove(ctx, owner, id)
 }
 
-func (crm channelRepositoryMiddleware) Connect(ctx context.Context, owner string, chIDs, thIDs []string) error {
+func (crm channelRepositoryMiddleware) Connect(ctx context.Context, owner string, chIDs, thIDs []string, acl things.ConnectionACL) error {
 	span := createSpan(ctx, crm.tracer, connectOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.Connect(ctx, owner, chIDs, thIDs)
+	return crm.repo.Connect(ctx, owner, chIDs, thIDs, acl)
 }
 
 func (crm channelRepositoryMiddleware) Disconnect(ctx context.Context, owner string, chIDs, thIDs []string) error {
@@ -106,20 +125,28 @@ func (crm channelRepositoryMiddleware) Disconnect(ctx context.Context, owner str
 	return crm.repo.Disconnect(ctx, owner, chIDs, thIDs)
 }
 
-func (crm channelRepositoryMiddleware) HasThing(ctx context.Context, chanID, key string) (string, error) {
+func (crm channelRepositoryMiddleware) HasThing(ctx context.Context, chanID, key, action string) (string, error) {
 	span := createSpan(ctx, crm.tracer, hasThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.HasThing(ctx, chanID, key)
+	return crm.repo.HasThing(ctx, chanID, key, action)
 }
 
-func (crm channelRepositoryMiddleware) HasThingByID(ctx context.Context, chanID, thingID string) error {
+func (crm channelRepositoryMiddleware) HasThingByID(ctx context.Context, chanID, thingID, action string) error {
 	span := createSpan(ctx, crm.tracer, hasThingByIDOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.HasThingByID(ctx, chanID, thingID)
+	return crm.repo.HasThingByID(ctx, chanID, thingID, action)
+}
+
+func (crm channelRepositoryMiddleware) RetrieveACL(ctx context.Context, chanID, thingID string) (things.ConnectionACL, error) {
+	span := createSpan(ctx, crm.tracer, retrieveACLOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveACL(ctx, chanID, thingID)
 }
 
 type channelCacheMiddleware struct {
@@ -136,20 +163,20 @@ func ChannelCacheMiddleware(tracer opentracing.Tracer, cache things.ChannelCache
 	}
 }
 
-func (ccm channelCacheMiddleware) Connect(ctx context.Context, chanID, thingID string) error {
+func (ccm channelCacheMiddleware) Connect(ctx context.Context, chanID, thingID, action string) error {
 	span := createSpan(ctx, ccm.tracer, connectOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return ccm.cache.Connect(ctx, chanID, thingID)
+	return ccm.cache.Connect(ctx, chanID, thingID, action)
 }
 
-func (ccm channelCacheMiddleware) HasThing(ctx context.Context, chanID, thingID string) bool {
+func (ccm channelCacheMiddleware) HasThing(ctx context.Context, chanID, thingID, action string) bool {
 	span := createSpan(ctx, ccm.tracer, hasThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return ccm.cache.HasThing(ctx, chanID, thingID)
+	return ccm.cache.HasThing(ctx, chanID, thingID, action)
 }
 
 func (ccm channelCacheMiddleware) Disconnect(ctx context.Context, chanID, thingID string) error {
@@ -0,0 +1,85 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/things"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	connectThingPresenceOp    = "connect_thing_presence"
+	disconnectThingPresenceOp = "disconnect_thing_presence"
+	seenThingOp               = "seen_thing"
+	retrievePresenceOp        = "retrieve_presence"
+	retrieveOnlineThingsOp    = "retrieve_online_things"
+	removePresenceOp          = "remove_presence"
+)
+
+var _ things.PresenceRepository = (*presenceRepositoryMiddleware)(nil)
+
+type presenceRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   things.PresenceRepository
+}
+
+// PresenceRepositoryMiddleware tracks request and their latency, and adds
+// spans to context.
+func PresenceRepositoryMiddleware(tracer opentracing.Tracer, repo things.PresenceRepository) things.PresenceRepository {
+	return presenceRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (prm presenceRepositoryMiddleware) Connect(ctx context.Context, thingID string, at time.Time) error {
+	span := createSpan(ctx, prm.tracer, connectThingPresenceOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Connect(ctx, thingID, at)
+}
+
+func (prm presenceRepositoryMiddleware) Disconnect(ctx context.Context, thingID string, at time.Time) error {
+	span := createSpan(ctx, prm.tracer, disconnectThingPresenceOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Disconnect(ctx, thingID, at)
+}
+
+func (prm presenceRepositoryMiddleware) Seen(ctx context.Context, thingID string, at time.Time) error {
+	span := createSpan(ctx, prm.tracer, seenThingOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Seen(ctx, thingID, at)
+}
+
+func (prm presenceRepositoryMiddleware) Retrieve(ctx context.Context, thingID string) (things.Presence, error) {
+	span := createSpan(ctx, prm.tracer, retrievePresenceOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Retrieve(ctx, thingID)
+}
+
+func (prm presenceRepositoryMiddleware) RetrieveOnline(ctx context.Context, thingIDs []string) ([]string, error) {
+	span := createSpan(ctx, prm.tracer, retrieveOnlineThingsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveOnline(ctx, thingIDs)
+}
+
+func (prm presenceRepositoryMiddleware) Remove(ctx context.Context, thingID string) error {
+	span := createSpan(ctx, prm.tracer, removePresenceOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Remove(ctx, thingID)
+}
@@ -15,12 +15,16 @@ const (
 	saveThingsOp              = "save_things"
 	updateThingOp             = "update_thing"
 	updateThingKeyOp          = "update_thing_by_key"
+	updateThingStatusOp       = "update_thing_status"
 	retrieveThingByIDOp       = "retrieve_thing_by_id"
 	retrieveThingByKeyOp      = "retrieve_thing_by_key"
 	retrieveAllThingsOp       = "retrieve_all_things"
 	retrieveThingsByChannelOp = "retrieve_things_by_chan"
 	removeThingOp             = "remove_thing"
 	retrieveThingIDByKeyOp    = "retrieve_id_by_key"
+	saveThingKeyOp            = "save_thing_key"
+	retrieveThingKeysOp       = "retrieve_thing_keys"
+	removeThingKeyOp          = "remove_thing_key"
 )
 
 var (
@@ -58,12 +62,44 @@ func (trm thingRepositoryMiddleware) Update(ctx context.Context, th things.Thing
 	return trm.repo.Update(ctx, th)
 }
 
-func (trm thingRepositoryMiddleware) UpdateKey(ctx context.Context, owner, id, key string) error {
+func (trm thingRepositoryMiddleware) UpdateStatus(ctx context.Context, owner, id, status string) error {
+	span := createSpan(ctx, trm.tracer, updateThingStatusOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.UpdateStatus(ctx, owner, id, status)
+}
+
+func (trm thingRepositoryMiddleware) UpdateKey(ctx context.Context, owner, id, key string, prev things.Key) error {
 	span := createSpan(ctx, trm.tracer, updateThingKeyOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return trm.repo.UpdateKey(ctx, owner, id, key)
+	return trm.repo.UpdateKey(ctx, owner, id, key, prev)
+}
+
+func (trm thingRepositoryMiddleware) SaveKey(ctx context.Context, owner string, key things.Key) error {
+	span := createSpan(ctx, trm.tracer, saveThingKeyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.SaveKey(ctx, owner, key)
+}
+
+func (trm thingRepositoryMiddleware) RetrieveKeys(ctx context.Context, owner, id string) ([]things.Key, error) {
+	span := createSpan(ctx, trm.tracer, retrieveThingKeysOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveKeys(ctx, owner, id)
+}
+
+func (trm thingRepositoryMiddleware) RemoveKey(ctx context.Context, owner, id, name string) error {
+	span := createSpan(ctx, trm.tracer, removeThingKeyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RemoveKey(ctx, owner, id, name)
 }
 
 func (trm thingRepositoryMiddleware) RetrieveByID(ctx context.Context, owner, id string) (things.Thing, error) {
@@ -74,7 +110,7 @@ func (trm thingRepositoryMiddleware) RetrieveByID(ctx context.Context, owner, id
 	return trm.repo.RetrieveByID(ctx, owner, id)
 }
 
-func (trm thingRepositoryMiddleware) RetrieveByKey(ctx context.Context, key string) (string, error) {
+func (trm thingRepositoryMiddleware) RetrieveByKey(ctx context.Context, key string) (things.Key, error) {
 	span := createSpan(ctx, trm.tracer, retrieveThingByKeyOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
@@ -82,12 +118,12 @@ func (trm thingRepositoryMiddleware) RetrieveByKey(ctx context.Context, key stri
 	return trm.repo.RetrieveByKey(ctx, key)
 }
 
-func (trm thingRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, pm things.PageMetadata) (things.Page, error) {
+func (trm thingRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, shared []string, pm things.PageMetadata) (things.Page, error) {
 	span := createSpan(ctx, trm.tracer, retrieveAllThingsOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return trm.repo.RetrieveAll(ctx, owner, pm)
+	return trm.repo.RetrieveAll(ctx, owner, shared, pm)
 }
 
 func (trm thingRepositoryMiddleware) RetrieveByIDs(ctx context.Context, thingIDs []string, pm things.PageMetadata) (things.Page, error) {
@@ -128,12 +164,12 @@ func ThingCacheMiddleware(tracer opentracing.Tracer, cache things.ThingCache) th
 	}
 }
 
-func (tcm thingCacheMiddleware) Save(ctx context.Context, thingKey string, thingID string) error {
+func (tcm thingCacheMiddleware) Save(ctx context.Context, key things.Key) error {
 	span := createSpan(ctx, tcm.tracer, saveThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return tcm.cache.Save(ctx, thingKey, thingID)
+	return tcm.cache.Save(ctx, key)
 }
 
 func (tcm thingCacheMiddleware) ID(ctx context.Context, thingKey string) (string, error) {
@@ -152,6 +188,14 @@ func (tcm thingCacheMiddleware) Remove(ctx context.Context, thingID string) erro
 	return tcm.cache.Remove(ctx, thingID)
 }
 
+func (tcm thingCacheMiddleware) RemoveKey(ctx context.Context, thingKey string) error {
+	span := createSpan(ctx, tcm.tracer, removeThingKeyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return tcm.cache.RemoveKey(ctx, thingKey)
+}
+
 func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		return tracer.StartSpan(
@@ -70,3 +70,17 @@ func (repo singleUserRepo) Members(ctx context.Context, req *mainflux.MembersReq
 func (repo singleUserRepo) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	return &empty.Empty{}, errUnsupported
 }
+
+func (repo singleUserRepo) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	return &empty.Empty{}, errUnsupported
+}
+
+func (repo singleUserRepo) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	return &empty.Empty{}, errUnsupported
+}
+
+// ListObjects returns no objects since there is nobody to share them with
+// in the single user mode.
+func (repo singleUserRepo) ListObjects(ctx context.Context, req *mainflux.ListObjectsReq, _ ...grpc.CallOption) (r *mainflux.ListObjectsRes, err error) {
+	return &mainflux.ListObjectsRes{}, nil
+}